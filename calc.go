@@ -220,6 +220,8 @@ type calcContext struct {
 	maxCalcIterations uint
 	iterations        map[string]uint
 	iterationsCache   map[string]formulaArg
+	evaluating        map[string]bool
+	circularRef       string
 }
 
 // cellRef defines the structure of a cell reference.
@@ -791,20 +793,31 @@ type formulaFuncs struct {
 //
 // 根据给定的工作表名和单元格坐标计算包含公式单元格的值。
 func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string, err error) {
-	var (
-		rawCellValue = getOptions(opts...).RawCellValue
-		styleIdx     int
-		token        formulaArg
-	)
-	if token, err = f.calcCellValue(&calcContext{
+	var token formulaArg
+	if token, err = f.calcCellValue(newCalcContext(sheet, cell, opts...), sheet, cell); err != nil {
+		result = token.String
+		return
+	}
+	return f.formattedCalcResult(sheet, cell, token, getOptions(opts...).RawCellValue)
+}
+
+// newCalcContext provides a function to create the formula execution context
+// by given worksheet name, cell reference and options.
+func newCalcContext(sheet, cell string, opts ...Options) *calcContext {
+	return &calcContext{
 		entry:             fmt.Sprintf("%s!%s", sheet, cell),
 		maxCalcIterations: getOptions(opts...).MaxCalcIterations,
 		iterations:        make(map[string]uint),
 		iterationsCache:   make(map[string]formulaArg),
-	}, sheet, cell); err != nil {
-		result = token.String
-		return
+		evaluating:        make(map[string]bool),
 	}
+}
+
+// formattedCalcResult provides a function to get the formatted value of the
+// formula calculation result by given worksheet name, cell reference, result
+// and whether to get the raw value without the number format.
+func (f *File) formattedCalcResult(sheet, cell string, token formulaArg, rawCellValue bool) (result string, err error) {
+	var styleIdx int
 	if !rawCellValue {
 		styleIdx, _ = f.GetCellStyle(sheet, cell)
 	}
@@ -821,6 +834,365 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 	return
 }
 
+// EvalError defines an error of the formula evaluation, the Code is the
+// formula error, such as "#DIV/0!", "#REF!" or "#VALUE!", and the Message
+// describes the reason of the error.
+type EvalError struct {
+	Code    string
+	Message string
+}
+
+func (err EvalError) Error() string {
+	if err.Message == "" || err.Message == err.Code {
+		return err.Code
+	}
+	return fmt.Sprintf("%s %s", err.Code, err.Message)
+}
+
+// CellValue directly maps the typed result of the formula evaluation. The Type
+// is CellTypeNumber for numeric results, CellTypeBool for boolean results,
+// CellTypeInlineString for text results even if the text looks like a number,
+// CellTypeError for formula errors and CellTypeUnset for empty results. The
+// Number specifies the numeric value of the numeric and boolean results, and
+// the String specifies the formatted value of the result.
+type CellValue struct {
+	Type   CellType
+	Number float64
+	String string
+}
+
+// formulaErrors defined the formula errors which can be the result of the
+// formula evaluation.
+var formulaErrors = map[string]bool{
+	formulaErrorDIV: true, formulaErrorNAME: true, formulaErrorNA: true, formulaErrorNUM: true,
+	formulaErrorVALUE: true, formulaErrorREF: true, formulaErrorNULL: true, formulaErrorSPILL: true,
+	formulaErrorCALC: true, formulaErrorGETTINGDATA: true,
+}
+
+// CalcCellTypedValue provides a function to get the typed result of the
+// formula evaluation by given worksheet name and cell reference, so that the
+// numeric results can be used without parsing the formatted value. This
+// function returns the EvalError if the formula evaluates to a formula error,
+// and the Type of the result will be CellTypeError. For example:
+//
+//	val, err := f.CalcCellTypedValue("Sheet1", "A3")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if val.Type == excelize.CellTypeNumber {
+//	    fmt.Println(val.Number * 2)
+//	}
+//
+// 根据给定的工作表名和单元格坐标计算单元格公式，并返回带有数据类型的计算结果。
+func (f *File) CalcCellTypedValue(sheet, cell string, opts ...Options) (CellValue, error) {
+	return f.calcCellTypedValue(newCalcContext(sheet, cell, opts...), sheet, cell, getOptions(opts...).RawCellValue)
+}
+
+// calcCellTypedValue provides a function to get the typed result of the
+// formula evaluation by given context, worksheet name, cell reference and
+// whether to get the raw value without the number format.
+func (f *File) calcCellTypedValue(ctx *calcContext, sheet, cell string, rawCellValue bool) (CellValue, error) {
+	var value CellValue
+	token, err := f.calcCellValue(ctx, sheet, cell)
+	if err != nil || token.Type == ArgError {
+		code, msg := token.String, token.Error
+		if err != nil {
+			msg = err.Error()
+		}
+		if !formulaErrors[code] {
+			if code = msg; !formulaErrors[code] {
+				return value, err
+			}
+		}
+		value.Type, value.String = CellTypeError, code
+		return value, EvalError{Code: code, Message: msg}
+	}
+	switch token.Type {
+	case ArgNumber:
+		value.Type, value.Number = CellTypeNumber, token.Number
+		if token.Boolean {
+			value.Type = CellTypeBool
+		}
+	case ArgString:
+		value.Type = CellTypeInlineString
+	}
+	if token.Value() == "" {
+		return CellValue{}, err
+	}
+	if value.Type == CellTypeInlineString {
+		value.String = token.String
+		return value, err
+	}
+	value.String, err = f.formattedCalcResult(sheet, cell, token, rawCellValue)
+	return value, err
+}
+
+// EvaluateFormula provides a function to evaluate the formula of the cell by
+// given worksheet name and cell reference, and update the cached value of the
+// cell with the result, so that the computed value can be read immediately
+// after the formula was set. The cell without formula will be kept unchanged,
+// and the result will be empty. The cross worksheet references and the formula
+// functions supported by the CalcCellValue function are supported. This
+// function returns the EvalError if the formula evaluates to a formula error,
+// and returns an error if the formula contains a circular reference when
+// iterative calculation is disabled. For example:
+//
+//	err := f.SetCellFormula("Sheet1", "A3", "=SUM(A1:A2)")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	result, err := f.EvaluateFormula("Sheet1", "A3")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(result)
+//
+// 根据给定的工作表名和单元格坐标计算单元格公式，并使用计算结果更新单元格的缓存值。
+func (f *File) EvaluateFormula(sheet, cell string) (string, error) {
+	ctx := newCalcContext(sheet, cell)
+	value, err := f.calcCellTypedValue(ctx, sheet, cell, false)
+	if ctx.circularRef != "" && f.options.MaxCalcIterations == 0 {
+		return "", newCircularReferenceError(ctx.circularRef)
+	}
+	if _, ok := err.(EvalError); err != nil && !ok {
+		return value.String, err
+	}
+	f.mu.Lock()
+	ws, wsErr := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if wsErr != nil {
+		return value.String, wsErr
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, cellErr := ws.getFormulaCell(cell)
+	if cellErr != nil || c == nil {
+		return value.String, cellErr
+	}
+	c.IS = nil
+	switch value.Type {
+	case CellTypeNumber:
+		c.T, c.V = "", strconv.FormatFloat(value.Number, 'f', -1, 64)
+	case CellTypeBool:
+		c.T, c.V = setCellBool(value.Number != 0)
+	case CellTypeError:
+		c.T, c.V = "e", value.String
+	case CellTypeInlineString:
+		c.T, c.V = "str", value.String
+	default:
+		c.T, c.V = "", ""
+	}
+	return value.String, err
+}
+
+// getFormulaCell provides a function to get the existing cell which contains
+// a formula by given cell reference, returns nil if the cell doesn't exist or
+// has no formula, so that the constant cells will not be overwritten.
+func (ws *xlsxWorksheet) getFormulaCell(cell string) (*xlsxC, error) {
+	cell, err := ws.mergeCellsParser(cell)
+	if err != nil {
+		return nil, err
+	}
+	_, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if rowData.R != row {
+			continue
+		}
+		for colIdx := range rowData.C {
+			if c := &rowData.C[colIdx]; c.R == cell && c.F != nil {
+				return c, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// formulaCell defines the worksheet name and coordinates of the formula cell.
+type formulaCell struct {
+	sheet    string
+	col, row int
+}
+
+// CalculateWorkbook provides a function to evaluate the formulas of all cells
+// in the workbook and update the cached values of the cells with the results,
+// so that the computed values can be read by the GetCellValue function and
+// the other spreadsheet readers without recalculation. The formula cells will
+// be evaluated in the order of dependency, the cells referenced by the other
+// formulas will be evaluated first. The formula errors, such as "#DIV/0!",
+// will be cached as the error values of the cells, and this function returns
+// an error if the formula contains a circular reference when iterative
+// calculation is disabled. The full calculation on load flag of the workbook
+// will be cleared after the calculation. For example:
+//
+//	if err := f.CalculateWorkbook(); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// 计算工作簿中全部公式单元格的值，并更新单元格的缓存值。
+func (f *File) CalculateWorkbook() error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	cells, err := f.getFormulaCells()
+	if err != nil {
+		return err
+	}
+	for _, fc := range f.sortFormulaCells(cells) {
+		cell, _ := CoordinatesToCellName(fc.col, fc.row)
+		if _, err = f.EvaluateFormula(fc.sheet, cell); err != nil {
+			if _, ok := err.(EvalError); !ok {
+				return err
+			}
+		}
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = &xlsxCalcPr{}
+	}
+	wb.CalcPr.FullCalcOnLoad = false
+	return nil
+}
+
+// getFormulaCells provides a function to get the formula cells of all
+// worksheets in the workbook in the order of the worksheets, rows and columns.
+func (f *File) getFormulaCells() ([]formulaCell, error) {
+	var cells []formulaCell
+	for _, sheet := range f.GetSheetList() {
+		if sheetXMLPath, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(sheetXMLPath, "xl/worksheets/") {
+			continue
+		}
+		f.mu.Lock()
+		ws, err := f.workSheetReader(sheet)
+		f.mu.Unlock()
+		if err != nil {
+			return cells, err
+		}
+		ws.mu.Lock()
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F == nil {
+					continue
+				}
+				if col, row, err := CellNameToCoordinates(c.R); err == nil {
+					cells = append(cells, formulaCell{sheet: sheet, col: col, row: row})
+				}
+			}
+		}
+		ws.mu.Unlock()
+	}
+	return cells, nil
+}
+
+// sortFormulaCells provides a function to sort the formula cells in the order
+// of dependency by topological sorting, the cells referenced by the other
+// formulas will be placed in front of them. The cells in the circular
+// references will be placed at the end in the original order. The formula
+// cells are indexed by the worksheets and sorted by the rows, so only the
+// cells in the rows of each referenced range will be checked.
+func (f *File) sortFormulaCells(cells []formulaCell) []formulaCell {
+	sheetCells := map[string][]int{}
+	for i, fc := range cells {
+		sheetCells[fc.sheet] = append(sheetCells[fc.sheet], i)
+	}
+	for _, indexes := range sheetCells {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return cells[indexes[a]].row < cells[indexes[b]].row
+		})
+	}
+	inDegree, dependents := make([]int, len(cells)), make([][]int, len(cells))
+	for i, fc := range cells {
+		cell, _ := CoordinatesToCellName(fc.col, fc.row)
+		formula, _ := f.GetCellFormula(fc.sheet, cell)
+		for _, cr := range f.getFormulaRanges(fc.sheet, formula) {
+			indexes := sheetCells[cr.From.Sheet]
+			for k := sort.Search(len(indexes), func(k int) bool {
+				return cells[indexes[k]].row >= cr.From.Row
+			}); k < len(indexes) && cells[indexes[k]].row <= cr.To.Row; k++ {
+				if j, ref := indexes[k], cells[indexes[k]]; j != i && cr.From.Col <= ref.col && ref.col <= cr.To.Col {
+					dependents[j] = append(dependents[j], i)
+					inDegree[i]++
+				}
+			}
+		}
+	}
+	queue, sorted, visited := make([]int, 0, len(cells)), make([]formulaCell, 0, len(cells)), make([]bool, len(cells))
+	for i := range cells {
+		if inDegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue, visited[i] = queue[1:], true
+		sorted = append(sorted, cells[i])
+		for _, j := range dependents[i] {
+			if inDegree[j]--; inDegree[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+	for i, fc := range cells {
+		if !visited[i] {
+			sorted = append(sorted, fc)
+		}
+	}
+	return sorted
+}
+
+// getFormulaRanges provides a function to get the cell ranges referenced by
+// the formula by given worksheet name and formula, the defined names in the
+// formula will be resolved to the references. The invalid references will be
+// ignored.
+func (f *File) getFormulaRanges(sheet, formula string) []cellRange {
+	var ranges []cellRange
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		reference := token.TValue
+		if refTo := f.getDefinedNameRefTo(reference, sheet); refTo != "" {
+			reference = refTo
+		}
+		var cr cellRange
+		for i, ref := range strings.Split(strings.ReplaceAll(reference, "$", ""), ":") {
+			cellRef, col, row, err := f.parseRef(ref)
+			if err != nil {
+				cr.From.Sheet = ""
+				break
+			}
+			if i == 0 {
+				if cellRef.Sheet == "" {
+					cellRef.Sheet = sheet
+				}
+				if col {
+					cellRef.Row = 1
+				}
+				if row {
+					cellRef.Col = 1
+				}
+				cr.From, cr.To = cellRef, cellRef
+				continue
+			}
+			if err = cr.prepareCellRange(col, row, cellRef); err != nil {
+				cr.From.Sheet = ""
+				break
+			}
+		}
+		if cr.From.Sheet != "" {
+			ranges = append(ranges, cr)
+		}
+	}
+	return ranges
+}
+
 // calcCellValue calculate cell value by given context, worksheet name and cell
 // reference.
 func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
@@ -995,7 +1367,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 					// calculate trigger
 					topOpt := opftStack.Peek().(efp.Token)
 					if err := calculate(opfdStack, topOpt); err != nil {
-						argsStack.Peek().(*list.List).PushFront(newErrorFormulaArg(formulaErrorVALUE, err.Error()))
+						argsStack.Peek().(*list.List).PushBack(newErrorFormulaArg(formulaErrorVALUE, err.Error()))
 					}
 					opftStack.Pop()
 				}
@@ -1059,11 +1431,14 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 			argsStack.Peek().(*list.List).PushBack(arg)
 		}
 	} else {
-		val := arg.Value()
 		if arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 {
-			val = arg.Matrix[0][0].Value()
+			arg = arg.Matrix[0][0]
 		}
-		opdStack.Push(newStringFormulaArg(val))
+		// keep the numeric and boolean result type of the formula function
+		if arg.Type != ArgNumber {
+			arg = newStringFormulaArg(arg.Value())
+		}
+		opdStack.Push(arg)
 	}
 	return newEmptyFormulaArg()
 }
@@ -1576,16 +1951,27 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 	if formula, _ := f.GetCellFormula(sheet, cell); len(formula) != 0 {
 		ctx.mu.Lock()
 		if ctx.entry != ref {
+			if ctx.evaluating[ref] && ctx.circularRef == "" {
+				ctx.circularRef = ref
+			}
 			if ctx.iterations[ref] <= f.options.MaxCalcIterations {
 				ctx.iterations[ref]++
+				ctx.evaluating[ref] = true
 				ctx.mu.Unlock()
 				arg, _ = f.calcCellValue(ctx, sheet, cell)
+				ctx.mu.Lock()
+				delete(ctx.evaluating, ref)
 				ctx.iterationsCache[ref] = arg
+				ctx.mu.Unlock()
 				return arg, nil
 			}
 			ctx.mu.Unlock()
 			return ctx.iterationsCache[ref], nil
 		}
+		// The formula references the cell being calculated
+		if ctx.circularRef == "" {
+			ctx.circularRef = ref
+		}
 		ctx.mu.Unlock()
 	}
 	if value, err = f.GetCellValue(sheet, cell, Options{RawCellValue: true}); err != nil {
@@ -11643,15 +12029,46 @@ func (fn *formulaFuncs) IFS(argsList *list.List) formulaArg {
 	if argsList.Len() < 2 {
 		return newErrorFormulaArg(formulaErrorVALUE, "IFS requires at least 2 arguments")
 	}
-	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
-		if arg.Value.(formulaArg).ToBool().Number == 1 {
+	if argsList.Len()%2 != 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, "IFS requires an even number of arguments")
+	}
+	for arg := argsList.Front(); arg != nil; arg = arg.Next().Next() {
+		condition := ifsCondition(arg.Value.(formulaArg))
+		if condition.Type == ArgError {
+			return condition
+		}
+		if condition.Number == 1 {
 			return arg.Next().Value.(formulaArg)
 		}
-		arg = arg.Next()
 	}
 	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 }
 
+// ifsCondition evaluates the logical test of the IFS function. The non-zero
+// numbers are treated as TRUE, the empty values are treated as FALSE, and the
+// text which can't be converted to a logical value results in the #VALUE!
+// error.
+func ifsCondition(arg formulaArg) formulaArg {
+	switch arg.Type {
+	case ArgError:
+		return arg
+	case ArgNumber:
+		return newBoolFormulaArg(arg.Number != 0)
+	case ArgString:
+		if arg.String == "" {
+			return newBoolFormulaArg(false)
+		}
+		if num := arg.ToNumber(); num.Type == ArgNumber {
+			return newBoolFormulaArg(num.Number != 0)
+		}
+		if b := arg.ToBool(); b.Type != ArgError {
+			return b
+		}
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	return newBoolFormulaArg(false)
+}
+
 // NOT function returns the opposite to a supplied logical value. The syntax
 // of the function is:
 //
@@ -11727,6 +12144,9 @@ func (fn *formulaFuncs) SWITCH(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "SWITCH requires at least 3 arguments")
 	}
 	target := argsList.Front().Value.(formulaArg)
+	if target.Type == ArgError {
+		return target
+	}
 	argCount := argsList.Len() - 1
 	switchCount := int(math.Floor(float64(argCount) / 2))
 	hasDefaultClause := argCount%2 != 0
@@ -11738,7 +12158,7 @@ func (fn *formulaFuncs) SWITCH(argsList *list.List) formulaArg {
 		arg := argsList.Front()
 		for i := 0; i < switchCount; i++ {
 			arg = arg.Next()
-			if target.Value() == arg.Value.(formulaArg).Value() {
+			if strings.EqualFold(target.Value(), arg.Value.(formulaArg).Value()) {
 				result = arg.Next().Value.(formulaArg)
 				break
 			}
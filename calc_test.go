@@ -1464,6 +1464,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=IFS(4>1,5/4,4<-1,-5/4,TRUE,0)":     "1.25",
 		"=IFS(-2>1,5/-2,-2<-1,-5/-2,TRUE,0)": "2.5",
 		"=IFS(0>1,5/0,0<-1,-5/0,TRUE,0)":     "0",
+		"=IFS(A4,\"A\",A1,\"B\")":            "B",
+		"=IFS(C1,1,5,2,TRUE,1/0)":            "2",
+		"=IFS(\"FALSE\",1,\"TRUE\",2)":       "2",
 		// NOT
 		"=NOT(FALSE())":     "TRUE",
 		"=NOT(\"false\")":   "TRUE",
@@ -1480,6 +1483,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=SWITCH(1,1,\"A\",2,\"B\",3,\"C\",\"N\")": "A",
 		"=SWITCH(3,1,\"A\",2,\"B\",3,\"C\",\"N\")": "C",
 		"=SWITCH(4,1,\"A\",2,\"B\",3,\"C\",\"N\")": "N",
+		"=SWITCH(D2,\"FEB\",2,\"JAN\",1)":          "1",
+		"=SWITCH(A2,1,\"A\",2,\"B\")":              "B",
+		"=SWITCH(TRUE,1,\"A\",\"N\")":              "N",
 		// TRUE
 		"=TRUE()": "TRUE",
 		// XOR
@@ -1489,7 +1495,7 @@ func TestCalcCellValue(t *testing.T) {
 		// Date and Time Functions
 		// DATE
 		"=DATE(2020,10,21)": "2020-10-21 00:00:00 +0000 UTC",
-		"=DATE(1900,1,1)":   "1899-12-31 00:00:00 +0000 UTC",
+		"=DATE(1900,1,1)":   "1900-01-01 00:00:00 +0000 UTC",
 		// DATEDIF
 		"=DATEDIF(43101,43101,\"D\")":  "0",
 		"=DATEDIF(43101,43891,\"d\")":  "790",
@@ -3490,8 +3496,11 @@ func TestCalcCellValue(t *testing.T) {
 		// IFNA
 		"=IFNA()": {"#VALUE!", "IFNA requires 2 arguments"},
 		// IFS
-		"=IFS()":            {"#VALUE!", "IFS requires at least 2 arguments"},
-		"=IFS(FALSE,FALSE)": {"#N/A", "#N/A"},
+		"=IFS()":                  {"#VALUE!", "IFS requires at least 2 arguments"},
+		"=IFS(FALSE,FALSE)":       {"#N/A", "#N/A"},
+		"=IFS(FALSE,1,TRUE)":      {"#VALUE!", "IFS requires an even number of arguments"},
+		"=IFS(NA(),1,TRUE,2)":     {"#N/A", "#N/A"},
+		"=IFS(\"text\",1,TRUE,2)": {"#VALUE!", "#VALUE!"},
 		// NOT
 		"=NOT()":      {"#VALUE!", "NOT requires 1 argument"},
 		"=NOT(NOT())": {"#VALUE!", "NOT requires 1 argument"},
@@ -3503,8 +3512,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=OR()":                                  {"#VALUE!", "OR requires at least 1 argument"},
 		"=OR(1" + strings.Repeat(",1", 30) + ")": {"#VALUE!", "OR accepts at most 30 arguments"},
 		// SWITCH
-		"=SWITCH()":      {"#VALUE!", "SWITCH requires at least 3 arguments"},
-		"=SWITCH(0,1,2)": {"#N/A", "#N/A"},
+		"=SWITCH()":           {"#VALUE!", "SWITCH requires at least 3 arguments"},
+		"=SWITCH(0,1,2)":      {"#N/A", "#N/A"},
+		"=SWITCH(NA(),1,2,3)": {"#N/A", "#N/A"},
 		// TRUE
 		"=TRUE(A1)": {"#VALUE!", "TRUE takes no arguments"},
 		// XOR
@@ -5923,3 +5933,206 @@ func TestParseToken(t *testing.T) {
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestEvaluateFormula(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2.5, "Go", true}))
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", 10))
+	for cell, formula := range map[string]string{
+		"A2": "=SUM(A1:B1)+Sheet2!A1",
+		"B2": "=UPPER(C1)&\"!\"",
+		"C2": "=NOT(D1)",
+		"D2": "=A1/0",
+		"E2": "=A2*2",
+		"F2": "=VLOOKUP(1,A1:B1,2,FALSE)",
+		"I2": "=\"1\"&\"2\"",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	for cell, expected := range map[string][]string{
+		"A2": {"13.5", "13.5", ""},
+		"B2": {"GO!", "GO!", "str"},
+		"C2": {"FALSE", "0", "b"},
+		"E2": {"27", "27", ""},
+		"F2": {"2.5", "2.5", ""},
+		"I2": {"12", "12", "str"},
+	} {
+		result, err := f.EvaluateFormula("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected[0], result, cell)
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		c, _, _, err := ws.prepareCell(cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1:], []string{c.V, c.T}, cell)
+		assert.NotNil(t, c.F, cell)
+	}
+	// Test evaluate formula with formula error
+	result, err := f.EvaluateFormula("Sheet1", "D2")
+	assert.Equal(t, formulaErrorDIV, result)
+	assert.Equal(t, EvalError{Code: formulaErrorDIV, Message: formulaErrorDIV}, err)
+	assert.EqualError(t, err, formulaErrorDIV)
+	cellType, err := f.GetCellType("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeError, cellType)
+	// Test evaluate formula with empty result
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G2", "=H2"))
+	result, err = f.EvaluateFormula("Sheet1", "G2")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// Test evaluate formula with circular reference
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=B3+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "=A3+1"))
+	_, err = f.EvaluateFormula("Sheet1", "A3")
+	assert.EqualError(t, err, newCircularReferenceError("Sheet1!A3").Error())
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "=SUM(C3:D3)"))
+	_, err = f.EvaluateFormula("Sheet1", "C3")
+	assert.EqualError(t, err, newCircularReferenceError("Sheet1!C3").Error())
+	// Test evaluate formula without circular reference for the cell referenced
+	// multiple times
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", "=B4+B4"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B4", "=A1+1"))
+	result, err = f.EvaluateFormula("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
+	// Test evaluate the constant cells without formula
+	for cell, expected := range map[string]string{"A1": "1", "C1": "Go", "J2": ""} {
+		result, err = f.EvaluateFormula("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Empty(t, result, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, val, cell)
+	}
+	cellType, err = f.GetCellType("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	// Test evaluate formula on not exists worksheet
+	_, err = f.EvaluateFormula("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test evaluate formula with invalid cell reference
+	_, err = f.EvaluateFormula("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestCalculateWorkbook(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Base", RefersTo: "Sheet1!$A$3"}))
+	for _, formula := range [][]string{
+		{"Sheet2", "A1", "=Sheet1!A2+1"},
+		{"Sheet1", "A2", "=Base*2"},
+		{"Sheet1", "A3", "=SUM(A1)+1"},
+		{"Sheet1", "B1", "=A1/0"},
+		{"Sheet1", "C1", "=\"Go\"&A3"},
+	} {
+		assert.NoError(t, f.SetCellFormula(formula[0], formula[1], formula[2]))
+	}
+	cells, err := f.getFormulaCells()
+	assert.NoError(t, err)
+	assert.Equal(t, []formulaCell{
+		{"Sheet1", 2, 1}, {"Sheet1", 1, 3}, {"Sheet1", 3, 1}, {"Sheet1", 1, 2}, {"Sheet2", 1, 1},
+	}, f.sortFormulaCells(cells))
+	// Test sort the formula cells which are not in the order of rows
+	assert.Equal(t, []formulaCell{{"Sheet1", 1, 3}, {"Sheet1", 1, 2}, {"Sheet2", 1, 1}},
+		f.sortFormulaCells([]formulaCell{{"Sheet2", 1, 1}, {"Sheet1", 1, 3}, {"Sheet1", 1, 2}}))
+	assert.NoError(t, f.CalculateWorkbook())
+	for _, expected := range [][]string{
+		{"Sheet1", "A2", "4", ""},
+		{"Sheet1", "A3", "2", ""},
+		{"Sheet1", "B1", formulaErrorDIV, "e"},
+		{"Sheet1", "C1", "Go2", "str"},
+		{"Sheet2", "A1", "5", ""},
+	} {
+		ws, err := f.workSheetReader(expected[0])
+		assert.NoError(t, err)
+		c, _, _, err := ws.prepareCell(expected[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected[2:], []string{c.V, c.T}, expected[1])
+	}
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.False(t, wb.CalcPr.FullCalcOnLoad)
+	// Test calculate workbook with the full calculation on load flag
+	wb.CalcPr.FullCalcOnLoad = true
+	assert.NoError(t, f.CalculateWorkbook())
+	assert.False(t, wb.CalcPr.FullCalcOnLoad)
+	// Test calculate workbook with chart sheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$A$2:$A$3"}},
+	}))
+	assert.NoError(t, f.CalculateWorkbook())
+	// Test calculate workbook with circular reference
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=E1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=D1+1"))
+	cells, err = f.getFormulaCells()
+	assert.NoError(t, err)
+	sorted := f.sortFormulaCells(cells)
+	assert.Equal(t, []formulaCell{{"Sheet1", 4, 1}, {"Sheet1", 5, 1}}, sorted[len(sorted)-2:])
+	assert.EqualError(t, f.CalculateWorkbook(), newCircularReferenceError("Sheet1!D1").Error())
+	// Test get formula ranges with whole column and row references
+	assert.Equal(t, []cellRange{
+		{From: cellRef{Sheet: "Sheet1", Col: 1, Row: 1}, To: cellRef{Sheet: "Sheet1", Col: 1, Row: TotalRows}},
+		{From: cellRef{Sheet: "Sheet2", Col: 1, Row: 2}, To: cellRef{Sheet: "Sheet2", Col: MaxColumns, Row: 3}},
+	}, f.getFormulaRanges("Sheet1", "=SUM(A:A,Sheet2!2:3)"))
+	// Test get formula ranges with invalid references
+	assert.Empty(t, f.getFormulaRanges("Sheet1", "=SUM(A1:Sheet2!B2)+XYZ1048577"))
+	// Test calculate workbook with invalid worksheet
+	f.Sheet.Store("xl/worksheets/sheet1.xml", nil)
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CalculateWorkbook(), "XML syntax error on line 1: invalid UTF-8")
+	// Test calculate workbook with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CalculateWorkbook(), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCalcCellTypedValue(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2.5))
+	style, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	for formula, expected := range map[string]CellValue{
+		"=A1*2":        {Type: CellTypeNumber, Number: 5, String: "500%"},
+		"=A1>0":        {Type: CellTypeBool, Number: 1, String: "TRUE"},
+		"=NOT(A1>0)":   {Type: CellTypeBool, String: "FALSE"},
+		"=\"Go\"":      {Type: CellTypeInlineString, String: "Go"},
+		"=\"1\"&\"2\"": {Type: CellTypeInlineString, String: "12"},
+		"=\"TRUE\"":    {Type: CellTypeInlineString, String: "TRUE"},
+		"=SUM(A1,1)":   {Type: CellTypeNumber, Number: 3.5, String: "350%"},
+		"=C1":          {},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		value, err := f.CalcCellTypedValue("Sheet1", "B1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, value, formula)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=A1*2"))
+	value, err := f.CalcCellTypedValue("Sheet1", "B1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", value.String)
+	// Test calculate the typed value with formula errors
+	for formula, expected := range map[string]string{
+		"=1/0":      formulaErrorDIV,
+		"=NA()":     formulaErrorNA,
+		"=SQRT(-1)": formulaErrorNUM,
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		value, err := f.CalcCellTypedValue("Sheet1", "B1")
+		assert.IsType(t, EvalError{}, err, formula)
+		assert.Equal(t, expected, err.(EvalError).Code, formula)
+		assert.Equal(t, CellValue{Type: CellTypeError, String: expected}, value, formula)
+	}
+	assert.Equal(t, "#NUM! SQRT requires 1 numeric argument", EvalError{Code: formulaErrorNUM, Message: "SQRT requires 1 numeric argument"}.Error())
+	// Test calculate the typed value on not exists worksheet
+	_, err = f.CalcCellTypedValue("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
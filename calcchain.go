@@ -13,7 +13,6 @@ package excelize
 
 import (
 	"bytes"
-	"encoding/xml"
 	"io"
 )
 
@@ -30,15 +29,6 @@ func (f *File) calcChainReader() (*xlsxCalcChain, error) {
 	return f.CalcChain, nil
 }
 
-// calcChainWriter provides a function to save xl/calcChain.xml after
-// serialize structure.
-func (f *File) calcChainWriter() {
-	if f.CalcChain != nil && f.CalcChain.C != nil {
-		output, _ := xml.Marshal(f.CalcChain)
-		f.saveFileList(defaultXMLPathCalcChain, output)
-	}
-}
-
 // deleteCalcChain provides a function to remove cell reference on the
 // calculation chain.
 func (f *File) deleteCalcChain(index int, cell string) error {
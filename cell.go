@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strconv"
@@ -97,6 +98,69 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 	return cellType, err
 }
 
+// GetCellTime provides a function to get the date and time value of the cell
+// by given worksheet name, cell reference and time zone. It returns true if
+// the cell is a date and time cell, which is a numeric cell with a date or
+// time number format, or a cell in the ISO 8601 date type. The serial number
+// of the cell will be converted with the date system of the workbook, and the
+// date and time in the cell will be treated as the local time of the given
+// time zone, use UTC if the time zone is nil. It returns the zero time and
+// false if the cell is not a date and time cell. For example, get the date of
+// the cell A1 on Sheet1 in the local time zone:
+//
+//	t, isDate, err := f.GetCellTime("Sheet1", "A1", time.Local)
+//
+// 根据给定的工作表名、单元格坐标和时区获取单元格的日期和时间值，若单元格不是日期和时间单元格将返回 false。
+func (f *File) GetCellTime(sheet, cell string, tz *time.Location) (time.Time, bool, error) {
+	var (
+		t      time.Time
+		isDate bool
+	)
+	if tz == nil {
+		tz = time.UTC
+	}
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		var err error
+		t, isDate, err = f.getCellTime(c, tz)
+		return "", true, err
+	})
+	return t, isDate, err
+}
+
+// getCellTime provides a function to get the date and time value of the cell
+// by given cell and time zone.
+func (f *File) getCellTime(c *xlsxC, tz *time.Location) (time.Time, bool, error) {
+	if c.T == "d" {
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+			if t, err := time.ParseInLocation(layout, c.V, tz); err == nil {
+				return t, true, nil
+			}
+		}
+		return time.Time{}, false, nil
+	}
+	if (c.T != "" && c.T != "n") || c.V == "" {
+		return time.Time{}, false, nil
+	}
+	fmtCode, ok, err := f.getNumFmtCode(c.S)
+	if err != nil || !ok || !isDateTimeNumFmt(fmtCode) {
+		return time.Time{}, false, err
+	}
+	excelTime, err := strconv.ParseFloat(c.V, 64)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	var date1904 bool
+	wb, err := f.workbookReader()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	t := timeFromExcelTime(excelTime, date1904)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), tz), true, err
+}
+
 // SetCellValue provides a function to set the value of a cell. This function
 // is concurrency safe. The specified coordinates should not be in the first
 // row of the table, a complex number can be set with string text. The
@@ -129,35 +193,39 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 // format style for the cell.
 // 根据给定的工作表名和单元格坐标设置单元格的值。此功能是并发安全的。指定的坐标不应在表格的第一行范围，使用字符文本设置复数。
 func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
-	var err error
-	switch v := value.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		err = f.setCellIntFunc(sheet, cell, v)
-	case float32:
-		err = f.SetCellFloat(sheet, cell, float64(v), -1, 32)
-	case float64:
-		err = f.SetCellFloat(sheet, cell, v, -1, 64)
-	case string:
-		err = f.SetCellStr(sheet, cell, v)
-	case []byte:
-		err = f.SetCellStr(sheet, cell, string(v))
-	case time.Duration:
-		_, d := setCellDuration(v)
-		err = f.SetCellDefault(sheet, cell, d)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	var date1904 bool
+	if _, ok := value.(time.Time); ok {
+		wb, err := f.workbookReader()
 		if err != nil {
 			return err
 		}
-		err = f.setDefaultTimeStyle(sheet, cell, 21)
-	case time.Time:
-		err = f.setCellTimeFunc(sheet, cell, v)
-	case bool:
-		err = f.SetCellBool(sheet, cell, v)
-	case nil:
-		err = f.SetCellDefault(sheet, cell, "")
-	default:
-		err = f.SetCellStr(sheet, cell, fmt.Sprint(value))
+		if wb != nil && wb.WorkbookPr != nil {
+			date1904 = wb.WorkbookPr.Date1904
+		}
 	}
-	return err
+	ws.mu.Lock()
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		ws.mu.Unlock()
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	numFmt, err := f.setCellValueOfType(c, value, date1904)
+	if err == nil {
+		err = f.removeFormula(c, ws, sheet)
+	}
+	ws.mu.Unlock()
+	if err != nil || numFmt == 0 {
+		return err
+	}
+	return f.setDefaultTimeStyle(sheet, cell, numFmt)
 }
 
 // String extracts characters from a string item.
@@ -205,65 +273,6 @@ func (f *File) removeFormula(c *xlsxC, ws *xlsxWorksheet, sheet string) error {
 	return nil
 }
 
-// setCellIntFunc is a wrapper of SetCellInt.
-func (f *File) setCellIntFunc(sheet, cell string, value interface{}) error {
-	var err error
-	switch v := value.(type) {
-	case int:
-		err = f.SetCellInt(sheet, cell, v)
-	case int8:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int16:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int32:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int64:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint8:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint16:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint32:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint64:
-		err = f.SetCellInt(sheet, cell, int(v))
-	}
-	return err
-}
-
-// setCellTimeFunc provides a method to process time type of value for
-// SetCellValue.
-func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return err
-	}
-	c, col, row, err := ws.prepareCell(cell)
-	if err != nil {
-		return err
-	}
-	ws.mu.Lock()
-	c.S = ws.prepareCellStyle(col, row, c.S)
-	ws.mu.Unlock()
-	var date1904, isNum bool
-	wb, err := f.workbookReader()
-	if err != nil {
-		return err
-	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
-	if isNum, err = c.setCellTime(value, date1904); err != nil {
-		return err
-	}
-	if isNum {
-		_ = f.setDefaultTimeStyle(sheet, cell, 22)
-	}
-	return err
-}
-
 // setCellTime prepares cell type and Excel time by given Go time.Time type
 // timestamp.
 func (c *xlsxC) setCellTime(value time.Time, date1904 bool) (isNum bool, err error) {
@@ -561,6 +570,14 @@ func (c *xlsxC) setCellDefault(value string) {
 // getCellDate parse cell value which contains a date in the ISO 8601 format.
 func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 	if !raw {
+		var date1904 bool
+		wb, err := f.workbookReader()
+		if err != nil {
+			return c.V, err
+		}
+		if wb != nil && wb.WorkbookPr != nil {
+			date1904 = wb.WorkbookPr.Date1904
+		}
 		layout := "20060102T150405.999"
 		if strings.HasSuffix(c.V, "Z") {
 			layout = "20060102T150405Z"
@@ -571,7 +588,7 @@ func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 			layout = "2006-01-02 15:04:05Z"
 		}
 		if timestamp, err := time.Parse(layout, strings.ReplaceAll(c.V, ",", ".")); err == nil {
-			excelTime, _ := timeToExcelTime(timestamp, false)
+			excelTime, _ := timeToExcelTime(timestamp, date1904)
 			c.V = strconv.FormatFloat(excelTime, 'G', 15, 64)
 		}
 	}
@@ -784,6 +801,95 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 	return err
 }
 
+// AddFormulaCell provides a function to set the formula and the cached value
+// of the formula result for the cell by given worksheet name, cell reference,
+// formula and cached value. The data type of the cached value will be
+// detected by the type of the given value, so that the applications which
+// read the cached value can get the formula result without recalculating.
+// The supported data types of the cached value are the integer, float, string,
+// []byte, bool, time.Duration, time.Time and nil, and the value in other data
+// types will be stored as string. For example, set the formula "=SUM(A1:B1)"
+// with the cached value 3 for the cell "C1" on "Sheet1":
+//
+//	err := f.AddFormulaCell("Sheet1", "C1", "=SUM(A1:B1)", 3)
+//
+// 根据给定的工作表名、单元格坐标、公式和缓存值设置单元格公式及其计算结果的缓存值，缓存值的数据类型将根据给定值的类型自动识别。
+func (f *File) AddFormulaCell(sheet, cell, formula string, cachedValue interface{}) error {
+	if formula == "" {
+		return ErrParameterInvalid
+	}
+	var date1904 bool
+	if _, ok := cachedValue.(time.Time); ok {
+		wb, err := f.workbookReader()
+		if err != nil {
+			return err
+		}
+		if wb != nil && wb.WorkbookPr != nil {
+			date1904 = wb.WorkbookPr.Date1904
+		}
+	}
+	if err := f.SetCellFormula(sheet, cell, formula); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	c, _, _, err := ws.prepareCell(cell)
+	if err != nil {
+		ws.mu.Unlock()
+		return err
+	}
+	err = c.setFormulaCachedValue(cachedValue, date1904)
+	ws.mu.Unlock()
+	if _, ok := cachedValue.(time.Time); ok && err == nil {
+		err = f.setDefaultTimeStyle(sheet, cell, 22)
+	}
+	return err
+}
+
+// setFormulaCachedValue provides a function to set cell data type and cached
+// value of the formula cell by given value.
+func (c *xlsxC) setFormulaCachedValue(value interface{}, date1904 bool) error {
+	c.IS = nil
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		c.T, c.V = "", fmt.Sprint(v)
+	case float32:
+		c.T, c.V = setCellFloat(float64(v), -1, 32)
+	case float64:
+		c.T, c.V = setCellFloat(v, -1, 64)
+	case string:
+		c.setStr(v)
+	case []byte:
+		c.setStr(string(v))
+	case time.Duration:
+		c.T, c.V = setCellDuration(v)
+	case time.Time:
+		_, offset := v.Zone()
+		excelTime, err := timeToExcelTime(v.Add(time.Duration(offset)*time.Second), date1904)
+		if err != nil {
+			return err
+		}
+		if excelTime > 0 {
+			c.T, c.V = "", strconv.FormatFloat(excelTime, 'f', -1, 64)
+			break
+		}
+		c.setStr(v.Format(time.RFC3339Nano))
+	case bool:
+		c.T, c.V = setCellBool(v)
+	case nil:
+		c.T, c.V = "", ""
+	default:
+		c.setStr(fmt.Sprint(value))
+	}
+	return nil
+}
+
 // setSharedFormula set shared formula for the cells.
 func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	coordinates, err := rangeRefToCoordinates(ref)
@@ -854,6 +960,78 @@ func (f *File) GetCellHyperLink(sheet, cell string) (bool, string, error) {
 	return false, "", err
 }
 
+// Hyperlink directly maps the settings of a cell hyperlink. Type is "External"
+// for a relationship-based link and "Location" for a link to a place in this
+// workbook.
+type Hyperlink struct {
+	Link    string
+	Type    string
+	Display string
+	Tooltip string
+}
+
+// HyperlinkOptions can be passed to GetAllHyperlinks to set optional scanning
+// behavior.
+type HyperlinkOptions struct {
+	IncludeHiddenSheets bool
+}
+
+// GetAllHyperlinks provides a function to get all hyperlinks of the workbook.
+// The returned map is keyed by worksheet name and then by the cell reference
+// (or range reference) of the hyperlink. Hidden worksheets are skipped unless
+// the IncludeHiddenSheets option is set, chart sheets, dialog sheets and macro
+// sheets are always skipped. For example, print the links of all visible
+// worksheets:
+//
+//	links, err := f.GetAllHyperlinks()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for sheet, cells := range links {
+//	    for cell, link := range cells {
+//	        fmt.Println(sheet, cell, link.Type, link.Link)
+//	    }
+//	}
+//
+// 获取工作簿中全部超链接，返回结果以工作表名称和单元格坐标为键。默认跳过隐藏的工作表，可通过 IncludeHiddenSheets 选项包含隐藏的工作表。
+func (f *File) GetAllHyperlinks(opts ...HyperlinkOptions) (map[string]map[string]Hyperlink, error) {
+	var includeHidden bool
+	for _, o := range opts {
+		includeHidden = o.IncludeHiddenSheets
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return nil, err
+	}
+	links := map[string]map[string]Hyperlink{}
+	for _, sheet := range wb.Sheets.Sheet {
+		if !includeHidden && sheet.State != "" && sheet.State != "visible" {
+			continue
+		}
+		if name, ok := f.getSheetXMLPath(sheet.Name); !ok || !strings.HasPrefix(name, "xl/worksheets/") {
+			continue
+		}
+		ws, err := f.workSheetReader(sheet.Name)
+		if err != nil {
+			return nil, err
+		}
+		if ws.Hyperlinks == nil || len(ws.Hyperlinks.Hyperlink) == 0 {
+			continue
+		}
+		cells := make(map[string]Hyperlink, len(ws.Hyperlinks.Hyperlink))
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			hyperlink := Hyperlink{Type: "Location", Link: link.Location, Display: link.Display, Tooltip: link.Tooltip}
+			if link.RID != "" {
+				hyperlink.Type, hyperlink.Link = "External", f.getSheetRelationshipsTargetByID(sheet.Name, link.RID)
+			}
+			cells[link.Ref] = hyperlink
+		}
+		links[sheet.Name] = cells
+	}
+	return links, nil
+}
+
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
 // attributes (e.g. display value)
 type HyperlinkOpts struct {
@@ -976,7 +1154,9 @@ func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 }
 
 // GetCellRichText provides a function to get rich text of cell by given
-// worksheet.
+// worksheet. Both of the rich text in the shared strings table and the inline
+// rich text will be returned, and nil will be returned for the cell which
+// doesn't contain the string value.
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -986,8 +1166,15 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 	if err != nil {
 		return
 	}
+	if c.T == "inlineStr" && c.IS != nil {
+		runs = getCellRichText(c.IS)
+		return
+	}
+	if c.T != "s" {
+		return
+	}
 	siIdx, err := strconv.Atoi(c.V)
-	if err != nil || c.T != "s" {
+	if err != nil {
 		return
 	}
 	sst, err := f.sharedStringsReader()
@@ -1082,8 +1269,9 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 }
 
 // SetCellRichText provides a function to set cell with rich text by given
-// worksheet. For example, set rich text on the A1 cell of the worksheet named
-// Sheet1:
+// worksheet. The rich text will be stored in the shared strings table, the
+// formula of the cell will be removed and the style of the cell will be kept.
+// For example, set rich text on the A1 cell of the worksheet named Sheet1:
 //
 //	package main
 //
@@ -1227,17 +1415,18 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	if si.R, err = setRichText(runs); err != nil {
 		return err
 	}
+	c.IS = nil
 	for idx, strItem := range sst.SI {
 		if reflect.DeepEqual(strItem, si) {
 			c.T, c.V = "s", strconv.Itoa(idx)
-			return err
+			return f.removeFormula(c, ws, sheet)
 		}
 	}
 	sst.SI = append(sst.SI, si)
 	sst.Count++
 	sst.UniqueCount++
 	c.T, c.V = "s", strconv.Itoa(len(sst.SI)-1)
-	return err
+	return f.removeFormula(c, ws, sheet)
 }
 
 // SetSheetRow writes an array to row by given worksheet name, starting
@@ -1252,6 +1441,131 @@ func (f *File) SetSheetRow(sheet, cell string, slice interface{}) error {
 	return f.setSheetCells(sheet, cell, slice, rows)
 }
 
+// SetSheetRows writes a two-dimensional array of values to the consecutive
+// rows by given worksheet name, starting row number and records, each record
+// will be written from the column A of the row. The worksheet will be read
+// and the rows and cells will be allocated once for all records, so that this
+// function is faster than setting the value of each cell one by one by
+// SetCellValue. The data type of each value is handled as the same as the
+// SetCellValue function. This function is concurrency safe. For example,
+// write the records to Sheet1 starting from row 2:
+//
+//	err := f.SetSheetRows("Sheet1", 2, [][]interface{}{
+//	    {"Apple", 3, 1.5},
+//	    {"Banana", 5, 0.25, true},
+//	})
+//
+// 根据给定的工作表名称、起始行号和二维数组从 A 列开始按行批量赋值。此功能是并发安全的。
+func (f *File) SetSheetRows(sheet string, startRow int, records [][]interface{}) error {
+	return f.setSheetRows(sheet, 1, startRow, records)
+}
+
+// setSheetRows provides a function to write the records to the consecutive
+// rows by given worksheet name, starting column number, starting row number
+// and records.
+func (f *File) setSheetRows(sheet string, startCol, startRow int, records [][]interface{}) error {
+	if startRow < 1 {
+		return newInvalidRowNumberError(startRow)
+	}
+	if startRow+len(records)-1 > TotalRows {
+		return ErrMaxRows
+	}
+	maxCol := 0
+	for _, record := range records {
+		if len(record) > maxCol {
+			maxCol = len(record)
+		}
+	}
+	if startCol+maxCol-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	var date1904 bool
+	if wb, err := f.workbookReader(); err != nil {
+		return err
+	} else if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	timeStyles := make(map[int]int)
+	for i, record := range records {
+		if len(record) > 0 {
+			ws.prepareSheetXML(startCol+len(record)-1, startRow+i)
+		}
+		for j, value := range record {
+			col, row := startCol+j, startRow+i
+			c := &ws.SheetData.Row[row-1].C[col-1]
+			if ws.MergeCells != nil {
+				cell, _ := CoordinatesToCellName(col, row)
+				if c, col, row, err = ws.prepareCell(cell); err != nil {
+					return err
+				}
+			}
+			c.S = ws.prepareCellStyle(col, row, c.S)
+			numFmt, err := f.setCellValueOfType(c, value, date1904)
+			if err != nil {
+				return err
+			}
+			if numFmt != 0 && c.S == 0 {
+				if _, ok := timeStyles[numFmt]; !ok {
+					if timeStyles[numFmt], err = f.NewStyle(&Style{NumFmt: numFmt}); err != nil {
+						return err
+					}
+				}
+				c.S = timeStyles[numFmt]
+			}
+			if err = f.removeFormula(c, ws, sheet); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// setCellValueOfType provides a function to set the value of the cell by
+// given cell, value and if the workbook uses the 1904 date system. It
+// detects the data type of the value for the SetCellValue, SetSheetRows and
+// SetRangeValue functions, and returns the built-in number format ID for the
+// date and time values.
+func (f *File) setCellValueOfType(c *xlsxC, value interface{}, date1904 bool) (numFmt int, err error) {
+	c.IS = nil
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		c.T, c.V = "", fmt.Sprint(v)
+	case float32:
+		c.T, c.V = setCellFloat(float64(v), -1, 32)
+	case float64:
+		c.T, c.V = setCellFloat(v, -1, 64)
+	case string:
+		c.T, c.V, err = f.setCellString(v)
+	case []byte:
+		c.T, c.V, err = f.setCellString(string(v))
+	case time.Duration:
+		_, d := setCellDuration(v)
+		c.setCellDefault(d)
+		numFmt = 21
+	case time.Time:
+		var isNum bool
+		if isNum, err = c.setCellTime(v, date1904); isNum {
+			numFmt = 22
+		}
+	case bool:
+		c.T, c.V = setCellBool(v)
+	case nil:
+		c.setCellDefault("")
+	default:
+		c.T, c.V, err = f.setCellString(fmt.Sprint(value))
+	}
+	return
+}
+
 // SetSheetCol writes an array to column by given worksheet name, starting
 // cell reference and a pointer to array type 'slice'. For example, writes an
 // array to column B start with the cell B6 on Sheet1:
@@ -1263,6 +1577,228 @@ func (f *File) SetSheetCol(sheet, cell string, slice interface{}) error {
 	return f.setSheetCells(sheet, cell, slice, columns)
 }
 
+// SetRangeValue provides a function to set the same value for each cell in
+// the range by given worksheet name, range reference and value. The data
+// type of the value will be detected once for all cells, and the string
+// value will be stored in the shared string table once and referenced by all
+// cells, so that this function is faster than setting the value of each cell
+// one by one by SetCellValue. For example, initialize the cells in the range
+// A1:D100 on Sheet1 with 0:
+//
+//	err := f.SetRangeValue("Sheet1", "A1:D100", 0)
+//
+// 根据给定的工作表名、单元格区域和值，为区域内的每个单元格设置相同的值。
+func (f *File) SetRangeValue(sheet, rangeRef string, value interface{}) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	var date1904 bool
+	if _, ok := value.(time.Time); ok {
+		wb, err := f.workbookReader()
+		if err != nil {
+			return err
+		}
+		if wb != nil && wb.WorkbookPr != nil {
+			date1904 = wb.WorkbookPr.Date1904
+		}
+	}
+	var (
+		tmpl    xlsxC
+		styleID int
+	)
+	numFmt, err := f.setCellValueOfType(&tmpl, value, date1904)
+	if err != nil {
+		return err
+	}
+	if numFmt != 0 {
+		if styleID, err = f.NewStyle(&Style{NumFmt: numFmt}); err != nil {
+			return err
+		}
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, err := CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			c, colIdx, rowIdx, err := ws.prepareCell(cell)
+			if err != nil {
+				return err
+			}
+			if c.S = ws.prepareCellStyle(colIdx, rowIdx, c.S); c.S == 0 {
+				c.S = styleID
+			}
+			c.T, c.V, c.IS = tmpl.T, tmpl.V, nil
+			if tmpl.IS != nil {
+				t := *tmpl.IS.T
+				c.IS = &xlsxSI{T: &t}
+			}
+			if err = f.removeFormula(c, ws, sheet); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// DeleteRange provides a function to delete the cells in the range by given
+// worksheet name and range reference. The values, formulas and styles of
+// the cells will be removed, the cell elements will be removed from the
+// worksheet, the rows which become empty will be removed, and the dimension
+// of the worksheet will be updated to the used range of the remaining cells.
+// For example, delete the cells in the range A1:D10 on Sheet1:
+//
+//	err := f.DeleteRange("Sheet1", "A1:D10")
+//
+// 根据给定的工作表名和单元格区域删除区域内的单元格，并移除变为空的行及更新工作表的已用区域。
+func (f *File) DeleteRange(sheet, rangeRef string) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	sheetID := f.getSheetID(sheet)
+	for rowIdx := coordinates[1] - 1; rowIdx < coordinates[3] && rowIdx < len(ws.SheetData.Row); rowIdx++ {
+		row := &ws.SheetData.Row[rowIdx]
+		for colIdx := coordinates[0] - 1; colIdx < coordinates[2] && colIdx < len(row.C); colIdx++ {
+			if row.C[colIdx].F != nil {
+				if err = f.deleteCalcChain(sheetID, row.C[colIdx].R); err != nil {
+					return err
+				}
+			}
+			row.C[colIdx] = xlsxC{R: row.C[colIdx].R}
+		}
+		// The empty cells and rows will be trimmed on saving, remove the
+		// trailing ones here to keep the cells in memory contiguous
+		for len(row.C) > 0 && !row.C[len(row.C)-1].hasValue() {
+			row.C = row.C[:len(row.C)-1]
+		}
+	}
+	for len(ws.SheetData.Row) > 0 {
+		if row := ws.SheetData.Row[len(ws.SheetData.Row)-1]; len(row.C) > 0 || row.hasAttr() {
+			break
+		}
+		ws.SheetData.Row = ws.SheetData.Row[:len(ws.SheetData.Row)-1]
+	}
+	ws.Dimension = &xlsxDimension{Ref: ws.getUsedRange()}
+	return err
+}
+
+// getUsedRange provides a function to get the range reference of the cells
+// which have value, formula or style in the worksheet.
+func (ws *xlsxWorksheet) getUsedRange() string {
+	coordinates := []int{0, 0, 0, 0}
+	for rowIdx, row := range ws.SheetData.Row {
+		for colIdx, c := range row.C {
+			if !c.hasValue() {
+				continue
+			}
+			col, r := colIdx+1, rowIdx+1
+			if coordinates[0] == 0 || col < coordinates[0] {
+				coordinates[0] = col
+			}
+			if coordinates[1] == 0 {
+				coordinates[1] = r
+			}
+			if col > coordinates[2] {
+				coordinates[2] = col
+			}
+			coordinates[3] = r
+		}
+	}
+	if coordinates[0] == 0 {
+		return "A1"
+	}
+	firstCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	lastCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+	if firstCell == lastCell {
+		return firstCell
+	}
+	return firstCell + ":" + lastCell
+}
+
+// SetRowHeader writes the values to consecutive columns starting at column A
+// in the given row, and applies the style to each of the cells by given
+// worksheet name, row number, values and style ID. For example, writes a bold
+// header to the first row on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetRowHeader("Sheet1", 1, []interface{}{"Name", "Age", "Score"}, style)
+//
+// 根据给定的工作表名称、行号、值和样式 ID 从 A 列开始按行写入表头并设置样式。
+func (f *File) SetRowHeader(sheet string, row int, values []interface{}, styleID int) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	cell, err := CoordinatesToCellName(1, row)
+	if err != nil {
+		return err
+	}
+	if len(values) > 0 {
+		endCell, err := CoordinatesToCellName(len(values), row)
+		if err != nil {
+			return err
+		}
+		if err = f.SetCellStyle(sheet, cell, endCell, styleID); err != nil {
+			return err
+		}
+	}
+	return f.SetSheetRow(sheet, cell, &values)
+}
+
+// SetColHeader writes the values to consecutive rows starting at row 1 in
+// the given column, and applies the style to each of the cells by given
+// worksheet name, column name, values and style ID. For example, writes a
+// bold header to the column A on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetColHeader("Sheet1", "A", []interface{}{"Q1", "Q2", "Q3"}, style)
+//
+// 根据给定的工作表名称、列名称、值和样式 ID 从第 1 行开始按列写入表头并设置样式。
+func (f *File) SetColHeader(sheet, col string, values []interface{}, styleID int) error {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	cell, _ := CoordinatesToCellName(colNum, 1)
+	if len(values) > 0 {
+		endCell, err := CoordinatesToCellName(colNum, len(values))
+		if err != nil {
+			return err
+		}
+		if err = f.SetCellStyle(sheet, cell, endCell, styleID); err != nil {
+			return err
+		}
+	}
+	return f.SetSheetCol(sheet, cell, &values)
+}
+
 // setSheetCells provides a function to set worksheet cells value.
 func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDirection) error {
 	col, row, err := CellNameToCoordinates(cell)
@@ -1589,3 +2125,250 @@ func shiftCell(cellID string, dCol, dRow int) string {
 	colName, _ := ColumnNumberToName(fCol)
 	return signCol + colName + signRow + strconv.Itoa(fRow)
 }
+
+// cellMetadataReader provides a function to get the pointer to the structure
+// after deserialization of the cell metadata custom XML part.
+func (f *File) cellMetadataReader() (*xlsxCellMetadata, error) {
+	meta := new(xlsxCellMetadata)
+	if _, ok := f.Pkg.Load(defaultXMLPathCellMetadata); !ok {
+		return meta, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathCellMetadata)))).
+		Decode(meta); err != nil && err != io.EOF {
+		return meta, err
+	}
+	return meta, nil
+}
+
+// cellMetadataWriter provides a function to save the cell metadata custom
+// XML part after serialize structure, and register the part in the package
+// relationships and content types.
+func (f *File) cellMetadataWriter(meta *xlsxCellMetadata) error {
+	output, _ := xml.Marshal(meta)
+	f.saveFileList(defaultXMLPathCellMetadata, output)
+	rels, err := f.relsReader("_rels/.rels")
+	if err != nil {
+		return err
+	}
+	var registered bool
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipCustomXML && strings.TrimPrefix(rel.Target, "/") == defaultXMLPathCellMetadata {
+				registered = true
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if !registered {
+		f.addRels("_rels/.rels", SourceRelationshipCustomXML, defaultXMLPathCellMetadata, "")
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for _, override := range content.Overrides {
+		if override.PartName == "/"+defaultXMLPathCellMetadata {
+			return err
+		}
+	}
+	content.Overrides = append(content.Overrides, xlsxOverride{
+		PartName: "/" + defaultXMLPathCellMetadata, ContentType: "application/xml",
+	})
+	return err
+}
+
+// adjustCellMetadataSheet provides a function to update the worksheet name of
+// the cell metadata by given source and target worksheet name, the metadata
+// of the worksheet will be removed if the target worksheet name is empty.
+func (f *File) adjustCellMetadataSheet(source, target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.Pkg.Load(defaultXMLPathCellMetadata); !ok {
+		return nil
+	}
+	meta, err := f.cellMetadataReader()
+	if err != nil {
+		return err
+	}
+	var (
+		changed bool
+		sheets  []xlsxCellMetadataSheet
+	)
+	for _, metaSheet := range meta.Sheet {
+		if strings.EqualFold(metaSheet.Name, source) {
+			if changed = true; target == "" {
+				continue
+			}
+			metaSheet.Name = target
+		}
+		sheets = append(sheets, metaSheet)
+	}
+	if !changed {
+		return nil
+	}
+	meta.Sheet = sheets
+	return f.cellMetadataWriter(meta)
+}
+
+// prepareCellMetadata provides a function to check the worksheet name, cell
+// reference and metadata key, and returns the normalized cell reference.
+func (f *File) prepareCellMetadata(sheet, cell, key string) (string, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return cell, err
+	}
+	if _, ok := f.getSheetXMLPath(sheet); !ok {
+		return cell, ErrSheetNotExist{sheet}
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return cell, err
+	}
+	if key == "" {
+		return cell, ErrParameterRequired
+	}
+	return CoordinatesToCellName(col, row)
+}
+
+// SetCellMetadata provides a function to attach the application-specific
+// metadata to the cell by given worksheet name, cell reference, metadata key
+// and value. The existing value of the same key will be replaced. The
+// metadata are stored in the custom XML part customXml/cellMeta.xml keyed by
+// the worksheet name and cell reference. The metadata will be moved to the
+// new worksheet name on renaming the worksheet, and removed on deleting the
+// worksheet, but they are not changed when inserting or removing rows and
+// columns. For example, attach the source of the value in the cell A1 on
+// Sheet1:
+//
+//	err := f.SetCellMetadata("Sheet1", "A1", "source", "ERP")
+//
+// 根据给定的工作表名称、单元格坐标、元数据键和值为单元格设置自定义元数据。
+func (f *File) SetCellMetadata(sheet, cell, key, value string) error {
+	cell, err := f.prepareCellMetadata(sheet, cell, key)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	meta, err := f.cellMetadataReader()
+	if err != nil {
+		return err
+	}
+	sheetIdx := -1
+	for i := range meta.Sheet {
+		if meta.Sheet[i].Name == sheet {
+			sheetIdx = i
+		}
+	}
+	if sheetIdx == -1 {
+		meta.Sheet = append(meta.Sheet, xlsxCellMetadataSheet{Name: sheet})
+		sheetIdx = len(meta.Sheet) - 1
+	}
+	metaSheet := &meta.Sheet[sheetIdx]
+	cellIdx := -1
+	for i := range metaSheet.Cell {
+		if metaSheet.Cell[i].R == cell {
+			cellIdx = i
+		}
+	}
+	if cellIdx == -1 {
+		metaSheet.Cell = append(metaSheet.Cell, xlsxCellMetadataCell{R: cell})
+		cellIdx = len(metaSheet.Cell) - 1
+	}
+	metaCell := &metaSheet.Cell[cellIdx]
+	for i := range metaCell.Item {
+		if metaCell.Item[i].Key == key {
+			metaCell.Item[i].Value = value
+			return f.cellMetadataWriter(meta)
+		}
+	}
+	metaCell.Item = append(metaCell.Item, xlsxCellMetadataItem{Key: key, Value: value})
+	return f.cellMetadataWriter(meta)
+}
+
+// GetCellMetadata provides a function to get the application-specific
+// metadata of the cell by given worksheet name, cell reference and metadata
+// key. An empty string will be returned if the metadata doesn't exist. For
+// example, get the metadata with the key "source" of the cell A1 on Sheet1:
+//
+//	value, err := f.GetCellMetadata("Sheet1", "A1", "source")
+//
+// 根据给定的工作表名称、单元格坐标和元数据键获取单元格的自定义元数据。
+func (f *File) GetCellMetadata(sheet, cell, key string) (string, error) {
+	cell, err := f.prepareCellMetadata(sheet, cell, key)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	meta, err := f.cellMetadataReader()
+	if err != nil {
+		return "", err
+	}
+	for _, metaSheet := range meta.Sheet {
+		if metaSheet.Name != sheet {
+			continue
+		}
+		for _, metaCell := range metaSheet.Cell {
+			if metaCell.R != cell {
+				continue
+			}
+			for _, item := range metaCell.Item {
+				if item.Key == key {
+					return item.Value, err
+				}
+			}
+		}
+	}
+	return "", err
+}
+
+// DeleteCellMetadata provides a function to delete the application-specific
+// metadata of the cell by given worksheet name, cell reference and metadata
+// key. For example, delete the metadata with the key "source" of the cell A1
+// on Sheet1:
+//
+//	err := f.DeleteCellMetadata("Sheet1", "A1", "source")
+//
+// 根据给定的工作表名称、单元格坐标和元数据键删除单元格的自定义元数据。
+func (f *File) DeleteCellMetadata(sheet, cell, key string) error {
+	cell, err := f.prepareCellMetadata(sheet, cell, key)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	meta, err := f.cellMetadataReader()
+	if err != nil {
+		return err
+	}
+	for i := range meta.Sheet {
+		metaSheet := &meta.Sheet[i]
+		if metaSheet.Name != sheet {
+			continue
+		}
+		for j := range metaSheet.Cell {
+			metaCell := &metaSheet.Cell[j]
+			if metaCell.R != cell {
+				continue
+			}
+			for k, item := range metaCell.Item {
+				if item.Key == key {
+					metaCell.Item = append(metaCell.Item[:k], metaCell.Item[k+1:]...)
+					break
+				}
+			}
+			if len(metaCell.Item) == 0 {
+				metaSheet.Cell = append(metaSheet.Cell[:j], metaSheet.Cell[j+1:]...)
+			}
+			break
+		}
+		if len(metaSheet.Cell) == 0 {
+			meta.Sheet = append(meta.Sheet[:i], meta.Sheet[i+1:]...)
+		}
+		return f.cellMetadataWriter(meta)
+	}
+	return err
+}
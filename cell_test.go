@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	_ "image/jpeg"
 	"os"
@@ -457,6 +458,111 @@ func TestGetCellType(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellTime(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", date))
+	customNumFmt := "yyyy/mm/dd"
+	dateStyle, err := f.NewStyle(&Style{CustomNumFmt: &customNumFmt})
+	assert.NoError(t, err)
+	timeStyle, err := f.NewStyle(&Style{NumFmt: 46})
+	assert.NoError(t, err)
+	numStyle, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B1", &[]interface{}{45021, 1.5, 45021, "2023/04/05"}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", dateStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C1", timeStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "E1", numStyle))
+	for _, c := range []struct {
+		cell     string
+		tz       *time.Location
+		expected time.Time
+		isDate   bool
+	}{
+		{"A1", nil, date, true},
+		{"A1", time.Local, time.Date(2023, 4, 5, 6, 7, 8, 0, time.Local), true},
+		{"B1", nil, time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC), true},
+		{"C1", nil, time.Date(1900, 1, 1, 12, 0, 0, 0, time.UTC), true},
+		{"D1", nil, time.Time{}, false},
+		{"E1", nil, time.Time{}, false},
+		{"F1", nil, time.Time{}, false},
+	} {
+		result, isDate, err := f.GetCellTime("Sheet1", c.cell, c.tz)
+		assert.NoError(t, err)
+		assert.Equal(t, c.isDate, isDate, c.cell)
+		assert.True(t, c.expected.Equal(result), c.cell)
+		if isDate {
+			assert.Equal(t, c.expected.Location(), result.Location(), c.cell)
+		}
+	}
+	// Test get cell time with the 1904 date system
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	result, isDate, err := f.GetCellTime("Sheet1", "B1", nil)
+	assert.NoError(t, err)
+	assert.True(t, isDate)
+	assert.Equal(t, time.Date(2027, 4, 6, 0, 0, 0, 0, time.UTC), result)
+	// Test get the serial number of the ISO 8601 date type cell with the 1904 date system
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[4] = xlsxC{R: "E1", T: "d", V: "2023-04-05T00:00:00Z"}
+	value, err := f.GetCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "43559", value)
+	// Test get cell time with the ISO 8601 date type
+	for value, expected := range map[string]time.Time{
+		"2023-04-05T06:07:08Z":      date,
+		"2023-04-05T06:07:08.5":     date.Add(500 * time.Millisecond),
+		"2023-04-05":                time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC),
+		"2023-04-05T06:07:08+08:00": time.Date(2023, 4, 5, 6, 7, 8, 0, time.FixedZone("", 8*3600)),
+	} {
+		ws.(*xlsxWorksheet).SheetData.Row[0].C[4] = xlsxC{R: "E1", T: "d", V: value}
+		result, isDate, err = f.GetCellTime("Sheet1", "E1", nil)
+		assert.NoError(t, err)
+		assert.True(t, isDate)
+		assert.True(t, expected.Equal(result), value)
+	}
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[4] = xlsxC{R: "E1", T: "d", V: "invalid"}
+	_, isDate, err = f.GetCellTime("Sheet1", "E1", nil)
+	assert.NoError(t, err)
+	assert.False(t, isDate)
+	// Test get cell time with invalid serial number
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[1].V = "invalid"
+	_, _, err = f.GetCellTime("Sheet1", "B1", nil)
+	assert.EqualError(t, err, `strconv.ParseFloat: parsing "invalid": invalid syntax`)
+	// Test get cell time with invalid cell reference
+	_, _, err = f.GetCellTime("Sheet1", "A", nil)
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get cell time on not exists worksheet
+	_, _, err = f.GetCellTime("SheetN", "A1", nil)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get cell time with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, _, err = f.GetCellTime("Sheet1", "A1", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get cell time with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, _, err = f.GetCellTime("Sheet1", "A1", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestIsDateTimeNumFmt(t *testing.T) {
+	for numFmt, expected := range map[string]bool{
+		"":                    false,
+		"General":             false,
+		"0.00":                false,
+		"#,##0;[Red]-#,##0":   false,
+		"m/d/yy":              true,
+		"[h]:mm:ss":           true,
+		"yyyy\\-mm;@":         true,
+		"\"Date\" yyyy-mm-dd": true,
+	} {
+		assert.Equal(t, expected, isDateTimeNumFmt(numFmt), numFmt)
+	}
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
@@ -556,6 +662,194 @@ func TestOverflowNumericCell(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddFormulaCell(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		cell        string
+		cachedValue interface{}
+		cellType    string
+		value       string
+	}{
+		{"A1", 1, "", "1"},
+		{"A2", uint8(2), "", "2"},
+		{"A3", float32(1.5), "", "1.5"},
+		{"A4", 2.25, "", "2.25"},
+		{"A5", "text", "str", "text"},
+		{"A6", []byte("bytes"), "str", "bytes"},
+		{"A7", true, "b", "1"},
+		{"A8", time.Duration(12) * time.Hour, "", "0.5"},
+		{"A9", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "", "44927"},
+		{"A10", time.Date(1899, 1, 1, 0, 0, 0, 0, time.UTC), "str", "1899-01-01T00:00:00Z"},
+		{"A11", nil, "", ""},
+		{"A12", complex64(5 + 3i), "str", "(5+3i)"},
+	} {
+		assert.NoError(t, f.AddFormulaCell("Sheet1", c.cell, "=B1", c.cachedValue))
+		formula, err := f.GetCellFormula("Sheet1", c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "=B1", formula)
+		ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		cell, _, _, err := ws.(*xlsxWorksheet).prepareCell(c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.cellType, cell.T, c.cell)
+		assert.Equal(t, c.value, cell.V, c.cell)
+		assert.Nil(t, cell.IS)
+	}
+	// Test the default date style for the time type cached value
+	styleID, err := f.GetCellStyle("Sheet1", "A9")
+	assert.NoError(t, err)
+	assert.Equal(t, 22, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	val, err := f.GetCellValue("Sheet1", "A7")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddFormulaCell.xlsx")))
+	// Test add formula cell with empty formula
+	assert.EqualError(t, f.AddFormulaCell("Sheet1", "A1", "", 1), ErrParameterInvalid.Error())
+	// Test add formula cell with invalid sheet name
+	assert.EqualError(t, f.AddFormulaCell("Sheet:1", "A1", "=B1", 1), ErrSheetNameInvalid.Error())
+	// Test add formula cell on not exists worksheet
+	assert.EqualError(t, f.AddFormulaCell("SheetN", "A1", "=B1", 1), "sheet SheetN does not exist")
+	// Test add formula cell with invalid cell reference
+	assert.EqualError(t, f.AddFormulaCell("Sheet1", "A", "=B1", 1), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test add formula cell with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddFormulaCell("Sheet1", "A1", "=B1", time.Now()), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetRangeValue(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		rangeRef string
+		value    interface{}
+		expected string
+	}{
+		{"A1:B2", 1, "1"},
+		{"C3:A1", uint16(2), "2"},
+		{"A1:A2", float32(1.5), "1.5"},
+		{"B1:B2", 2.25, "2.25"},
+		{"A1:C3", "text", "text"},
+		{"A1:C3", []byte("bytes"), "bytes"},
+		{"A1:C3", true, "TRUE"},
+		{"A1:A2", time.Duration(12) * time.Hour, "12:00:00"},
+		{"B1:B2", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "1/1/23 00:00"},
+		{"A1:C3", complex64(5 + 3i), "(5+3i)"},
+		{"A1:C3", nil, ""},
+	} {
+		assert.NoError(t, f.SetRangeValue("Sheet1", c.rangeRef, c.value))
+		coordinates, err := rangeRefToCoordinates(c.rangeRef)
+		assert.NoError(t, err)
+		_ = sortCoordinates(coordinates)
+		for row := coordinates[1]; row <= coordinates[3]; row++ {
+			for col := coordinates[0]; col <= coordinates[2]; col++ {
+				cell, err := CoordinatesToCellName(col, row)
+				assert.NoError(t, err)
+				val, err := f.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Equal(t, c.expected, val, cell)
+			}
+		}
+	}
+	// Test the shared string is stored once for all cells
+	assert.NoError(t, f.SetRangeValue("Sheet1", "A1:C3", "shared"))
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	var count int
+	for _, si := range sst.SI {
+		if si.String() == "shared" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	// Test set range value removes the formula of cells
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1"))
+	assert.NoError(t, f.SetRangeValue("Sheet1", "A1:B1", 1))
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRangeValue.xlsx")))
+	// Test set range value with invalid range reference
+	assert.EqualError(t, f.SetRangeValue("Sheet1", "A1", 1), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetRangeValue("Sheet1", "A:B1", 1), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set range value with invalid sheet name
+	assert.EqualError(t, f.SetRangeValue("Sheet:1", "A1:B2", 1), ErrSheetNameInvalid.Error())
+	// Test set range value on not exists worksheet
+	assert.EqualError(t, f.SetRangeValue("SheetN", "A1:B2", 1), "sheet SheetN does not exist")
+	// Test set range value with unsupported charset calculation chain
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1"))
+	assert.EqualError(t, f.SetRangeValue("Sheet1", "A1:B1", 1), "XML syntax error on line 1: invalid UTF-8")
+	// Test set range value with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetRangeValue("Sheet1", "C1:C2", time.Hour), "XML syntax error on line 1: invalid UTF-8")
+	// Test set range value with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetRangeValue("Sheet1", "C1:C2", "text"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestDeleteRange(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A1", "B1", "C2", "B3", "D4", "B5"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D4", "=A1"))
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "D4", I: 1}}}
+	assert.NoError(t, f.SetRowHeight("Sheet1", 5, 30))
+	assert.NoError(t, f.DeleteRange("Sheet1", "E5:B3"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "A1:C2", ws.(*xlsxWorksheet).Dimension.Ref)
+	// Test the rows which become empty are removed except the formatted row
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 5)
+	assert.Empty(t, ws.(*xlsxWorksheet).SheetData.Row[2].C)
+	assert.Empty(t, ws.(*xlsxWorksheet).SheetData.Row[4].C)
+	calcChain, err := f.calcChainReader()
+	assert.NoError(t, err)
+	assert.Empty(t, calcChain.C)
+	for cell, expected := range map[string]string{"A1": "A1", "B1": "B1", "C2": "C2", "B3": "", "D4": "", "B5": ""} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	// Test set cell value after delete range
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", 1))
+	val, err := f.GetCellValue("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val)
+	assert.NoError(t, f.DeleteRange("Sheet1", "D4:D4"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteRange.xlsx")))
+	// Test delete range out of the used range
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 1))
+	assert.NoError(t, f.DeleteRange("Sheet1", "A10:C20"))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", dimension)
+	// Test delete all cells of the worksheet
+	assert.NoError(t, f.DeleteRange("Sheet1", "A1:XFD1048576"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", dimension)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	// Test delete range with invalid range reference
+	assert.EqualError(t, f.DeleteRange("Sheet1", "A1"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.DeleteRange("Sheet1", "A:B1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test delete range with invalid sheet name
+	assert.EqualError(t, f.DeleteRange("Sheet:1", "A1:B2"), ErrSheetNameInvalid.Error())
+	// Test delete range on not exists worksheet
+	assert.EqualError(t, f.DeleteRange("SheetN", "A1:B2"), "sheet SheetN does not exist")
+	// Test delete range with unsupported charset calculation chain
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1"))
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteRange("Sheet1", "A1:B1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetCellFormula(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -674,6 +968,19 @@ func TestGetCellRichText(t *testing.T) {
 	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = "x"
 	_, err = f.GetCellRichText("Sheet1", "A1")
 	assert.EqualError(t, err, "strconv.Atoi: parsing \"x\": invalid syntax")
+	// Test get cell rich text on the empty cell
+	runs, err = f.GetCellRichText("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Nil(t, runs)
+	// Test get cell rich text on the inline rich text cell
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0] = xlsxC{R: "A1", T: "inlineStr", IS: &xlsxSI{R: []xlsxR{
+		{T: &xlsxT{Val: "inline"}, RPr: &xlsxRPr{B: stringPtr("")}},
+	}}}
+	runs, err = f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []RichTextRun{{Text: "inline", Font: &Font{Bold: true, Underline: "none"}}}, runs)
 	// Test set cell rich text on not exists worksheet
 	_, err = f.GetCellRichText("SheetN", "A1")
 	assert.EqualError(t, err, "sheet SheetN does not exist")
@@ -790,6 +1097,38 @@ func TestSetCellRichText(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellRichText.xlsx")))
+	// Test set cell rich text on the cell with formula and number format
+	numFmt, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=1+1"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", numFmt))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A3", richTextRun[:2]))
+	formula, err := f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	styleID, err := f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, numFmt, styleID)
+	val, err := f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "bold and ", val)
+	runs, err := f.GetCellRichText("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	// Test set cell rich text on the inline string cell
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "inline"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[3].C[0].T, ws.(*xlsxWorksheet).SheetData.Row[3].C[0].IS = "inlineStr", &xlsxSI{T: &xlsxT{Val: "inline"}}
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A4", richTextRun[:1]))
+	assert.Nil(t, ws.(*xlsxWorksheet).SheetData.Row[3].C[0].IS)
+	// Test set cell rich text on the cell with formula and unsupported charset calculation chain
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "=1+1"))
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellRichText("Sheet1", "A5", richTextRun[:1]), "XML syntax error on line 1: invalid UTF-8")
+	f.CalcChain = nil
+	f.Pkg.Delete(defaultXMLPathCalcChain)
 	// Test set cell rich text on not exists worksheet
 	assert.EqualError(t, f.SetCellRichText("SheetN", "A1", richTextRun), "sheet SheetN does not exist")
 	// Test set cell rich text with invalid sheet name
@@ -998,3 +1337,122 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestCellMetadata(t *testing.T) {
+	f := NewFile()
+	// Test get metadata of the cell without metadata
+	value, err := f.GetCellMetadata("Sheet1", "A1", "source")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "a1", "source", "ERP"))
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "A1", "owner", "Finance"))
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "B2", "source", "CRM"))
+	// Test replace the existing metadata value
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "A1", "source", "<ERP>"))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellMetadata("Sheet2", "A1", "source", "Manual"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCellMetadata.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCellMetadata.xlsx"))
+	assert.NoError(t, err)
+	for _, c := range []struct{ sheet, cell, key, value string }{
+		{"Sheet1", "A1", "source", "<ERP>"},
+		{"Sheet1", "A1", "owner", "Finance"},
+		{"Sheet1", "B2", "source", "CRM"},
+		{"Sheet1", "B2", "owner", ""},
+		{"Sheet2", "A1", "source", "Manual"},
+		{"Sheet2", "B2", "source", ""},
+	} {
+		value, err := f.GetCellMetadata(c.sheet, c.cell, c.key)
+		assert.NoError(t, err)
+		assert.Equal(t, c.value, value)
+	}
+	rels, err := f.relsReader("_rels/.rels")
+	assert.NoError(t, err)
+	var count int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipCustomXML {
+			assert.Equal(t, defaultXMLPathCellMetadata, rel.Target)
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/customXml/cellMeta.xml", ContentType: "application/xml"})
+	// Test delete metadata
+	assert.NoError(t, f.DeleteCellMetadata("Sheet1", "A1", "source"))
+	assert.NoError(t, f.DeleteCellMetadata("Sheet1", "B2", "source"))
+	assert.NoError(t, f.DeleteCellMetadata("Sheet2", "A1", "source"))
+	assert.NoError(t, f.DeleteCellMetadata("Sheet2", "A1", "source"))
+	value, err = f.GetCellMetadata("Sheet1", "A1", "source")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+	value, err = f.GetCellMetadata("Sheet1", "A1", "owner")
+	assert.NoError(t, err)
+	assert.Equal(t, "Finance", value)
+	meta, err := f.cellMetadataReader()
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCellMetadataSheet{{Name: "Sheet1", Cell: []xlsxCellMetadataCell{
+		{R: "A1", Item: []xlsxCellMetadataItem{{Key: "owner", Value: "Finance"}}},
+	}}}, meta.Sheet)
+	assert.NoError(t, f.DeleteCellMetadata("Sheet1", "C3", "owner"))
+	assert.NoError(t, f.DeleteCellMetadata("Sheet1", "A1", "owner"))
+	meta, err = f.cellMetadataReader()
+	assert.NoError(t, err)
+	assert.Empty(t, meta.Sheet)
+	// Test cell metadata with invalid parameters
+	for _, fn := range []func(sheet, cell, key string) error{
+		func(sheet, cell, key string) error { return f.SetCellMetadata(sheet, cell, key, "value") },
+		func(sheet, cell, key string) error { _, err := f.GetCellMetadata(sheet, cell, key); return err },
+		f.DeleteCellMetadata,
+	} {
+		assert.EqualError(t, fn("Sheet:1", "A1", "key"), ErrSheetNameInvalid.Error())
+		assert.EqualError(t, fn("SheetN", "A1", "key"), "sheet SheetN does not exist")
+		assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), fn("Sheet1", "A", "key"))
+		assert.Equal(t, ErrParameterRequired, fn("Sheet1", "A1", ""))
+		// Test cell metadata with unsupported charset metadata part
+		f.Pkg.Store(defaultXMLPathCellMetadata, MacintoshCyrillicCharset)
+		assert.EqualError(t, fn("Sheet1", "A1", "key"), "XML syntax error on line 1: invalid UTF-8")
+		f.Pkg.Delete(defaultXMLPathCellMetadata)
+	}
+	// Test set cell metadata with unsupported charset relationships and content types
+	f.Relationships.Delete("_rels/.rels")
+	f.Pkg.Store("_rels/.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellMetadata("Sheet1", "A1", "key", "value"), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store("_rels/.rels", []byte(xml.Header+templateRels))
+	f.Relationships.Delete("_rels/.rels")
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellMetadata("Sheet1", "A1", "key", "value"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test cell metadata on renaming and deleting worksheet
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellMetadata("Sheet1", "A1", "source", "ERP"))
+	assert.NoError(t, f.SetCellMetadata("Sheet2", "A1", "source", "CRM"))
+	assert.NoError(t, f.SetSheetName("Sheet1", "Data"))
+	value, err = f.GetCellMetadata("Data", "A1", "source")
+	assert.NoError(t, err)
+	assert.Equal(t, "ERP", value)
+	assert.NoError(t, f.DeleteSheet("Sheet2"))
+	meta, err = f.cellMetadataReader()
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCellMetadataSheet{{Name: "Data", Cell: []xlsxCellMetadataCell{
+		{R: "A1", Item: []xlsxCellMetadataItem{{Key: "source", Value: "ERP"}}},
+	}}}, meta.Sheet)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	value, err = f.GetCellMetadata("Sheet2", "A1", "source")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+	// Test rename and delete worksheet with unsupported charset metadata part
+	f.Pkg.Store(defaultXMLPathCellMetadata, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetName("Data", "Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteSheet("Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -12,8 +12,11 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -78,6 +81,9 @@ const (
 	WireframeContour
 	Bubble
 	Bubble3D
+	Waterfall
+	Treemap
+	Sunburst
 )
 
 // This section defines the default value of chart properties.
@@ -259,6 +265,16 @@ var (
 		Bubble:                      0,
 		Bubble3D:                    0,
 	}
+	chartExLayoutIDs = map[ChartType]string{
+		Waterfall: "waterfall",
+		Treemap:   "treemap",
+		Sunburst:  "sunburst",
+	}
+	chartExDataLabelsPos = map[ChartType]string{
+		Waterfall: "outEnd",
+		Treemap:   "inEnd",
+		Sunburst:  "ctr",
+	}
 	chartLegendPosition = map[string]string{
 		"bottom":    "b",
 		"left":      "l",
@@ -266,6 +282,17 @@ var (
 		"top":       "t",
 		"top_right": "tr",
 	}
+	chartTrendLineTypes = map[string]string{
+		"exponential": "exp",
+		"linear":      "linear",
+		"logarithmic": "log",
+		"movingAvg":   "movingAvg",
+		"polynomial":  "poly",
+		"power":       "power",
+	}
+	chartTrendLineSupported = map[ChartType]bool{
+		Area: true, Bar: true, Col: true, Line: true, Scatter: true, Bubble: true,
+	}
 	chartValAxNumFmtFormatCode = map[ChartType]string{
 		Area:                        "General",
 		AreaStacked:                 "General",
@@ -513,6 +540,14 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.VaryColors == nil {
 		opts.VaryColors = boolPtr(true)
 	}
+	for _, series := range opts.Series {
+		if _, ok := chartTrendLineTypes[series.TrendLine.Type]; !ok && series.TrendLine.Type != "" {
+			return opts, newUnsupportedChartTrendLineType(series.TrendLine.Type)
+		}
+		if err := checkChartMarkerColors(series.Marker); err != nil {
+			return opts, err
+		}
+	}
 	if opts.ShowBlanksAs == "" {
 		opts.ShowBlanksAs = defaultChartShowBlanksAs
 	}
@@ -652,6 +687,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	 52 | WireframeContour            | wireframe contour chart
 //	 53 | Bubble                      | bubble chart
 //	 54 | Bubble3D                    | 3D bubble chart
+//	 55 | Waterfall                   | waterfall chart
+//	 56 | Treemap                     | treemap chart
+//	 57 | Sunburst                    | sunburst chart
 //
 // In Excel a chart series is a collection of information that defines which
 // data is plotted such as values, axis labels and formatting.
@@ -665,6 +703,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Fill
 //	Line
 //	Marker
+//	TrendLine
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -685,12 +724,16 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //
 // Line: This sets the line format of the line chart. The 'Line' property is
 // optional and if it isn't supplied it will default style. The options that
-// can be set are width and color. The range of width is 0.25pt - 999pt. If the
-// value of width is outside the range, the default width of the line is 2pt.
+// can be set are width, color and smooth. The range of width is 0.25pt -
+// 999pt. If the value of width is outside the range, the default width of the
+// line is 2pt. Set 'Smooth' to true to draw the line of this series with
+// smooth segments.
 //
 // Marker: This sets the marker of the line chart and scatter chart. The range
-// of optional field 'Size' is 2-72 (default value is 5). The enumeration value
-// of optional field 'Symbol' are (default value is 'auto'):
+// of optional field 'Size' is 2-72 (default value is 5). The optional fields
+// 'FillColor' and 'BorderColor' set the fill and border color of the marker
+// in hex RGB, the theme color will be used if it's empty. The enumeration
+// value of optional field 'Symbol' are (default value is 'auto'):
 //
 //	circle
 //	dash
@@ -705,6 +748,47 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	x
 //	auto
 //
+// TrendLine: This sets the trend line of the data series, which is supported
+// by the area, bar, column, line, scatter and bubble charts without stacking
+// and 3D effects. The options that can be set are:
+//
+//	Type
+//	Order
+//	Period
+//	Forward
+//	Backward
+//	Intercept
+//	DisplayEquation
+//	DisplayRSquared
+//
+// Type: Set the type of the trend line. The available types are:
+//
+//	exponential
+//	linear
+//	logarithmic
+//	movingAvg
+//	polynomial
+//	power
+//
+// Order: Set the order of the polynomial trend line, the range is 2-6 (default
+// value is 2).
+//
+// Period: Set the period of the moving average trend line, the range is 2-255
+// (default value is 2).
+//
+// Forward: Set the number of periods that the trend line extends forward.
+//
+// Backward: Set the number of periods that the trend line extends backward.
+//
+// Intercept: Set the value where the trend line crosses the vertical axis,
+// which is supported by the exponential, linear and polynomial trend line.
+//
+// DisplayEquation: Set the equation of the trend line shall be displayed on
+// the chart.
+//
+// DisplayRSquared: Set the R-squared value of the trend line shall be
+// displayed on the chart.
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
@@ -723,6 +807,13 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // ShowLegendKey: Set the legend keys shall be shown in data labels. The default
 // value is false.
 //
+// Overlay: Specifies that other chart elements shall be allowed to overlap
+// this chart legend. The default value is false.
+//
+// Font: Set the font properties of the legend text. The properties that can
+// be set are 'Bold', 'Italic', 'Underline', 'Strike', 'Family', 'Size' and
+// 'Color'. The 'Font' property is optional.
+//
 // Set properties of the chart title. The properties that can be set are:
 //
 //	Title
@@ -748,6 +839,19 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // Specifies that each data marker in the series has a different color by
 // 'VaryColors'. The default value is true.
 //
+// Specifies the size of the hole in the doughnut chart as a percentage of the
+// size of the plot area by 'HoleSize'. The value range is 10 to 90, and the
+// default value is 75.
+//
+// Specifies the angle of the first slice in the pie and doughnut chart by
+// 'FirstSliceAngle'. The value range is 0 to 359 degrees, and the default
+// value is 0.
+//
+// Specifies that all series of the line and scatter chart are drawn with
+// smooth segments by 'Smooth'. The default value is false, which draws
+// straight segments. A series is drawn with smooth segments when either this
+// property or the 'Smooth' property of the series line is true.
+//
 // Set chart offset, scale, aspect ratio setting and print settings by format,
 // same as function 'AddPicture'.
 //
@@ -761,6 +865,8 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	ShowPercent
 //	ShowSerName
 //	ShowVal
+//	Layout
+//	PlotVisOnly
 //
 // SecondPlotValues: Specifies the values in second plot for the 'pieOfPie' and
 // 'barOfPie' chart.
@@ -783,6 +889,14 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // ShowVal: Specifies that the value shall be shown in a data label.
 // The 'ShowVal' property is optional. The default value is false.
 //
+// Layout: Specifies the manual position and size of the inner plot area, the
+// values of 'X', 'Y', 'Width' and 'Height' are specified as a fraction of the
+// width or height of the chart. The 'Layout' property is optional. The plot
+// area will be automatically placed by default.
+//
+// PlotVisOnly: Specifies that only visible cells shall be plotted on the
+// chart. The 'PlotVisOnly' property is optional. The default value is false.
+//
 // Set the primary horizontal and vertical axis options by 'XAxis' and 'YAxis'.
 // The properties of 'XAxis' that can be set are:
 //
@@ -794,6 +908,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Maximum
 //	Minimum
 //	Font
+//	NumFmt
 //
 // The properties of 'YAxis' that can be set are:
 //
@@ -805,6 +920,8 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Maximum
 //	Minimum
 //	Font
+//	LogBase
+//	NumFmt
 //
 // None: Disable axes.
 //
@@ -841,9 +958,62 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Color
 //	VertAlign
 //
+// LogBase: Specifies the base of the logarithmic scale of the vertical axis,
+// the value should be between 2 and 1000. The 'LogBase' property is optional.
+// The default is the linear scale.
+//
+// NumFmt: Specifies the number format of the tick labels on the axis. The
+// 'CustomNumFmt' property is the Excel number format code, such as
+// "#,##0.00", "$#,##0" or "0%". The tick labels will use the number format of
+// the source data cells instead if the 'SourceLinked' property is true. The
+// 'NumFmt' property is optional. The default format is "General". For
+// example, display the vertical axis labels in currency:
+//
+//	YAxis: excelize.ChartAxis{
+//	    NumFmt: excelize.ChartNumFmt{CustomNumFmt: "$#,##0.00"},
+//	},
+//
 // Set chart size by 'Dimension' property. The 'Dimension' property is optional.
 // The default width is 480, and height is 290.
 //
+// The waterfall, treemap and sunburst chart are stored as an Office 2016
+// chartEx part, those require exactly one series and can't be combined with
+// other chart types. The categories of the treemap and sunburst chart series
+// could be a multiple columns range, each column as a level of the hierarchy
+// from the outermost to the innermost, such as "Sheet1!$A$2:$C$10". Set the
+// fill color of the series by the 'Fill' property with only one color, and
+// the data labels by the 'ShowCatName', 'ShowSerName' and 'ShowVal' property
+// of the 'PlotArea'.
+//
+// Set the waterfall chart options by 'Waterfall', the properties that can be
+// set are:
+//
+//	ConnectorLines
+//	IncreaseColor
+//	DecreaseColor
+//	TotalColor
+//	IsTotal
+//
+// ConnectorLines: Specifies that the connector lines between the bars shall
+// be shown. The 'ConnectorLines' property is optional. The default value is
+// true.
+//
+// IncreaseColor: Specifies the fill color of the positive data points, such as
+// "#70AD47". The 'IncreaseColor' property is optional.
+//
+// DecreaseColor: Specifies the fill color of the negative data points. The
+// 'DecreaseColor' property is optional.
+//
+// TotalColor: Specifies the fill color of the total data points. The
+// 'TotalColor' property is optional.
+//
+// IsTotal: Specifies which data points of the series shall be shown as
+// subtotal bars, indexed by the position of the data point in the series
+// values. The 'IsTotal' property is optional.
+//
+// The increase and decrease colors were applied depending on the cell values
+// of the series at the time the chart was added.
+//
 // combo: Specifies the create a chart that combines two or more chart types in
 // a single chart. For example, create a clustered column - line chart with
 // data Sheet1!$E$1:$L$15:
@@ -964,14 +1134,29 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
 	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
-	drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
-	err = f.addDrawingChart(sheet, drawingXML, cell, int(opts.Dimension.Width), int(opts.Dimension.Height), drawingRID, &opts.Format)
-	if err != nil {
-		return err
-	}
-	f.addChart(opts, comboCharts)
-	if err = f.addContentTypePart(chartID, "chart"); err != nil {
-		return err
+	if _, ok := chartExLayoutIDs[opts.Type]; ok {
+		chartExID := f.countChartExs() + 1
+		if err = f.addChartEx(opts, chartExID); err != nil {
+			return err
+		}
+		drawingRID := f.addRels(drawingRels, SourceRelationshipChartEx, "../charts/chartEx"+strconv.Itoa(chartExID)+".xml", "")
+		err = f.addDrawingChartEx(sheet, drawingXML, cell, int(opts.Dimension.Width), int(opts.Dimension.Height), drawingRID, &opts.Format)
+		if err != nil {
+			return err
+		}
+		if err = f.addContentTypePart(chartExID, "chartEx"); err != nil {
+			return err
+		}
+	} else {
+		drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
+		err = f.addDrawingChart(sheet, drawingXML, cell, int(opts.Dimension.Width), int(opts.Dimension.Height), drawingRID, &opts.Format)
+		if err != nil {
+			return err
+		}
+		f.addChart(opts, comboCharts)
+		if err = f.addContentTypePart(chartID, "chart"); err != nil {
+			return err
+		}
 	}
 	_ = f.addContentTypePart(drawingID, "drawings")
 	f.addSheetNameSpace(sheet, SourceRelationship)
@@ -995,6 +1180,9 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	if err != nil {
 		return err
 	}
+	if _, ok := chartExLayoutIDs[opts.Type]; ok {
+		return newUnsupportedChartType(opts.Type)
+	}
 	cs := xlsxChartsheet{
 		SheetViews: &xlsxChartsheetViews{
 			SheetView: []*xlsxChartsheetView{{ZoomScaleAttr: 100, ZoomToFitAttr: true}},
@@ -1055,6 +1243,12 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 		}
 		comboCharts = append(comboCharts, comboChart)
 	}
+	if _, ok := chartExLayoutIDs[options.Type]; ok {
+		if len(options.Series) != 1 || len(comboCharts) > 0 {
+			return options, comboCharts, ErrChartExSeries
+		}
+		return options, comboCharts, err
+	}
 	if _, ok := chartValAxNumFmtFormatCode[options.Type]; !ok {
 		return options, comboCharts, newUnsupportedChartType(options.Type)
 	}
@@ -1086,7 +1280,20 @@ func (f *File) DeleteChart(sheet, cell string) error {
 func (f *File) countCharts() int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/charts/chart") {
+		if strings.Contains(k.(string), "xl/charts/chart") && !strings.Contains(k.(string), "xl/charts/chartEx") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// countChartExs provides a function to get chartEx files count storage in
+// the folder xl/charts.
+func (f *File) countChartExs() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/charts/chartEx") {
 			count++
 		}
 		return true
@@ -1094,6 +1301,37 @@ func (f *File) countCharts() int {
 	return count
 }
 
+// getChartSeriesValues provides a function to get the cell values of the chart
+// series by given formula reference, such as "Sheet1!$B$2:$B$6".
+func (f *File) getChartSeriesValues(ref string) ([]string, error) {
+	var values []string
+	idx := strings.LastIndex(ref, "!")
+	if idx == -1 {
+		return values, ErrParameterInvalid
+	}
+	sheet := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(ref[:idx], "'"), "'"), "''", "'")
+	cells := ref[idx+1:]
+	if !strings.Contains(cells, ":") {
+		cells += ":" + cells
+	}
+	coordinates, err := rangeRefToCoordinates(cells)
+	if err != nil {
+		return values, err
+	}
+	_ = sortCoordinates(coordinates)
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			val, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return values, err
+			}
+			values = append(values, val)
+		}
+	}
+	return values, err
+}
+
 // ptToEMUs provides a function to convert pt to EMUs, 1 pt = 12700 EMUs. The
 // range of pt is 0.25pt - 999pt. If the value of pt is outside the range, the
 // default EMUs will be returned.
@@ -1103,3 +1341,1281 @@ func (f *File) ptToEMUs(pt float64) int {
 	}
 	return int(12700 * pt)
 }
+
+// chartPartNamespacePrefixes defined the prefixes of the namespaces which
+// used in the struct field tags of the chart part.
+var chartPartNamespacePrefixes = map[string]string{
+	NameSpaceDrawingML.Value: "a",
+	SourceRelationship.Value: "r",
+	"xmlns":                  "xmlns",
+}
+
+// prefixedTokenReader provides a token reader which maps the name of the
+// elements and attributes in the given namespaces to the prefixed name, this
+// is used for decoding the XML part with prefixed struct field tags.
+type prefixedTokenReader struct {
+	decoder  *xml.Decoder
+	prefixes map[string]string
+}
+
+// Token provides a function to read the next XML token and map the name of
+// the element and attributes in the given namespaces to the prefixed name.
+func (r *prefixedTokenReader) Token() (xml.Token, error) {
+	token, err := r.decoder.Token()
+	switch t := token.(type) {
+	case xml.StartElement:
+		t = t.Copy()
+		t.Name = r.prefixedName(t.Name)
+		for i := range t.Attr {
+			t.Attr[i].Name = r.prefixedName(t.Attr[i].Name)
+		}
+		return t, err
+	case xml.EndElement:
+		t.Name = r.prefixedName(t.Name)
+		return t, err
+	}
+	return token, err
+}
+
+// prefixedName provides a function to get the prefixed name by given XML
+// name.
+func (r *prefixedTokenReader) prefixedName(name xml.Name) xml.Name {
+	if prefix, ok := r.prefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+	return name
+}
+
+// chartReader provides a function to get the pointer to the structure after
+// deserialization of the chart part by given path.
+func (f *File) chartReader(path string) (*xlsxChartSpace, error) {
+	cs, content := new(xlsxChartSpace), namespaceStrictToTransitional(f.readXML(path))
+	decoder := xml.NewTokenDecoder(&prefixedTokenReader{
+		decoder:  f.xmlNewDecoder(bytes.NewReader(content)),
+		prefixes: chartPartNamespacePrefixes,
+	})
+	if err := decoder.Decode(cs); err != nil && err != io.EOF {
+		return cs, err
+	}
+	var decodeCS decodeChartSpace
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(&decodeCS); err != nil && err != io.EOF {
+		return cs, err
+	}
+	var namespaces []xml.Attr
+	for _, attr := range cs.Namespaces {
+		if strings.HasPrefix(attr.Name.Local, "xmlns:") {
+			namespaces = append(namespaces, attr)
+		}
+	}
+	cs.XMLNSa, cs.Namespaces = NameSpaceDrawingML.Value, namespaces
+	if decodeCS.AlternateContent != nil {
+		cs.AlternateContent = &xlsxAlternateContent{
+			Content: decodeCS.AlternateContent.Content,
+			XMLNSMC: SourceRelationshipCompatibility.Value,
+		}
+	}
+	cs.PivotSource, cs.Protection, cs.ExtLst = decodeCS.PivotSource, decodeCS.Protection, decodeCS.ExtLst
+	cs.Chart.PivotFmts, cs.Chart.ExtLst = decodeCS.Chart.PivotFmts, decodeCS.Chart.ExtLst
+	if cs.Chart.PlotArea != nil {
+		cs.Chart.PlotArea.DTable = decodeCS.Chart.PlotArea.DTable
+		cs.Chart.PlotArea.ExtLst = decodeCS.Chart.PlotArea.ExtLst
+	}
+	return cs, nil
+}
+
+// chartWriter provides a function to save the chart part after serialize
+// structure by given path.
+func (f *File) chartWriter(path string, cs *xlsxChartSpace) {
+	chart, _ := xml.Marshal(cs)
+	f.saveFileList(path, chart)
+}
+
+// getChartPath provides a function to get the path of the chart part by given
+// worksheet name and cell reference.
+func (f *File) getChartPath(sheet, cell string) (string, error) {
+	path, _, err := f.getChartAnchor(sheet, cell)
+	return path, err
+}
+
+// getChartAnchor provides a function to get the path of the chart part and
+// the cell anchor of the chart by given worksheet name and cell reference.
+func (f *File) getChartAnchor(sheet, cell string) (string, *xdrCellAnchor, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return "", nil, err
+	}
+	col--
+	row--
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return "", nil, err
+	}
+	if ws.Drawing == nil {
+		return "", nil, newNoExistChartError(cell)
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.ReplaceAll(target, "..", "xl")
+	drawingRels := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return "", nil, err
+	}
+	wsDr.mu.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.mu.Unlock()
+	for _, anchor := range anchors {
+		deAnchor, err := f.decodeDrawingAnchor(anchor)
+		if err != nil {
+			return "", nil, err
+		}
+		if deAnchor.From == nil || deAnchor.From.Col != col || deAnchor.From.Row != row ||
+			deAnchor.GraphicFrame == nil || deAnchor.GraphicFrame.Graphic == nil ||
+			deAnchor.GraphicFrame.Graphic.GraphicData == nil ||
+			deAnchor.GraphicFrame.Graphic.GraphicData.Chart == nil {
+			continue
+		}
+		if drawRel := f.getDrawingRelationships(drawingRels,
+			deAnchor.GraphicFrame.Graphic.GraphicData.Chart.RID); drawRel != nil {
+			if strings.HasPrefix(drawRel.Target, "/") {
+				return strings.TrimPrefix(drawRel.Target, "/"), anchor, err
+			}
+			return strings.ReplaceAll(drawRel.Target, "..", "xl"), anchor, err
+		}
+	}
+	return "", nil, newNoExistChartError(cell)
+}
+
+// chartGroups provides a function to get all chart groups in the plot area.
+func (pa *cPlotArea) chartGroups() []*cCharts {
+	var groups []*cCharts
+	for _, c := range []*cCharts{
+		pa.AreaChart, pa.Area3DChart, pa.BarChart, pa.Bar3DChart, pa.BubbleChart,
+		pa.DoughnutChart, pa.LineChart, pa.Line3DChart, pa.PieChart, pa.Pie3DChart,
+		pa.OfPieChart, pa.RadarChart, pa.ScatterChart, pa.Surface3DChart, pa.SurfaceChart,
+	} {
+		if c != nil {
+			groups = append(groups, c)
+		}
+	}
+	return groups
+}
+
+// GetChartPlotArea provides a function to get the format settings of the plot
+// area for the chart by given worksheet name and cell reference where the
+// chart is located. The data labels settings are read from the first chart
+// group in the plot area. For example, get the plot area settings of the
+// chart in the cell E1 on Sheet1:
+//
+//	area, err := f.GetChartPlotArea("Sheet1", "E1")
+func (f *File) GetChartPlotArea(sheet, cell string) (*ChartPlotArea, error) {
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return nil, err
+	}
+	return extractChartPlotArea(cs), err
+}
+
+// extractChartPlotArea provides a function to extract the format settings of
+// the plot area from the chart part.
+func extractChartPlotArea(cs *xlsxChartSpace) *ChartPlotArea {
+	area := &ChartPlotArea{}
+	if cs.Chart.PlotVisOnly != nil && cs.Chart.PlotVisOnly.Val != nil {
+		area.PlotVisOnly = *cs.Chart.PlotVisOnly.Val
+	}
+	if cs.Chart.PlotArea == nil {
+		return area
+	}
+	area.Layout = extractChartLayout(cs.Chart.PlotArea.Layout)
+	for _, c := range cs.Chart.PlotArea.chartGroups() {
+		if c.SplitPos != nil && c.SplitPos.Val != nil {
+			area.SecondPlotValues = *c.SplitPos.Val
+		}
+		if c.DLbls != nil {
+			extractChartDLbls(c.DLbls, area)
+			break
+		}
+	}
+	return area
+}
+
+// extractChartLayout provides a function to extract the manual layout
+// settings from the c:layout element.
+func extractChartLayout(layout *cLayout) *ChartLayout {
+	if layout == nil || layout.ManualLayout == nil {
+		return nil
+	}
+	var opts ChartLayout
+	for _, item := range []struct {
+		val *attrValFloat
+		ptr *float64
+	}{
+		{layout.ManualLayout.X, &opts.X}, {layout.ManualLayout.Y, &opts.Y},
+		{layout.ManualLayout.W, &opts.Width}, {layout.ManualLayout.H, &opts.Height},
+	} {
+		if item.val != nil && item.val.Val != nil {
+			*item.ptr = *item.val.Val
+		}
+	}
+	return &opts
+}
+
+// extractChartDLbls provides a function to extract the data labels settings
+// from the c:dLbls element to the format settings of the plot area.
+func extractChartDLbls(dLbls *cDLbls, area *ChartPlotArea) {
+	for _, item := range []struct {
+		val *attrValBool
+		ptr *bool
+	}{
+		{dLbls.ShowBubbleSize, &area.ShowBubbleSize}, {dLbls.ShowCatName, &area.ShowCatName},
+		{dLbls.ShowLeaderLines, &area.ShowLeaderLines}, {dLbls.ShowPercent, &area.ShowPercent},
+		{dLbls.ShowSerName, &area.ShowSerName}, {dLbls.ShowVal, &area.ShowVal},
+	} {
+		if item.val != nil && item.val.Val != nil {
+			*item.ptr = *item.val.Val
+		}
+	}
+	if dLbls.NumFmt != nil {
+		area.NumFmt = ChartNumFmt{CustomNumFmt: dLbls.NumFmt.FormatCode, SourceLinked: dLbls.NumFmt.SourceLinked}
+	}
+}
+
+// SetChartPlotArea provides a function to set the format settings of the plot
+// area for the chart by given worksheet name, cell reference where the chart
+// is located and plot area format settings. This function overwrites the
+// manual layout and plotting visible cells only settings of the chart, and
+// the data labels settings of each chart group in the plot area. The
+// properties of the plot area are the same with the 'PlotArea' of the
+// function AddChart. For example, set the inner plot area of the chart in the
+// cell E1 on Sheet1 to take the right half of the chart:
+//
+//	area, err := f.GetChartPlotArea("Sheet1", "E1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	area.Layout = &excelize.ChartLayout{X: 0.5, Y: 0.1, Width: 0.45, Height: 0.8}
+//	err = f.SetChartPlotArea("Sheet1", "E1", area)
+func (f *File) SetChartPlotArea(sheet, cell string, area *ChartPlotArea) error {
+	if area == nil {
+		return ErrParameterInvalid
+	}
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return err
+	}
+	cs.Chart.PlotVisOnly = &attrValBool{Val: boolPtr(area.PlotVisOnly)}
+	if cs.Chart.PlotArea == nil {
+		cs.Chart.PlotArea = &cPlotArea{}
+	}
+	cs.Chart.PlotArea.Layout = f.drawChartLayout(area.Layout)
+	opts := &Chart{PlotArea: *area}
+	for _, c := range cs.Chart.PlotArea.chartGroups() {
+		if c.SplitPos != nil && area.SecondPlotValues > 0 {
+			c.SplitPos.Val = intPtr(area.SecondPlotValues)
+		}
+		if c.DLbls == nil {
+			continue
+		}
+		dLbls := f.drawChartDLbls(opts)
+		dLbls.SpPr, dLbls.TxPr, dLbls.ShowLegendKey = c.DLbls.SpPr, c.DLbls.TxPr, c.DLbls.ShowLegendKey
+		c.DLbls = dLbls
+	}
+	f.chartWriter(path, cs)
+	return err
+}
+
+// GetChartPlotVisOnly provides a function to get whether only the data of
+// the visible cells are plotted on the chart by given worksheet name and cell
+// reference where the chart is located. For example, get the setting of the
+// chart in the cell E1 on Sheet1:
+//
+//	visOnly, err := f.GetChartPlotVisOnly("Sheet1", "E1")
+func (f *File) GetChartPlotVisOnly(sheet, cell string) (bool, error) {
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return false, err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return false, err
+	}
+	return extractChartPlotArea(cs).PlotVisOnly, err
+}
+
+// SetChartPlotVisOnly provides a function to set whether only the data of
+// the visible cells are plotted on the chart by given worksheet name, cell
+// reference where the chart is located and the setting, the data of the rows
+// and columns hidden by the filter or manually will not be plotted on the
+// chart if it's true. For example, plot only the visible cells for the chart
+// in the cell E1 on Sheet1:
+//
+//	err := f.SetChartPlotVisOnly("Sheet1", "E1", true)
+func (f *File) SetChartPlotVisOnly(sheet, cell string, visOnly bool) error {
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return err
+	}
+	cs.Chart.PlotVisOnly = &attrValBool{Val: boolPtr(visOnly)}
+	f.chartWriter(path, cs)
+	return err
+}
+
+// GetChartLegend provides a function to get the format settings of the chart
+// legend by given worksheet name and cell reference where the chart is
+// located. The position of the legend will be "none" if the chart has no
+// legend. For example, get the legend settings of the chart in the cell E1 on
+// Sheet1:
+//
+//	legend, err := f.GetChartLegend("Sheet1", "E1")
+func (f *File) GetChartLegend(sheet, cell string) (*ChartLegend, error) {
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return nil, err
+	}
+	return extractChartLegend(cs), err
+}
+
+// extractChartLegend provides a function to extract the format settings of
+// the legend from the chart part.
+func extractChartLegend(cs *xlsxChartSpace) *ChartLegend {
+	legend := &ChartLegend{Position: "none"}
+	if cs.Chart.PlotArea != nil {
+		for _, c := range cs.Chart.PlotArea.chartGroups() {
+			if c.DLbls != nil && c.DLbls.ShowLegendKey != nil && c.DLbls.ShowLegendKey.Val != nil {
+				legend.ShowLegendKey = *c.DLbls.ShowLegendKey.Val
+				break
+			}
+		}
+	}
+	if cs.Chart.Legend == nil {
+		return legend
+	}
+	legend.Position = defaultChartLegendPosition
+	if cs.Chart.Legend.LegendPos != nil && cs.Chart.Legend.LegendPos.Val != nil {
+		for position, val := range chartLegendPosition {
+			if val == *cs.Chart.Legend.LegendPos.Val {
+				legend.Position = position
+			}
+		}
+	}
+	if cs.Chart.Legend.Overlay != nil && cs.Chart.Legend.Overlay.Val != nil {
+		legend.Overlay = *cs.Chart.Legend.Overlay.Val
+	}
+	legend.Font = extractChartFont(cs.Chart.Legend.TxPr)
+	return legend
+}
+
+// extractChartFont provides a function to extract the font settings from the
+// c:txPr element.
+func extractChartFont(txPr *cTxPr) *Font {
+	if txPr == nil || txPr.P.PPr == nil {
+		return nil
+	}
+	rPr := txPr.P.PPr.DefRPr
+	font := &Font{Bold: rPr.B, Italic: rPr.I, Size: rPr.Sz / 100, Strike: rPr.Strike == "sngStrike"}
+	if rPr.U != "none" {
+		font.Underline = rPr.U
+	}
+	if rPr.Latin != nil && !strings.HasPrefix(rPr.Latin.Typeface, "+") {
+		font.Family = rPr.Latin.Typeface
+	}
+	if rPr.SolidFill != nil && rPr.SolidFill.SrgbClr != nil && rPr.SolidFill.SrgbClr.Val != nil {
+		font.Color = *rPr.SolidFill.SrgbClr.Val
+	}
+	return font
+}
+
+// SetChartLegend provides a function to set the format settings of the chart
+// legend by given worksheet name, cell reference where the chart is located
+// and legend format settings. The legend of the chart will be removed if the
+// position is "none", the text properties of the existing legend will be
+// kept if the font is not specified. The properties of the legend are the
+// same with the 'Legend' of the function AddChart. For example, show the
+// legend with bold font at the top of the chart in the cell E1 on Sheet1:
+//
+//	err := f.SetChartLegend("Sheet1", "E1", &excelize.ChartLegend{
+//	    Position: "top",
+//	    Font:     &excelize.Font{Bold: true, Size: 10, Color: "4E81BD"},
+//	})
+func (f *File) SetChartLegend(sheet, cell string, opts *ChartLegend) error {
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	position := opts.Position
+	if position == "" {
+		position = defaultChartLegendPosition
+	}
+	if _, ok := chartLegendPosition[position]; !ok && position != "none" {
+		return ErrParameterInvalid
+	}
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return err
+	}
+	if cs.Chart.PlotArea != nil {
+		for _, c := range cs.Chart.PlotArea.chartGroups() {
+			if c.DLbls != nil {
+				c.DLbls.ShowLegendKey = &attrValBool{Val: boolPtr(opts.ShowLegendKey)}
+			}
+		}
+	}
+	if position == "none" {
+		cs.Chart.Legend = nil
+		f.chartWriter(path, cs)
+		return err
+	}
+	legend := &cLegend{}
+	if cs.Chart.Legend != nil {
+		legend = cs.Chart.Legend
+	}
+	legend.LegendPos = &attrValString{Val: stringPtr(chartLegendPosition[position])}
+	legend.Overlay = &attrValBool{Val: boolPtr(opts.Overlay)}
+	if opts.Font != nil {
+		legend.TxPr = f.drawChartLegendTxPr(opts.Font)
+	}
+	cs.Chart.Legend = legend
+	f.chartWriter(path, cs)
+	return err
+}
+
+// chartSeries provides a function to get the data series of the chart groups
+// in the plot area in order, and whether the color of each series was drawn
+// as the line color.
+func (pa *cPlotArea) chartSeries() ([]*cSer, []bool) {
+	var (
+		series []*cSer
+		isLine []bool
+	)
+	for _, c := range pa.chartGroups() {
+		if c.Ser == nil {
+			continue
+		}
+		line := c == pa.LineChart || c == pa.Line3DChart || c == pa.RadarChart || c == pa.ScatterChart
+		for idx := range *c.Ser {
+			series = append(series, &(*c.Ser)[idx])
+			isLine = append(isLine, line)
+		}
+	}
+	return series, isLine
+}
+
+// GetChartColorScheme provides a function to get the explicit colors of the
+// data series for the chart by given worksheet name and cell reference where
+// the chart is located. The colors are returned in the order of the series,
+// and the color will be empty if the series uses the theme color. For
+// example, get the colors of the series for the chart in the cell E1 on
+// Sheet1:
+//
+//	colors, err := f.GetChartColorScheme("Sheet1", "E1")
+func (f *File) GetChartColorScheme(sheet, cell string) ([]string, error) {
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil || cs.Chart.PlotArea == nil {
+		return nil, err
+	}
+	var colors []string
+	series, _ := cs.Chart.PlotArea.chartSeries()
+	for _, ser := range series {
+		var color string
+		if ser.SpPr != nil {
+			if ser.SpPr.SolidFill != nil && ser.SpPr.SolidFill.SrgbClr != nil && ser.SpPr.SolidFill.SrgbClr.Val != nil {
+				color = *ser.SpPr.SolidFill.SrgbClr.Val
+			} else if ser.SpPr.Ln != nil && ser.SpPr.Ln.SolidFill != nil && ser.SpPr.Ln.SolidFill.SrgbClr != nil && ser.SpPr.Ln.SolidFill.SrgbClr.Val != nil {
+				color = *ser.SpPr.Ln.SolidFill.SrgbClr.Val
+			}
+		}
+		if color == "" && ser.Marker != nil && ser.Marker.SpPr != nil && ser.Marker.SpPr.SolidFill != nil &&
+			ser.Marker.SpPr.SolidFill.SrgbClr != nil && ser.Marker.SpPr.SolidFill.SrgbClr.Val != nil {
+			color = *ser.Marker.SpPr.SolidFill.SrgbClr.Val
+		}
+		colors = append(colors, color)
+	}
+	return colors, err
+}
+
+// SetChartColorScheme provides a function to set the explicit colors of the
+// data series for the chart by given worksheet name, cell reference where the
+// chart is located and a list of colors in hex RGB. The colors are applied to
+// the series in order, and the list wraps around if fewer colors are provided
+// than series. The color will be used as the line and marker color for the
+// line, radar and scatter charts, and as the fill color for the others. For
+// example, set the colors of the series for the chart in the cell E1 on
+// Sheet1:
+//
+//	err := f.SetChartColorScheme("Sheet1", "E1", []string{"4472C4", "ED7D31", "A5A5A5"})
+func (f *File) SetChartColorScheme(sheet, cell string, colors []string) error {
+	if len(colors) == 0 {
+		return ErrParameterInvalid
+	}
+	rgbColors := make([]string, len(colors))
+	for idx, color := range colors {
+		rgb := strings.TrimPrefix(color, "#")
+		if _, err := strconv.ParseUint(rgb, 16, 32); err != nil || len(rgb) != 6 {
+			return ErrParameterInvalid
+		}
+		rgbColors[idx] = strings.ToUpper(rgb)
+	}
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil || cs.Chart.PlotArea == nil {
+		return err
+	}
+	series, isLine := cs.Chart.PlotArea.chartSeries()
+	for idx, ser := range series {
+		solidFill := &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(rgbColors[idx%len(rgbColors)])}}
+		if ser.SpPr == nil {
+			ser.SpPr = &cSpPr{}
+		}
+		if isLine[idx] {
+			if ser.Marker != nil && (ser.Marker.Symbol == nil || ser.Marker.Symbol.Val == nil || *ser.Marker.Symbol.Val != "none") {
+				ser.Marker.SpPr = &cSpPr{SolidFill: solidFill, Ln: &aLn{W: 9525, SolidFill: solidFill}}
+			}
+			if ser.SpPr.Ln == nil {
+				ser.SpPr.Ln = &aLn{W: 28575, Cap: "rnd"}
+			}
+			if ser.SpPr.Ln.NoFill == nil {
+				ser.SpPr.Ln.SolidFill = solidFill
+			}
+			continue
+		}
+		ser.SpPr.NoFill, ser.SpPr.SolidFill = nil, solidFill
+	}
+	f.chartWriter(path, cs)
+	return err
+}
+
+// SetChartMarker provides a function to set the marker of the data series for
+// the line, radar and scatter chart by given worksheet name, cell reference
+// where the chart is located, the zero-based index of the data series and
+// marker format settings. The properties of the marker are the same with the
+// 'Marker' of the data series of the function AddChart, and the empty
+// properties keep the existing settings. For example, set diamond markers
+// with red fill and black border for the first series of the chart in the
+// cell E1 on Sheet1:
+//
+//	err := f.SetChartMarker("Sheet1", "E1", 0, &excelize.ChartMarker{
+//	    Symbol:      "diamond",
+//	    Size:        8,
+//	    FillColor:   "FF0000",
+//	    BorderColor: "000000",
+//	})
+func (f *File) SetChartMarker(sheet, cell string, series int, opts *ChartMarker) error {
+	if opts == nil || series < 0 || (opts.Size != 0 && (opts.Size < 2 || opts.Size > 72)) {
+		return ErrParameterInvalid
+	}
+	if opts.Symbol != "" && inStrSlice([]string{
+		"circle", "dash", "diamond", "dot", "none", "picture", "plus", "square", "star", "triangle", "x", "auto",
+	}, opts.Symbol, true) == -1 {
+		return ErrParameterInvalid
+	}
+	if err := checkChartMarkerColors(*opts); err != nil {
+		return err
+	}
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return err
+	}
+	var ser []*cSer
+	var isLine []bool
+	if cs.Chart.PlotArea != nil {
+		ser, isLine = cs.Chart.PlotArea.chartSeries()
+	}
+	if series >= len(ser) || !isLine[series] {
+		return ErrParameterInvalid
+	}
+	marker := ser[series].Marker
+	if marker == nil {
+		marker = &cMarker{}
+	}
+	if opts.Symbol != "" {
+		marker.Symbol = &attrValString{Val: stringPtr(opts.Symbol)}
+	}
+	if opts.Size != 0 {
+		marker.Size = &attrValInt{Val: intPtr(opts.Size)}
+	}
+	setChartMarkerColors(marker, *opts)
+	ser[series].Marker = marker
+	f.chartWriter(path, cs)
+	return err
+}
+
+// trendLineSeries provides a function to get the data series of the chart
+// groups in the plot area in order, and whether the trend line is supported
+// by each series. The trend line is supported by the area, bar, column, line,
+// scatter and bubble chart groups without stacking and 3D effects.
+func (pa *cPlotArea) trendLineSeries() ([]*cSer, []bool) {
+	var (
+		series    []*cSer
+		supported []bool
+	)
+	for _, c := range pa.chartGroups() {
+		if c.Ser == nil {
+			continue
+		}
+		ok := c == pa.AreaChart || c == pa.BarChart || c == pa.LineChart || c == pa.ScatterChart || c == pa.BubbleChart
+		if c.Grouping != nil && c.Grouping.Val != nil {
+			ok = ok && inStrSlice([]string{"clustered", "standard"}, *c.Grouping.Val, true) != -1
+		}
+		for idx := range *c.Ser {
+			series = append(series, &(*c.Ser)[idx])
+			supported = append(supported, ok)
+		}
+	}
+	return series, supported
+}
+
+// AddTrendLine provides a function to add a trend line to the data series of
+// the existing chart by given worksheet name, cell reference where the chart
+// is located, the zero-based index of the data series and trend line format
+// settings. The properties of the trend line are the same with the
+// 'TrendLine' of the data series of the function AddChart, and a data series
+// can have multiple trend lines. The trend line is supported by the area,
+// bar, column, line, scatter and bubble charts without stacking and 3D
+// effects. For example, add a polynomial trend line with the equation
+// displayed for the first series of the chart in the cell E1 on Sheet1:
+//
+//	err := f.AddTrendLine("Sheet1", "E1", 0, &excelize.ChartTrendLine{
+//	    Type:            "polynomial",
+//	    Order:           3,
+//	    DisplayEquation: true,
+//	})
+func (f *File) AddTrendLine(sheet, cell string, series int, opts *ChartTrendLine) error {
+	if opts == nil || series < 0 {
+		return ErrParameterInvalid
+	}
+	trendline := newChartTrendline(*opts)
+	if trendline == nil {
+		return newUnsupportedChartTrendLineType(opts.Type)
+	}
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return err
+	}
+	var (
+		ser       []*cSer
+		supported []bool
+	)
+	if cs.Chart.PlotArea != nil {
+		ser, supported = cs.Chart.PlotArea.trendLineSeries()
+	}
+	if series >= len(ser) || !supported[series] {
+		return ErrParameterInvalid
+	}
+	ser[series].Trendline = append(ser[series].Trendline, trendline)
+	f.chartWriter(path, cs)
+	return err
+}
+
+// DeleteTrendLine provides a function to delete a trend line of the data
+// series for the existing chart by given worksheet name, cell reference where
+// the chart is located, the zero-based index of the data series and the
+// zero-based index of the trend line in the data series. For example, delete
+// the first trend line of the second series for the chart in the cell E1 on
+// Sheet1:
+//
+//	err := f.DeleteTrendLine("Sheet1", "E1", 1, 0)
+func (f *File) DeleteTrendLine(sheet, cell string, series, trendline int) error {
+	if series < 0 || trendline < 0 {
+		return ErrParameterInvalid
+	}
+	path, err := f.getChartPath(sheet, cell)
+	if err != nil {
+		return err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return err
+	}
+	var ser []*cSer
+	if cs.Chart.PlotArea != nil {
+		ser, _ = cs.Chart.PlotArea.trendLineSeries()
+	}
+	if series >= len(ser) || trendline >= len(ser[series].Trendline) {
+		return ErrParameterInvalid
+	}
+	ser[series].Trendline = append(ser[series].Trendline[:trendline], ser[series].Trendline[trendline+1:]...)
+	f.chartWriter(path, cs)
+	return err
+}
+
+// AddChartAnnotation provides a function to add a text box annotation on the
+// chart by given worksheet name, the anchor cell reference where the top left
+// corner of the annotation is located, the cell reference where the chart is
+// located, the annotation text and format settings. The anchor cell must be
+// within the chart area, and the annotation is positioned relative to the
+// chart, so it keeps the position when the chart is moved or resized. The
+// properties of the annotation that can be set are:
+//
+//	Width
+//	Height
+//	OffsetX
+//	OffsetY
+//	Font
+//	Fill
+//	Line
+//
+// Width: The width of the annotation in pixels, the default value is 160.
+//
+// Height: The height of the annotation in pixels, the default value is 40.
+//
+// OffsetX: The horizontal offset of the annotation from the anchor cell in
+// pixels.
+//
+// OffsetY: The vertical offset of the annotation from the anchor cell in
+// pixels.
+//
+// Font: The font of the annotation text, the 'Bold', 'Italic', 'Underline',
+// 'Family', 'Size' and 'Color' properties are supported. The default font
+// size is 10.
+//
+// Fill: The solid fill color of the annotation is specified by the first
+// color of the 'Color' property, the annotation has no fill by default.
+//
+// Line: The border color and width in points of the annotation, the
+// annotation has no border by default.
+//
+// For example, add a callout with red text and yellow fill on the chart in
+// the cell E1 on Sheet1, and the top left corner of the callout is located in
+// the cell F3:
+//
+//	err := f.AddChartAnnotation("Sheet1", "F3", "E1", "Target", &excelize.AnnotationOptions{
+//	    Font: excelize.Font{Bold: true, Color: "FF0000"},
+//	    Fill: excelize.Fill{Color: []string{"FFFF00"}},
+//	    Line: excelize.ShapeLine{Color: "000000"},
+//	})
+func (f *File) AddChartAnnotation(sheet, anchorCell, chartCell, text string, opts *AnnotationOptions) error {
+	if opts == nil {
+		opts = &AnnotationOptions{}
+	}
+	if text == "" {
+		return ErrParameterRequired
+	}
+	col, row, err := CellNameToCoordinates(anchorCell)
+	if err != nil {
+		return err
+	}
+	var colors []string
+	if len(opts.Fill.Color) > 0 {
+		colors = append(colors, opts.Fill.Color[0])
+	}
+	for _, color := range append(colors, opts.Line.Color, opts.Font.Color) {
+		if rgb := strings.TrimPrefix(color, "#"); color != "" {
+			if _, err := strconv.ParseUint(rgb, 16, 32); err != nil || len(rgb) != 6 {
+				return ErrParameterInvalid
+			}
+		}
+	}
+	path, anchor, err := f.getChartAnchor(sheet, chartCell)
+	if err != nil {
+		return err
+	}
+	deAnchor, err := f.decodeDrawingAnchor(anchor)
+	if err != nil {
+		return err
+	}
+	width, height := f.getPictureAnchorPixels(sheet, deAnchor)
+	x, y := opts.OffsetX-deAnchor.From.ColOff/EMU, opts.OffsetY-deAnchor.From.RowOff/EMU
+	for c := deAnchor.From.Col + 1; c < col; c++ {
+		x += f.getColWidth(sheet, c)
+	}
+	for r := deAnchor.From.Row + 1; r < row; r++ {
+		y += f.getRowHeight(sheet, r)
+	}
+	if col <= deAnchor.From.Col || row <= deAnchor.From.Row || x < 0 || y < 0 || x >= width || y >= height {
+		return ErrChartAnnotationAnchor
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return err
+	}
+	drawingXML, err := f.prepareChartUserShapes(path, cs)
+	if err != nil {
+		return err
+	}
+	userShapes := decodeUserShapes{}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(drawingXML)))).
+		Decode(&userShapes); err != nil && err != io.EOF {
+		return err
+	}
+	w, h := defaultAnnotationWidth, defaultAnnotationHeight
+	if opts.Width > 0 {
+		w = int(opts.Width)
+	}
+	if opts.Height > 0 {
+		h = int(opts.Height)
+	}
+	cNvPrID := len(userShapes.RelSizeAnchor) + len(userShapes.AbsSizeAnchor) + 2
+	shape, err := f.drawChartAnnotation(text, opts)
+	if err != nil {
+		return err
+	}
+	shape.NvSpPr.CNvPr = xlsxCNvPr{ID: cNvPrID, Name: "TextBox " + strconv.Itoa(cNvPrID-1)}
+	relSizeAnchor, _ := xml.Marshal(cdrRelSizeAnchor{
+		From: cdrMarker{X: float64(x) / float64(width), Y: float64(y) / float64(height)},
+		To: cdrMarker{
+			X: math.Min(float64(x+w)/float64(width), 1),
+			Y: math.Min(float64(y+h)/float64(height), 1),
+		},
+		Sp: *shape,
+	})
+	output, _ := xml.Marshal(cdrUserShapes{
+		XMLNSc:   NameSpaceDrawingMLChart.Value,
+		XMLNSa:   NameSpaceDrawingML.Value,
+		XMLNScdr: NameSpaceDrawingMLChartDrawing,
+		Content:  userShapes.Content + string(relSizeAnchor),
+	})
+	f.saveFileList(drawingXML, output)
+	f.chartWriter(path, cs)
+	return err
+}
+
+// prepareChartUserShapes provides a function to get the path of the chart
+// drawing part by given chart part path, the chart drawing part and the
+// relationship will be created if it doesn't exist.
+func (f *File) prepareChartUserShapes(path string, cs *xlsxChartSpace) (string, error) {
+	chartRels := strings.Replace(path, "xl/charts/", "xl/charts/_rels/", 1) + ".rels"
+	if cs.UserShapes != nil {
+		if rel := f.getDrawingRelationships(chartRels, cs.UserShapes.RID); rel != nil {
+			return strings.ReplaceAll(rel.Target, "..", "xl"), nil
+		}
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	rID := f.addRels(chartRels, SourceRelationshipChartUserShapes, "../"+strings.TrimPrefix(drawingXML, "xl/"), "")
+	cs.XMLNSr = SourceRelationship.Value
+	cs.UserShapes = &cUserShapes{RID: "rId" + strconv.Itoa(rID)}
+	return drawingXML, f.addContentTypePart(drawingID, "chartDrawing")
+}
+
+// drawChartAnnotation provides a function to draw the text box shape of the
+// chart annotation by given text and format settings.
+func (f *File) drawChartAnnotation(text string, opts *AnnotationOptions) (*cdrSp, error) {
+	shape := cdrSp{
+		NvSpPr: cdrNvSpPr{CNvSpPr: xdrCNvSpPr{TxBox: true}},
+		SpPr:   cdrSpPr{PrstGeom: xlsxPrstGeom{Prst: "rect"}, NoFill: stringPtr("")},
+		TxBody: xdrTxBody{BodyPr: &aBodyPr{VertOverflow: "clip", Wrap: "square"}},
+	}
+	if len(opts.Fill.Color) > 0 && opts.Fill.Color[0] != "" {
+		shape.SpPr.NoFill = nil
+		shape.SpPr.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(strings.TrimPrefix(opts.Fill.Color[0], "#")))},
+		}
+	}
+	shape.SpPr.Ln = &aLn{NoFill: stringPtr("")}
+	if opts.Line.Color != "" {
+		lineWidth := 0.75
+		if opts.Line.Width != nil {
+			lineWidth = *opts.Line.Width
+		}
+		shape.SpPr.Ln = &aLn{
+			W: f.ptToEMUs(lineWidth),
+			SolidFill: &aSolidFill{
+				SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(strings.TrimPrefix(opts.Line.Color, "#")))},
+			},
+		}
+	}
+	family, err := f.GetDefaultFont()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Font.Family != "" {
+		family = opts.Font.Family
+	}
+	size := float64(defaultAnnotationFontSize)
+	if opts.Font.Size > 0 {
+		size = opts.Font.Size
+	}
+	u := "none"
+	if idx := inStrSlice(supportedDrawingUnderlineTypes, opts.Font.Underline, true); idx != -1 {
+		u = supportedDrawingUnderlineTypes[idx]
+	}
+	rPr := aRPr{
+		B:       opts.Font.Bold,
+		I:       opts.Font.Italic,
+		Lang:    "en-US",
+		AltLang: "en-US",
+		U:       u,
+		Sz:      size * 100,
+		Latin:   &xlsxCTTextFont{Typeface: family},
+	}
+	if opts.Font.Color != "" {
+		rPr.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(strings.TrimPrefix(opts.Font.Color, "#")))},
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		shape.TxBody.P = append(shape.TxBody.P, &aP{
+			R:          &aR{RPr: rPr, T: line},
+			EndParaRPr: &aEndParaRPr{Lang: "en-US"},
+		})
+	}
+	return &shape, err
+}
+
+// chartGroupTypes defined the chart types which can be drawn in each chart
+// group of the plot area.
+var chartGroupTypes = []struct {
+	group func(pa *cPlotArea) *cCharts
+	types []ChartType
+}{
+	{func(pa *cPlotArea) *cCharts { return pa.AreaChart }, []ChartType{Area, AreaStacked, AreaPercentStacked}},
+	{func(pa *cPlotArea) *cCharts { return pa.Area3DChart }, []ChartType{Area3D, Area3DStacked, Area3DPercentStacked}},
+	{func(pa *cPlotArea) *cCharts { return pa.BarChart }, []ChartType{Bar, BarStacked, BarPercentStacked, Col, ColStacked, ColPercentStacked}},
+	{func(pa *cPlotArea) *cCharts { return pa.Bar3DChart }, []ChartType{
+		Bar3DClustered, Bar3DStacked, Bar3DPercentStacked, Bar3DConeClustered, Bar3DConeStacked,
+		Bar3DConePercentStacked, Bar3DPyramidClustered, Bar3DPyramidStacked, Bar3DPyramidPercentStacked,
+		Bar3DCylinderClustered, Bar3DCylinderStacked, Bar3DCylinderPercentStacked, Col3D, Col3DClustered,
+		Col3DStacked, Col3DPercentStacked, Col3DCone, Col3DConeClustered, Col3DConeStacked,
+		Col3DConePercentStacked, Col3DPyramid, Col3DPyramidClustered, Col3DPyramidStacked,
+		Col3DPyramidPercentStacked, Col3DCylinder, Col3DCylinderClustered, Col3DCylinderStacked,
+		Col3DCylinderPercentStacked,
+	}},
+	{func(pa *cPlotArea) *cCharts { return pa.BubbleChart }, []ChartType{Bubble, Bubble3D}},
+	{func(pa *cPlotArea) *cCharts { return pa.DoughnutChart }, []ChartType{Doughnut}},
+	{func(pa *cPlotArea) *cCharts { return pa.LineChart }, []ChartType{Line}},
+	{func(pa *cPlotArea) *cCharts { return pa.Line3DChart }, []ChartType{Line3D}},
+	{func(pa *cPlotArea) *cCharts { return pa.PieChart }, []ChartType{Pie}},
+	{func(pa *cPlotArea) *cCharts { return pa.Pie3DChart }, []ChartType{Pie3D}},
+	{func(pa *cPlotArea) *cCharts { return pa.OfPieChart }, []ChartType{PieOfPie, BarOfPie}},
+	{func(pa *cPlotArea) *cCharts { return pa.RadarChart }, []ChartType{Radar}},
+	{func(pa *cPlotArea) *cCharts { return pa.ScatterChart }, []ChartType{Scatter}},
+	{func(pa *cPlotArea) *cCharts { return pa.Surface3DChart }, []ChartType{Surface3D, WireframeSurface3D}},
+	{func(pa *cPlotArea) *cCharts { return pa.SurfaceChart }, []ChartType{Contour, WireframeContour}},
+}
+
+// GetChart provides a function to get the format settings of the chart by
+// given worksheet name and cell reference where the chart is located. The
+// returned settings can be used as the chart format settings of the function
+// AddChart to create an equivalent chart. For the combo chart, only the chart
+// type which contains the first data series will be returned. The waterfall,
+// treemap and sunburst charts are not supported currently. For example, get
+// the chart in the cell E1 on Sheet1 and create a copy of it in the cell E20:
+//
+//	chart, err := f.GetChart("Sheet1", "E1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddChart("Sheet1", "E20", chart)
+//
+// 根据给定的工作表名称和单元格坐标获取图表的格式设置，返回的格式设置可用于 AddChart 函数创建等效的图表。
+func (f *File) GetChart(sheet, cell string) (*Chart, error) {
+	path, anchor, err := f.getChartAnchor(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := f.chartReader(path)
+	if err != nil {
+		return nil, err
+	}
+	opts := &Chart{Format: GraphicOptions{ScaleX: defaultPictureScale, ScaleY: defaultPictureScale}}
+	if err = f.extractChartAnchor(sheet, anchor, opts); err != nil {
+		return nil, err
+	}
+	if cs.Chart.Title != nil && cs.Chart.Title.Tx != nil && cs.Chart.Title.Tx.Rich != nil && cs.Chart.Title.Tx.Rich.P.R != nil {
+		opts.Title.Name = strings.TrimSpace(cs.Chart.Title.Tx.Rich.P.R.T)
+	}
+	opts.Legend = *extractChartLegend(cs)
+	opts.PlotArea = *extractChartPlotArea(cs)
+	opts.ShowBlanksAs = defaultChartShowBlanksAs
+	if cs.Chart.DispBlanksAs != nil && cs.Chart.DispBlanksAs.Val != nil {
+		opts.ShowBlanksAs = *cs.Chart.DispBlanksAs.Val
+	}
+	if cs.Chart.PlotArea == nil {
+		return opts, err
+	}
+	c := cs.Chart.PlotArea.primaryChartGroup()
+	if c == nil {
+		return opts, err
+	}
+	opts.Type = f.extractChartType(cs.Chart.PlotArea, c)
+	if c.VaryColors != nil && c.VaryColors.Val != nil {
+		opts.VaryColors = boolPtr(*c.VaryColors.Val)
+	}
+	if c.HoleSize != nil && c.HoleSize.Val != nil {
+		opts.HoleSize = *c.HoleSize.Val
+	}
+	if c.FirstSliceAng != nil && c.FirstSliceAng.Val != nil {
+		opts.FirstSliceAngle = *c.FirstSliceAng.Val
+	}
+	if c.Smooth != nil && c.Smooth.Val != nil {
+		opts.Smooth = *c.Smooth.Val
+	}
+	if c.Ser != nil {
+		for _, ser := range *c.Ser {
+			opts.Series = append(opts.Series, extractChartSeries(&ser, c == cs.Chart.PlotArea.LineChart))
+		}
+	}
+	extractChartAxes(cs.Chart.PlotArea, opts)
+	return opts, err
+}
+
+// extractChartAnchor provides a function to extract the dimension and the
+// graphic format settings of the chart from the cell anchor of the chart.
+func (f *File) extractChartAnchor(sheet string, anchor *xdrCellAnchor, opts *Chart) error {
+	deAnchor, err := f.decodeDrawingAnchor(anchor)
+	if err != nil {
+		return err
+	}
+	opts.Format.Positioning = anchor.EditAs
+	if deAnchor.ClientData != nil {
+		opts.Format.Locked = boolPtr(deAnchor.ClientData.FLocksWithSheet)
+		opts.Format.PrintObject = boolPtr(deAnchor.ClientData.FPrintsWithSheet)
+	}
+	if anchor.ClientData != nil {
+		opts.Format.Locked = boolPtr(anchor.ClientData.FLocksWithSheet)
+		opts.Format.PrintObject = boolPtr(anchor.ClientData.FPrintsWithSheet)
+	}
+	if deAnchor.From != nil {
+		opts.Format.OffsetX, opts.Format.OffsetY = deAnchor.From.ColOff/EMU, deAnchor.From.RowOff/EMU
+	}
+	if deAnchor.To != nil || deAnchor.Ext != nil {
+		width, height := f.getPictureAnchorPixels(sheet, deAnchor)
+		opts.Dimension = ChartDimension{Width: uint(width), Height: uint(height)}
+	}
+	return err
+}
+
+// primaryChartGroup provides a function to get the chart group in the plot
+// area which contains the first data series.
+func (pa *cPlotArea) primaryChartGroup() *cCharts {
+	var (
+		primary *cCharts
+		first   int
+	)
+	for _, c := range pa.chartGroups() {
+		if c.Ser == nil {
+			continue
+		}
+		for _, ser := range *c.Ser {
+			if ser.Order != nil && ser.Order.Val != nil && (primary == nil || *ser.Order.Val < first) {
+				primary, first = c, *ser.Order.Val
+			}
+		}
+		if primary == nil {
+			primary = c
+		}
+	}
+	return primary
+}
+
+// extractChartType provides a function to get the chart type by given plot
+// area and the chart group in it.
+func (f *File) extractChartType(pa *cPlotArea, c *cCharts) ChartType {
+	val := func(attr *attrValString, empty string) string {
+		if attr == nil || attr.Val == nil || *attr.Val == empty {
+			return ""
+		}
+		return *attr.Val
+	}
+	var bubble3D bool
+	if c.Ser != nil && len(*c.Ser) > 0 {
+		if attr := (*c.Ser)[0].Bubble3D; attr != nil && attr.Val != nil {
+			bubble3D = *attr.Val
+		}
+	}
+	for _, groupTypes := range chartGroupTypes {
+		if groupTypes.group(pa) != c {
+			continue
+		}
+		for _, chartType := range groupTypes.types {
+			if (c.BarDir != nil && val(c.BarDir, "") != plotAreaChartBarDir[chartType]) ||
+				(c.Grouping != nil && val(c.Grouping, "") != plotAreaChartGrouping[chartType]) ||
+				val(c.Shape, "box") != val(f.drawChartShape(&Chart{Type: chartType}), "") ||
+				(c.OfPieType != nil && val(c.OfPieType, "") != map[ChartType]string{PieOfPie: "pie", BarOfPie: "bar"}[chartType]) ||
+				(c.Wireframe != nil && c.Wireframe.Val != nil && *c.Wireframe.Val) != (chartType == WireframeSurface3D || chartType == WireframeContour) ||
+				bubble3D != (chartType == Bubble3D) {
+				continue
+			}
+			return chartType
+		}
+		return groupTypes.types[0]
+	}
+	return Col
+}
+
+// extractChartSeries provides a function to extract the format settings of
+// the data series from the c:ser element.
+func extractChartSeries(ser *cSer, isLine bool) ChartSeries {
+	var series ChartSeries
+	if ser.Tx != nil && ser.Tx.StrRef != nil {
+		series.Name = ser.Tx.StrRef.F
+	}
+	for _, cat := range []*cCat{ser.Cat, ser.XVal} {
+		if cat != nil && cat.StrRef != nil {
+			series.Categories = cat.StrRef.F
+		}
+	}
+	for _, val := range []*cVal{ser.Val, ser.YVal} {
+		if val != nil && val.NumRef != nil {
+			series.Values = val.NumRef.F
+		}
+	}
+	if ser.BubbleSize != nil && ser.BubbleSize.NumRef != nil {
+		series.Sizes = ser.BubbleSize.NumRef.F
+	}
+	if ser.SpPr != nil {
+		if ser.SpPr.SolidFill != nil && ser.SpPr.SolidFill.SrgbClr != nil && ser.SpPr.SolidFill.SrgbClr.Val != nil {
+			series.Fill.Color = []string{*ser.SpPr.SolidFill.SrgbClr.Val}
+		}
+		if ln := ser.SpPr.Ln; isLine && ln != nil {
+			if ln.SolidFill != nil && ln.SolidFill.SrgbClr != nil && ln.SolidFill.SrgbClr.Val != nil {
+				series.Fill.Color = []string{*ln.SolidFill.SrgbClr.Val}
+			}
+			series.Line.Width = float64(ln.W) / 12700
+		}
+	}
+	if ser.Smooth != nil && ser.Smooth.Val != nil {
+		series.Line.Smooth = *ser.Smooth.Val
+	}
+	if ser.Marker != nil {
+		if ser.Marker.Symbol != nil && ser.Marker.Symbol.Val != nil {
+			series.Marker.Symbol = *ser.Marker.Symbol.Val
+		}
+		if ser.Marker.Size != nil && ser.Marker.Size.Val != nil {
+			series.Marker.Size = *ser.Marker.Size.Val
+		}
+		if spPr := ser.Marker.SpPr; spPr != nil {
+			if spPr.SolidFill != nil && spPr.SolidFill.SrgbClr != nil && spPr.SolidFill.SrgbClr.Val != nil {
+				series.Marker.FillColor = *spPr.SolidFill.SrgbClr.Val
+			}
+			if spPr.Ln != nil && spPr.Ln.SolidFill != nil && spPr.Ln.SolidFill.SrgbClr != nil && spPr.Ln.SolidFill.SrgbClr.Val != nil {
+				series.Marker.BorderColor = *spPr.Ln.SolidFill.SrgbClr.Val
+			}
+		}
+	}
+	if len(ser.Trendline) > 0 {
+		series.TrendLine = extractChartTrendLine(ser.Trendline[0])
+	}
+	return series
+}
+
+// extractChartTrendLine provides a function to extract the format settings of
+// the trend line from the c:trendline element.
+func extractChartTrendLine(trendline *cTrendline) ChartTrendLine {
+	var opts ChartTrendLine
+	if trendline.TrendlineType != nil && trendline.TrendlineType.Val != nil {
+		for trendLineType, val := range chartTrendLineTypes {
+			if val == *trendline.TrendlineType.Val {
+				opts.Type = trendLineType
+			}
+		}
+	}
+	if trendline.Order != nil && trendline.Order.Val != nil {
+		opts.Order = *trendline.Order.Val
+	}
+	if trendline.Period != nil && trendline.Period.Val != nil {
+		opts.Period = *trendline.Period.Val
+	}
+	if trendline.Forward != nil && trendline.Forward.Val != nil {
+		opts.Forward = *trendline.Forward.Val
+	}
+	if trendline.Backward != nil && trendline.Backward.Val != nil {
+		opts.Backward = *trendline.Backward.Val
+	}
+	if trendline.Intercept != nil && trendline.Intercept.Val != nil {
+		opts.Intercept = float64Ptr(*trendline.Intercept.Val)
+	}
+	if trendline.DispEq != nil && trendline.DispEq.Val != nil {
+		opts.DisplayEquation = *trendline.DispEq.Val
+	}
+	if trendline.DispRSqr != nil && trendline.DispRSqr.Val != nil {
+		opts.DisplayRSquared = *trendline.DispRSqr.Val
+	}
+	return opts
+}
+
+// extractChartAxes provides a function to extract the format settings of the
+// horizontal and vertical axis from the plot area to the chart format
+// settings.
+func extractChartAxes(pa *cPlotArea, opts *Chart) {
+	axes := append(append([]*cAxs{}, pa.CatAx...), pa.ValAx...)
+	if len(axes) < 2 {
+		return
+	}
+	opts.XAxis = extractChartAxis(axes[0], "General")
+	opts.YAxis = extractChartAxis(axes[1], chartValAxNumFmtFormatCode[opts.Type])
+	// The font of each axis was drawn in the text properties of the crossing
+	// axis, see the functions drawPlotAreaCatAx and drawPlotAreaValAx.
+	opts.XAxis.Font, opts.YAxis.Font = extractChartAxisFont(axes[1].TxPr), extractChartAxisFont(axes[0].TxPr)
+}
+
+// extractChartAxis provides a function to extract the format settings of the
+// axis from the c:catAx or c:valAx element by given default number format
+// code of the axis.
+func extractChartAxis(ax *cAxs, formatCode string) ChartAxis {
+	var axis ChartAxis
+	if ax.Delete != nil && ax.Delete.Val != nil {
+		axis.None = *ax.Delete.Val
+	}
+	axis.MajorGridLines, axis.MinorGridLines = ax.MajorGridlines != nil, ax.MinorGridlines != nil
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.MajorUnit = *ax.MajorUnit.Val
+	}
+	if ax.TickLblSkip != nil && ax.TickLblSkip.Val != nil {
+		axis.TickLabelSkip = *ax.TickLblSkip.Val
+	}
+	if scaling := ax.Scaling; scaling != nil {
+		axis.ReverseOrder = scaling.Orientation != nil && scaling.Orientation.Val != nil && *scaling.Orientation.Val == orientation[true]
+		if scaling.Max != nil && scaling.Max.Val != nil {
+			axis.Maximum = float64Ptr(*scaling.Max.Val)
+		}
+		if scaling.Min != nil && scaling.Min.Val != nil {
+			axis.Minimum = float64Ptr(*scaling.Min.Val)
+		}
+		if scaling.LogBase != nil && scaling.LogBase.Val != nil {
+			axis.LogBase = *scaling.LogBase.Val
+		}
+	}
+	if ax.NumFmt != nil && (ax.NumFmt.FormatCode != formatCode || ax.NumFmt.SourceLinked) {
+		axis.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	return axis
+}
+
+// extractChartAxisFont provides a function to extract the font settings of
+// the axis from the c:txPr element.
+func extractChartAxisFont(txPr *cTxPr) Font {
+	var font Font
+	if f := extractChartFont(txPr); f != nil {
+		font = Font{Bold: f.Bold, Italic: f.Italic, Underline: f.Underline, Color: f.Color}
+	}
+	return font
+}
@@ -295,11 +295,11 @@ func TestAddChart(t *testing.T) {
 	// Test with illegal cell reference
 	assert.EqualError(t, f.AddChart("Sheet2", "A", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "2D Column Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	// Test with unsupported chart type
-	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0x37, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "Bubble 3D Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0x3A, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "Bubble 3D Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0x3A).Error())
 	// Test add combo chart with invalid format set
 	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "2D Column Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}, nil), ErrParameterInvalid.Error())
 	// Test add combo chart with unsupported chart type
-	assert.EqualError(t, f.AddChart("Sheet2", "BD64", &Chart{Type: BarOfPie, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}, &Chart{Type: 0x37, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChart("Sheet2", "BD64", &Chart{Type: BarOfPie, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}, &Chart{Type: 0x3A, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}), newUnsupportedChartType(0x3A).Error())
 	assert.NoError(t, f.Close())
 
 	// Test add chart with unsupported charset content types.
@@ -342,7 +342,7 @@ func TestAddChartSheet(t *testing.T) {
 	// Test add chartsheet with invalid sheet name
 	assert.EqualError(t, f.AddChartSheet("Sheet:1", nil, &Chart{Type: Col3DClustered, Series: series, Title: ChartTitle{Name: "Fruit 3D Clustered Column Chart"}}), ErrSheetNameInvalid.Error())
 	// Test with unsupported chart type
-	assert.EqualError(t, f.AddChartSheet("Chart2", &Chart{Type: 0x37, Series: series, Title: ChartTitle{Name: "Fruit 3D Clustered Column Chart"}}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChartSheet("Chart2", &Chart{Type: 0x3A, Series: series, Title: ChartTitle{Name: "Fruit 3D Clustered Column Chart"}}), newUnsupportedChartType(0x3A).Error())
 
 	assert.NoError(t, f.UpdateLinkedValue())
 
@@ -501,3 +501,867 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestChartPlotArea(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	series := []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{
+		Type: Col, Series: series,
+		PlotArea: ChartPlotArea{ShowVal: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.00%"}},
+	}))
+	area, err := f.GetChartPlotArea("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ChartPlotArea{ShowVal: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.00%"}}, area)
+	// Test set plot area with manual layout
+	area.Layout = &ChartLayout{X: 0.1, Y: 0.2, Width: 0.7, Height: 0.6}
+	area.PlotVisOnly, area.ShowCatName = true, true
+	assert.NoError(t, f.SetChartPlotArea("Sheet1", "P1", area))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartPlotArea.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestChartPlotArea.xlsx"))
+	assert.NoError(t, err)
+	expected := *area
+	area, err = f.GetChartPlotArea("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, area)
+	// Test the prefixed elements of the chart part was kept
+	path, err := f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	cs, err := f.chartReader(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "bg1", cs.SpPr.SolidFill.SchemeClr.Val)
+	// Test get plot area of the chart created by the spreadsheet application
+	area, err = f.GetChartPlotArea("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, area.PlotVisOnly)
+	// Test get and set plot area without chart in the cell
+	_, err = f.GetChartPlotArea("Sheet1", "Z100")
+	assert.EqualError(t, err, newNoExistChartError("Z100").Error())
+	assert.EqualError(t, f.SetChartPlotArea("Sheet1", "Z100", area), newNoExistChartError("Z100").Error())
+	_, err = f.GetChartPlotArea("Sheet2", "A1")
+	assert.EqualError(t, err, newNoExistChartError("A1").Error())
+	// Test get and set plot area with invalid cell reference
+	_, err = f.GetChartPlotArea("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get and set plot area on not exists worksheet
+	_, err = f.GetChartPlotArea("SheetN", "P1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set plot area with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.SetChartPlotArea("Sheet1", "P1", nil))
+	// Test get and set plot area with unsupported charset chart part
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	_, err = f.GetChartPlotArea("Sheet1", "P1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetChartPlotArea("Sheet1", "P1", area), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartAxisNumFmt(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	series := []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{
+		Type: Col, Series: series,
+		XAxis: ChartAxis{NumFmt: ChartNumFmt{SourceLinked: true}},
+		YAxis: ChartAxis{NumFmt: ChartNumFmt{CustomNumFmt: "\"$\"#,##0.00"}},
+	}))
+	path, err := f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	cs, err := f.chartReader(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &cNumFmt{SourceLinked: true}, cs.Chart.PlotArea.CatAx[0].NumFmt)
+	assert.Equal(t, &cNumFmt{FormatCode: "\"$\"#,##0.00"}, cs.Chart.PlotArea.ValAx[0].NumFmt)
+	output, err := xml.Marshal(cs.Chart.PlotArea.ValAx[0].NumFmt)
+	assert.NoError(t, err)
+	assert.Equal(t, `<cNumFmt formatCode="&#34;$&#34;#,##0.00" sourceLinked="false"></cNumFmt>`, string(output))
+	// Test get the axis number format of the chart
+	chart, err := f.GetChart("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartNumFmt{SourceLinked: true}, chart.XAxis.NumFmt)
+	assert.Equal(t, ChartNumFmt{CustomNumFmt: "\"$\"#,##0.00"}, chart.YAxis.NumFmt)
+	assert.NoError(t, f.Close())
+}
+
+func TestChartPlotVisOnly(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	series := []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: series}))
+	visOnly, err := f.GetChartPlotVisOnly("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.False(t, visOnly)
+	assert.NoError(t, f.SetChartPlotVisOnly("Sheet1", "P1", true))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartPlotVisOnly.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestChartPlotVisOnly.xlsx"))
+	assert.NoError(t, err)
+	visOnly, err = f.GetChartPlotVisOnly("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.True(t, visOnly)
+	// Test the other plot area settings of the chart will be kept
+	area, err := f.GetChartPlotArea("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ChartPlotArea{PlotVisOnly: true}, area)
+	// Test get and set plot visible only without chart in the cell
+	_, err = f.GetChartPlotVisOnly("Sheet1", "Z100")
+	assert.EqualError(t, err, newNoExistChartError("Z100").Error())
+	assert.EqualError(t, f.SetChartPlotVisOnly("Sheet1", "Z100", true), newNoExistChartError("Z100").Error())
+	// Test get and set plot visible only on not exists worksheet
+	_, err = f.GetChartPlotVisOnly("SheetN", "P1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get and set plot visible only with unsupported charset chart part
+	path, err := f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	_, err = f.GetChartPlotVisOnly("Sheet1", "P1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetChartPlotVisOnly("Sheet1", "P1", false), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartLegend(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	series := []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Line, Series: series, Legend: ChartLegend{Position: "left", Overlay: true}}))
+	legend, err := f.GetChartLegend("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ChartLegend{Position: "left", Overlay: true}, legend)
+	// Test set legend with font
+	expected := &ChartLegend{
+		Position: "top_right", ShowLegendKey: true,
+		Font: &Font{Bold: true, Italic: true, Underline: "sng", Strike: true, Family: "Arial", Size: 12, Color: "4E81BD"},
+	}
+	assert.NoError(t, f.SetChartLegend("Sheet1", "P1", expected))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartLegend.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestChartLegend.xlsx"))
+	assert.NoError(t, err)
+	legend, err = f.GetChartLegend("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, legend)
+	// Test set legend without font keeps the existing text properties
+	assert.NoError(t, f.SetChartLegend("Sheet1", "P1", &ChartLegend{}))
+	legend, err = f.GetChartLegend("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ChartLegend{Position: "bottom", Font: expected.Font}, legend)
+	// Test remove the legend
+	assert.NoError(t, f.SetChartLegend("Sheet1", "P1", &ChartLegend{Position: "none"}))
+	legend, err = f.GetChartLegend("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ChartLegend{Position: "none"}, legend)
+	// Test create legend for the chart without legend
+	assert.NoError(t, f.SetChartLegend("Sheet1", "P1", &ChartLegend{Position: "right"}))
+	legend, err = f.GetChartLegend("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ChartLegend{Position: "right"}, legend)
+	// Test get and set legend with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.SetChartLegend("Sheet1", "P1", nil))
+	assert.Equal(t, ErrParameterInvalid, f.SetChartLegend("Sheet1", "P1", &ChartLegend{Position: "unknown"}))
+	_, err = f.GetChartLegend("Sheet1", "Z100")
+	assert.EqualError(t, err, newNoExistChartError("Z100").Error())
+	assert.EqualError(t, f.SetChartLegend("Sheet1", "Z100", &ChartLegend{}), newNoExistChartError("Z100").Error())
+	// Test get and set legend with unsupported charset chart part
+	path, err := f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	_, err = f.GetChartLegend("Sheet1", "P1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetChartLegend("Sheet1", "P1", &ChartLegend{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartTrendLine(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	intercept := 1.5
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30", TrendLine: ChartTrendLine{Type: "linear", Forward: 1, Backward: 0.5, Intercept: &intercept, DisplayEquation: true, DisplayRSquared: true}},
+		{Name: "Sheet1!$A$31", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$31:$D$31", TrendLine: ChartTrendLine{Type: "polynomial", Order: 3}},
+		{Name: "Sheet1!$A$32", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$32:$D$32", TrendLine: ChartTrendLine{Type: "movingAvg", Forward: 1}},
+		{Name: "Sheet1!$A$33", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$33:$D$33", TrendLine: ChartTrendLine{Type: "power", Intercept: &intercept}},
+		{Name: "Sheet1!$A$34", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$34:$D$34"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Line, Series: series}))
+	cs, err := f.chartReader("xl/charts/chart3.xml")
+	assert.NoError(t, err)
+	ser := *cs.Chart.PlotArea.LineChart.Ser
+	assert.Len(t, ser, 5)
+	assert.Equal(t, &cTrendline{
+		TrendlineType: &attrValString{Val: stringPtr("linear")},
+		Forward:       &attrValFloat{Val: float64Ptr(1)},
+		Backward:      &attrValFloat{Val: float64Ptr(0.5)},
+		Intercept:     &attrValFloat{Val: float64Ptr(1.5)},
+		DispRSqr:      &attrValBool{Val: boolPtr(true)},
+		DispEq:        &attrValBool{Val: boolPtr(true)},
+	}, ser[0].Trendline[0])
+	assert.Equal(t, &cTrendline{
+		TrendlineType: &attrValString{Val: stringPtr("poly")},
+		Order:         &attrValInt{Val: intPtr(3)},
+		DispRSqr:      &attrValBool{Val: boolPtr(false)},
+		DispEq:        &attrValBool{Val: boolPtr(false)},
+	}, ser[1].Trendline[0])
+	assert.Equal(t, &cTrendline{
+		TrendlineType: &attrValString{Val: stringPtr("movingAvg")},
+		Period:        &attrValInt{Val: intPtr(2)},
+	}, ser[2].Trendline[0])
+	assert.Nil(t, ser[3].Trendline[0].Intercept)
+	assert.Nil(t, ser[4].Trendline)
+	// Test add chart with trend line on the chart type which doesn't support
+	assert.NoError(t, f.AddChart("Sheet1", "X1", &Chart{Type: Pie, Series: series[:1]}))
+	cs, err = f.chartReader("xl/charts/chart4.xml")
+	assert.NoError(t, err)
+	assert.Nil(t, (*cs.Chart.PlotArea.PieChart.Ser)[0].Trendline)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartTrendLine.xlsx")))
+	// Test add chart with unsupported trend line type
+	assert.EqualError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Line, Series: []ChartSeries{{Values: "Sheet1!$B$30:$D$30", TrendLine: ChartTrendLine{Type: "unknown"}}}}),
+		newUnsupportedChartTrendLineType("unknown").Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestAddTrendLine(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"},
+		{Name: "Sheet1!$A$31", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$31:$D$31", TrendLine: ChartTrendLine{Type: "linear"}},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: ColStacked, Series: series}))
+	assert.NoError(t, f.AddTrendLine("Sheet1", "P1", 0, &ChartTrendLine{Type: "polynomial", Order: 3, DisplayEquation: true}))
+	assert.NoError(t, f.AddTrendLine("Sheet1", "P1", 1, &ChartTrendLine{Type: "movingAvg", Period: 3}))
+	path, err := f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	cs, err := f.chartReader(path)
+	assert.NoError(t, err)
+	ser := *cs.Chart.PlotArea.LineChart.Ser
+	assert.Equal(t, []*cTrendline{{
+		TrendlineType: &attrValString{Val: stringPtr("poly")},
+		Order:         &attrValInt{Val: intPtr(3)},
+		DispRSqr:      &attrValBool{Val: boolPtr(false)},
+		DispEq:        &attrValBool{Val: boolPtr(true)},
+	}}, ser[0].Trendline)
+	assert.Len(t, ser[1].Trendline, 2)
+	assert.Equal(t, &cTrendline{
+		TrendlineType: &attrValString{Val: stringPtr("movingAvg")},
+		Period:        &attrValInt{Val: intPtr(3)},
+	}, ser[1].Trendline[1])
+	// Test delete the trend line of the existing chart
+	assert.NoError(t, f.DeleteTrendLine("Sheet1", "P1", 1, 0))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTrendLine.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddTrendLine.xlsx"))
+	assert.NoError(t, err)
+	chart, err := f.GetChart("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartTrendLine{Type: "polynomial", Order: 3, DisplayEquation: true}, chart.Series[0].TrendLine)
+	assert.Equal(t, ChartTrendLine{Type: "movingAvg", Period: 3}, chart.Series[1].TrendLine)
+	// Test add trend line with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddTrendLine("Sheet1", "P1", 0, nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddTrendLine("Sheet1", "P1", -1, &ChartTrendLine{Type: "linear"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddTrendLine("Sheet1", "P1", 2, &ChartTrendLine{Type: "linear"}))
+	assert.EqualError(t, f.AddTrendLine("Sheet1", "P1", 0, &ChartTrendLine{Type: "unknown"}), newUnsupportedChartTrendLineType("unknown").Error())
+	// Test add trend line for the chart which doesn't support trend line
+	assert.Equal(t, ErrParameterInvalid, f.AddTrendLine("Sheet1", "P20", 0, &ChartTrendLine{Type: "linear"}))
+	assert.EqualError(t, f.AddTrendLine("Sheet1", "Z100", 0, &ChartTrendLine{Type: "linear"}), newNoExistChartError("Z100").Error())
+	// Test delete trend line with invalid index
+	assert.Equal(t, ErrParameterInvalid, f.DeleteTrendLine("Sheet1", "P1", -1, 0))
+	assert.Equal(t, ErrParameterInvalid, f.DeleteTrendLine("Sheet1", "P1", 0, -1))
+	assert.Equal(t, ErrParameterInvalid, f.DeleteTrendLine("Sheet1", "P1", 2, 0))
+	assert.Equal(t, ErrParameterInvalid, f.DeleteTrendLine("Sheet1", "P1", 0, 1))
+	assert.EqualError(t, f.DeleteTrendLine("Sheet1", "Z100", 0, 0), newNoExistChartError("Z100").Error())
+	// Test add and delete trend line with unsupported charset chart part
+	path, err = f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTrendLine("Sheet1", "P1", 0, &ChartTrendLine{Type: "linear"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteTrendLine("Sheet1", "P1", 0, 0), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartSmooth(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Line: ChartLine{Smooth: true}},
+	}
+	// Test add line chart with straight segments by default
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Line, Series: series}))
+	cs, err := f.chartReader("xl/charts/chart1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &attrValBool{Val: boolPtr(false)}, cs.Chart.PlotArea.LineChart.Smooth)
+	ser := *cs.Chart.PlotArea.LineChart.Ser
+	assert.Equal(t, &attrValBool{Val: boolPtr(false)}, ser[0].Smooth)
+	assert.Equal(t, &attrValBool{Val: boolPtr(true)}, ser[1].Smooth)
+	// Test add line chart with smooth segments for all series
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Line, Series: series, Smooth: true}))
+	cs, err = f.chartReader("xl/charts/chart2.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &attrValBool{Val: boolPtr(true)}, cs.Chart.PlotArea.LineChart.Smooth)
+	for _, ser := range *cs.Chart.PlotArea.LineChart.Ser {
+		assert.Equal(t, &attrValBool{Val: boolPtr(true)}, ser.Smooth)
+	}
+	// Test add scatter chart with smooth segments for all series
+	assert.NoError(t, f.AddChart("Sheet1", "M1", &Chart{Type: Scatter, Series: series[:1], Smooth: true}))
+	cs, err = f.chartReader("xl/charts/chart3.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &attrValBool{Val: boolPtr(true)}, (*cs.Chart.PlotArea.ScatterChart.Ser)[0].Smooth)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSmooth.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDoughnut(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Apple", 2}, {"Orange", 3}, {"Pear", 5}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Fruit", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}}
+	// Test add doughnut chart with default hole size and first slice angle
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Doughnut, Series: series}))
+	cs, err := f.chartReader("xl/charts/chart1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &attrValInt{Val: intPtr(75)}, cs.Chart.PlotArea.DoughnutChart.HoleSize)
+	assert.Equal(t, &attrValInt{Val: intPtr(0)}, cs.Chart.PlotArea.DoughnutChart.FirstSliceAng)
+	// Test add doughnut chart with custom hole size and first slice angle
+	assert.NoError(t, f.AddChart("Sheet1", "D16", &Chart{Type: Doughnut, Series: series, HoleSize: 10, FirstSliceAngle: 359}))
+	cs, err = f.chartReader("xl/charts/chart2.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &attrValInt{Val: intPtr(10)}, cs.Chart.PlotArea.DoughnutChart.HoleSize)
+	assert.Equal(t, &attrValInt{Val: intPtr(359)}, cs.Chart.PlotArea.DoughnutChart.FirstSliceAng)
+	chart, err := f.GetChart("Sheet1", "D16")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, chart.HoleSize)
+	assert.Equal(t, 359, chart.FirstSliceAngle)
+	// Test add doughnut chart with out of range hole size and first slice angle
+	assert.NoError(t, f.AddChart("Sheet1", "L1", &Chart{Type: Doughnut, Series: series, HoleSize: 9, FirstSliceAngle: 360}))
+	cs, err = f.chartReader("xl/charts/chart3.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &attrValInt{Val: intPtr(75)}, cs.Chart.PlotArea.DoughnutChart.HoleSize)
+	assert.Equal(t, &attrValInt{Val: intPtr(0)}, cs.Chart.PlotArea.DoughnutChart.FirstSliceAng)
+	// Test add pie chart with first slice angle
+	assert.NoError(t, f.AddChart("Sheet1", "L16", &Chart{Type: Pie, Series: series, FirstSliceAngle: 90}))
+	cs, err = f.chartReader("xl/charts/chart4.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &attrValInt{Val: intPtr(90)}, cs.Chart.PlotArea.PieChart.FirstSliceAng)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDoughnut.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartAnnotation(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Apple", 2}, {"Orange", 3}, {"Pear", 5}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Fruit", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChartAnnotation("Sheet1", "F3", "E1", "Target\nReached", &AnnotationOptions{
+		Width: 120, Font: Font{Bold: true, Size: 12, Color: "FF0000", Underline: "sng"},
+		Fill: Fill{Color: []string{"#FFFF00"}}, Line: ShapeLine{Color: "000000", Width: float64Ptr(1.5)},
+	}))
+	cs, err := f.chartReader("xl/charts/chart1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &cUserShapes{RID: "rId1"}, cs.UserShapes)
+	rel := f.getDrawingRelationships("xl/charts/_rels/chart1.xml.rels", "rId1")
+	assert.Equal(t, SourceRelationshipChartUserShapes, rel.Type)
+	assert.Equal(t, "../drawings/drawing2.xml", rel.Target)
+	type relMarker struct {
+		X float64 `xml:"x"`
+		Y float64 `xml:"y"`
+	}
+	var userShapes struct {
+		RelSizeAnchor []struct {
+			From relMarker `xml:"from"`
+			To   relMarker `xml:"to"`
+			Sp   struct {
+				NvSpPr struct {
+					CNvPr xlsxCNvPr `xml:"cNvPr"`
+				} `xml:"nvSpPr"`
+				TxBody struct {
+					P []struct {
+						T string `xml:"r>t"`
+					} `xml:"p"`
+				} `xml:"txBody"`
+			} `xml:"sp"`
+		} `xml:"relSizeAnchor"`
+	}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/drawings/drawing2.xml"), &userShapes))
+	assert.Len(t, userShapes.RelSizeAnchor, 1)
+	assert.Equal(t, relMarker{X: 64.0 / 480, Y: 36.0 / 260}, userShapes.RelSizeAnchor[0].From)
+	assert.Equal(t, relMarker{X: 184.0 / 480, Y: 76.0 / 260}, userShapes.RelSizeAnchor[0].To)
+	assert.Equal(t, 2, userShapes.RelSizeAnchor[0].Sp.NvSpPr.CNvPr.ID)
+	assert.Len(t, userShapes.RelSizeAnchor[0].Sp.TxBody.P, 2)
+	assert.Equal(t, "Reached", userShapes.RelSizeAnchor[0].Sp.TxBody.P[1].T)
+	// Test add another annotation on the same chart with default format
+	assert.NoError(t, f.AddChartAnnotation("Sheet1", "L10", "E1", "Note", nil))
+	userShapes.RelSizeAnchor = nil
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/drawings/drawing2.xml"), &userShapes))
+	assert.Len(t, userShapes.RelSizeAnchor, 2)
+	assert.Equal(t, 3, userShapes.RelSizeAnchor[1].Sp.NvSpPr.CNvPr.ID)
+	assert.Equal(t, relMarker{X: 1, Y: 202.0 / 260}, userShapes.RelSizeAnchor[1].To)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/drawings/drawing2.xml", ContentType: ContentTypeDrawingMLChartShapes})
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartAnnotation.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test add annotation on the chart in the opened workbook
+	f, err = OpenFile(filepath.Join("test", "TestAddChartAnnotation.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartAnnotation("Sheet1", "G5", "E1", "Reopened", nil))
+	cs, err = f.chartReader("xl/charts/chart1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, &cUserShapes{RID: "rId1"}, cs.UserShapes)
+	userShapes.RelSizeAnchor = nil
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/drawings/drawing2.xml"), &userShapes))
+	assert.Len(t, userShapes.RelSizeAnchor, 3)
+	// Test add annotation with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.AddChartAnnotation("Sheet1", "F3", "E1", "", nil))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddChartAnnotation("Sheet1", "A", "E1", "Note", nil))
+	for _, opts := range []*AnnotationOptions{
+		{Fill: Fill{Color: []string{"FFFF"}}}, {Line: ShapeLine{Color: "X00000"}}, {Font: Font{Color: "#FF00000"}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddChartAnnotation("Sheet1", "F3", "E1", "Note", opts))
+	}
+	// Test add annotation with the anchor cell outside of the chart area
+	for _, cell := range []string{"A1", "E20", "N1"} {
+		assert.Equal(t, ErrChartAnnotationAnchor, f.AddChartAnnotation("Sheet1", cell, "E1", "Note", nil))
+	}
+	assert.Equal(t, ErrChartAnnotationAnchor, f.AddChartAnnotation("Sheet1", "E1", "E1", "Note", &AnnotationOptions{OffsetX: -1}))
+	// Test add annotation on not exists chart
+	assert.Equal(t, newNoExistChartError("A1"), f.AddChartAnnotation("Sheet1", "F3", "A1", "Note", nil))
+	// Test add annotation with unsupported charset chart drawing part
+	f.Pkg.Store("xl/drawings/drawing2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddChartAnnotation("Sheet1", "F3", "E1", "Note", nil), "XML syntax error on line 1: invalid UTF-8")
+	// Test add annotation with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddChartAnnotation("Sheet1", "F3", "E1", "Note", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartColorScheme(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"},
+		{Name: "Sheet1!$A$31", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$31:$D$31"},
+		{Name: "Sheet1!$A$32", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$32:$D$32"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Line, Series: series[:2]}))
+	assert.NoError(t, f.AddChart("Sheet1", "P40", &Chart{Type: Scatter, Series: series[:1]}))
+	colors, err := f.GetChartColorScheme("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "", ""}, colors)
+	// Test set colors with fewer colors than series
+	assert.NoError(t, f.SetChartColorScheme("Sheet1", "P1", []string{"#4472c4", "ED7D31"}))
+	assert.NoError(t, f.SetChartColorScheme("Sheet1", "P20", []string{"70AD47", "FFC000", "5B9BD5"}))
+	assert.NoError(t, f.SetChartColorScheme("Sheet1", "P40", []string{"A5A5A5"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartColorScheme.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestChartColorScheme.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string][]string{
+		"P1":  {"4472C4", "ED7D31", "4472C4"},
+		"P20": {"70AD47", "FFC000"},
+		"P40": {"A5A5A5"},
+	} {
+		colors, err = f.GetChartColorScheme("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, colors, cell)
+	}
+	path, err := f.getChartPath("Sheet1", "P40")
+	assert.NoError(t, err)
+	cs, err := f.chartReader(path)
+	assert.NoError(t, err)
+	ser := (*cs.Chart.PlotArea.ScatterChart.Ser)[0]
+	assert.Nil(t, ser.SpPr.Ln.SolidFill)
+	assert.Equal(t, "A5A5A5", *ser.Marker.SpPr.SolidFill.SrgbClr.Val)
+	// Test get and set colors with invalid options
+	for _, colors := range [][]string{nil, {"FFF"}, {"GGGGGG"}} {
+		assert.Equal(t, ErrParameterInvalid, f.SetChartColorScheme("Sheet1", "P1", colors))
+	}
+	_, err = f.GetChartColorScheme("Sheet1", "Z100")
+	assert.EqualError(t, err, newNoExistChartError("Z100").Error())
+	assert.EqualError(t, f.SetChartColorScheme("Sheet1", "Z100", []string{"FFFFFF"}), newNoExistChartError("Z100").Error())
+	// Test get and set colors with unsupported charset chart part
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	_, err = f.GetChartColorScheme("Sheet1", "P40")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetChartColorScheme("Sheet1", "P40", []string{"FFFFFF"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartMarker(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	series := []ChartSeries{
+		{
+			Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30",
+			Marker: ChartMarker{Symbol: "square", Size: 7, FillColor: "#ff0000", BorderColor: "000000"},
+		},
+		{Name: "Sheet1!$A$31", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$31:$D$31"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Col, Series: series}))
+	path, err := f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	cs, err := f.chartReader(path)
+	assert.NoError(t, err)
+	marker := (*cs.Chart.PlotArea.LineChart.Ser)[0].Marker
+	assert.Equal(t, "square", *marker.Symbol.Val)
+	assert.Equal(t, 7, *marker.Size.Val)
+	assert.Equal(t, &aSolidFill{SrgbClr: &attrValString{Val: stringPtr("FF0000")}}, marker.SpPr.SolidFill)
+	assert.Equal(t, &aSolidFill{SrgbClr: &attrValString{Val: stringPtr("000000")}}, marker.SpPr.Ln.SolidFill)
+	// Test set marker of the existing chart
+	assert.NoError(t, f.SetChartMarker("Sheet1", "P1", 1, &ChartMarker{Symbol: "triangle", FillColor: "4472C4"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartMarker.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestChartMarker.xlsx"))
+	assert.NoError(t, err)
+	chart, err := f.GetChart("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartMarker{Symbol: "square", Size: 7, FillColor: "FF0000", BorderColor: "000000"}, chart.Series[0].Marker)
+	assert.Equal(t, ChartMarker{Symbol: "triangle", Size: 5, FillColor: "4472C4"}, chart.Series[1].Marker)
+	// Test set marker with invalid options
+	for _, opts := range []*ChartMarker{
+		nil, {Size: 1}, {Size: 73}, {Symbol: "unknown"}, {FillColor: "FFF"}, {BorderColor: "GGGGGG"},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.SetChartMarker("Sheet1", "P1", 0, opts))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.SetChartMarker("Sheet1", "P1", -1, &ChartMarker{}))
+	// Test add chart with invalid marker colors
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "P40", &Chart{
+		Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30", Marker: ChartMarker{FillColor: "FFF"}}},
+	}))
+	assert.Equal(t, ErrParameterInvalid, f.SetChartMarker("Sheet1", "P1", 2, &ChartMarker{}))
+	// Test set marker for the chart which doesn't support marker
+	assert.Equal(t, ErrParameterInvalid, f.SetChartMarker("Sheet1", "P20", 0, &ChartMarker{}))
+	assert.EqualError(t, f.SetChartMarker("Sheet1", "Z100", 0, &ChartMarker{}), newNoExistChartError("Z100").Error())
+	// Test set marker for the series without marker
+	path, err = f.getChartPath("Sheet1", "P1")
+	assert.NoError(t, err)
+	cs, err = f.chartReader(path)
+	assert.NoError(t, err)
+	(*cs.Chart.PlotArea.LineChart.Ser)[0].Marker = nil
+	f.chartWriter(path, cs)
+	assert.NoError(t, f.SetChartMarker("Sheet1", "P1", 0, &ChartMarker{Symbol: "x", BorderColor: "000000"}))
+	chart, err = f.GetChart("Sheet1", "P1")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartMarker{Symbol: "x", BorderColor: "000000"}, chart.Series[0].Marker)
+	// Test set marker with unsupported charset chart part
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetChartMarker("Sheet1", "P1", 0, &ChartMarker{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartWriter(t *testing.T) {
+	f, err := prepareTestBook1()
+	assert.NoError(t, err)
+	// Test write the chart created by the spreadsheet application without
+	// changes, the alternate content and namespaces should be kept
+	path, err := f.getChartPath("Sheet1", "A1")
+	assert.NoError(t, err)
+	cs, err := f.chartReader(path)
+	assert.NoError(t, err)
+	f.chartWriter(path, cs)
+	content := string(f.readXML(path))
+	assert.Contains(t, content, `xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"`)
+	assert.Contains(t, content, `<roundedCorners val="0"></roundedCorners><mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"><mc:Choice Requires="c14" xmlns:c14="http://schemas.microsoft.com/office/drawing/2007/8/2/chart"><c14:style val="102"/></mc:Choice><mc:Fallback><c:style val="2"/></mc:Fallback></mc:AlternateContent><chart>`)
+	// Test write the chart with modified settings
+	cs.Chart.PlotVisOnly.Val = boolPtr(false)
+	cs.Chart.PlotArea.DoughnutChart.Ser = nil
+	f.chartWriter(path, cs)
+	cs, err = f.chartReader(path)
+	assert.NoError(t, err)
+	assert.False(t, *cs.Chart.PlotVisOnly.Val)
+	assert.Empty(t, cs.Chart.PlotArea.DoughnutChart.Ser)
+	assert.NotNil(t, cs.AlternateContent)
+	// Test write the chart with the elements which not supported by the chart
+	// structures, the elements should be kept in the schema order
+	f.Pkg.Store(path, []byte(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:c16r2="http://schemas.microsoft.com/office/drawing/2015/06/chart"><c:date1904 val="0"/><c:style val="2"/><c:clrMapOvr bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" accent3="accent3" accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"/><c:pivotSource><c:name>[Book1.xlsx]Sheet1!PivotTable1</c:name><c:fmtId val="0"/></c:pivotSource><c:protection><c:chartObject val="1"/></c:protection><c:chart><c:autoTitleDeleted val="1"/><c:pivotFmts><c:pivotFmt><c:idx val="0"/></c:pivotFmt></c:pivotFmts><c:plotArea><c:layout/><c:dTable><c:showHorzBorder val="1"/></c:dTable><c:spPr><a:noFill/></c:spPr><c:extLst><c:ext uri="{A}"><c16r2:ext/></c:ext></c:extLst></c:plotArea><c:plotVisOnly val="1"/><c:extLst><c:ext uri="{B}"/></c:extLst></c:chart><c:externalData r:id="rId1"><c:autoUpdate val="0"/></c:externalData><c:extLst><c:ext uri="{C}"/></c:extLst></c:chartSpace>`))
+	cs, err = f.chartReader(path)
+	assert.NoError(t, err)
+	cs.Chart.PlotVisOnly.Val = boolPtr(false)
+	f.chartWriter(path, cs)
+	assert.Equal(t, xml.Header+`<chartSpace xmlns="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:c16r2="http://schemas.microsoft.com/office/drawing/2015/06/chart"><date1904 val="0"></date1904><style val="2"></style><clrMapOvr bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" accent3="accent3" accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"></clrMapOvr><pivotSource><c:name>[Book1.xlsx]Sheet1!PivotTable1</c:name><c:fmtId val="0"/></pivotSource><protection><c:chartObject val="1"/></protection><chart><autoTitleDeleted val="true"></autoTitleDeleted><pivotFmts><c:pivotFmt><c:idx val="0"/></c:pivotFmt></pivotFmts><plotArea><layout></layout><dTable><c:showHorzBorder val="1"/></dTable><spPr><a:noFill></a:noFill></spPr><extLst><c:ext uri="{A}"><c16r2:ext/></c:ext></extLst></plotArea><plotVisOnly val="0"></plotVisOnly><extLst><c:ext uri="{B}"/></extLst></chart><externalData r:id="rId1"><autoUpdate val="0"></autoUpdate></externalData><extLst><c:ext uri="{C}"/></extLst></chartSpace>`, string(f.readXML(path)))
+	// Test read the chart with unsupported charset
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	_, err = f.chartReader(path)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	// Test get chart type of each chart
+	for chartType := Area; chartType <= Bubble3D; chartType++ {
+		cell, err := CoordinatesToCellName(1, int(chartType)*20+10)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddChart("Sheet1", cell, &Chart{Type: chartType, Series: series}))
+		chart, err := f.GetChart("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, chartType, chart.Type, cell)
+		assert.Equal(t, series[:1], []ChartSeries{{Name: chart.Series[0].Name, Categories: chart.Series[0].Categories, Values: chart.Series[0].Values}}, cell)
+	}
+	// Test get chart with format settings
+	intercept, max, min := 1.5, 10.0, 1.0
+	expected := &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{
+				Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2",
+				Fill:      Fill{Color: []string{"4472C4"}},
+				Line:      ChartLine{Smooth: true, Width: 1.5},
+				Marker:    ChartMarker{Symbol: "diamond", Size: 8},
+				TrendLine: ChartTrendLine{Type: "linear", Intercept: &intercept, DisplayEquation: true},
+			},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Line: ChartLine{Width: 2}, Marker: ChartMarker{Size: 5}},
+		},
+		Format:     GraphicOptions{ScaleX: 1, ScaleY: 1, OffsetX: 15, OffsetY: 10, Locked: boolPtr(false), PrintObject: boolPtr(true), Positioning: "oneCell"},
+		Dimension:  ChartDimension{Width: 640, Height: 320},
+		Legend:     ChartLegend{Position: "top", Overlay: true, ShowLegendKey: true},
+		Title:      ChartTitle{Name: "Fruit Line Chart"},
+		VaryColors: boolPtr(false),
+		XAxis:      ChartAxis{ReverseOrder: true, MajorGridLines: true, TickLabelSkip: 2, Font: Font{Bold: true, Color: "000000"}},
+		YAxis:      ChartAxis{MinorGridLines: true, MajorUnit: 2, Maximum: &max, Minimum: &min, LogBase: 10, NumFmt: ChartNumFmt{CustomNumFmt: "0.00"}},
+		PlotArea: ChartPlotArea{
+			ShowVal: true, ShowCatName: true, PlotVisOnly: true,
+			Layout: &ChartLayout{X: 0.1, Y: 0.1, Width: 0.8, Height: 0.7},
+		},
+		ShowBlanksAs: "zero",
+		Smooth:       true,
+	}
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChart("Sheet2", "B2", expected))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetChart.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetChart.xlsx"))
+	assert.NoError(t, err)
+	chart, err := f.GetChart("Sheet2", "B2")
+	assert.NoError(t, err)
+	// The series of the chart with smooth segments are all smoothed
+	expected.Series[1].Line.Smooth = true
+	assert.Equal(t, expected, chart)
+	// Test create chart by the format settings of the existing chart
+	assert.NoError(t, f.AddChart("Sheet2", "B30", chart))
+	source, err := f.chartReader(fmt.Sprintf("xl/charts/chart%d.xml", Bubble3D+2))
+	assert.NoError(t, err)
+	target, err := f.chartReader(fmt.Sprintf("xl/charts/chart%d.xml", Bubble3D+3))
+	assert.NoError(t, err)
+	assert.Equal(t, source.Chart, target.Chart)
+	// Test get chart on the cell without chart
+	_, err = f.GetChart("Sheet2", "Z100")
+	assert.EqualError(t, err, newNoExistChartError("Z100").Error())
+	// Test get chart with invalid cell reference
+	_, err = f.GetChart("Sheet2", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get chart with unsupported charset chart part
+	path, err := f.getChartPath("Sheet2", "B2")
+	assert.NoError(t, err)
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	_, err = f.GetChart("Sheet2", "B2")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test get chart without chart groups in the plot area
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Col, Series: series}))
+	cs, err := f.chartReader("xl/charts/chart1.xml")
+	assert.NoError(t, err)
+	cs.Chart.PlotArea = &cPlotArea{}
+	f.chartWriter("xl/charts/chart1.xml", cs)
+	chart, err = f.GetChart("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, chart.Series)
+	cs.Chart.PlotArea = nil
+	f.chartWriter("xl/charts/chart1.xml", cs)
+	chart, err = f.GetChart("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, chart.Series)
+	assert.NoError(t, f.Close())
+}
+
+func TestAddWaterfallChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Start", 100}, {"Sales", 40}, {"Returns", -15}, {"Costs", -30}, {"End", 95},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$1:$A$5", Values: "Sheet1!$B$1:$B$5"}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{
+		Type:   Waterfall,
+		Series: series,
+		Title:  ChartTitle{Name: "Waterfall Chart"},
+		Legend: ChartLegend{Position: "top_right"},
+		Waterfall: WaterfallOptions{
+			ConnectorLines: boolPtr(false),
+			IncreaseColor:  "#70ad47",
+			DecreaseColor:  "FF0000",
+			TotalColor:     "4472C4",
+			IsTotal:        []bool{true, false, false, false, true},
+		},
+		PlotArea: ChartPlotArea{ShowVal: true},
+		YAxis:    ChartAxis{MajorGridLines: true, Maximum: float64Ptr(200), Minimum: float64Ptr(-50)},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "D20", &Chart{Type: Waterfall, Series: series, Legend: ChartLegend{Position: "none"}}))
+	assert.NoError(t, f.AddChart("Sheet1", "D40", &Chart{Type: Col, Series: series}))
+	assert.Equal(t, 1, f.countCharts())
+	assert.Equal(t, 2, f.countChartExs())
+
+	chartSpace, err := chartExReader(f, "xl/charts/chartEx1.xml")
+	assert.NoError(t, err)
+	ser := chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0]
+	assert.Equal(t, "waterfall", ser.LayoutID)
+	assert.False(t, ser.LayoutPr.Visibility.ConnectorLines)
+	assert.Len(t, ser.LayoutPr.Subtotals.Idx, 2)
+	assert.Equal(t, 4, *ser.LayoutPr.Subtotals.Idx[1].Val)
+	assert.Len(t, ser.DataPt, 5)
+	for idx, color := range []string{"4472C4", "70AD47", "FF0000", "FF0000", "4472C4"} {
+		assert.Equal(t, color, *ser.DataPt[idx].SpPr.SolidFill.SrgbClr.Val)
+	}
+	assert.Equal(t, "r", chartSpace.Chart.Legend.Pos)
+	assert.Equal(t, "Waterfall Chart", chartSpace.Chart.Title.Tx.TxData.V)
+	assert.Equal(t, "200", chartSpace.Chart.PlotArea.Axis[1].ValScaling.Max)
+
+	chartSpace, err = chartExReader(f, "xl/charts/chartEx2.xml")
+	assert.NoError(t, err)
+	ser = chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0]
+	assert.True(t, ser.LayoutPr.Visibility.ConnectorLines)
+	assert.Nil(t, ser.LayoutPr.Subtotals)
+	assert.Empty(t, ser.DataPt)
+	assert.Nil(t, chartSpace.Chart.Legend)
+	assert.Nil(t, chartSpace.Chart.Title)
+
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/charts/chartEx1.xml", ContentType: ContentTypeDrawingMLChartEx})
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, SourceRelationshipChartEx, rels.Relationships[0].Type)
+	assert.Equal(t, "../charts/chartEx1.xml", rels.Relationships[0].Target)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddWaterfallChart.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddWaterfallChart.xlsx"))
+	assert.NoError(t, err)
+	// Test add waterfall chart to the worksheet which contains chartEx anchors
+	assert.NoError(t, f.AddChart("Sheet1", "L1", &Chart{Type: Waterfall, Series: series}))
+	assert.Equal(t, 3, f.countChartExs())
+	drawing, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, drawing.TwoCellAnchor, 4)
+	assert.Contains(t, drawing.TwoCellAnchor[0].GraphicFrame, "mc:AlternateContent")
+	// Test add waterfall chart with invalid series
+	assert.Equal(t, ErrChartExSeries, f.AddChart("Sheet1", "L20", &Chart{Type: Waterfall}))
+	assert.Equal(t, ErrChartExSeries, f.AddChart("Sheet1", "L20", &Chart{Type: Waterfall, Series: series}, &Chart{Type: Col, Series: series}))
+	assert.EqualError(t, f.AddChartSheet("Chart1", &Chart{Type: Waterfall, Series: series}), newUnsupportedChartType(Waterfall).Error())
+	// Test add waterfall chart with invalid series values reference
+	for _, values := range []string{"$B$1:$B$5", "Sheet1!B0:B5"} {
+		assert.Error(t, f.AddChart("Sheet1", "L20", &Chart{Type: Waterfall, Series: []ChartSeries{{Values: values}}, Waterfall: WaterfallOptions{TotalColor: "4472C4"}}))
+	}
+	assert.EqualError(t, f.AddChart("Sheet1", "L20", &Chart{Type: Waterfall, Series: []ChartSeries{{Values: "'SheetN'!$B$1"}}, Waterfall: WaterfallOptions{TotalColor: "4472C4"}}), "sheet SheetN does not exist")
+	// Test add waterfall chart with invalid cell reference
+	assert.EqualError(t, f.AddChart("Sheet1", "A", &Chart{Type: Waterfall, Series: series}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestAddTreemapAndSunburstChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Fruit", "Apple", "Fuji", 10}, {"Fruit", "Apple", "Gala", 8}, {"Fruit", "Pear", nil, 6},
+		{"Vegetable", "Carrot", nil, 12}, {"Vegetable", "Potato", nil, 5},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+2), &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$D$1", Categories: "Sheet1!$A$2:$C$6", Values: "Sheet1!$D$2:$D$6"}}
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type:     Treemap,
+		Series:   []ChartSeries{{Categories: series[0].Categories, Values: series[0].Values, Fill: Fill{Color: []string{"#4472C4"}}}},
+		Title:    ChartTitle{Name: "Treemap Chart"},
+		PlotArea: ChartPlotArea{ShowCatName: true},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "F20", &Chart{
+		Type:     Sunburst,
+		Series:   series,
+		PlotArea: ChartPlotArea{ShowCatName: true, ShowVal: true},
+		XAxis:    ChartAxis{MajorGridLines: true},
+	}))
+	for path, expected := range map[string]struct {
+		layoutID, labelPos, parentLabelLayout string
+	}{
+		"xl/charts/chartEx1.xml": {"treemap", "inEnd", "overlapping"},
+		"xl/charts/chartEx2.xml": {"sunburst", "ctr", ""},
+	} {
+		chartSpace, err := chartExReader(f, path)
+		assert.NoError(t, err)
+		assert.Equal(t, "Sheet1!$A$2:$C$6", chartSpace.ChartData.Data[0].StrDim.F)
+		assert.Equal(t, "size", chartSpace.ChartData.Data[0].NumDim.Type)
+		assert.Empty(t, chartSpace.Chart.PlotArea.Axis)
+		ser := chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0]
+		assert.Equal(t, expected.layoutID, ser.LayoutID)
+		assert.Equal(t, expected.labelPos, ser.DataLabels.Pos)
+		assert.True(t, ser.DataLabels.Visibility.CategoryName)
+		if expected.parentLabelLayout == "" {
+			assert.Nil(t, ser.LayoutPr)
+			assert.Nil(t, ser.SpPr)
+			assert.True(t, ser.DataLabels.Visibility.Value)
+			assert.Equal(t, "Sheet1!$D$1", ser.Tx.TxData.F)
+			continue
+		}
+		assert.Equal(t, expected.parentLabelLayout, *ser.LayoutPr.ParentLabelLayout.Val)
+		assert.Equal(t, "4472C4", *ser.SpPr.SolidFill.SrgbClr.Val)
+		assert.Nil(t, ser.Tx)
+	}
+	assert.Equal(t, ErrChartExSeries, f.AddChart("Sheet1", "F40", &Chart{Type: Sunburst, Series: append(series, series...)}))
+	assert.EqualError(t, f.AddChartSheet("Chart1", &Chart{Type: Treemap, Series: series}), newUnsupportedChartType(Treemap).Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTreemapAndSunburstChart.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+// chartExReader provides a function to get the pointer to the structure
+// after deserialization of the chartEx part by given path.
+func chartExReader(f *File, path string) (*xlsxChartExSpace, error) {
+	chartSpace := new(xlsxChartExSpace)
+	err := xml.NewTokenDecoder(&prefixedTokenReader{
+		decoder: xml.NewDecoder(bytes.NewReader(f.readXML(path))),
+		prefixes: map[string]string{
+			NameSpaceDrawingML.Value:        "a",
+			SourceRelationship.Value:        "r",
+			NameSpaceDrawingMLChartEx.Value: "cx",
+			"xmlns":                         "xmlns",
+		},
+	}).Decode(chartSpace)
+	return chartSpace, err
+}
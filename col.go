@@ -17,6 +17,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/mohae/deepcopy"
 )
@@ -430,6 +431,134 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 	return err
 }
 
+// GroupColumns provides a function to group the columns by given worksheet
+// name, the first and last column name of the group, and if the group is
+// collapsed. The outline level of each column in the group will be increased
+// by 1, so the groups could be nested up to 7 levels. The columns of the
+// collapsed group will be hidden, and the summary column, which is on the
+// right or left of the group depends on the outline properties of the
+// worksheet, will be marked as collapsed. For example, group the columns B to
+// D in Sheet1 and collapse the group:
+//
+//	err := f.GroupColumns("Sheet1", "B", "D", true)
+//
+// 根据给定的工作表名称、起止列名称和折叠状态创建列分组。
+func (f *File) GroupColumns(sheet, firstCol, lastCol string, collapsed bool) error {
+	min, max, err := f.parseColRange(firstCol + ":" + lastCol)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.flatColsRange(min, max)
+	for _, col := range ws.Cols.Col {
+		if col.Min >= min && col.Max <= max && col.OutlineLevel >= 7 {
+			return ErrOutlineLevel
+		}
+	}
+	for idx := range ws.Cols.Col {
+		if col := &ws.Cols.Col[idx]; col.Min >= min && col.Max <= max {
+			col.OutlineLevel++
+			col.Hidden = col.Hidden || collapsed
+		}
+	}
+	if summaryCol := ws.getOutlineSummaryCol(min, max); collapsed && summaryCol >= MinColumns && summaryCol <= MaxColumns {
+		ws.flatColsRange(summaryCol, summaryCol)
+		for idx := range ws.Cols.Col {
+			if ws.Cols.Col[idx].Min == summaryCol {
+				ws.Cols.Col[idx].Collapsed = true
+			}
+		}
+	}
+	ws.updateOutlineLevel()
+	return err
+}
+
+// UngroupColumns provides a function to ungroup the columns by given
+// worksheet name, the first and last column name of the group. The outline
+// level of each column in the range will be decreased by 1, and the columns
+// will be shown if the group was collapsed. For example, ungroup the columns
+// B to D in Sheet1:
+//
+//	err := f.UngroupColumns("Sheet1", "B", "D")
+//
+// 根据给定的工作表名称和起止列名称取消列分组。
+func (f *File) UngroupColumns(sheet, firstCol, lastCol string) error {
+	min, max, err := f.parseColRange(firstCol + ":" + lastCol)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols == nil {
+		return err
+	}
+	var expand bool
+	summaryCol := ws.getOutlineSummaryCol(min, max)
+	for _, col := range ws.Cols.Col {
+		if col.Min <= summaryCol && summaryCol <= col.Max && col.Collapsed {
+			expand = true
+		}
+	}
+	ws.flatColsRange(min, max)
+	if expand {
+		// split the wider column definition, so that only the summary column
+		// will be expanded
+		ws.flatColsRange(summaryCol, summaryCol)
+		for idx := range ws.Cols.Col {
+			if col := &ws.Cols.Col[idx]; col.Min == summaryCol && col.Max == summaryCol {
+				col.Collapsed = false
+			}
+		}
+	}
+	for idx := range ws.Cols.Col {
+		if col := &ws.Cols.Col[idx]; col.Min >= min && col.Max <= max {
+			if col.OutlineLevel > 0 {
+				col.OutlineLevel--
+			}
+			col.Hidden = col.Hidden && !expand
+		}
+	}
+	ws.updateOutlineLevel()
+	return err
+}
+
+// flatColsRange provides a function to split the column definitions of the
+// worksheet into single columns for the given column range.
+func (ws *xlsxWorksheet) flatColsRange(min, max int) {
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	ws.Cols.Col = flatCols(xlsxCol{Min: min, Max: max}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		c.Min, c.Max = fc.Min, fc.Max
+		return c
+	})
+}
+
+// getOutlineSummaryCol returns the summary column number of the columns group
+// by given the first and last column number of the group.
+func (ws *xlsxWorksheet) getOutlineSummaryCol(min, max int) int {
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryRight != nil && !*ws.SheetPr.OutlinePr.SummaryRight {
+		return min - 1
+	}
+	return max + 1
+}
+
 // SetColStyle provides a function to set style of columns by given worksheet
 // name, columns range and style ID. This function is concurrency safe. Note
 // that this will overwrite the existing styles for the columns, it won't
@@ -542,6 +671,300 @@ func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error
 	return err
 }
 
+// AutoFitColumnWidth provides a function to set the width of the columns by
+// given worksheet name and column range, to fit the widest displayed cell
+// value in each column. The width is calculated by the formatted cell value,
+// the font name, font size, bold and italic attributes of the cell style. The
+// style of the row with custom format, or the style of the column, will be
+// used if the cell doesn't have the style. The width of the wrapped text cell
+// is based on the longest word, and the cells merged across columns will be
+// ignored. The columns without any value keep the original width. Note that
+// the calculation is based on an internal character width table instead of
+// the actual font metrics, so the width may be slightly different with the
+// width in the spreadsheet application. For example, auto fit the width of
+// the columns A to D in Sheet1:
+//
+//	err := f.AutoFitColumnWidth("Sheet1", "A", "D")
+//
+// 根据给定的工作表名称和列范围，按照列中单元格显示内容的宽度自动调整列宽。
+func (f *File) AutoFitColumnWidth(sheet, startCol, endCol string) error {
+	min, max, err := f.parseColRange(startCol + ":" + endCol)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	styles, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	measurer, widths := newTextMeasurer(styles), make([]float64, max-min+1)
+	ws.mu.Lock()
+	merged := ws.getMergeCellsCoordinates(func(coordinates []int) bool {
+		return coordinates[0] != coordinates[2]
+	})
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		for _, c := range row.C {
+			col, rowNum, err := CellNameToCoordinates(c.R)
+			if err != nil || col < min || col > max || cellInRanges(col, rowNum, merged) {
+				continue
+			}
+			if c.S == 0 {
+				c.S = ws.getRowColStyle(row, col)
+			}
+			val, err := c.getValueFrom(f, sst, false)
+			if err != nil {
+				ws.mu.Unlock()
+				return err
+			}
+			if width := measurer.measure(c.S, val); width > widths[col-min] {
+				widths[col-min] = width
+			}
+		}
+	}
+	ws.mu.Unlock()
+	for idx, width := range widths {
+		if width == 0 {
+			continue
+		}
+		colName, _ := ColumnNumberToName(min + idx)
+		if err = f.SetColWidth(sheet, colName, colName, width); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// getColStyle returns the style ID of the column by given column number.
+func (ws *xlsxWorksheet) getColStyle(col int) int {
+	var styleID int
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= col && col <= c.Max {
+				styleID = c.Style
+			}
+		}
+	}
+	return styleID
+}
+
+// getRowColStyle returns the style ID for the cell without style by given row
+// and column number, the style of the row with custom format takes precedence
+// over the style of the column.
+func (ws *xlsxWorksheet) getRowColStyle(row *xlsxRow, col int) int {
+	if row.CustomFormat && row.S != 0 {
+		return row.S
+	}
+	return ws.getColStyle(col)
+}
+
+// getMergeCellsCoordinates returns the sorted coordinates of the merged cells
+// in the worksheet which match the given filter function.
+func (ws *xlsxWorksheet) getMergeCellsCoordinates(filter func(coordinates []int) bool) [][]int {
+	var merged [][]int
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if coordinates, err := rangeRefToCoordinates(mergeCell.Ref); err == nil {
+				if _ = sortCoordinates(coordinates); filter(coordinates) {
+					merged = append(merged, coordinates)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// cellInRanges returns if the cell in any of the given ranges, each range is
+// given by the sorted coordinates of the range.
+func cellInRanges(col, row int, ranges [][]int) bool {
+	for _, coordinates := range ranges {
+		if coordinates[0] <= col && col <= coordinates[2] && coordinates[1] <= row && row <= coordinates[3] {
+			return true
+		}
+	}
+	return false
+}
+
+// cellPaddingWidth defined the width of the cell padding in the unit of the
+// column width, which is 5 pixels in the maximum digit width of 7 pixels.
+const cellPaddingWidth = 5.0 / 7
+
+// textFont defined the font settings used to measure the width of the text.
+type textFont struct {
+	name               string
+	size               float64
+	bold, italic, wrap bool
+}
+
+// textMeasurer measures the displayed width of the cell value in the unit of
+// the column width. Widths of the characters are based on the Calibri font,
+// and scaled by the font name, font size, bold and italic attributes.
+type textMeasurer struct {
+	styles *xlsxStyleSheet
+	base   textFont
+	fonts  map[int]textFont
+}
+
+// fontWidthFactors defined the average character width of the fonts relative
+// to the Calibri font.
+var fontWidthFactors = map[string]float64{
+	"Arial":           1.1,
+	"Calibri":         1,
+	"Cambria":         1.05,
+	"Consolas":        1.15,
+	"Courier New":     1.25,
+	"Georgia":         1.15,
+	"Segoe UI":        1.1,
+	"Tahoma":          1.05,
+	"Times New Roman": 0.95,
+	"Verdana":         1.25,
+}
+
+// newTextMeasurer returns a text measurer by given style sheet, the first
+// font in the style sheet will be used as the base font of the column width.
+func newTextMeasurer(styles *xlsxStyleSheet) *textMeasurer {
+	m := &textMeasurer{styles: styles, fonts: map[int]textFont{}}
+	m.base = m.getFont(-1)
+	return m
+}
+
+// getFont returns the font settings by given cell style ID.
+func (m *textMeasurer) getFont(styleID int) textFont {
+	if font, ok := m.fonts[styleID]; ok {
+		return font
+	}
+	font, fontID := textFont{name: "Calibri", size: 11}, 0
+	if m.styles.CellXfs != nil && styleID >= 0 && styleID < len(m.styles.CellXfs.Xf) {
+		xf := m.styles.CellXfs.Xf[styleID]
+		if xf.FontID != nil {
+			fontID = *xf.FontID
+		}
+		font.wrap = xf.Alignment != nil && xf.Alignment.WrapText
+	}
+	if m.styles.Fonts != nil && fontID < len(m.styles.Fonts.Font) {
+		if fnt := m.styles.Fonts.Font[fontID]; fnt != nil {
+			if fnt.Name != nil && fnt.Name.Val != nil {
+				font.name = *fnt.Name.Val
+			}
+			if fnt.Sz != nil && fnt.Sz.Val != nil && *fnt.Sz.Val > 0 {
+				font.size = *fnt.Sz.Val
+			}
+			font.bold = fnt.B != nil && (fnt.B.Val == nil || *fnt.B.Val)
+			font.italic = fnt.I != nil && (fnt.I.Val == nil || *fnt.I.Val)
+		}
+	}
+	m.fonts[styleID] = font
+	return font
+}
+
+// measure returns the width in the unit of the column width to display the
+// given text by the cell style ID, returns 0 if the text is empty.
+func (m *textMeasurer) measure(styleID int, text string) float64 {
+	if text == "" {
+		return 0
+	}
+	font := m.getFont(styleID)
+	parts := strings.Split(text, "\n")
+	if font.wrap {
+		parts = strings.Fields(text)
+	}
+	var units float64
+	for _, part := range parts {
+		units = math.Max(units, m.textWidth(font, part))
+	}
+	width := math.Ceil((units+cellPaddingWidth)*100) / 100
+	return math.Min(width, MaxColumnWidth)
+}
+
+// textWidth returns the width of the single line text in the unit of the
+// column width by given font, the cell padding is not included.
+func (m *textMeasurer) textWidth(font textFont, text string) float64 {
+	var units float64
+	for _, r := range text {
+		units += getRuneWidth(r)
+	}
+	factor, baseFactor := fontWidthFactors[font.name], fontWidthFactors[m.base.name]
+	if factor == 0 {
+		factor = 1
+	}
+	if baseFactor == 0 {
+		baseFactor = 1
+	}
+	units *= font.size * factor / (m.base.size * baseFactor)
+	if font.bold {
+		units *= 1.07
+	}
+	if font.italic {
+		units *= 1.03
+	}
+	return units
+}
+
+// countLines returns the number of lines to display the wrapped text by given
+// font and the available width of the cell in the unit of the column width.
+// The text will be broken at the new line characters and the spaces, and the
+// word longer than the available width will be broken into multiple lines.
+func (m *textMeasurer) countLines(font textFont, text string, available float64) int {
+	if available <= 0 {
+		available = 1
+	}
+	var lines int
+	space := m.textWidth(font, " ")
+	for _, paragraph := range strings.Split(text, "\n") {
+		var cur float64
+		lines++
+		for _, word := range strings.Fields(paragraph) {
+			width := m.textWidth(font, word)
+			if cur > 0 && cur+space+width <= available {
+				cur += space + width
+				continue
+			}
+			if cur > 0 {
+				lines++
+			}
+			for cur = width; cur > available; cur -= available {
+				lines++
+			}
+		}
+	}
+	return lines
+}
+
+// getRuneWidth returns the width of the character relative to the width of
+// the digit in the Calibri font.
+func getRuneWidth(r rune) float64 {
+	switch {
+	case r >= 0x1100 && (unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) ||
+		r >= 0xFF01 && r <= 0xFF60 || r >= 0x3000 && r <= 0x303F):
+		return 2
+	case strings.ContainsRune(" iljI.,:;'|!`", r):
+		return 3.0 / 7
+	case strings.ContainsRune("frt()[]{}/\\-\"", r):
+		return 4.0 / 7
+	case strings.ContainsRune("szJ*^", r):
+		return 5.0 / 7
+	case strings.ContainsRune("ABCEFKLPRSTXYZ", r):
+		return 8.0 / 7
+	case strings.ContainsRune("DGHNOQUV", r):
+		return 9.0 / 7
+	case strings.ContainsRune("mw%&@", r):
+		return 11.0 / 7
+	case strings.ContainsRune("MW", r):
+		return 13.0 / 7
+	}
+	return 1
+}
+
 // flatCols provides a method for the column's operation functions to flatten
 // and check the worksheet columns.
 func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) []xlsxCol {
@@ -726,22 +1149,96 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 	f.mu.Unlock()
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	return ws.getColWidth(colNum), err
+}
+
+// getColWidth returns the width of the column by given column number.
+func (ws *xlsxWorksheet) getColWidth(col int) float64 {
 	if ws.Cols != nil {
 		var width float64
 		for _, v := range ws.Cols.Col {
-			if v.Min <= colNum && colNum <= v.Max && v.Width != nil {
+			if v.Min <= col && col <= v.Max && v.Width != nil {
 				width = *v.Width
 			}
 		}
 		if width != 0 {
-			return width, err
+			return width
 		}
 	}
 	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
-		return ws.SheetFormatPr.DefaultColWidth, err
+		return ws.SheetFormatPr.DefaultColWidth
 	}
 	// Optimization for when the column widths haven't changed.
-	return defaultColWidth, err
+	return defaultColWidth
+}
+
+// GetSheetColumnWidths provides a function to get the widths of the columns
+// by given worksheet name, returns a map keyed by the column name. The map
+// contains the columns explicitly listed in the columns settings of the
+// worksheet, and the columns used by the cells of the worksheet which are not
+// listed will be filled with the default column width. This function is
+// concurrency safe. For example:
+//
+//	widths, err := f.GetSheetColumnWidths("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(widths["A"])
+//
+// 根据给定的工作表名称获取工作表中全部列的宽度，返回以列名为键的映射。此功能是并发安全的。
+func (f *File) GetSheetColumnWidths(sheet string) (map[string]float64, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	defaultWidth := defaultColWidth
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		defaultWidth = ws.SheetFormatPr.DefaultColWidth
+	}
+	widths := make(map[string]float64)
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			for colNum := c.Min; colNum <= c.Max; colNum++ {
+				colName, err := ColumnNumberToName(colNum)
+				if err != nil {
+					return widths, err
+				}
+				if c.Width != nil && *c.Width != 0 {
+					widths[colName] = *c.Width
+					continue
+				}
+				if _, ok := widths[colName]; !ok {
+					widths[colName] = defaultWidth
+				}
+			}
+		}
+	}
+	var maxCol int
+	for _, row := range ws.SheetData.Row {
+		for idx, c := range row.C {
+			colNum := idx + 1
+			if c.R != "" {
+				if colNum, _, err = CellNameToCoordinates(c.R); err != nil {
+					return widths, err
+				}
+			}
+			if colNum > maxCol {
+				maxCol = colNum
+			}
+		}
+	}
+	for colNum := 1; colNum <= maxCol; colNum++ {
+		colName, _ := ColumnNumberToName(colNum)
+		if _, ok := widths[colName]; !ok {
+			widths[colName] = defaultWidth
+		}
+	}
+	return widths, nil
 }
 
 // InsertCols provides a function to insert new columns before the given column
@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -247,6 +248,65 @@ func TestColumnVisibility(t *testing.T) {
 	})
 }
 
+func TestAutoFitColumnWidth(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"0000000000", "merged", nil, "00000", "1234\n123456", "中文"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "00000"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "C1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", "000"))
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", boldStyle))
+	wrapStyle, err := f.NewStyle(&Style{Font: &Font{Family: "Arial", Size: 22}, Alignment: &Alignment{WrapText: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "G1", "00 0000 000"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "G1", "G1", wrapStyle))
+	// Test auto fit column width with column number format
+	numFmtStyle, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "H1", 0.5))
+	assert.NoError(t, f.SetColStyle("Sheet1", "H", numFmtStyle))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[7].S = 0
+	// Test auto fit column width with italic font and row style
+	italicStyle, err := f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "J1", "00000"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "J1", "J1", italicStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "K3", "00000"))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, boldStyle))
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[10].S = 0
+
+	assert.NoError(t, f.AutoFitColumnWidth("Sheet1", "A", "K"))
+	for col, expected := range map[string]float64{
+		"A": 10.72, "B": defaultColWidth, "C": 3.72, "D": 6.07, "E": 6.72,
+		"F": 4.72, "G": 9.52, "H": 4.29, "I": defaultColWidth, "J": 5.87, "K": 6.07,
+	} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	// Test auto fit column width with characters in different widths
+	m := newTextMeasurer(&xlsxStyleSheet{})
+	assert.Equal(t, 0.0, m.measure(0, ""))
+	assert.Equal(t, 10.58, m.measure(0, "il(sAGmMW"))
+	assert.Equal(t, float64(MaxColumnWidth), m.measure(0, strings.Repeat("W", 200)))
+	// Test auto fit column width with invalid column name
+	assert.EqualError(t, f.AutoFitColumnWidth("Sheet1", "*", "B"), newInvalidColumnNameError("*").Error())
+	// Test auto fit column width on not exists worksheet
+	assert.EqualError(t, f.AutoFitColumnWidth("SheetN", "A", "B"), "sheet SheetN does not exist")
+	// Test auto fit column width with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColumnWidth("Sheet1", "A", "B"), "XML syntax error on line 1: invalid UTF-8")
+	// Test auto fit column width with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColumnWidth("Sheet1", "A", "B"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestOutlineLevel(t *testing.T) {
 	f := NewFile()
 	level, err := f.GetColOutlineLevel("Sheet1", "D")
@@ -316,6 +376,87 @@ func TestOutlineLevel(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGroupColumns(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "C", 20))
+	assert.NoError(t, f.GroupColumns("Sheet1", "D", "B", true))
+	for _, col := range []string{"B", "C", "D"} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(1), level)
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.False(t, visible)
+	}
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, uint8(1), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	for _, col := range ws.(*xlsxWorksheet).Cols.Col {
+		assert.Equal(t, col.Min == 5, col.Collapsed)
+	}
+	// Test nested group and ungroup columns
+	assert.NoError(t, f.GroupColumns("Sheet1", "C", "C", false))
+	level, err := f.GetColOutlineLevel("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), level)
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	assert.NoError(t, f.UngroupColumns("Sheet1", "C", "C"))
+	assert.NoError(t, f.UngroupColumns("Sheet1", "B", "D"))
+	for _, col := range []string{"B", "C", "D"} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0), level)
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.True(t, visible)
+	}
+	assert.Equal(t, uint8(0), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	// Test group columns with summary column on the left
+	assert.NoError(t, f.SetSheetOutlineProps("Sheet1", OutlineProps{SummaryBelow: true, SummaryRight: false}))
+	assert.NoError(t, f.GroupColumns("Sheet1", "A", "B", true))
+	assert.NoError(t, f.GroupColumns("Sheet1", "E", "F", true))
+	for _, col := range ws.(*xlsxWorksheet).Cols.Col {
+		assert.Equal(t, col.Min == 4, col.Collapsed)
+	}
+	assert.NoError(t, f.UngroupColumns("Sheet1", "E", "F"))
+	visible, err := f.GetColVisible("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	// Test ungroup columns with the summary column in a wider column definition
+	ws.(*xlsxWorksheet).Cols.Col = append(ws.(*xlsxWorksheet).Cols.Col,
+		xlsxCol{Min: 10, Max: 11, OutlineLevel: 1, Hidden: true},
+		xlsxCol{Min: 12, Max: 15, Width: float64Ptr(15), CustomWidth: true, Collapsed: true},
+	)
+	assert.NoError(t, f.SetSheetOutlineProps("Sheet1", OutlineProps{SummaryRight: true}))
+	assert.NoError(t, f.UngroupColumns("Sheet1", "J", "K"))
+	visible, err = f.GetColVisible("Sheet1", "K")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	for _, col := range ws.(*xlsxWorksheet).Cols.Col {
+		if col.Min >= 12 {
+			assert.Equal(t, col.Min != 12, col.Collapsed)
+			assert.Equal(t, 15.0, *col.Width)
+		}
+	}
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "H", 7))
+	assert.EqualError(t, f.GroupColumns("Sheet1", "G", "H", false), ErrOutlineLevel.Error())
+	// Test group and ungroup columns with invalid column name
+	assert.EqualError(t, f.GroupColumns("Sheet1", "*", "B", false), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.UngroupColumns("Sheet1", "*", "B"), newInvalidColumnNameError("*").Error())
+	// Test group and ungroup columns on not exists worksheet
+	assert.EqualError(t, f.GroupColumns("SheetN", "A", "B", false), "sheet SheetN does not exist")
+	assert.EqualError(t, f.UngroupColumns("SheetN", "A", "B"), "sheet SheetN does not exist")
+	// Test ungroup columns on the worksheet without columns
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.UngroupColumns("Sheet2", "A", "B"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupColumns.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestSetColStyle(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Hello"))
@@ -395,6 +536,48 @@ func TestColWidth(t *testing.T) {
 	convertRowHeightToPixels(0)
 }
 
+func TestGetSheetColumnWidths(t *testing.T) {
+	f := NewFile()
+	widths, err := f.GetSheetColumnWidths("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, widths)
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "E", 20))
+	assert.NoError(t, f.SetColWidth("Sheet1", "G", "G", 5.5))
+	assert.NoError(t, f.SetColVisible("Sheet1", "H", false))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	widths, err = f.GetSheetColumnWidths("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		"A": defaultColWidth, "B": defaultColWidth, "C": 20, "D": 20, "E": 20,
+		"G": 5.5, "H": defaultColWidth,
+	}, widths)
+	for col, width := range widths {
+		expected, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	// Test get column widths with default column width of the worksheet
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetFormatPr = &xlsxSheetFormatPr{DefaultColWidth: 10}
+	widths, err = f.GetSheetColumnWidths("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, widths["A"])
+	assert.Equal(t, 10.0, widths["B"])
+	// Test get column widths with invalid cell reference
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[1].R = "B"
+	_, err = f.GetSheetColumnWidths("Sheet1")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	// Test get column widths with invalid column number
+	ws.(*xlsxWorksheet).Cols.Col[0].Max = MaxColumns + 1
+	ws.(*xlsxWorksheet).Cols.Col[0].Min = MaxColumns + 1
+	_, err = f.GetSheetColumnWidths("Sheet1")
+	assert.EqualError(t, err, ErrColumnNumber.Error())
+	// Test get column widths on not exists worksheet
+	_, err = f.GetSheetColumnWidths("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestGetColStyle(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.GetColStyle("Sheet1", "A")
@@ -16,6 +16,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -64,15 +66,223 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 	return comments, nil
 }
 
-// getSheetComments provides the method to get the target comment reference by
-// given worksheet file path.
-func (f *File) getSheetComments(sheetFile string) string {
+// GetCellComments retrieves all comments in a worksheet by given worksheet
+// name, and returns a map keyed by the cell reference. This function merges
+// the legacy comments with the threaded comments of the worksheet, the text
+// and author of a threaded comment will replace the placeholder of the legacy
+// comment generated by the spreadsheet application, and the replies of the
+// threaded comment will be appended to the text on separate lines. The
+// visibility, width, height (in pixels) and background color of each comment
+// box were read from the legacy VML drawing of the worksheet. For example, get
+// the comment in the cell Sheet1!A1:
+//
+//	comments, err := f.GetCellComments("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if comment, ok := comments["A1"]; ok {
+//	    fmt.Println(comment.Author, comment.Text)
+//	}
+//
+// 根据给定的工作表名称获取工作表中的全部批注（包括传统批注与线程批注），并以单元格坐标为键返回。
+func (f *File) GetCellComments(sheet string) (map[string]*Comment, error) {
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return nil, err
+	}
+	cellComments := make(map[string]*Comment, len(comments))
+	for i := range comments {
+		comment := comments[i]
+		if comment.Text == "" {
+			for _, run := range comment.Runs {
+				comment.Text += run.Text
+			}
+		}
+		cellComments[comment.Cell] = &comment
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if err = f.getThreadedComments(sheetXMLPath, cellComments); err != nil {
+		return cellComments, err
+	}
+	return cellComments, f.getCommentShapes(sheet, cellComments)
+}
+
+// getThreadedComments provides a function to merge the threaded comments of
+// the worksheet into the given comments map.
+func (f *File) getThreadedComments(sheetXMLPath string, comments map[string]*Comment) error {
+	target := f.getSheetRelsTargetByType(filepath.Base(sheetXMLPath), SourceRelationshipThreadedComment)
+	if target == "" {
+		return nil
+	}
+	threadedComments := new(xlsxThreadedComments)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(getRelsTargetPath(target))))).
+		Decode(threadedComments); err != nil && err != io.EOF {
+		return err
+	}
+	persons, err := f.getPersons()
+	if err != nil {
+		return err
+	}
+	roots := map[string]string{}
+	for _, tc := range threadedComments.ThreadedComment {
+		ref := tc.Ref
+		if tc.ParentID != "" {
+			if ref = roots[tc.ParentID]; ref == "" {
+				continue
+			}
+			comment := comments[ref]
+			comment.Text += "\n" + tc.Text
+			comment.Runs = append(comment.Runs, RichTextRun{Text: tc.Text})
+			continue
+		}
+		roots[tc.ID] = ref
+		comment, ok := comments[ref]
+		if !ok {
+			comment = &Comment{Cell: ref}
+			comments[ref] = comment
+		}
+		comment.Author, comment.Text = persons[tc.PersonID], tc.Text
+		comment.Runs = []RichTextRun{{Text: tc.Text}}
+	}
+	return err
+}
+
+// getPersons provides a function to get the display names of the threaded
+// comments authors in the workbook, keyed by the person ID.
+func (f *File) getPersons() (map[string]string, error) {
+	persons := map[string]string{}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return persons, err
+	}
+	var target string
+	rels.mu.Lock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipPerson {
+			target = rel.Target
+		}
+	}
+	rels.mu.Unlock()
+	if target == "" {
+		return persons, err
+	}
+	if strings.HasPrefix(target, "/") {
+		target = strings.TrimPrefix(target, "/")
+	} else {
+		target = path.Join(path.Dir(f.getWorkbookPath()), target)
+	}
+	personList := new(xlsxPersonList)
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(target)))).
+		Decode(personList); err != nil && err != io.EOF {
+		return persons, err
+	}
+	for _, person := range personList.Person {
+		persons[person.ID] = person.DisplayName
+	}
+	return persons, nil
+}
+
+// getCommentShapes provides a function to fill the visibility, size and
+// background color of the comments by the shapes in the legacy VML drawing of
+// the worksheet.
+func (f *File) getCommentShapes(sheet string, comments map[string]*Comment) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil || ws.LegacyDrawing == nil {
+		return err
+	}
+	drawingVML := getRelsTargetPath(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID))
+	var shapes []decodeShape
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, shape := range vml.Shape {
+			shapes = append(shapes, decodeShape{Style: shape.Style, Fillcolor: shape.Fillcolor, Val: shape.Val})
+		}
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil {
+			return err
+		}
+		if d != nil {
+			shapes = d.Shape
+		}
+	}
+	for _, shape := range shapes {
+		var val decodeShapeVal
+		if err = xml.Unmarshal([]byte("<shape>"+shape.Val+"</shape>"), &val); err != nil {
+			return err
+		}
+		if val.ClientData.ObjectType != "Note" {
+			continue
+		}
+		cell, err := CoordinatesToCellName(val.ClientData.Column+1, val.ClientData.Row+1)
+		if err != nil {
+			return err
+		}
+		comment, ok := comments[cell]
+		if !ok {
+			continue
+		}
+		comment.Visible = val.ClientData.Visible != nil
+		for _, prop := range strings.Split(shape.Style, ";") {
+			kv := strings.SplitN(prop, ":", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch strings.TrimSpace(kv[0]) {
+			case "width":
+				comment.Width = parseVMLLength(kv[1])
+			case "height":
+				comment.Height = parseVMLLength(kv[1])
+			case "visibility":
+				comment.Visible = comment.Visible || strings.TrimSpace(kv[1]) == "visible"
+			}
+		}
+		if fields := strings.Fields(shape.Fillcolor); len(fields) > 0 {
+			comment.BackgroundColor = strings.ToUpper(strings.TrimPrefix(fields[0], "#"))
+		}
+	}
+	return nil
+}
+
+// parseVMLLength provides a function to convert the length in the VML style
+// attribute to pixels, the length without unit will be treated as pixels.
+func parseVMLLength(length string) uint {
+	length = strings.TrimSpace(length)
+	units := map[string]float64{"pt": 96.0 / 72, "px": 1, "in": 96, "cm": 96 / 2.54, "mm": 96 / 25.4}
+	scale := 1.0
+	for unit, ratio := range units {
+		if strings.HasSuffix(length, unit) {
+			length, scale = strings.TrimSuffix(length, unit), ratio
+			break
+		}
+	}
+	val, err := strconv.ParseFloat(length, 64)
+	if err != nil || val < 0 {
+		return 0
+	}
+	return uint(math.Round(val * scale))
+}
+
+// getRelsTargetPath provides a function to convert the relationship target
+// in the worksheet relationships to the path in the package.
+func getRelsTargetPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return "xl" + strings.TrimPrefix(target, "..")
+}
+
+// getSheetRelsTargetByType provides a function to get the target of the first
+// relationship with given type by given worksheet file path.
+func (f *File) getSheetRelsTargetByType(sheetFile, relType string) string {
 	rels, _ := f.relsReader("xl/worksheets/_rels/" + sheetFile + ".rels")
-	if sheetRels := rels; sheetRels != nil {
-		sheetRels.mu.Lock()
-		defer sheetRels.mu.Unlock()
-		for _, v := range sheetRels.Relationships {
-			if v.Type == SourceRelationshipComments {
+	if rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for _, v := range rels.Relationships {
+			if v.Type == relType {
 				return v.Target
 			}
 		}
@@ -80,6 +290,12 @@ func (f *File) getSheetComments(sheetFile string) string {
 	return ""
 }
 
+// getSheetComments provides the method to get the target comment reference by
+// given worksheet file path.
+func (f *File) getSheetComments(sheetFile string) string {
+	return f.getSheetRelsTargetByType(sheetFile, SourceRelationshipComments)
+}
+
 // AddComment provides the method to add comment in a sheet by given worksheet
 // index, cell and format set (such as author and text). Note that the max
 // author length is 255 and the max text length is 32512. For example, add a
@@ -396,17 +612,6 @@ func (f *File) decodeVMLDrawingReader(path string) (*decodeVmlDrawing, error) {
 	return f.DecodeVMLDrawing[path], nil
 }
 
-// vmlDrawingWriter provides a function to save xl/drawings/vmlDrawing%d.xml
-// after serialize structure.
-func (f *File) vmlDrawingWriter() {
-	for path, vml := range f.VMLDrawing {
-		if vml != nil {
-			v, _ := xml.Marshal(vml)
-			f.Pkg.Store(path, v)
-		}
-	}
-}
-
 // commentsReader provides a function to get the pointer to the structure
 // after deserialization of xl/comments%d.xml.
 func (f *File) commentsReader(path string) (*xlsxComments, error) {
@@ -422,14 +627,3 @@ func (f *File) commentsReader(path string) (*xlsxComments, error) {
 	}
 	return f.Comments[path], nil
 }
-
-// commentsWriter provides a function to save xl/comments%d.xml after
-// serialize structure.
-func (f *File) commentsWriter() {
-	for path, c := range f.Comments {
-		if c != nil {
-			v, _ := xml.Marshal(c)
-			f.saveFileList(path, v)
-		}
-	}
-}
@@ -122,6 +122,91 @@ func TestDeleteComment(t *testing.T) {
 	assert.EqualError(t, f.DeleteComment("Sheet2", "A41"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetCellComments(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Runs: []RichTextRun{{Text: "Excelize: ", Font: &Font{Bold: true}}, {Text: "This is a comment."}}}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "tc={6F84C9D0-8E54-4A5C-9E27-0B4F2E6B7A11}", Text: "[Threaded comment]"}))
+	comments, err := f.GetCellComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "Excelize", comments["A1"].Author)
+	assert.Equal(t, "Excelize: This is a comment.", comments["A1"].Text)
+	assert.Len(t, comments["A1"].Runs, 2)
+	assert.False(t, comments["A1"].Visible)
+	assert.Equal(t, uint(144), comments["A1"].Width)
+	assert.Equal(t, uint(79), comments["A1"].Height)
+	assert.Equal(t, "FBF6D6", comments["A1"].BackgroundColor)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCellComments.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetCellComments.xlsx"))
+	assert.NoError(t, err)
+	// Test get comments with threaded comments and visible comment box
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", []byte(`<xml xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:x="urn:schemas-microsoft-com:office:excel"><v:shape id="_x0000_s1025" type="#_x0000_t202" style="position:absolute;width:2in;height:72pt;z-index:1;visibility:visible" fillcolor="#ffffe1 [80]" o:insetmode="auto"><x:ClientData ObjectType="Note"><x:Visible/><x:Row>0</x:Row><x:Column>0</x:Column></x:ClientData></v:shape><v:shape id="_x0000_s1026" type="#_x0000_t202" style="width:100px;height:50"><x:ClientData ObjectType="Note"><x:Row>1</x:Row><x:Column>1</x:Column></x:ClientData></v:shape><v:shape id="_x0000_s1027" type="#_x0000_t201"><x:ClientData ObjectType="Button"><x:Row>2</x:Row><x:Column>2</x:Column></x:ClientData></v:shape><v:shape id="_x0000_s1028" type="#_x0000_t202"><x:ClientData ObjectType="Note"><x:Row>9</x:Row><x:Column>9</x:Column></x:ClientData></v:shape></xml>`))
+	f.addRels("xl/worksheets/_rels/sheet1.xml.rels", SourceRelationshipThreadedComment, "../threadedComments/threadedComment1.xml", "")
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "")
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", []byte(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><threadedComment ref="B2" personId="{0C5E8D24-2F7B-4C1D-A1E9-3B6F1D2A9C01}" id="{6F84C9D0-8E54-4A5C-9E27-0B4F2E6B7A11}"><text>Threaded comment</text></threadedComment><threadedComment ref="B2" personId="{0C5E8D24-2F7B-4C1D-A1E9-3B6F1D2A9C02}" id="{6F84C9D0-8E54-4A5C-9E27-0B4F2E6B7A12}" parentId="{6F84C9D0-8E54-4A5C-9E27-0B4F2E6B7A11}"><text>Reply</text></threadedComment><threadedComment ref="C3" personId="{0C5E8D24-2F7B-4C1D-A1E9-3B6F1D2A9C01}" id="{6F84C9D0-8E54-4A5C-9E27-0B4F2E6B7A13}"><text>Without legacy comment</text></threadedComment><threadedComment ref="D4" personId="{0C5E8D24-2F7B-4C1D-A1E9-3B6F1D2A9C01}" id="{6F84C9D0-8E54-4A5C-9E27-0B4F2E6B7A14}" parentId="{00000000-0000-0000-0000-000000000000}"><text>Orphan reply</text></threadedComment></ThreadedComments>`))
+	f.Pkg.Store("xl/persons/person.xml", []byte(`<personList xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><person displayName="Alice" id="{0C5E8D24-2F7B-4C1D-A1E9-3B6F1D2A9C01}"/><person displayName="Bob" id="{0C5E8D24-2F7B-4C1D-A1E9-3B6F1D2A9C02}"/></personList>`))
+	comments, err = f.GetCellComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.True(t, comments["A1"].Visible)
+	assert.Equal(t, uint(192), comments["A1"].Width)
+	assert.Equal(t, uint(96), comments["A1"].Height)
+	assert.Equal(t, "FFFFE1", comments["A1"].BackgroundColor)
+	assert.Equal(t, "Alice", comments["B2"].Author)
+	assert.Equal(t, "Threaded comment\nReply", comments["B2"].Text)
+	assert.Equal(t, []RichTextRun{{Text: "Threaded comment"}, {Text: "Reply"}}, comments["B2"].Runs)
+	assert.False(t, comments["B2"].Visible)
+	assert.Equal(t, uint(100), comments["B2"].Width)
+	assert.Equal(t, uint(50), comments["B2"].Height)
+	assert.Equal(t, &Comment{Author: "Alice", Cell: "C3", Text: "Without legacy comment", Runs: []RichTextRun{{Text: "Without legacy comment"}}}, comments["C3"])
+
+	// Test get comments on not exists worksheet
+	_, err = f.GetCellComments("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get comments with invalid cell reference in the VML drawing
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[0].Val = `<x:ClientData ObjectType="Note"><x:Row>0</x:Row><x:Column>-1</x:Column></x:ClientData>`
+	_, err = f.GetCellComments("Sheet1")
+	assert.EqualError(t, err, "invalid cell reference [0, 1]")
+	// Test get comments with invalid shape in the VML drawing
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[0].Val = "<x:ClientData>"
+	_, err = f.GetCellComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <ClientData> closed by </shape>")
+	// Test get comments with unsupported charset VML drawing
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err = f.GetCellComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get comments with unsupported charset persons
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCellComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get comments with unsupported charset workbook relationships
+	f.Relationships.Delete(f.getWorkbookRelsPath())
+	f.Pkg.Store(f.getWorkbookRelsPath(), MacintoshCyrillicCharset)
+	_, err = f.GetCellComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get comments with unsupported charset threaded comments
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCellComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get comments with unsupported charset comments
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCellComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestParseVMLLength(t *testing.T) {
+	for length, expected := range map[string]uint{
+		"108pt": 144, " 10px ": 10, "1in": 96, "2.54cm": 96, "25.4mm": 96, "20": 20, "-1pt": 0, "auto": 0,
+	} {
+		assert.Equal(t, expected, parseVMLLength(length), length)
+	}
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -23,8 +24,10 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/xml"
+	"fmt"
 	"hash"
 	"math"
+	"path"
 	"path/filepath"
 	"reflect"
 	"sort"
@@ -38,6 +41,11 @@ import (
 
 var (
 	blockKey                    = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6} // Block keys used for encryption
+	verifierHashInputBlockKey   = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79}
+	verifierHashValueBlockKey   = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e}
+	hmacKeyBlockKey             = []byte{0x5f, 0xb2, 0xad, 0x01, 0x0c, 0xb9, 0xe1, 0xf6}
+	hmacValueBlockKey           = []byte{0xa0, 0x67, 0x7f, 0x02, 0xb2, 0x2c, 0x84, 0x33}
+	agileEncryptionSpinCount    = 100000
 	oleIdentifier               = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
 	headerCLSID                 = make([]byte, 16)
 	difSect                     = -4
@@ -129,14 +137,6 @@ type StandardEncryptionVerifier struct {
 	EncryptedVerifierHash []byte
 }
 
-// encryptionInfo structure is used for standard encryption with SHA1
-// cryptographic algorithm.
-type encryption struct {
-	BlockSize, SaltSize                                                                  int
-	EncryptedKeyValue, EncryptedVerifierHashInput, EncryptedVerifierHashValue, SaltValue []byte
-	KeyBits                                                                              uint32
-}
-
 // Decrypt API decrypts the CFB file format with ECMA-376 agile encryption and
 // standard encryption. Support cryptographic algorithm: MD4, MD5, RIPEMD-160,
 // SHA1, SHA256, SHA384 and SHA512 currently.
@@ -156,32 +156,24 @@ func Decrypt(raw []byte, opts *Options) (packageBuf []byte, err error) {
 	return standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, opts)
 }
 
-// Encrypt API encrypt data with the password.
+// Encrypt API encrypts the data with the password by ECMA-376 agile
+// encryption, using the AES-256 cipher with CBC chaining mode and the SHA-512
+// hash algorithm, and returns the CFB file format.
 func Encrypt(raw []byte, opts *Options) ([]byte, error) {
-	encryptor := encryption{
-		EncryptedVerifierHashInput: make([]byte, 16),
-		EncryptedVerifierHashValue: make([]byte, 32),
-		SaltValue:                  make([]byte, 16),
-		BlockSize:                  16,
-		KeyBits:                    128,
-		SaltSize:                   16,
-	}
-	// Key Encryption
-	encryptionInfoBuffer, err := encryptor.standardKeyEncryption(opts.Password)
+	if len(opts.Password) == 0 || len(opts.Password) > MaxFieldLength {
+		return nil, ErrPasswordLengthInvalid
+	}
+	encryptionInfoBuf, encryptedPackageBuf, err := agileEncrypt(raw, opts.Password)
 	if err != nil {
 		return nil, err
 	}
-	// Package Encryption
-	encryptedPackage := make([]byte, 8)
-	binary.LittleEndian.PutUint64(encryptedPackage, uint64(len(raw)))
-	encryptedPackage = append(encryptedPackage, encryptor.encrypt(raw)...)
 	// Create a new CFB
 	compoundFile := &cfb{
 		paths:   []string{"Root Entry/"},
 		sectors: []sector{{name: "Root Entry", typeID: 5}},
 	}
-	compoundFile.put("EncryptionInfo", encryptionInfoBuffer)
-	compoundFile.put("EncryptedPackage", encryptedPackage)
+	compoundFile.put("EncryptionInfo", encryptionInfoBuf)
+	compoundFile.put("EncryptedPackage", encryptedPackageBuf)
 	return compoundFile.write(), nil
 }
 
@@ -329,68 +321,6 @@ func standardXORBytes(a, b []byte) []byte {
 	return buf
 }
 
-// encrypt provides a function to encrypt given value with AES cryptographic
-// algorithm.
-func (e *encryption) encrypt(input []byte) []byte {
-	inputBytes := len(input)
-	if pad := inputBytes % e.BlockSize; pad != 0 {
-		inputBytes += e.BlockSize - pad
-	}
-	var output, chunk []byte
-	encryptedChunk := make([]byte, e.BlockSize)
-	for i := 0; i < inputBytes; i += e.BlockSize {
-		if i+e.BlockSize <= len(input) {
-			chunk = input[i : i+e.BlockSize]
-		} else {
-			chunk = input[i:]
-		}
-		chunk = append(chunk, make([]byte, e.BlockSize-len(chunk))...)
-		c, _ := aes.NewCipher(e.EncryptedKeyValue)
-		c.Encrypt(encryptedChunk, chunk)
-		output = append(output, encryptedChunk...)
-	}
-	return output
-}
-
-// standardKeyEncryption encrypt convert the password to an encryption key.
-func (e *encryption) standardKeyEncryption(password string) ([]byte, error) {
-	if len(password) == 0 || len(password) > MaxFieldLength {
-		return nil, ErrPasswordLengthInvalid
-	}
-	var storage cfb
-	storage.writeUint16(0x0003)
-	storage.writeUint16(0x0002)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0xA4)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0x00)
-	storage.writeUint32(0x660E)
-	storage.writeUint32(0x8004)
-	storage.writeUint32(0x80)
-	storage.writeUint32(0x18)
-	storage.writeUint64(0x00)
-	providerName := "Microsoft Enhanced RSA and AES Cryptographic Provider (Prototype)"
-	storage.writeStrings(providerName)
-	storage.writeUint16(0x00)
-	storage.writeUint32(0x10)
-	keyDataSaltValue, _ := randomBytes(16)
-	verifierHashInput, _ := randomBytes(16)
-	e.SaltValue = keyDataSaltValue
-	e.EncryptedKeyValue, _ = standardConvertPasswdToKey(
-		StandardEncryptionHeader{KeySize: e.KeyBits},
-		StandardEncryptionVerifier{Salt: e.SaltValue},
-		&Options{Password: password})
-	verifierHashInputKey := hashing("sha1", verifierHashInput)
-	e.EncryptedVerifierHashInput = e.encrypt(verifierHashInput)
-	e.EncryptedVerifierHashValue = e.encrypt(verifierHashInputKey)
-	storage.writeBytes(e.SaltValue)
-	storage.writeBytes(e.EncryptedVerifierHashInput)
-	storage.writeUint32(0x14)
-	storage.writeBytes(e.EncryptedVerifierHashValue)
-	storage.position = 0
-	return storage.stream, nil
-}
-
 // ECMA-376 Agile Encryption
 
 // agileDecrypt decrypt the CFB file format with ECMA-376 agile encryption.
@@ -401,11 +331,22 @@ func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options)
 	if encryptionInfo, err = parseEncryptionInfo(encryptionInfoBuf[8:]); err != nil {
 		return
 	}
-	// Convert the password into an encryption key.
-	key, err := convertPasswdToKey(opts.Password, blockKey, encryptionInfo)
+	if len(encryptionInfo.KeyEncryptors.KeyEncryptor) == 0 || len(encryptedPackageBuf) < packageOffset {
+		err = ErrUnsupportedEncryptMechanism
+		return
+	}
+	// Hash the password once, the keys for the verifier and the package key
+	// are all derived from the hash.
+	passwdHash, err := hashPasswd(opts.Password, encryptionInfo)
 	if err != nil {
 		return
 	}
+	// Verify the password with the verifier hash input and value.
+	if err = agileVerifyPasswd(passwdHash, encryptionInfo); err != nil {
+		return
+	}
+	// Convert the password hash into an encryption key.
+	key := convertPasswdToKey(passwdHash, blockKey, encryptionInfo)
 	// Use the key to decrypt the package key.
 	encryptedKey := encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey
 	saltValue, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
@@ -417,12 +358,126 @@ func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options)
 		return
 	}
 	packageKey, _ := decrypt(key, saltValue, encryptedKeyValue)
-	// Use the package key to decrypt the package.
-	return decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo)
+	// Use the package key to decrypt the package, and truncate the padding
+	// of the last block by the size of the stream.
+	if packageBuf, err = decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo); err != nil {
+		return
+	}
+	if size := binary.LittleEndian.Uint64(encryptedPackageBuf[:packageOffset]); size < uint64(len(packageBuf)) {
+		packageBuf = packageBuf[:size]
+	}
+	return
+}
+
+// agileVerifyPasswd provides a function to verify the password by decrypting
+// the verifier hash input and value of the password key encryptor with the
+// keys derived from the given password hash. It returns ErrWorkbookPassword
+// if the hash of the verifier hash input doesn't match the verifier hash
+// value.
+func agileVerifyPasswd(passwdHash []byte, encryption Encryption) error {
+	encryptedKey := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	saltValue, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
+	if err != nil {
+		return err
+	}
+	var verifier [2][]byte
+	for idx, value := range []struct {
+		blockKey []byte
+		verifier string
+	}{
+		{verifierHashInputBlockKey, encryptedKey.EncryptedVerifierHashInput},
+		{verifierHashValueBlockKey, encryptedKey.EncryptedVerifierHashValue},
+	} {
+		key := convertPasswdToKey(passwdHash, value.blockKey, encryption)
+		buf, err := base64.StdEncoding.DecodeString(value.verifier)
+		if err != nil {
+			return err
+		}
+		if len(buf) == 0 || len(buf)%aes.BlockSize != 0 {
+			return ErrWorkbookPassword
+		}
+		if verifier[idx], err = decrypt(key, saltValue, buf); err != nil {
+			return err
+		}
+	}
+	if encryptedKey.SaltSize < 0 || len(verifier[0]) < encryptedKey.SaltSize {
+		return ErrWorkbookPassword
+	}
+	hashValue := hashing(encryptedKey.HashAlgorithm, verifier[0][:encryptedKey.SaltSize])
+	if len(hashValue) == 0 || len(verifier[1]) < len(hashValue) || !bytes.Equal(hashValue, verifier[1][:len(hashValue)]) {
+		return ErrWorkbookPassword
+	}
+	return nil
 }
 
-// convertPasswdToKey convert the password into an encryption key.
-func convertPasswdToKey(passwd string, blockKey []byte, encryption Encryption) (key []byte, err error) {
+// agileEncrypt encrypt the data with the password by ECMA-376 agile
+// encryption, and returns the encryption information stream and the
+// encrypted package stream.
+func agileEncrypt(raw []byte, passwd string) (encryptionInfoBuf, encryptedPackageBuf []byte, err error) {
+	random := make([][]byte, 5)
+	for idx, size := range []int{16, 16, 32, 16, 64} {
+		if random[idx], err = randomBytes(size); err != nil {
+			return
+		}
+	}
+	keyDataSalt, keySalt, packageKey, verifierHashInput, hmacKey := random[0], random[1], random[2], random[3], random[4]
+	keyData := KeyData{
+		SaltSize: 16, BlockSize: 16, KeyBits: 256, HashSize: 64, CipherAlgorithm: "AES",
+		CipherChaining: "ChainingModeCBC", HashAlgorithm: "SHA512", SaltValue: base64.StdEncoding.EncodeToString(keyDataSalt),
+	}
+	encryptedKey := EncryptedKey{SpinCount: agileEncryptionSpinCount, KeyData: keyData}
+	encryptedKey.SaltValue = base64.StdEncoding.EncodeToString(keySalt)
+	encryptionInfo := Encryption{KeyData: keyData, KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{{EncryptedKey: encryptedKey}}}}
+	// Encrypt the verifier hash input, verifier hash value and package key
+	// with the keys derived from the password.
+	passwdHash, err := hashPasswd(passwd, encryptionInfo)
+	if err != nil {
+		return
+	}
+	var encrypted [5][]byte
+	for idx, value := range [][2][]byte{
+		{verifierHashInputBlockKey, verifierHashInput},
+		{verifierHashValueBlockKey, hashing(keyData.HashAlgorithm, verifierHashInput)},
+		{blockKey, packageKey},
+	} {
+		key := convertPasswdToKey(passwdHash, value[0], encryptionInfo)
+		if encrypted[idx], err = encrypt(key, keySalt, value[1]); err != nil {
+			return nil, nil, err
+		}
+	}
+	// Use the package key to encrypt the package.
+	var storage cfb
+	storage.writeUint64(len(raw))
+	if encryptedPackageBuf, err = encryptPackage(packageKey, raw, encryptionInfo); err != nil {
+		return
+	}
+	encryptedPackageBuf = append(storage.stream, encryptedPackageBuf...)
+	// Generate the data integrity with the HMAC of the encrypted package.
+	handler := hmac.New(sha512.New, hmacKey)
+	_, _ = handler.Write(encryptedPackageBuf)
+	for idx, value := range [][2][]byte{{hmacKeyBlockKey, hmacKey}, {hmacValueBlockKey, handler.Sum(nil)}} {
+		iv, err := createIV(value[0], encryptionInfo)
+		if err != nil {
+			return nil, nil, err
+		}
+		if encrypted[idx+3], err = encrypt(packageKey, iv, value[1]); err != nil {
+			return nil, nil, err
+		}
+	}
+	storage = cfb{}
+	storage.writeUint16(0x0004)
+	storage.writeUint16(0x0004)
+	storage.writeUint32(0x40)
+	storage.writeBytes([]byte(fmt.Sprintf(agileEncryptionInfoTemplate,
+		keyData.SaltValue, base64.StdEncoding.EncodeToString(encrypted[3]), base64.StdEncoding.EncodeToString(encrypted[4]),
+		agileEncryptionSpinCount, encryptedKey.SaltValue, base64.StdEncoding.EncodeToString(encrypted[0]),
+		base64.StdEncoding.EncodeToString(encrypted[1]), base64.StdEncoding.EncodeToString(encrypted[2]))))
+	return storage.stream, encryptedPackageBuf, err
+}
+
+// hashPasswd provides a function to hash the password with the salt value of
+// the password key encryptor by the spin count iterations.
+func hashPasswd(passwd string, encryption Encryption) (key []byte, err error) {
 	var b bytes.Buffer
 	saltValue, err := base64.StdEncoding.DecodeString(encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SaltValue)
 	if err != nil {
@@ -442,8 +497,14 @@ func convertPasswdToKey(passwd string, blockKey []byte, encryption Encryption) (
 		iterator := createUInt32LEBuffer(i, 4)
 		key = hashing(encryption.KeyData.HashAlgorithm, iterator, key)
 	}
+	return
+}
+
+// convertPasswdToKey convert the password hash into an encryption key by
+// given block key.
+func convertPasswdToKey(passwdHash, blockKey []byte, encryption Encryption) (key []byte) {
 	// Now generate the final hash.
-	key = hashing(encryption.KeyData.HashAlgorithm, key, blockKey)
+	key = hashing(encryption.KeyData.HashAlgorithm, passwdHash, blockKey)
 	// Truncate or pad as needed to get to length of keyBits.
 	keyBytes := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.KeyBits / 8
 	if len(key) < keyBytes {
@@ -502,6 +563,23 @@ func decrypt(key, iv, input []byte) (packageKey []byte, err error) {
 	return input, nil
 }
 
+// encrypt provides a function to encrypt input by given key and
+// initialization vector with AES cryptographic algorithm and CBC chaining
+// mode, the input will be padded to an integer multiple of the block size.
+func encrypt(key, iv, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]byte, len(input))
+	copy(output, input)
+	if remainder := len(output) % aes.BlockSize; remainder != 0 {
+		output = append(output, make([]byte, aes.BlockSize-remainder)...)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(output, output)
+	return output, nil
+}
+
 // decryptPackage decrypt package by given packageKey and encryption
 // info.
 func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChunks []byte, err error) {
@@ -544,6 +622,27 @@ func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChun
 	return
 }
 
+// encryptPackage encrypt package by given packageKey and encryption info.
+// The package is divided into segments of 4096 bytes, and each segment is
+// encrypted with the initialization vector created by the segment index.
+func encryptPackage(packageKey, input []byte, encryption Encryption) (outputChunks []byte, err error) {
+	var iv, outputChunk []byte
+	for i, start := 0, 0; start < len(input); i, start = i+1, start+packageEncryptionChunkSize {
+		end := start + packageEncryptionChunkSize
+		if end > len(input) {
+			end = len(input)
+		}
+		if iv, err = createIV(i, encryption); err != nil {
+			return
+		}
+		if outputChunk, err = encrypt(packageKey, iv, input[start:end]); err != nil {
+			return
+		}
+		outputChunks = append(outputChunks, outputChunk...)
+	}
+	return
+}
+
 // createIV create an initialization vector (IV).
 func createIV(blockKey interface{}, encryption Encryption) ([]byte, error) {
 	encryptedKey := encryption.KeyData
@@ -708,7 +807,7 @@ func (c *cfb) put(name string, content []byte) {
 // a storage object) is represented as a red-black tree. The parent object of
 // this set of siblings will have a pointer to the top of this tree.
 func (c *cfb) compare(left, right string) int {
-	L, R, i, j := strings.Split(left, "/"), strings.Split(right, "/"), 0, 0
+	L, R, i, j := strings.Split(strings.ToUpper(left), "/"), strings.Split(strings.ToUpper(right), "/"), 0, 0
 	for Z := int(math.Min(float64(len(L)), float64(len(R)))); i < Z; i++ {
 		if j = len(L[i]) - len(R[i]); j != 0 {
 			return j
@@ -737,7 +836,7 @@ func (c *cfb) prepare() {
 		objects = append(objects, object{path: c.paths[i], sector: c.sectors[i]})
 	}
 	sort.Slice(objects, func(i, j int) bool {
-		return c.compare(objects[i].path, objects[j].path) == 0
+		return c.compare(objects[i].path, objects[j].path) < 0
 	})
 	c.paths, c.sectors = []string{}, []sector{}
 	for i := 0; i < len(objects); i++ {
@@ -758,8 +857,22 @@ func (c *cfb) prepare() {
 				sector.C = 1
 			}
 			sector.size, sector.typeID = 0, 5
+		} else if strings.HasSuffix(path, "/") {
+			for j := i + 1; j < len(c.paths); j++ {
+				if c.dirname(c.paths[j]) == strings.TrimSuffix(path, "/") {
+					sector.C = j
+					break
+				}
+			}
+			for j := i + 1; j < len(c.paths); j++ {
+				if c.dirname(c.paths[j]) == c.dirname(path) {
+					sector.R = j
+					break
+				}
+			}
+			sector.typeID = 1
 		} else {
-			if len(c.paths) > i+1 && filepath.Dir(c.paths[i+1]) == filepath.Dir(path) {
+			if len(c.paths) > i+1 && c.dirname(c.paths[i+1]) == c.dirname(path) {
 				sector.R = i + 1
 			}
 			sector.typeID = 2
@@ -767,6 +880,12 @@ func (c *cfb) prepare() {
 	}
 }
 
+// dirname provides a function to get the path of the parent storage object by
+// given object path, the path of a storage object ends with a slash.
+func (c *cfb) dirname(name string) string {
+	return path.Dir(strings.TrimSuffix(name, "/"))
+}
+
 // locate provides a function to locate sectors location and size of the
 // compound file.
 func (c *cfb) locate() []int {
@@ -944,7 +1063,7 @@ func (c *cfb) writeSectorChains(location []int) sector {
 		if sectorSize = len(sector.content); sectorSize == 0 || sectorSize >= 0x1000 {
 			continue
 		}
-		sector.start = offset
+		c.sectors[j].start = offset
 		offset = writeSectorChain((sectorSize+0x3F)>>6, offset)
 	}
 	for c.position&0x1FF != 0 {
@@ -13,6 +13,9 @@ package excelize
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
 	"encoding/binary"
 	"os"
 	"path/filepath"
@@ -46,6 +49,51 @@ func TestEncrypt(t *testing.T) {
 	// Test encrypt spreadsheet with new password
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "Encryption.xlsx"), Options{Password: "passwd"}))
 	assert.NoError(t, f.Close())
+	// Test the workbook was encrypted by agile encryption with data integrity
+	encrypted, err := os.ReadFile(filepath.Join("test", "Encryption.xlsx"))
+	assert.NoError(t, err)
+	doc, err := mscfb.New(bytes.NewReader(encrypted))
+	assert.NoError(t, err)
+	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	mechanism, err := encryptionMechanism(encryptionInfoBuf)
+	assert.NoError(t, err)
+	assert.Equal(t, "agile", mechanism)
+	encryptionInfo, err := parseEncryptionInfo(encryptionInfoBuf[8:])
+	assert.NoError(t, err)
+	encryptedKey := encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	assert.Equal(t, "SHA512", encryptedKey.HashAlgorithm)
+	assert.Equal(t, 256, encryptedKey.KeyBits)
+	assert.Equal(t, agileEncryptionSpinCount, encryptedKey.SpinCount)
+	passwdHash, err := hashPasswd("passwd", encryptionInfo)
+	assert.NoError(t, err)
+	key := convertPasswdToKey(passwdHash, blockKey, encryptionInfo)
+	salt, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
+	assert.NoError(t, err)
+	encryptedKeyValue, err := base64.StdEncoding.DecodeString(encryptedKey.EncryptedKeyValue)
+	assert.NoError(t, err)
+	packageKey, err := decrypt(key, salt, encryptedKeyValue)
+	assert.NoError(t, err)
+	var hmacKeyValue [2][]byte
+	for idx, value := range [][2]interface{}{
+		{hmacKeyBlockKey, encryptionInfo.DataIntegrity.EncryptedHmacKey},
+		{hmacValueBlockKey, encryptionInfo.DataIntegrity.EncryptedHmacValue},
+	} {
+		iv, err := createIV(value[0], encryptionInfo)
+		assert.NoError(t, err)
+		buf, err := base64.StdEncoding.DecodeString(value[1].(string))
+		assert.NoError(t, err)
+		hmacKeyValue[idx], err = decrypt(packageKey, iv, buf)
+		assert.NoError(t, err)
+	}
+	handler := hmac.New(sha512.New, hmacKeyValue[0][:sha512.Size])
+	_, err = handler.Write(encryptedPackageBuf)
+	assert.NoError(t, err)
+	assert.Equal(t, handler.Sum(nil), hmacKeyValue[1][:sha512.Size])
+	// Test decrypt the encrypted workbook with incorrect password
+	for _, password := range []string{"", "password"} {
+		_, err = OpenFile(filepath.Join("test", "Encryption.xlsx"), Options{Password: password})
+		assert.Equal(t, ErrWorkbookPassword, err)
+	}
 	f, err = OpenFile(filepath.Join("test", "Encryption.xlsx"), Options{Password: "passwd"})
 	assert.NoError(t, err)
 	cell, err = f.GetCellValue("Sheet1", "A1")
@@ -55,9 +103,9 @@ func TestEncrypt(t *testing.T) {
 	assert.NoError(t, f.Save(Options{Password: ""}))
 	assert.NoError(t, f.Close())
 
-	doc, err := mscfb.New(bytes.NewReader(raw))
+	doc, err = mscfb.New(bytes.NewReader(raw))
 	assert.NoError(t, err)
-	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	encryptionInfoBuf, encryptedPackageBuf = extractPart(doc)
 	binary.LittleEndian.PutUint64(encryptionInfoBuf[20:32], uint64(0))
 	_, err = standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, &Options{Password: "password"})
 	assert.NoError(t, err)
@@ -65,7 +113,7 @@ func TestEncrypt(t *testing.T) {
 	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
 	_, err = agileDecrypt(encryptionInfoBuf, MacintoshCyrillicCharset, &Options{Password: "password"})
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid character entity &0 (no semicolon)")
-	_, err = convertPasswdToKey("password", nil, Encryption{
+	_, err = hashPasswd("password", Encryption{
 		KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{
 			{EncryptedKey: EncryptedKey{KeyData: KeyData{SaltValue: "=="}}},
 		}},
@@ -73,6 +121,23 @@ func TestEncrypt(t *testing.T) {
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
 	_, err = createIV([]byte{0}, Encryption{KeyData: KeyData{SaltValue: "=="}})
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+	// Test decrypt spreadsheet without password key encryptor
+	_, err = agileDecrypt(append(make([]byte, 8), []byte("<encryption/>")...), encryptedPackageBuf, &Options{Password: "password"})
+	assert.Equal(t, ErrUnsupportedEncryptMechanism, err)
+	// Test verify password with invalid verifier
+	for _, encryptedKey := range []EncryptedKey{
+		{KeyData: KeyData{SaltValue: "=="}},
+		{EncryptedVerifierHashInput: "=="},
+		{EncryptedVerifierHashInput: "AA=="},
+	} {
+		err = agileVerifyPasswd(nil, Encryption{KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{{EncryptedKey: encryptedKey}}}})
+		assert.Error(t, err)
+	}
+	// Test verify password with the salt size exceeds the verifier hash input
+	encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SaltSize = 64
+	assert.Equal(t, ErrWorkbookPassword, agileVerifyPasswd(passwdHash, encryptionInfo))
+	_, err = encrypt(nil, nil, nil)
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
 }
 
 func TestEncryptionMechanism(t *testing.T) {
@@ -15,7 +15,10 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"unicode"
 	"unicode/utf16"
+
+	"github.com/mohae/deepcopy"
 )
 
 // DataValidationType defined the type of data validation.
@@ -78,6 +81,14 @@ var formulaEscaper = strings.NewReplacer(
 	`"`, `""`,
 )
 
+// formulaXMLEscaper escapes the XML special characters in the data validation
+// formula, the quotes in the formula will be kept as it is.
+var formulaXMLEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+)
+
 // NewDataValidation return data validation struct.
 func NewDataValidation(allowBlank bool) *DataValidation {
 	return &DataValidation{
@@ -112,7 +123,9 @@ func (dv *DataValidation) SetInput(title, msg string) {
 	dv.Prompt = &msg
 }
 
-// SetDropList data validation list.
+// SetDropList data validation list. The total length of the list items
+// separated by commas is limited to 255 characters, use SetSqrefDropList to
+// create drop list with the source in the cells range for longer list.
 func (dv *DataValidation) SetDropList(keys []string) error {
 	formula := strings.Join(keys, ",")
 	if MaxFieldLength < len(utf16.Encode([]rune(formula))) {
@@ -170,11 +183,33 @@ func (dv *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 //	dv.Sqref = "A7:B8"
 //	dv.SetSqrefDropList("$E$1:$E$3")
 //	err := f.AddDataValidation("Sheet1", dv)
+//
+// The source could be the cells range on the other worksheet or a formula
+// which returns the cells range, such as "=Sheet2!$A$1:$A$10" or
+// "=INDIRECT($A$1)" for the dependent drop list, the leading equal sign is
+// optional. The source reference is not limited to 255 characters as the
+// items of the list created by SetDropList.
 func (dv *DataValidation) SetSqrefDropList(sqref string) {
-	dv.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", sqref)
+	dv.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", formulaXMLEscaper.Replace(strings.TrimPrefix(sqref, "=")))
 	dv.Type = convDataValidationType(typeList)
 }
 
+// SetCustomFormula provides a function to set the custom formula of the data
+// validation, the entered value is valid when the formula evaluates to TRUE,
+// and the leading equal sign of the formula is optional. The formula is
+// relative to the top-left cell of the data validation range. For example,
+// only allow numbers entered in Sheet1!A1:A10:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A1:A10"
+//	dv.SetCustomFormula("=ISNUMBER(A1)")
+//	err := f.AddDataValidation("Sheet1", dv)
+func (dv *DataValidation) SetCustomFormula(formula string) {
+	dv.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", formulaXMLEscaper.Replace(strings.TrimPrefix(formula, "=")))
+	dv.Formula2, dv.Operator = "", ""
+	dv.Type = convDataValidationType(DataValidationTypeCustom)
+}
+
 // SetSqref provides function to set data validation range in drop list.
 func (dv *DataValidation) SetSqref(sqref string) {
 	if dv.Sqref == "" {
@@ -246,6 +281,14 @@ func convDataValidationOperator(o DataValidationOperator) string {
 //	dv.Sqref = "A5:B6"
 //	dv.SetDropList([]string{"1", "2", "3"})
 //	err = f.AddDataValidation("Sheet1", dv)
+//
+// Example 4, set data validation on Sheet1!C1:C10 with the custom formula,
+// only allow the text which length is less than 10 characters:
+//
+//	dv = excelize.NewDataValidation(true)
+//	dv.Sqref = "C1:C10"
+//	dv.SetCustomFormula("=LEN(C1)<10")
+//	err = f.AddDataValidation("Sheet1", dv)
 func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -259,6 +302,98 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	return err
 }
 
+// AddValidationDropdownFromTable provides a function to create the drop list
+// data validation on the cell by given worksheet name, cell reference, table
+// name and column name, the items of the list are the data rows of the table
+// column, excluding the header row and the totals row. The table can be on
+// any worksheet of the workbook, and the column name is case-insensitive. A
+// workbook scope defined name referring to the data rows of the column will
+// be created as the source of the list, which is named by the table name and
+// the column name joined by an underscore, and the characters not allowed in
+// the defined name will be replaced with underscores, such as "Table1_Region"
+// for the column named Region in the table named Table1. The existing
+// defined name with the same reference will be reused. Note that the
+// reference of the defined name will not be updated when the table range
+// changes. For example, create a drop list on Sheet1!A1 with the values of
+// the column named Region in the table named Table1:
+//
+//	err := f.AddValidationDropdownFromTable("Sheet1", "A1", "Table1", "Region")
+func (f *File) AddValidationDropdownFromTable(sheet, cell, tableName, colName string) error {
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	tableSheet, t, err := f.getTable(tableName)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return newNoExistTableError(tableName)
+	}
+	col := -1
+	if t.TableColumns != nil {
+		for idx, column := range t.TableColumns.TableColumn {
+			if strings.EqualFold(column.Name, colName) {
+				col = idx
+				break
+			}
+		}
+	}
+	if col == -1 {
+		return ErrColumnNotExist
+	}
+	coordinates, err := rangeRefToCoordinates(t.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	headerRowCount := 1
+	if t.HeaderRowCount != nil {
+		headerRowCount = *t.HeaderRowCount
+	}
+	firstRow, lastRow := coordinates[1]+headerRowCount, coordinates[3]-t.TotalsRowCount
+	if lastRow < firstRow {
+		lastRow = firstRow
+	}
+	firstCell, _ := CoordinatesToCellName(coordinates[0]+col, firstRow, true)
+	lastCell, err := CoordinatesToCellName(coordinates[0]+col, lastRow, true)
+	if err != nil {
+		return err
+	}
+	definedName := &DefinedName{
+		Name: t.Name + "_" + strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return '_'
+		}, t.TableColumns.TableColumn[col].Name),
+		RefersTo: tableSheet + "!" + firstCell + ":" + lastCell,
+	}
+	if err = f.SetDefinedName(definedName); err != nil {
+		if err != ErrDefinedNameDuplicate {
+			return err
+		}
+		var exist bool
+		for _, dn := range f.GetDefinedName() {
+			if exist = dn.Scope == "Workbook" && dn.Name == definedName.Name &&
+				dn.RefersTo == quoteDefinedNameRef(definedName.RefersTo); exist {
+				break
+			}
+		}
+		if !exist {
+			return err
+		}
+	}
+	dv := NewDataValidation(true)
+	dv.Sqref = cell
+	dv.SetSqrefDropList(definedName.Name)
+	return f.AddDataValidation(sheet, dv)
+}
+
 // GetDataValidations returns data validations list by given worksheet name.
 func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -268,7 +403,17 @@ func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
 	if ws.DataValidations == nil || len(ws.DataValidations.DataValidation) == 0 {
 		return nil, err
 	}
-	return ws.DataValidations.DataValidation, err
+	dataValidations := make([]*DataValidation, 0, len(ws.DataValidations.DataValidation))
+	for _, dataValidation := range ws.DataValidations.DataValidation {
+		dv := deepcopy.Copy(dataValidation).(*DataValidation)
+		// Both of the formulas are decoded into the Formula1 from the
+		// worksheet part, split the second formula of the data validation
+		if idx := strings.Index(dv.Formula1, "<formula2>"); idx != -1 && dv.Formula2 == "" {
+			dv.Formula1, dv.Formula2 = dv.Formula1[:idx], dv.Formula1[idx:]
+		}
+		dataValidations = append(dataValidations, dv)
+	}
+	return dataValidations, err
 }
 
 // DeleteDataValidation delete data validation by given worksheet name and
@@ -105,6 +105,65 @@ func TestDataValidation(t *testing.T) {
 	assert.Equal(t, []*DataValidation(nil), dataValidations)
 }
 
+func TestDataValidationFormula(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A10"
+	assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	dv.SetCustomFormula("=AND(ISNUMBER(A1),A1<>\"\")")
+	dv.SetInput("input title", "input body")
+	assert.Equal(t, "<formula1>AND(ISNUMBER(A1),A1&lt;&gt;\"\")</formula1>", dv.Formula1)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "B1:B10"
+	dv.SetSqrefDropList("=Sheet2!$A$1:$A$10")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "C1:C10"
+	dv.SetSqrefDropList("INDIRECT($B1)")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "D1"
+	assert.NoError(t, dv.SetRange(10, 20, DataValidationTypeDecimal, DataValidationOperatorNotBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	path := filepath.Join("test", "TestDataValidationFormula.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	dataValidations, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dataValidations, 4)
+	assert.Equal(t, "custom", dataValidations[0].Type)
+	assert.Empty(t, dataValidations[0].Operator)
+	assert.True(t, dataValidations[0].ShowInputMessage)
+	assert.Equal(t, "input title", *dataValidations[0].PromptTitle)
+	assert.Equal(t, "<formula1>AND(ISNUMBER(A1),A1&lt;&gt;\"\")</formula1>", dataValidations[0].Formula1)
+	assert.Equal(t, "<formula1>Sheet2!$A$1:$A$10</formula1>", dataValidations[1].Formula1)
+	assert.Equal(t, "<formula1>INDIRECT($B1)</formula1>", dataValidations[2].Formula1)
+	// Test get data validation with both of the formulas
+	assert.Equal(t, "<formula1>10</formula1>", dataValidations[3].Formula1)
+	assert.Equal(t, "<formula2>20</formula2>", dataValidations[3].Formula2)
+	// Test the stored data validations are not modified by getting or
+	// changing the returned data validations
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "<formula1>10</formula1><formula2>20</formula2>", ws.(*xlsxWorksheet).DataValidations.DataValidation[3].Formula1)
+	*dataValidations[0].PromptTitle = "changed"
+	assert.Equal(t, "input title", *ws.(*xlsxWorksheet).DataValidations.DataValidation[0].PromptTitle)
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	dataValidations, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "<formula2>20</formula2>", dataValidations[3].Formula2)
+	assert.NoError(t, f.Close())
+}
+
 func TestDataValidationError(t *testing.T) {
 	resultFile := filepath.Join("test", "TestDataValidationError.xlsx")
 
@@ -216,3 +275,51 @@ func TestDeleteDataValidation(t *testing.T) {
 	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
 	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
 }
+
+func TestAddValidationDropdownFromTable(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Data Sheet")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Data Sheet", "B2", &[]interface{}{"Region Name", "Sales"}))
+	assert.NoError(t, f.AddTable("Data Sheet", &Table{
+		Range:         "B2:C6",
+		Name:          "Regions",
+		ShowTotalsRow: true,
+		Columns:       []TableColumn{{Name: "Sales", TotalsRowFunction: "sum"}},
+	}))
+	assert.NoError(t, f.AddValidationDropdownFromTable("Sheet1", "A1", "Regions", "region name"))
+	// Test add drop list with the same table column to reuse the defined name,
+	// and the table name is case insensitive
+	assert.NoError(t, f.AddValidationDropdownFromTable("Sheet1", "A2", "REGIONS", "Region Name"))
+	assert.Equal(t, []DefinedName{
+		{Name: "Regions_Region_Name", RefersTo: "'Data Sheet'!$B$3:$B$5", Scope: "Workbook"},
+	}, f.GetDefinedName())
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	for i, sqref := range []string{"A1", "A2"} {
+		assert.Equal(t, sqref, dvs[i].Sqref)
+		assert.Equal(t, "list", dvs[i].Type)
+		assert.Equal(t, "<formula1>Regions_Region_Name</formula1>", dvs[i].Formula1)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddValidationDropdownFromTable.xlsx")))
+	// Test add drop list with the defined name already exists
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Regions_Sales", RefersTo: "Sheet1!$A$1"}))
+	assert.Equal(t, ErrDefinedNameDuplicate, f.AddValidationDropdownFromTable("Sheet1", "B1", "Regions", "Sales"))
+	// Test add drop list with invalid parameters
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddValidationDropdownFromTable("Sheet1", "A", "Regions", "Sales"))
+	assert.EqualError(t, f.AddValidationDropdownFromTable("SheetN", "A1", "Regions", "Sales"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddValidationDropdownFromTable("Sheet1", "A1", "TableN", "Sales"), "table TableN does not exist")
+	assert.Equal(t, ErrColumnNotExist, f.AddValidationDropdownFromTable("Sheet1", "A1", "Regions", "Revenue"))
+	// Test add drop list with the table without header row and data rows
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table name="Regions" ref="B2:C2" headerRowCount="0"><tableColumns count="2"><tableColumn id="1" name="Region Name"/><tableColumn id="2" name="Amount"/></tableColumns></table>`))
+	assert.NoError(t, f.AddValidationDropdownFromTable("Sheet1", "C1", "Regions", "Amount"))
+	assert.Equal(t, "'Data Sheet'!$C$2:$C$2", f.GetDefinedName()[2].RefersTo)
+	// Test add drop list with invalid table range reference
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table name="Regions" ref="B2"><tableColumns count="1"><tableColumn id="1" name="Region"/></tableColumns></table>`))
+	assert.Equal(t, ErrParameterInvalid, f.AddValidationDropdownFromTable("Sheet1", "A1", "Regions", "Region"))
+	// Test add drop list with unsupported charset table
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddValidationDropdownFromTable("Sheet1", "A1", "Regions", "Region"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
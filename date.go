@@ -141,13 +141,17 @@ func timeFromExcelTime(excelTime float64, date1904 bool) time.Time {
 		const OFFSET1900 = 15018.0
 		const OFFSET1904 = 16480.0
 		const MJD0 float64 = 2400000.5
-		var date time.Time
 		if date1904 {
-			date = julianDateToGregorianTime(MJD0, excelTime+OFFSET1904)
-		} else {
-			date = julianDateToGregorianTime(MJD0, excelTime+OFFSET1900)
+			return julianDateToGregorianTime(MJD0, excelTime+OFFSET1904)
+		}
+		// Excel treats the year 1900 as a leap year for compatibility with
+		// Lotus 1-2-3, the serial numbers from 1 to 59 are one day behind the
+		// actual days since 30th December 1899, and the fictitious 29th
+		// February 1900 (serial number 60) is treated as 28th February 1900.
+		if wholeDaysPart >= 1 && wholeDaysPart < 60 {
+			excelTime++
 		}
-		return date
+		return julianDateToGregorianTime(MJD0, excelTime+OFFSET1900)
 	}
 	floatPart := excelTime - float64(wholeDaysPart) + roundEpsilon
 	if date1904 {
@@ -163,12 +167,29 @@ func timeFromExcelTime(excelTime float64, date1904 bool) time.Time {
 	return date.Truncate(time.Second)
 }
 
-// ExcelDateToTime converts a float-based excel date representation to a time.Time.
-func ExcelDateToTime(excelDate float64, use1904Format bool) (time.Time, error) {
+// ExcelDateToTime converts a float-based excel date representation to a
+// time.Time. The is1904 specifies whether the serial number is based on the
+// 1904 date system, which can be read by the Date1904 field of the
+// GetWorkbookProps function result.
+func ExcelDateToTime(excelDate float64, is1904 bool) (time.Time, error) {
 	if excelDate < 0 {
 		return time.Time{}, newInvalidExcelDateError(excelDate)
 	}
-	return timeFromExcelTime(excelDate, use1904Format), nil
+	return timeFromExcelTime(excelDate, is1904), nil
+}
+
+// TimeToExcelDate converts a time.Time to a float-based excel date
+// representation. The is1904 specifies whether to use the 1904 date system,
+// and 0 will be returned for the time before the first day of the date
+// system. In the 1900 date system, the time on 30th December 1899 will be
+// converted to the serial number below 1, the same as the ExcelDateToTime
+// function converts such serial number.
+func TimeToExcelDate(t time.Time, is1904 bool) float64 {
+	if !is1904 && !t.Before(excel1900Epoc) && t.Before(excelMinTime1900) {
+		return float64(t.Sub(excel1900Epoc)) / float64(dayNanoseconds)
+	}
+	excelDate, _ := timeToExcelTime(t, is1904)
+	return excelDate
 }
 
 // isLeapYear determine if leap year for a given year.
@@ -110,7 +110,68 @@ func TestExcelDateToTime(t *testing.T) {
 			assert.NoError(t, err)
 		})
 	}
+	// Check the boundaries of the 1900 and 1904 date systems
+	for _, test := range []struct {
+		excelDate float64
+		is1904    bool
+		expected  time.Time
+	}{
+		{1, false, time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{59, false, time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		{59.5, false, time.Date(1900, time.February, 28, 12, 0, 0, 0, time.UTC)},
+		{60, false, time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		{61, false, time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{1462, false, time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{73416, false, time.Date(2101, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{0, true, time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{59, true, time.Date(1904, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{71954, true, time.Date(2101, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		timeValue, err := ExcelDateToTime(test.excelDate, test.is1904)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, timeValue, test.excelDate)
+	}
 	// Check error case
 	_, err := ExcelDateToTime(-1, false)
 	assert.EqualError(t, err, newInvalidExcelDateError(-1).Error())
 }
+
+func TestTimeToExcelDate(t *testing.T) {
+	for _, test := range []struct {
+		date     time.Time
+		is1904   bool
+		expected float64
+	}{
+		{time.Date(1899, time.December, 29, 0, 0, 0, 0, time.UTC), false, 0},
+		{time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC), false, 1},
+		{time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC), false, 59},
+		{time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC), false, 61},
+		{time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC), false, 1462},
+		{time.Date(2101, time.January, 1, 0, 0, 0, 0, time.UTC), false, 73416},
+		{time.Date(1903, time.December, 31, 0, 0, 0, 0, time.UTC), true, 0},
+		{time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC), true, 0},
+		{time.Date(1904, time.January, 2, 12, 0, 0, 0, time.UTC), true, 1.5},
+		{time.Date(2101, time.January, 1, 0, 0, 0, 0, time.UTC), true, 71954},
+	} {
+		excelDate := TimeToExcelDate(test.date, test.is1904)
+		assert.Equal(t, test.expected, excelDate, test.date.String())
+		// Test convert the serial number back to the time
+		if excelDate > 0 {
+			timeValue, err := ExcelDateToTime(excelDate, test.is1904)
+			assert.NoError(t, err)
+			assert.Equal(t, test.date, timeValue)
+		}
+	}
+	// Test convert the fractional serial numbers to the time and back
+	for _, test := range []struct {
+		excelDate float64
+		is1904    bool
+	}{
+		{0.25, false}, {0.5, false}, {1.75, false}, {59.5, false},
+		{61.25, false}, {43559.5, false}, {0.5, true}, {1.25, true},
+	} {
+		timeValue, err := ExcelDateToTime(test.excelDate, test.is1904)
+		assert.NoError(t, err)
+		assert.Equal(t, test.excelDate, TimeToExcelDate(timeValue, test.is1904), timeValue.String())
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"time"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -275,3 +276,28 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}
 	return
 }
+
+// SetWorkbookCreated provides a function to set the created time of the
+// workbook in the document core properties by given time, the time will be
+// converted to UTC and stored in the W3CDTF format. This is useful to generate
+// the workbook with deterministic properties. For example:
+//
+//	err := f.SetWorkbookCreated(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+//
+// 设置工作簿的创建时间
+func (f *File) SetWorkbookCreated(t time.Time) error {
+	return f.SetDocProps(&DocProperties{Created: t.UTC().Format(time.RFC3339)})
+}
+
+// GetWorkbookCreated provides a function to get the created time of the
+// workbook in the document core properties, the time will be returned in UTC.
+// It returns the zero time if the created time doesn't exist.
+// 获取工作簿的创建时间
+func (f *File) GetWorkbookCreated() (time.Time, error) {
+	props, err := f.GetDocProps()
+	if err != nil || props.Created == "" {
+		return time.Time{}, err
+	}
+	created, err := time.Parse(time.RFC3339, props.Created)
+	return created.UTC(), err
+}
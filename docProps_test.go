@@ -14,6 +14,7 @@ package excelize
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -116,3 +117,31 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestWorkbookCreated(t *testing.T) {
+	f := NewFile()
+	// Test get the created time without core properties
+	f.Pkg.Store(defaultXMLPathDocPropsCore, nil)
+	created, err := f.GetWorkbookCreated()
+	assert.NoError(t, err)
+	assert.True(t, created.IsZero())
+	assert.NoError(t, f.SetDocProps(&DocProperties{Creator: "Go Excelize"}))
+	assert.NoError(t, f.SetWorkbookCreated(time.Date(2023, 1, 2, 11, 4, 5, 0, time.FixedZone("UTC+8", 8*60*60))))
+	created, err = f.GetWorkbookCreated()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), created)
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-01-02T03:04:05Z", props.Created)
+	assert.Equal(t, "Go Excelize", props.Creator)
+	// Test get the created time with invalid time
+	assert.NoError(t, f.SetDocProps(&DocProperties{Created: "2023-01-02"}))
+	_, err = f.GetWorkbookCreated()
+	assert.Error(t, err)
+	// Test set and get the created time with unsupported charset
+	f.Pkg.Store(defaultXMLPathDocPropsCore, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookCreated(time.Now()), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetWorkbookCreated()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
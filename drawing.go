@@ -64,7 +64,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		RoundedCorners: &attrValBool{Val: boolPtr(false)},
 		Chart: cChart{
 			Title: &cTitle{
-				Tx: cTx{
+				Tx: &cTx{
 					Rich: &cRich{
 						P: aP{
 							PPr: &aPPr{
@@ -105,7 +105,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 						},
 					},
 				},
-				TxPr: cTxPr{
+				TxPr: &cTxPr{
 					P: aP{
 						PPr: &aPPr{
 							DefRPr: aRPr{
@@ -137,13 +137,14 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 			BackWall: &cThicknessSpPr{
 				Thickness: &attrValInt{Val: intPtr(0)},
 			},
-			PlotArea: &cPlotArea{},
+			PlotArea: &cPlotArea{Layout: f.drawChartLayout(opts.PlotArea.Layout)},
 			Legend: &cLegend{
 				LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
-				Overlay:   &attrValBool{Val: boolPtr(false)},
+				Overlay:   &attrValBool{Val: boolPtr(opts.Legend.Overlay)},
+				TxPr:      f.drawChartLegendTxPr(opts.Legend.Font),
 			},
 
-			PlotVisOnly:      &attrValBool{Val: boolPtr(false)},
+			PlotVisOnly:      &attrValBool{Val: boolPtr(opts.PlotArea.PlotVisOnly)},
 			DispBlanksAs:     &attrValString{Val: stringPtr(opts.ShowBlanksAs)},
 			ShowDLblsOverMax: &attrValBool{Val: boolPtr(false)},
 		},
@@ -262,6 +263,154 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 	f.saveFileList(media, chart)
 }
 
+// addChartEx provides a function to create chart as xl/charts/chartEx%d.xml
+// by given format sets, this used to create the waterfall, treemap and
+// sunburst chart.
+func (f *File) addChartEx(opts *Chart, chartExID int) error {
+	series := opts.Series[0]
+	data := &cxData{NumDim: &cxDim{Type: "size", F: series.Values}}
+	if opts.Type == Waterfall {
+		data.NumDim.Type = "val"
+	}
+	if series.Categories != "" {
+		data.StrDim = &cxDim{Type: "cat", F: series.Categories}
+	}
+	ser := &cxSeries{LayoutID: chartExLayoutIDs[opts.Type], DataID: &attrValInt{Val: intPtr(0)}}
+	if series.Name != "" {
+		ser.Tx = &cxTx{TxData: &cxTxData{F: series.Name}}
+	}
+	if color := series.Fill.Color; len(color) == 1 {
+		ser.SpPr = &cSpPr{SolidFill: &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(color[0], "#"))}}}
+	}
+	if opts.PlotArea.ShowSerName || opts.PlotArea.ShowCatName || opts.PlotArea.ShowVal {
+		ser.DataLabels = &cxDataLabels{
+			Pos: chartExDataLabelsPos[opts.Type],
+			Visibility: &cxDataLabelsVisibility{
+				SeriesName:   opts.PlotArea.ShowSerName,
+				CategoryName: opts.PlotArea.ShowCatName,
+				Value:        opts.PlotArea.ShowVal,
+			},
+		}
+	}
+	chartSpace := xlsxChartExSpace{
+		XMLNSa:    NameSpaceDrawingML.Value,
+		XMLNSr:    SourceRelationship.Value,
+		XMLNScx:   NameSpaceDrawingMLChartEx.Value,
+		ChartData: cxChartData{Data: []*cxData{data}},
+		Chart: cxChart{
+			PlotArea: cxPlotArea{
+				PlotAreaRegion: cxPlotAreaRegion{Series: []*cxSeries{ser}},
+			},
+		},
+	}
+	switch opts.Type {
+	case Waterfall:
+		if err := f.drawWaterfallSeries(opts, ser); err != nil {
+			return err
+		}
+		chartSpace.Chart.PlotArea.Axis = f.drawChartExAxis(opts)
+	case Treemap:
+		ser.LayoutPr = &cxLayoutPr{ParentLabelLayout: &attrValString{Val: stringPtr("overlapping")}}
+	}
+	if name := strings.TrimSpace(opts.Title.Name); name != "" {
+		chartSpace.Chart.Title = &cxTitle{Pos: "t", Align: "ctr", Tx: &cxTx{TxData: &cxTxData{V: opts.Title.Name}}}
+	}
+	if opts.Legend.Position != "none" {
+		pos, ok := chartLegendPosition[opts.Legend.Position]
+		if !ok {
+			pos = chartLegendPosition[defaultChartLegendPosition]
+		}
+		if pos == "tr" {
+			pos = "r"
+		}
+		chartSpace.Chart.Legend = &cxLegend{Pos: pos, Align: "ctr", Overlay: opts.Legend.Overlay}
+	}
+	chartEx, _ := xml.Marshal(chartSpace)
+	f.saveFileList("xl/charts/chartEx"+strconv.Itoa(chartExID)+".xml", chartEx)
+	return nil
+}
+
+// drawWaterfallSeries provides a function to draw the data points and layout
+// properties of the waterfall chart series by given format sets.
+func (f *File) drawWaterfallSeries(opts *Chart, ser *cxSeries) error {
+	dataPt, err := f.drawWaterfallDataPt(opts)
+	if err != nil {
+		return err
+	}
+	ser.DataPt = dataPt
+	ser.LayoutPr = &cxLayoutPr{
+		Visibility: &cxSeriesVisibility{
+			ConnectorLines: opts.Waterfall.ConnectorLines == nil || *opts.Waterfall.ConnectorLines,
+		},
+	}
+	for idx, isTotal := range opts.Waterfall.IsTotal {
+		if isTotal {
+			if ser.LayoutPr.Subtotals == nil {
+				ser.LayoutPr.Subtotals = &cxSubtotals{}
+			}
+			ser.LayoutPr.Subtotals.Idx = append(ser.LayoutPr.Subtotals.Idx, &attrValInt{Val: intPtr(idx)})
+		}
+	}
+	return err
+}
+
+// drawChartExAxis provides a function to draw the category and value axis of
+// the chartEx part by given format sets.
+func (f *File) drawChartExAxis(opts *Chart) []*cxAxis {
+	valAx := &cxAxis{ID: 1, Hidden: opts.YAxis.None, ValScaling: &cxValScaling{}, TickLabels: &cxTickLabels{}}
+	if opts.YAxis.Maximum != nil && *opts.YAxis.Maximum != 0 {
+		valAx.ValScaling.Max = strconv.FormatFloat(*opts.YAxis.Maximum, 'f', -1, 64)
+	}
+	if opts.YAxis.Minimum != nil && *opts.YAxis.Minimum != 0 {
+		valAx.ValScaling.Min = strconv.FormatFloat(*opts.YAxis.Minimum, 'f', -1, 64)
+	}
+	if opts.YAxis.MajorGridLines {
+		valAx.MajorGridlines = &cxGridlines{}
+	}
+	if opts.YAxis.MinorGridLines {
+		valAx.MinorGridlines = &cxGridlines{}
+	}
+	return []*cxAxis{
+		{ID: 0, Hidden: opts.XAxis.None, CatScaling: &cxCatScaling{GapWidth: "0.5"}, TickLabels: &cxTickLabels{}},
+		valAx,
+	}
+}
+
+// drawWaterfallDataPt provides a function to draw the data points of the
+// waterfall chart by given format sets. The increase, decrease and total color
+// was applied to each data point depending on the cell values of the series.
+func (f *File) drawWaterfallDataPt(opts *Chart) ([]*cxDataPt, error) {
+	var dataPt []*cxDataPt
+	wf := opts.Waterfall
+	if wf.IncreaseColor == "" && wf.DecreaseColor == "" && wf.TotalColor == "" {
+		return dataPt, nil
+	}
+	values, err := f.getChartSeriesValues(opts.Series[0].Values)
+	if err != nil {
+		return dataPt, err
+	}
+	for idx, val := range values {
+		color := wf.IncreaseColor
+		if idx < len(wf.IsTotal) && wf.IsTotal[idx] {
+			color = wf.TotalColor
+		} else if num, err := strconv.ParseFloat(val, 64); err == nil && num < 0 {
+			color = wf.DecreaseColor
+		}
+		if color == "" {
+			continue
+		}
+		dataPt = append(dataPt, &cxDataPt{
+			Idx: idx,
+			SpPr: &cSpPr{
+				SolidFill: &aSolidFill{
+					SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(strings.ToUpper(color), "#"))},
+				},
+			},
+		})
+	}
+	return dataPt, err
+}
+
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
 // and column series charts by given format sets.
 func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
@@ -511,8 +660,8 @@ func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
 // drawDoughnutChart provides a function to draw the c:plotArea element for
 // doughnut chart by given format sets.
 func (f *File) drawDoughnutChart(opts *Chart) *cPlotArea {
-	holeSize := 75
-	if opts.HoleSize > 0 && opts.HoleSize <= 90 {
+	holeSize := defaultChartHoleSize
+	if opts.HoleSize >= 10 && opts.HoleSize <= 90 {
 		holeSize = opts.HoleSize
 	}
 
@@ -521,12 +670,23 @@ func (f *File) drawDoughnutChart(opts *Chart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: opts.VaryColors,
 			},
-			Ser:      f.drawChartSeries(opts),
-			HoleSize: &attrValInt{Val: intPtr(holeSize)},
+			Ser:           f.drawChartSeries(opts),
+			FirstSliceAng: f.drawChartFirstSliceAng(opts),
+			HoleSize:      &attrValInt{Val: intPtr(holeSize)},
 		},
 	}
 }
 
+// drawChartFirstSliceAng provides a function to draw the c:firstSliceAng
+// element for pie and doughnut chart by given format sets.
+func (f *File) drawChartFirstSliceAng(opts *Chart) *attrValInt {
+	var angle int
+	if opts.FirstSliceAngle > 0 && opts.FirstSliceAngle <= 359 {
+		angle = opts.FirstSliceAngle
+	}
+	return &attrValInt{Val: intPtr(angle)}
+}
+
 // drawLineChart provides a function to draw the c:plotArea element for line
 // chart by given format sets.
 func (f *File) drawLineChart(opts *Chart) *cPlotArea {
@@ -538,8 +698,9 @@ func (f *File) drawLineChart(opts *Chart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: boolPtr(false),
 			},
-			Ser:   f.drawChartSeries(opts),
-			DLbls: f.drawChartDLbls(opts),
+			Ser:    f.drawChartSeries(opts),
+			DLbls:  f.drawChartDLbls(opts),
+			Smooth: &attrValBool{Val: boolPtr(opts.Smooth)},
 			AxID: []*attrValInt{
 				{Val: intPtr(754001152)},
 				{Val: intPtr(753999904)},
@@ -581,7 +742,8 @@ func (f *File) drawPieChart(opts *Chart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: opts.VaryColors,
 			},
-			Ser: f.drawChartSeries(opts),
+			Ser:           f.drawChartSeries(opts),
+			FirstSliceAng: f.drawChartFirstSliceAng(opts),
 		},
 	}
 }
@@ -803,8 +965,9 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 			DPt:              f.drawChartSeriesDPt(k, opts),
 			DLbls:            f.drawChartSeriesDLbls(opts),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
+			Trendline:        f.drawChartSeriesTrendline(opts.Series[k], opts),
 			Cat:              f.drawChartSeriesCat(opts.Series[k], opts),
-			Smooth:           &attrValBool{Val: boolPtr(opts.Series[k].Line.Smooth)},
+			Smooth:           &attrValBool{Val: boolPtr(opts.Smooth || opts.Series[k].Line.Smooth)},
 			Val:              f.drawChartSeriesVal(opts.Series[k], opts),
 			XVal:             f.drawChartSeriesXVal(opts.Series[k], opts),
 			YVal:             f.drawChartSeriesYVal(opts.Series[k], opts),
@@ -831,7 +994,7 @@ func (f *File) drawChartSeriesSpPr(i int, opts *Chart) *cSpPr {
 	spPrScatter := &cSpPr{
 		Ln: &aLn{
 			W:      25400,
-			NoFill: " ",
+			NoFill: stringPtr(""),
 		},
 	}
 	spPrLine := &cSpPr{
@@ -855,6 +1018,56 @@ func (f *File) drawChartSeriesSpPr(i int, opts *Chart) *cSpPr {
 	return nil
 }
 
+// drawChartSeriesTrendline provides a function to draw the c:trendline
+// element by given data series and format sets.
+func (f *File) drawChartSeriesTrendline(v ChartSeries, opts *Chart) []*cTrendline {
+	if !chartTrendLineSupported[opts.Type] {
+		return nil
+	}
+	if trendline := newChartTrendline(v.TrendLine); trendline != nil {
+		return []*cTrendline{trendline}
+	}
+	return nil
+}
+
+// newChartTrendline provides a function to create the c:trendline element by
+// given trend line format settings, it returns nil if the trend line type is
+// unknown.
+func newChartTrendline(opts ChartTrendLine) *cTrendline {
+	trendLineType, ok := chartTrendLineTypes[opts.Type]
+	if !ok {
+		return nil
+	}
+	trendline := &cTrendline{TrendlineType: &attrValString{Val: stringPtr(trendLineType)}}
+	switch trendLineType {
+	case "poly":
+		order := opts.Order
+		if order < 2 || order > 6 {
+			order = 2
+		}
+		trendline.Order = &attrValInt{Val: intPtr(order)}
+	case "movingAvg":
+		period := opts.Period
+		if period < 2 || period > 255 {
+			period = 2
+		}
+		trendline.Period = &attrValInt{Val: intPtr(period)}
+		return trendline
+	}
+	if opts.Forward > 0 {
+		trendline.Forward = &attrValFloat{Val: float64Ptr(opts.Forward)}
+	}
+	if opts.Backward > 0 {
+		trendline.Backward = &attrValFloat{Val: float64Ptr(opts.Backward)}
+	}
+	if opts.Intercept != nil && inStrSlice([]string{"exp", "linear", "poly"}, trendLineType, true) != -1 {
+		trendline.Intercept = &attrValFloat{Val: float64Ptr(*opts.Intercept)}
+	}
+	trendline.DispRSqr = &attrValBool{Val: boolPtr(opts.DisplayRSquared)}
+	trendline.DispEq = &attrValBool{Val: boolPtr(opts.DisplayEquation)}
+	return trendline
+}
+
 // drawChartSeriesDPt provides a function to draw the c:dPt element by given
 // data index and format sets.
 func (f *File) drawChartSeriesDPt(i int, opts *Chart) []*cDPt {
@@ -945,10 +1158,45 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 			},
 		}
 	}
+	setChartMarkerColors(marker, opts.Series[i].Marker)
 	chartSeriesMarker := map[ChartType]*cMarker{Scatter: marker, Line: marker}
 	return chartSeriesMarker[opts.Type]
 }
 
+// checkChartMarkerColors provides a function to check the fill and border
+// color of the marker, the colors should be in the hex RGB format.
+func checkChartMarkerColors(opts ChartMarker) error {
+	for _, color := range []string{opts.FillColor, opts.BorderColor} {
+		if rgb := strings.TrimPrefix(color, "#"); color != "" {
+			if _, err := strconv.ParseUint(rgb, 16, 32); err != nil || len(rgb) != 6 {
+				return ErrParameterInvalid
+			}
+		}
+	}
+	return nil
+}
+
+// setChartMarkerColors provides a function to set the fill and border color
+// of the c:marker element by given marker format sets.
+func setChartMarkerColors(marker *cMarker, opts ChartMarker) {
+	if opts.FillColor == "" && opts.BorderColor == "" {
+		return
+	}
+	if marker.SpPr == nil {
+		marker.SpPr = &cSpPr{}
+	}
+	if opts.FillColor != "" {
+		marker.SpPr.NoFill = nil
+		marker.SpPr.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(strings.TrimPrefix(opts.FillColor, "#")))}}
+	}
+	if opts.BorderColor != "" {
+		if marker.SpPr.Ln == nil {
+			marker.SpPr.Ln = &aLn{W: 9525}
+		}
+		marker.SpPr.Ln.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(strings.TrimPrefix(opts.BorderColor, "#")))}}
+	}
+}
+
 // drawChartSeriesXVal provides a function to draw the c:xVal element by given
 // chart series and format sets.
 func (f *File) drawChartSeriesXVal(v ChartSeries, opts *Chart) *cCat {
@@ -1008,6 +1256,25 @@ func (f *File) drawChartNumFmt(labels ChartNumFmt) *cNumFmt {
 	return numFmt
 }
 
+// drawChartLayout provides a function to draw the c:layout element by given
+// manual layout format sets.
+func (f *File) drawChartLayout(layout *ChartLayout) *cLayout {
+	if layout == nil {
+		return nil
+	}
+	return &cLayout{
+		ManualLayout: &cManualLayout{
+			LayoutTarget: &attrValString{Val: stringPtr("inner")},
+			XMode:        &attrValString{Val: stringPtr("edge")},
+			YMode:        &attrValString{Val: stringPtr("edge")},
+			X:            &attrValFloat{Val: float64Ptr(layout.X)},
+			Y:            &attrValFloat{Val: float64Ptr(layout.Y)},
+			W:            &attrValFloat{Val: float64Ptr(layout.Width)},
+			H:            &attrValFloat{Val: float64Ptr(layout.Height)},
+		},
+	}
+}
+
 // drawChartDLbls provides a function to draw the c:dLbls element by given
 // format sets.
 func (f *File) drawChartDLbls(opts *Chart) *cDLbls {
@@ -1239,6 +1506,28 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 	return cTxPr
 }
 
+// drawChartLegendTxPr provides a function to draw the c:txPr element of the
+// chart legend by given font settings.
+func (f *File) drawChartLegendTxPr(font *Font) *cTxPr {
+	if font == nil {
+		return nil
+	}
+	txPr := f.drawPlotAreaTxPr(&ChartAxis{Font: *font})
+	txPr.BodyPr.Rot = 0
+	if font.Size > 0 {
+		txPr.P.PPr.DefRPr.Sz = font.Size * 100
+	}
+	if font.Family != "" {
+		txPr.P.PPr.DefRPr.Latin = &xlsxCTTextFont{Typeface: font.Family}
+		txPr.P.PPr.DefRPr.Ea = &aEa{Typeface: font.Family}
+		txPr.P.PPr.DefRPr.Cs = &aCs{Typeface: font.Family}
+	}
+	if font.Strike {
+		txPr.P.PPr.DefRPr.Strike = "sngStrike"
+	}
+	return txPr
+}
+
 // drawingParser provides a function to parse drawingXML. In order to solve
 // the problem that the label structure is changed after serialization and
 // deserialization, two different structures: decodeWsDr and encodeWsDr are
@@ -1293,6 +1582,80 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 // addDrawingChart provides a function to add chart graphic frame by given
 // sheet, drawingXML, cell, width, height, relationship index and format sets.
 func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rID int, opts *GraphicOptions) error {
+	return f.addDrawingChartFrame(sheet, drawingXML, cell, width, height, opts, func(cNvPrID int) string {
+		graphicFrame := xlsxGraphicFrame{
+			NvGraphicFramePr: xlsxNvGraphicFramePr{
+				CNvPr: &xlsxCNvPr{
+					ID:   cNvPrID,
+					Name: "Chart " + strconv.Itoa(cNvPrID),
+				},
+			},
+			Graphic: &xlsxGraphic{
+				GraphicData: &xlsxGraphicData{
+					URI: NameSpaceDrawingMLChart.Value,
+					Chart: &xlsxChart{
+						C:   NameSpaceDrawingMLChart.Value,
+						R:   SourceRelationship.Value,
+						RID: "rId" + strconv.Itoa(rID),
+					},
+				},
+			},
+		}
+		graphic, _ := xml.Marshal(graphicFrame)
+		return string(graphic)
+	})
+}
+
+// addDrawingChartEx provides a function to add chartEx graphic frame by given
+// sheet, drawingXML, cell, width, height, relationship index and format sets.
+// The graphic frame was wrapped by the alternate content with a shape
+// fallback for the applications which doesn't support the chartEx part.
+func (f *File) addDrawingChartEx(sheet, drawingXML, cell string, width, height, rID int, opts *GraphicOptions) error {
+	return f.addDrawingChartFrame(sheet, drawingXML, cell, width, height, opts, func(cNvPrID int) string {
+		cNvPr := &xlsxCNvPr{ID: cNvPrID, Name: "Chart " + strconv.Itoa(cNvPrID)}
+		alternateContent := xdrAlternateContent{
+			XMLNSMC: SourceRelationshipCompatibility.Value,
+			Choice: &xdrChoice{
+				XMLNSCX1: NameSpaceDrawingMLChartEx2015.Value,
+				Requires: NameSpaceDrawingMLChartEx2015.Name.Local,
+				GraphicFrame: &xlsxGraphicFrame{
+					NvGraphicFramePr: xlsxNvGraphicFramePr{CNvPr: cNvPr},
+					Graphic: &xlsxGraphic{
+						GraphicData: &xlsxGraphicData{
+							URI: NameSpaceDrawingMLChartEx.Value,
+							ChartEx: &xlsxChartEx{
+								CX:  NameSpaceDrawingMLChartEx.Value,
+								R:   SourceRelationship.Value,
+								RID: "rId" + strconv.Itoa(rID),
+							},
+						},
+					},
+				},
+			},
+			Fallback: &xdrFallback{
+				Sp: &xdrSp{
+					NvSpPr: &xdrNvSpPr{CNvPr: cNvPr, CNvSpPr: &xdrCNvSpPr{TxBox: true}},
+					SpPr:   &xlsxSpPr{PrstGeom: xlsxPrstGeom{Prst: "rect"}},
+					TxBody: &xdrTxBody{
+						BodyPr: &aBodyPr{},
+						P: []*aP{{R: &aR{
+							RPr: aRPr{Lang: "en-US", Sz: 1100},
+							T:   "This chart isn't available in your version of Excel.",
+						}}},
+					},
+				},
+			},
+		}
+		graphic, _ := xml.Marshal(alternateContent)
+		return string(graphic)
+	})
+}
+
+// addDrawingChartFrame provides a function to add the two cell anchor of the
+// chart by given sheet, drawingXML, cell, width, height, format sets and the
+// function which returns the graphic frame of the anchor by given non-visual
+// drawing properties ID.
+func (f *File) addDrawingChartFrame(sheet, drawingXML, cell string, width, height int, opts *GraphicOptions, graphicFrame func(cNvPrID int) string) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -1321,27 +1684,7 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 	to.RowOff = y2 * EMU
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
-
-	graphicFrame := xlsxGraphicFrame{
-		NvGraphicFramePr: xlsxNvGraphicFramePr{
-			CNvPr: &xlsxCNvPr{
-				ID:   cNvPrID,
-				Name: "Chart " + strconv.Itoa(cNvPrID),
-			},
-		},
-		Graphic: &xlsxGraphic{
-			GraphicData: &xlsxGraphicData{
-				URI: NameSpaceDrawingMLChart.Value,
-				Chart: &xlsxChart{
-					C:   NameSpaceDrawingMLChart.Value,
-					R:   SourceRelationship.Value,
-					RID: "rId" + strconv.Itoa(rID),
-				},
-			},
-		},
-	}
-	graphic, _ := xml.Marshal(graphicFrame)
-	twoCellAnchor.GraphicFrame = string(graphic)
+	twoCellAnchor.GraphicFrame = graphicFrame(cNvPrID)
 	twoCellAnchor.ClientData = &xdrClientData{
 		FLocksWithSheet:  *opts.Locked,
 		FPrintsWithSheet: *opts.PrintObject,
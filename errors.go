@@ -52,6 +52,12 @@ func newUnsupportedChartType(chartType ChartType) error {
 	return fmt.Errorf("unsupported chart type %d", chartType)
 }
 
+// newUnsupportedChartTrendLineType defined the error message on receiving the
+// chart trend line type are unsupported.
+func newUnsupportedChartTrendLineType(trendLineType string) error {
+	return fmt.Errorf("unsupported chart trend line type %s", trendLineType)
+}
+
 // newUnzipSizeLimitError defined the error message on unzip size exceeds the
 // limit.
 func newUnzipSizeLimitError(unzipSizeLimit int64) error {
@@ -64,12 +70,30 @@ func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newInvalidThemeColorError defined the error message on receiving the
+// invalid theme color.
+func newInvalidThemeColorError(color string) error {
+	return fmt.Errorf("invalid theme color %s, the color should be in RRGGBB hexadecimal notation", color)
+}
+
+// newUnsupportedThemeError defined the error message on receiving the not
+// supported built-in theme name.
+func newUnsupportedThemeError(name string) error {
+	return fmt.Errorf("unsupported built-in theme %s", name)
+}
+
 // newFieldLengthError defined the error message on receiving the field length
 // overflow.
 func newFieldLengthError(name string) error {
 	return fmt.Errorf("field %s must be less than or equal to 255 characters", name)
 }
 
+// newHeaderFooterFormatCodeError defined the error message on receiving the
+// malformed formatting codes in the header or footer field.
+func newHeaderFooterFormatCodeError(name string) error {
+	return fmt.Errorf("field %s contains invalid header and footer formatting code", name)
+}
+
 // newCellNameToCoordinatesError defined the error message on converts
 // alphanumeric cell name to coordinates.
 func newCellNameToCoordinatesError(cell string, err error) error {
@@ -94,19 +118,87 @@ func newStreamSetRowError(row int) error {
 	return fmt.Errorf("row %d has already been written", row)
 }
 
+// newStreamFormulaRefError defined the error message on the stream writer
+// receiving the shared or array formula range reference which doesn't
+// contain the cell.
+func newStreamFormulaRefError(cell, ref string) error {
+	return fmt.Errorf("cell %s is outside of the formula range %s", cell, ref)
+}
+
+// newUnsupportedSmartArtType defined the error message on receiving the
+// SmartArt type which is unsupported.
+func newUnsupportedSmartArtType(smartArtType SmartArtType) error {
+	return fmt.Errorf("unsupported SmartArt type %d", smartArtType)
+}
+
+// newCircularReferenceError defined the error message on receiving the formula
+// which contains a circular reference.
+func newCircularReferenceError(ref string) error {
+	return fmt.Errorf("circular reference found in the formula of the cell %s", ref)
+}
+
+// newNoExistChartError defined the error message on receiving the cell
+// reference which doesn't contain a chart.
+func newNoExistChartError(cell string) error {
+	return fmt.Errorf("no chart found in the cell %s", cell)
+}
+
+// newNoExistTableError defined the error message on receiving the table name
+// which doesn't exist in the worksheet.
+func newNoExistTableError(name string) error {
+	return fmt.Errorf("table %s does not exist", name)
+}
+
+// newNoExistPivotTableError defined the error message on receiving the pivot
+// table name which doesn't exist in the worksheet.
+func newNoExistPivotTableError(name string) error {
+	return fmt.Errorf("pivot table %s does not exist", name)
+}
+
+// newNoExistPartError defined the error message on receiving the part name
+// which doesn't exist in the spreadsheet package.
+func newNoExistPartError(partName string) error {
+	return fmt.Errorf("part %s does not exist", partName)
+}
+
 // newViewIdxError defined the error message on receiving a invalid sheet view
 // index.
 func newViewIdxError(viewIndex int) error {
 	return fmt.Errorf("view index %d out of range", viewIndex)
 }
 
+// newUnexpectedHTTPStatusError defined the error message on receiving an
+// unexpected HTTP response status code.
+func newUnexpectedHTTPStatusError(status string) error {
+	return fmt.Errorf("unexpected HTTP response status %s", status)
+}
+
+// newSortKeyOutOfRangeError defined the error message on receiving the sort
+// key column which is out of the range to be sorted.
+func newSortKeyOutOfRangeError(col, rangeRef string) error {
+	return fmt.Errorf("sort key column %s is out of range %s", col, rangeRef)
+}
+
+// newUnsupportedImageContentTypeError defined the error message on receiving
+// an unsupported image MIME type.
+func newUnsupportedImageContentTypeError(contentType string) error {
+	return fmt.Errorf("unsupported image content type %q", contentType)
+}
+
 var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
+	// ErrStreamSetColStyle defined the error message on set column style in
+	// stream writing mode.
+	ErrStreamSetColStyle = errors.New("must call the SetColStyle function before the SetRow function")
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrStreamSharedFormula defined the error message on set the shared
+	// formula in stream writer without the formula on the first cell of the
+	// shared formula range.
+	ErrStreamSharedFormula = errors.New("the first cell of the shared formula range must be set with the formula first")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf(`the column number must be greater than or equal to %d and less than or equal to %d`, MinColumns, MaxColumns)
@@ -130,6 +222,18 @@ var (
 	// ErrAddVBAProject defined the error message on add the VBA project in
 	// the workbook.
 	ErrAddVBAProject = errors.New("unsupported VBA project")
+	// ErrPictureRedirects defined the error message on the number of
+	// redirects exceeds the limit when downloading the picture.
+	ErrPictureRedirects = fmt.Errorf("stopped after %d redirects", maxPictureRedirects)
+	// ErrMaxPictureSize defined the error message on the size of the
+	// downloaded picture exceeds the limit.
+	ErrMaxPictureSize = fmt.Errorf("the size of the picture exceeds the %d bytes limit", maxPictureSize)
+	// ErrVBAProjectReferenced defined the error message on disable the VBA
+	// project which still be referenced by the macros in the workbook.
+	ErrVBAProjectReferenced = errors.New("the VBA project is still referenced by macros")
+	// ErrVBAModuleName defined the error message on receive an invalid VBA
+	// module name.
+	ErrVBAModuleName = errors.New("the VBA module name must start with a letter and contain only letters, digits and underscores, up to 31 characters")
 	// ErrMaxRows defined the error message on receive a row number exceeds maximum limit.
 	ErrMaxRows = errors.New("row number exceeds maximum limit")
 	// ErrMaxRowHeight defined the error message on receive an invalid row
@@ -162,6 +266,9 @@ var (
 	// ErrParameterRequired defined the error message on receive the empty
 	// parameter.
 	ErrParameterRequired = errors.New("parameter is required")
+	// ErrSortRangeMergedCells defined the error message on sorting the range
+	// which contains merged cells.
+	ErrSortRangeMergedCells = errors.New("cannot sort the range which contains merged cells")
 	// ErrParameterInvalid defined the error message on receive the invalid
 	// parameter.
 	ErrParameterInvalid = errors.New("parameter is invalid")
@@ -178,6 +285,21 @@ var (
 	ErrFontLength = fmt.Errorf("the length of the font family name must be less than or equal to %d", MaxFontFamilyLength)
 	// ErrFontSize defined the error message on the size of the font is invalid.
 	ErrFontSize = fmt.Errorf("font size must be between %d and %d points", MinFontSize, MaxFontSize)
+	// ErrCellIndent defined the error message on receive the invalid indent
+	// level of the cell.
+	ErrCellIndent = fmt.Errorf("indent level must be between 0 and %d", maxCellIndent)
+	// ErrThemeColor defined the error message on the theme color does not
+	// exist.
+	ErrThemeColor = errors.New("the theme color does not exist")
+	// ErrColorTint defined the error message on receive the invalid tint value
+	// of the color.
+	ErrColorTint = errors.New("tint value must be between -1 and 1")
+	// ErrGradientType defined the error message on receive the invalid
+	// gradient fill type.
+	ErrGradientType = errors.New("gradient type must be linear or path")
+	// ErrGradientStopPosition defined the error message on receive the invalid
+	// gradient stop position.
+	ErrGradientStopPosition = errors.New("gradient stop position must be between 0 and 1")
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
 	// index.
 	ErrSheetIdx = errors.New("invalid worksheet index")
@@ -241,6 +363,12 @@ var (
 	ErrNameLength = fmt.Errorf("the name length exceeds the %d characters limit", MaxFieldLength)
 	// ErrExistsTableName defined the error message on given table already exists.
 	ErrExistsTableName = errors.New("the same name table already exists")
+	// ErrColumnNotExist defined the error message on receiving the table column
+	// name which doesn't exist in the table.
+	ErrColumnNotExist = errors.New("the table column does not exist")
+	// ErrExistsSlicerName defined the error message on given slicer already
+	// exists.
+	ErrExistsSlicerName = errors.New("the same name slicer already exists")
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
 	// ErrUnprotectWorkbook defined the error message on workbook has set no
@@ -249,4 +377,26 @@ var (
 	// ErrUnprotectWorkbookPassword defined the error message on remove workbook
 	// protection with password verification failed.
 	ErrUnprotectWorkbookPassword = errors.New("workbook protect password not match")
+	// ErrFitToPages defined the error message on receive the invalid number of
+	// pages to fit the worksheet on.
+	ErrFitToPages = errors.New("the number of pages to fit on must be between 0 and 32767")
+	// ErrChartExSeries defined the error message on receive the waterfall,
+	// treemap or sunburst chart without exactly one series or combined with
+	// other charts.
+	ErrChartExSeries = errors.New("waterfall, treemap and sunburst chart must contain exactly one series and can not be combined with other charts")
+	// ErrSmartArtItems defined the error message on receive the SmartArt
+	// graphic without any items.
+	ErrSmartArtItems = errors.New("SmartArt must contain at least one item")
+	// ErrWriteToZipPassword defined the error message on writing the workbook
+	// with password protection into an existing ZIP archive.
+	ErrWriteToZipPassword = errors.New("can not write the workbook with password protection into the ZIP archive")
+	// ErrChartAnnotationAnchor defined the error message on receiving the
+	// chart annotation anchor cell outside of the chart area.
+	ErrChartAnnotationAnchor = errors.New("the annotation anchor cell must be within the chart area")
+	// ErrDeleteFirstSheetView defined the error message on deleting the first
+	// view of the worksheet.
+	ErrDeleteFirstSheetView = errors.New("the first view of the worksheet is required and can not be deleted")
+	// ErrCustomSheetViewID defined the error message on receiving the invalid
+	// custom sheet view ID.
+	ErrCustomSheetViewID = errors.New("the custom sheet view ID should be a GUID in the form {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}")
 )
@@ -15,10 +15,12 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/xml"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -91,6 +93,15 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 //
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
+//
+// ZipPrefix specifies the prefix of the entry names when writing the workbook
+// parts into an existing ZIP archive by the WriteToZip function, such as
+// "report/data.xlsx", the default value is empty.
+//
+// MaxRows specifies the maximum number of rows to read from the top of the
+// worksheet by the GetRows, Rows and GetRowIterator functions, the worksheet
+// will not be parsed after reaching the limit, the default value is 0 which
+// means no limit.
 type Options struct {
 	MaxCalcIterations uint   // MaxCalcIterations指定迭代计算的最大迭代次数，默认值为0。
 	Password          string //以明文形式指定打开和保存工作簿时所使用的密码，默认值为空。
@@ -101,6 +112,8 @@ type Options struct {
 	LongDatePattern   string
 	LongTimePattern   string
 	CultureInfo       CultureName
+	ZipPrefix         string
+	MaxRows           int
 }
 
 // OpenFile take the name of an spreadsheet file and returns a populated
@@ -170,6 +183,27 @@ func (f *File) checkOpenReaderOptions() error {
 // spreadsheet file.
 // OpenReader 从 io.Reader 读取数据流。
 func OpenReader(r io.Reader, opts ...Options) (*File, error) {
+	return OpenReaderWithContext(context.Background(), r, opts...)
+}
+
+// OpenReaderWithContext read data stream from io.Reader and return a
+// populated spreadsheet file, the given context is checked between reading
+// each part of the workbook package. If the context is canceled, any
+// temporary files created while reading are removed, and a nil file with the
+// context error will be returned. For example, stop parsing the uploaded
+// spreadsheet when the client disconnects in an HTTP handler:
+//
+//	f, err := excelize.OpenReaderWithContext(r.Context(), r.Body)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//
+// OpenReaderWithContext 从 io.Reader 读取数据流，并在读取工作簿中每个部件之间检查给定的上下文，上下文被取消时将返回 nil 和上下文错误。
+func OpenReaderWithContext(ctx context.Context, r io.Reader, opts ...Options) (*File, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	b, err := io.ReadAll(r)
 	if err != nil {
 		return nil, err
@@ -181,6 +215,9 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	}
 	if bytes.Contains(b, oleIdentifier) {
 		if b, err = Decrypt(b, f.options); err != nil {
+			if err == ErrWorkbookPassword {
+				return nil, err
+			}
 			return nil, ErrWorkbookFileFormat
 		}
 	}
@@ -191,8 +228,12 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 		}
 		return nil, err
 	}
-	file, sheetCount, err := f.ReadZipReader(zr)
+	file, sheetCount, err := f.readZipReader(ctx, zr)
+	if err == nil {
+		err = ctx.Err()
+	}
 	if err != nil {
+		_ = f.Close()
 		return nil, err
 	}
 	f.SheetCount = sheetCount
@@ -531,20 +572,159 @@ func (f *File) AddVBAProject(file []byte) error {
 			Type:   SourceRelationshipVBAProject,
 		})
 	}
-	f.Pkg.Store("xl/vbaProject.bin", file)
+	f.Pkg.Store(defaultXMLPathVBAProject, file)
+	return err
+}
+
+// SetWorkbookVBAEnabled provides a function to enable or disable the macro
+// support of the workbook. When enabled, the content type of the workbook
+// part will be changed to the macro-enabled content type, which upgrades the
+// workbook to XLSM (or XLTM for templates). When disabled, the relationship
+// and the part of the VBA project will be removed, and the content type of
+// the workbook part will be reset to XLSX (or XLTX for templates). This
+// function returns ErrVBAProjectReferenced when disable the macro support if
+// the shapes or form controls in the workbook still reference the macros.
+// Note that the content type of the workbook part will be decided by the file
+// extension on saving the workbook with a file path. For example, remove the
+// VBA project from the workbook:
+//
+//	if err := f.SetWorkbookVBAEnabled(false); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SaveAs("Book1.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) SetWorkbookVBAEnabled(enabled bool) error {
+	contentTypes := map[string]string{
+		ContentTypeSheetML:  ContentTypeMacro,
+		ContentTypeTemplate: ContentTypeTemplateMacro,
+	}
+	if !enabled {
+		if f.hasMacroReference() {
+			return ErrVBAProjectReferenced
+		}
+		contentTypes = map[string]string{
+			ContentTypeMacro:         ContentTypeSheetML,
+			ContentTypeAddinMacro:    ContentTypeSheetML,
+			ContentTypeTemplateMacro: ContentTypeTemplate,
+		}
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	partName := "/" + f.getWorkbookPath()
+	for idx, o := range content.Overrides {
+		if contentType, ok := contentTypes[o.ContentType]; ok && o.PartName == partName {
+			content.Overrides[idx].ContentType = contentType
+		}
+	}
+	content.mu.Unlock()
+	if enabled {
+		return err
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return err
+	}
+	rels.mu.Lock()
+	for idx := len(rels.Relationships) - 1; idx >= 0; idx-- {
+		if rel := rels.Relationships[idx]; rel.Type == SourceRelationshipVBAProject {
+			rels.Relationships = append(rels.Relationships[:idx], rels.Relationships[idx+1:]...)
+			f.Pkg.Delete(f.getWorksheetPath(rel.Target))
+		}
+	}
+	rels.mu.Unlock()
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	if !f.isDefaultExtensionUsed(content, "bin") {
+		for idx := len(content.Defaults) - 1; idx >= 0; idx-- {
+			if v := content.Defaults[idx]; v.Extension == "bin" && v.ContentType == ContentTypeVBA {
+				content.Defaults = append(content.Defaults[:idx], content.Defaults[idx+1:]...)
+			}
+		}
+	}
 	return err
 }
 
+// isDefaultExtensionUsed provides a function to check if any part of the
+// workbook with the given extension has no override content type by given
+// content types and extension, which uses the default content type of the
+// extension.
+func (f *File) isDefaultExtensionUsed(content *xlsxTypes, extension string) bool {
+	overrides := make(map[string]bool, len(content.Overrides))
+	for _, o := range content.Overrides {
+		overrides[strings.TrimPrefix(o.PartName, "/")] = true
+	}
+	var used bool
+	f.Pkg.Range(func(p, _ interface{}) bool {
+		if name := p.(string); strings.HasSuffix(strings.ToLower(name), "."+extension) && !overrides[name] {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// macroReferenceExp defined the regular expression to match the macro
+// reference of the shapes and form controls.
+var macroReferenceExp = regexp.MustCompile(`\smacro="[^"]+"|<x:FmlaMacro>`)
+
+// hasMacroReference provides a function to check if the shapes in the
+// drawings or the form controls in the VML drawings reference the macros.
+func (f *File) hasMacroReference() bool {
+	var referenced bool
+	f.Drawings.Range(func(_, d interface{}) bool {
+		if d != nil {
+			content, _ := xml.Marshal(d.(*xlsxWsDr))
+			referenced = macroReferenceExp.Match(content)
+		}
+		return !referenced
+	})
+	for _, vml := range f.VMLDrawing {
+		if vml != nil {
+			content, _ := xml.Marshal(vml)
+			if referenced = referenced || macroReferenceExp.Match(content); referenced {
+				break
+			}
+		}
+	}
+	f.Pkg.Range(func(p, content interface{}) bool {
+		if !strings.HasPrefix(p.(string), "xl/drawings/") || strings.Contains(p.(string), "_rels/") {
+			return !referenced
+		}
+		if _, ok := f.Drawings.Load(p); ok {
+			return !referenced
+		}
+		if _, ok := f.VMLDrawing[p.(string)]; ok {
+			return !referenced
+		}
+		referenced = referenced || macroReferenceExp.Match(content.([]byte))
+		return !referenced
+	})
+	return referenced
+}
+
 // setContentTypePartProjectExtensions provides a function to set the content
 // type for relationship parts and the main document part.
 func (f *File) setContentTypePartProjectExtensions(contentType string) error {
-	var ok bool
 	content, err := f.contentTypesReader()
 	if err != nil {
 		return err
 	}
 	content.mu.Lock()
 	defer content.mu.Unlock()
+	content.setPartProjectExtensions(contentType)
+	return err
+}
+
+// setPartProjectExtensions provides a function to set the content type of the
+// workbook part and the default content type of the VBA project extension by
+// given content type.
+func (content *xlsxTypes) setPartProjectExtensions(contentType string) {
+	var ok bool
 	for _, v := range content.Defaults {
 		if v.Extension == "bin" {
 			ok = true
@@ -561,5 +741,4 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 			ContentType: ContentTypeVBA,
 		})
 	}
-	return err
 }
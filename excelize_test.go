@@ -4,8 +4,10 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/xml"
 	"fmt"
+	"image"
 	"image/color"
 	_ "image/gif"
 	_ "image/jpeg"
@@ -328,6 +330,63 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
 }
 
+// countdownContext is a context which be canceled after the Done function
+// has been called the given times.
+type countdownContext struct {
+	context.Context
+	cancel context.CancelFunc
+	count  int
+}
+
+func (ctx *countdownContext) Done() <-chan struct{} {
+	if ctx.count--; ctx.count < 0 {
+		ctx.cancel()
+	}
+	return ctx.Context.Done()
+}
+
+func TestOpenReaderWithContext(t *testing.T) {
+	file, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	f, err := OpenReaderWithContext(context.Background(), bytes.NewReader(file))
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Monitor", val)
+	assert.NoError(t, f.Close())
+
+	// Test open workbook with canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f, err = OpenReaderWithContext(ctx, bytes.NewReader(file))
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, f)
+
+	// Test cancel context while reading the workbook, temporary files should be
+	// removed
+	tempFiles, err := filepath.Glob(filepath.Join(os.TempDir(), "excelize-*"))
+	assert.NoError(t, err)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	f, err = OpenReaderWithContext(&countdownContext{Context: ctx, cancel: cancel, count: 25},
+		bytes.NewReader(file), Options{UnzipXMLSizeLimit: 128})
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, f)
+	leftFiles, err := filepath.Glob(filepath.Join(os.TempDir(), "excelize-*"))
+	assert.NoError(t, err)
+	assert.Equal(t, tempFiles, leftFiles)
+
+	// Test open workbook with the context exceeded deadline
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	_, err = OpenReaderWithContext(ctx, bytes.NewReader(file))
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// Test open workbook with invalid data stream
+	_, err = OpenReaderWithContext(context.Background(), strings.NewReader(""))
+	assert.EqualError(t, err, zip.ErrFormat.Error())
+}
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct
 	f := File{}
@@ -491,6 +550,60 @@ func TestGetCellHyperLink(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetAllHyperlinks(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	display, tooltip := "Excelize", "Excelize on GitHub"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External", HyperlinkOpts{
+		Display: &display,
+		Tooltip: &tooltip,
+	}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "Sheet2!A1", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet3", "C3", "https://github.com", "External"))
+	assert.NoError(t, f.SetSheetVisible("Sheet3", false))
+
+	links, err := f.GetAllHyperlinks()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]Hyperlink{
+		"Sheet1": {
+			"A1": {Link: "https://github.com/xuri/excelize", Type: "External", Display: display, Tooltip: tooltip},
+			"B2": {Link: "Sheet2!A1", Type: "Location"},
+		},
+	}, links)
+
+	// Test get all hyperlinks including hidden worksheets
+	links, err = f.GetAllHyperlinks(HyperlinkOptions{IncludeHiddenSheets: true})
+	assert.NoError(t, err)
+	assert.Len(t, links, 2)
+	assert.Equal(t, Hyperlink{Link: "https://github.com", Type: "External"}, links["Sheet3"]["C3"])
+
+	// Test get all hyperlinks skip chart sheets
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1", Values: "Sheet1!$C$1"}},
+	}))
+	links, err = f.GetAllHyperlinks()
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+
+	// Test get all hyperlinks with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetAllHyperlinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+
+	// Test get all hyperlinks with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = make(map[string]bool)
+	_, err = f.GetAllHyperlinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetSheetBackground(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -753,7 +866,7 @@ func TestSetCellStyleNumberFormat(t *testing.T) {
 		{"37947.7500001", "37948", "37947.75", "37,948", "37,947.75", "3794775%", "3794775.00%", "3.79E+04", "37947.7500001", "37947.7500001", "11-22-03", "22-Nov-03", "22-Nov", "Nov-03", "6:00 PM", "6:00:00 PM", "18:00", "18:00:00", "11/22/03 18:00", "37,948 ", "37,948 ", "37,947.75 ", "37,947.75 ", "37947.7500001", "37947.7500001", "37947.7500001", "37947.7500001", "00:00", "910746:00:00", "00:00.0", "37947.7500001", "37947.7500001"},
 		{"-37947.7500001", "-37948", "-37947.75", "-37,948", "-37,947.75", "-3794775%", "-3794775.00%", "-3.79E+04", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "(37,948)", "(37,948)", "(37,947.75)", "(37,947.75)", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001"},
 		{"0.007", "0", "0.01", "0", "0.01", "1%", "0.70%", "7.00E-03", "0.007", "0.007", "12-30-99", "30-Dec-99", "30-Dec", "Dec-99", "12:10 AM", "12:10:05 AM", "00:10", "00:10:05", "12/30/99 00:10", "0 ", "0 ", "0.01 ", "0.01 ", "0.007", "0.007", "0.007", "0.007", "10:05", "0:10:05", "10:04.8", "0.007", "0.007"},
-		{"2.1", "2", "2.10", "2", "2.10", "210%", "210.00%", "2.10E+00", "2.1", "2.1", "01-01-00", "1-Jan-00", "1-Jan", "Jan-00", "2:24 AM", "2:24:00 AM", "02:24", "02:24:00", "1/1/00 02:24", "2 ", "2 ", "2.10 ", "2.10 ", "2.1", "2.1", "2.1", "2.1", "24:00", "50:24:00", "24:00.0", "2.1", "2.1"},
+		{"2.1", "2", "2.10", "2", "2.10", "210%", "210.00%", "2.10E+00", "2.1", "2.1", "01-02-00", "2-Jan-00", "2-Jan", "Jan-00", "2:24 AM", "2:24:00 AM", "02:24", "02:24:00", "1/2/00 02:24", "2 ", "2 ", "2.10 ", "2.10 ", "2.1", "2.1", "2.1", "2.1", "24:00", "50:24:00", "24:00.0", "2.1", "2.1"},
 		{"String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String"},
 	}
 
@@ -1011,6 +1124,173 @@ func TestCopySheetError(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetError.xlsx")))
 }
 
+func TestCopySheetFrom(t *testing.T) {
+	src := NewFile()
+	_, err := src.NewSheet("Template")
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetCellValue("Template", "A1", "Header"))
+	assert.NoError(t, src.SetCellValue("Template", "B1", 100))
+	assert.NoError(t, src.SetCellFormula("Template", "C1", "B1*2"))
+	assert.NoError(t, src.SetCellRichText("Template", "A2", []RichTextRun{
+		{Text: "bold", Font: &Font{Bold: true}}, {Text: " text"},
+	}))
+	numFmt := "0.000%"
+	style, err := src.NewStyle(&Style{
+		Font:         &Font{Bold: true, Color: "FF0000"},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:       []Border{{Type: "left", Color: "0000FF", Style: 2}},
+		CustomNumFmt: &numFmt,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetCellStyle("Template", "A1", "B1", style))
+	assert.NoError(t, src.MergeCell("Template", "A3", "B4"))
+	assert.NoError(t, src.SetColWidth("Template", "A", "A", 30))
+	assert.NoError(t, src.SetRowHeight("Template", 1, 40))
+	assert.NoError(t, src.AddPicture("Template", "D2", filepath.Join("test", "images", "excel.png"), nil))
+	dv := NewDataValidation(true)
+	dv.Sqref = "E1:E10"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	assert.NoError(t, src.AddDataValidation("Template", dv))
+	format, err := src.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetConditionalFormat("Template", "B1:B10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "6"},
+	}))
+	assert.NoError(t, src.SetPageMargins("Template", &PageLayoutMarginsOptions{Left: float64Ptr(1.5)}))
+	assert.NoError(t, src.SetCellHyperLink("Template", "F1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, src.AddComment("Template", Comment{Cell: "A1", Author: "Excelize", Text: "comment"}))
+	wb, err := src.workbookReader()
+	assert.NoError(t, err)
+	wb.DefinedNames = &xlsxDefinedNames{DefinedName: []xlsxDefinedName{
+		{Name: "_xlnm.Print_Area", LocalSheetID: intPtr(1), Data: "Template!$A$1:$F$10"},
+		{Name: "_xlnm.Print_Titles", LocalSheetID: intPtr(1), Data: "'Template'!$1:$1,MyTemplate!$A:$A"},
+	}}
+
+	f := NewFile()
+	_, err = f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	_, err = f.NewConditionalStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Existing"))
+	assert.NoError(t, f.CopySheetFrom(src, "Template", "Sheet2"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetFrom.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCopySheetFrom.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "Header", "B1": "10000.000%", "A2": "bold text"} {
+		val, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	formula, err := f.GetCellFormula("Sheet2", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1*2", formula)
+	runs, err := f.GetCellRichText("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.True(t, runs[0].Font.Bold)
+	styleID, err := f.GetCellStyle("Sheet2", "A1")
+	assert.NoError(t, err)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	xf := styles.CellXfs.Xf[styleID]
+	assert.Equal(t, "FFFF0000", styles.Fonts.Font[*xf.FontID].Color.RGB)
+	assert.NotNil(t, styles.Fonts.Font[*xf.FontID].B)
+	assert.Equal(t, "FFFFFF00", styles.Fills.Fill[*xf.FillID].PatternFill.FgColor.RGB)
+	assert.Equal(t, "0000FF", strings.TrimPrefix(styles.Borders.Border[*xf.BorderID].Left.Color.RGB, "FF"))
+	assert.Equal(t, numFmt, styles.NumFmts.NumFmt[len(styles.NumFmts.NumFmt)-1].FormatCode)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A3", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B4", mergeCells[0].GetEndAxis())
+	width, err := f.GetColWidth("Sheet2", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, width)
+	height, err := f.GetRowHeight("Sheet2", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, height)
+	pics, err := f.GetPictures("Sheet2", "D2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	dvs, err := f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "E1:E10", dvs[0].Sqref)
+	conditionalFormats, err := f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, conditionalFormats["B1:B10"][0].Format)
+	margins, err := f.GetPageMargins("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, *margins.Left)
+	link, target, err := f.GetCellHyperLink("Sheet2", "F1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	comments, err := f.GetComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.Contains(t, f.GetDefinedName(), DefinedName{Name: "_xlnm.Print_Area", RefersTo: "'Sheet2'!$A$1:$F$10", Scope: "Sheet2"})
+	assert.Contains(t, f.GetDefinedName(), DefinedName{Name: "_xlnm.Print_Titles", RefersTo: "'Sheet2'!$1:$1,MyTemplate!$A:$A", Scope: "Sheet2"})
+	assert.NoError(t, f.Close())
+	// Test replace the worksheet name of the references in the formula
+	for formula, expected := range map[string]string{
+		"Template!A1+Template2!A1":          "'Sheet 2'!A1+Template2!A1",
+		"'template'!A1&\"Template!A1\"":     "'Sheet 2'!A1&\"Template!A1\"",
+		"'My Template'!A1,'Template''s'!A1": "'My Template'!A1,'Template''s'!A1",
+		"SUM(Template!A:A)/'Template":       "SUM('Sheet 2'!A:A)/'Template",
+		"\"Template!":                       "\"Template!",
+	} {
+		assert.Equal(t, expected, replaceSheetRefs(formula, "Template", "Sheet 2"), formula)
+	}
+
+	// Test copy sheet with invalid parameters
+	f = NewFile()
+	assert.EqualError(t, f.CopySheetFrom(nil, "Template", "Sheet2"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.CopySheetFrom(src, "Template", "Sheet:1"), ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.CopySheetFrom(src, "Template", "Sheet1"), ErrExistsSheet.Error())
+	assert.EqualError(t, f.CopySheetFrom(src, "SheetN", "Sheet2"), "sheet SheetN does not exist")
+	// Test copy sheet with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Template", "Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test copy sheet with unsupported charset extension list
+	f = NewFile()
+	ws, err := src.workSheetReader("Template")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	assert.EqualError(t, f.CopySheetFrom(src, "Template", "Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	ws.ExtLst = nil
+	// Test copy sheet failed without changing the style sheet and shared
+	// strings table of the workbook
+	assertNotChanged := func(f *File, expected string) {
+		styles, err := f.stylesReader()
+		assert.NoError(t, err)
+		xfs, fonts := len(styles.CellXfs.Xf), len(styles.Fonts.Font)
+		assert.EqualError(t, f.CopySheetFrom(src, "Template", "Sheet2"), expected)
+		assert.Len(t, styles.CellXfs.Xf, xfs)
+		assert.Len(t, styles.Fonts.Font, fonts)
+		assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	}
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assertNotChanged(f, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	f = NewFile()
+	src.Pkg.Store("xl/media/image1.png", []byte("unknown"))
+	assertNotChanged(f, image.ErrFormat.Error())
+	pics, err = f.GetPictures("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Empty(t, pics)
+	assert.NoError(t, f.Close())
+	assert.NoError(t, src.Close())
+}
+
 func TestGetSheetComments(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, "", f.getSheetComments("sheet0"))
@@ -1025,7 +1305,9 @@ func TestGetActiveSheetIndex(t *testing.T) {
 func TestRelsWriter(t *testing.T) {
 	f := NewFile()
 	f.Relationships.Store("xl/worksheets/sheet/rels/sheet1.xml.rel", &xlsxRelationships{})
-	f.relsWriter()
+	assert.NoError(t, f.marshalParts("", false, func(path string, content []byte) {
+		f.Pkg.Store(path, content)
+	}))
 }
 
 func TestConditionalFormat(t *testing.T) {
@@ -1290,6 +1572,102 @@ func TestSetSheetRow(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestSetSheetRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "E2", "F3"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=1+1"))
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, f.SetSheetRows("Sheet1", 2, [][]interface{}{
+		{"Apple", 3, 1.5, true, "merged", "hidden"},
+		{int64(1), float32(0.5), now, time.Hour, nil, []byte("bytes")},
+		{},
+		{struct{}{}, uint8(8)},
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"Apple", "3", "1.5", "TRUE", "bytes"},
+		{"1", "0.5", "1/2/23 03:04", "01:00:00"},
+		nil,
+		{"{}", "8"},
+	}, rows)
+	formula, err := f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	styleID, err := f.GetCellStyle("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.NotZero(t, styleID)
+	// Test the style of the existing cell will be kept for the date time value
+	customStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C6", "C6", customStyle))
+	assert.NoError(t, f.SetSheetRows("Sheet1", 6, [][]interface{}{{nil, nil, now}}))
+	cellStyle, err := f.GetCellStyle("Sheet1", "C6")
+	assert.NoError(t, err)
+	assert.Equal(t, customStyle, cellStyle)
+	// Test set the rows without records
+	assert.NoError(t, f.SetSheetRows("Sheet1", 1, nil))
+	// Test set the rows with the 1904 date system
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	assert.NoError(t, f.SetSheetRows("Sheet1", 7, [][]interface{}{{now}}))
+	val, err := f.GetCellValue("Sheet1", "A7", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "43466.12783564815", val)
+	// Test set the rows with invalid row number
+	assert.EqualError(t, f.SetSheetRows("Sheet1", 0, [][]interface{}{{1}}), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.SetSheetRows("Sheet1", TotalRows, [][]interface{}{{1}, {2}}), ErrMaxRows.Error())
+	// Test set the rows with too many columns
+	assert.EqualError(t, f.SetSheetRows("Sheet1", 1, [][]interface{}{make([]interface{}, MaxColumns+1)}), ErrColumnNumber.Error())
+	assert.EqualError(t, f.SetSheetRow("Sheet1", "XFD1", &[]interface{}{1, 2}), ErrColumnNumber.Error())
+	// Test set the rows on not exists worksheet
+	assert.EqualError(t, f.SetSheetRows("SheetN", 1, [][]interface{}{{1}}), "sheet SheetN does not exist")
+	// Test set the rows with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetRows("Sheet1", 1, [][]interface{}{{"text"}}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set the rows with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetRows("Sheet1", 1, [][]interface{}{{1}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetRowColHeader(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowHeader("Sheet1", 1, []interface{}{"Name", "Age", 3}, style))
+	assert.NoError(t, f.SetColHeader("Sheet1", "E", []interface{}{"Q1", "Q2"}, style))
+	for cell, expected := range map[string]string{"A1": "Name", "B1": "Age", "C1": "3", "E1": "Q1", "E2": "Q2"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+	// Test set row and column header without values
+	assert.NoError(t, f.SetRowHeader("Sheet1", 2, nil, style))
+	assert.NoError(t, f.SetColHeader("Sheet1", "F", nil, style))
+	// Test set row and column header with invalid row number and column name
+	assert.EqualError(t, f.SetRowHeader("Sheet1", 0, []interface{}{"Name"}, style), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.SetColHeader("Sheet1", "*", []interface{}{"Q1"}, style), newInvalidColumnNameError("*").Error())
+	// Test set row and column header with too many values
+	assert.EqualError(t, f.SetRowHeader("Sheet1", 1, make([]interface{}, MaxColumns+1), style), ErrColumnNumber.Error())
+	assert.EqualError(t, f.SetColHeader("Sheet1", "A", make([]interface{}, TotalRows+1), style), ErrMaxRows.Error())
+	// Test set row and column header with invalid style ID
+	assert.EqualError(t, f.SetRowHeader("Sheet1", 1, []interface{}{"Name"}, 10), newInvalidStyleID(10).Error())
+	assert.EqualError(t, f.SetColHeader("Sheet1", "A", []interface{}{"Q1"}, 10), newInvalidStyleID(10).Error())
+	// Test set row and column header with invalid sheet name
+	assert.EqualError(t, f.SetRowHeader("Sheet:1", 1, []interface{}{"Name"}, style), ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.SetColHeader("Sheet:1", "A", []interface{}{"Q1"}, style), ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowColHeader.xlsx")))
+}
+
 func TestHSL(t *testing.T) {
 	var hsl HSL
 	r, g, b, a := hsl.RGBA()
@@ -1472,6 +1850,36 @@ func TestUnprotectWorkbook(t *testing.T) {
 	assert.EqualError(t, f.UnprotectWorkbook(), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetWorkbookProtectionStatus(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetWorkbookProtectionStatus()
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
+		AlgorithmName: "SHA-256",
+		Password:      "password",
+		LockStructure: true,
+	}))
+	opts, err = f.GetWorkbookProtectionStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, &WorkbookProtectionOptions{AlgorithmName: "SHA-256", LockStructure: true, IsProtected: true}, opts)
+	// Test get protection status after remove workbook protection
+	assert.NoError(t, f.UnprotectWorkbook("password"))
+	opts, err = f.GetWorkbookProtectionStatus()
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+	// Test get protection status of the workbook protected without password
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{LockWindows: true}))
+	opts, err = f.GetWorkbookProtectionStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, &WorkbookProtectionOptions{LockWindows: true, IsProtected: true}, opts)
+	// Test get protection status with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookProtectionStatus()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetDefaultTimeStyle(t *testing.T) {
 	f := NewFile()
 	// Test set default time style on not exists worksheet.
@@ -1499,6 +1907,88 @@ func TestAddVBAProject(t *testing.T) {
 	assert.EqualError(t, f.AddVBAProject(file), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetWorkbookVBAEnabled(t *testing.T) {
+	f := NewFile()
+	getContentType := func() string {
+		for _, o := range f.ContentTypes.Overrides {
+			if o.PartName == "/xl/workbook.xml" {
+				return o.ContentType
+			}
+		}
+		return ""
+	}
+	assert.NoError(t, f.SetWorkbookVBAEnabled(true))
+	assert.Equal(t, ContentTypeMacro, getContentType())
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddVBAProject(file))
+	// Test disable the VBA project which referenced by the shape macro
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect", Macro: "Button1_Click", Paragraph: []RichTextRun{{Text: "Run"}}}))
+	assert.EqualError(t, f.SetWorkbookVBAEnabled(false), ErrVBAProjectReferenced.Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetWorkbookVBAEnabled.xlsm")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSetWorkbookVBAEnabled.xlsm"))
+	assert.NoError(t, err)
+	assert.EqualError(t, f.SetWorkbookVBAEnabled(false), ErrVBAProjectReferenced.Error())
+	// Test disable the VBA project without macro references
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Delete("xl/drawings/drawing1.xml")
+	assert.NoError(t, f.SetWorkbookVBAEnabled(false))
+	assert.Equal(t, ContentTypeSheetML, getContentType())
+	_, ok := f.Pkg.Load("xl/vbaProject.bin")
+	assert.False(t, ok)
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, SourceRelationshipVBAProject, rel.Type)
+	}
+	for _, v := range f.ContentTypes.Defaults {
+		assert.NotEqual(t, "bin", v.Extension)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test disable the VBA project with absolute relationship target, and keep
+	// the default content type of the extension used by other parts
+	f = NewFile()
+	assert.NoError(t, f.AddVBAProject(file))
+	rels, err = f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	for idx, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipVBAProject {
+			rels.Relationships[idx].Target = "/xl/vbaProject.bin"
+		}
+	}
+	f.ContentTypes.Defaults = append(f.ContentTypes.Defaults, xlsxDefault{Extension: "bin", ContentType: ContentTypeVBA})
+	f.Pkg.Store("xl/embeddings/oleObject1.bin", []byte{})
+	assert.NoError(t, f.SetWorkbookVBAEnabled(false))
+	_, ok = f.Pkg.Load("xl/vbaProject.bin")
+	assert.False(t, ok)
+	assert.Contains(t, f.ContentTypes.Defaults, xlsxDefault{Extension: "bin", ContentType: ContentTypeVBA})
+	assert.NoError(t, f.Close())
+
+	// Test disable the VBA project which referenced by the form control macro
+	f = NewFile()
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = &vmlDrawing{}
+	f.Pkg.Store("xl/drawings/vmlDrawing2.vml", []byte(`<xml><v:shape><x:ClientData ObjectType="Button"><x:FmlaMacro>Button1_Click</x:FmlaMacro></x:ClientData></v:shape></xml>`))
+	assert.EqualError(t, f.SetWorkbookVBAEnabled(false), ErrVBAProjectReferenced.Error())
+	f.Pkg.Delete("xl/drawings/vmlDrawing2.vml")
+	// Test set workbook VBA enabled with template workbook
+	f.ContentTypes.Overrides[0].ContentType = ContentTypeTemplate
+	f.ContentTypes.Overrides[0].PartName = "/xl/workbook.xml"
+	assert.NoError(t, f.SetWorkbookVBAEnabled(true))
+	assert.Equal(t, ContentTypeTemplateMacro, getContentType())
+	assert.NoError(t, f.SetWorkbookVBAEnabled(false))
+	assert.Equal(t, ContentTypeTemplate, getContentType())
+	// Test set workbook VBA enabled with unsupported charset workbook relationships
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookVBAEnabled(false), "XML syntax error on line 1: invalid UTF-8")
+	// Test set workbook VBA enabled with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookVBAEnabled(true), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestContentTypesReader(t *testing.T) {
 	// Test unsupported charset
 	f := NewFile()
@@ -20,6 +20,8 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+
+	"github.com/mohae/deepcopy"
 )
 
 // NewFile provides a function to create new file by default template.
@@ -145,7 +147,7 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 	zw := zip.NewWriter(buf)
 
-	if err := f.writeToZip(zw); err != nil {
+	if err := f.writeToZip(zw, ""); err != nil {
 		return buf, zw.Close()
 	}
 
@@ -167,30 +169,77 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 // writeDirectToWriter provides a function to write to io.Writer.
 func (f *File) writeDirectToWriter(w io.Writer) error {
 	zw := zip.NewWriter(w)
-	if err := f.writeToZip(zw); err != nil {
+	if err := f.writeToZip(zw, ""); err != nil {
 		_ = zw.Close()
 		return err
 	}
 	return zw.Close()
 }
 
-// writeToZip provides a function to write to zip.Writer
-func (f *File) writeToZip(zw *zip.Writer) error {
-	f.calcChainWriter()
-	f.commentsWriter()
-	f.contentTypesWriter()
-	f.drawingsWriter()
-	f.vmlDrawingWriter()
-	f.workBookWriter()
-	f.workSheetWriter()
-	f.relsWriter()
+// WriteToZip provides a function to write the parts of the workbook as the
+// entries of the given ZIP archive, this is useful for building the package
+// which contains the workbook alongside other files without a temporary file.
+// The name of each entry will be prefixed by the 'ZipPrefix' option of the
+// workbook to namespace the entries. For example, write the workbook parts
+// with names such as "report/data.xlsx/xl/workbook.xml":
+//
+//	f := excelize.NewFile(excelize.Options{ZipPrefix: "report/data.xlsx"})
+//	zw := zip.NewWriter(w)
+//	if err := f.WriteToZip(zw); err != nil {
+//	    fmt.Println(err)
+//	}
+//	if err := zw.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// The given ZIP archive will not be closed by this function. The workbook
+// with password protection can't be written by this function, because the
+// encrypted workbook is not a ZIP package.
+func (f *File) WriteToZip(zw *zip.Writer) error {
+	if zw == nil {
+		return ErrParameterInvalid
+	}
+	var prefix string
+	if f.options != nil {
+		if f.options.Password != "" {
+			return ErrWriteToZipPassword
+		}
+		if prefix = strings.Trim(f.options.ZipPrefix, "/"); prefix != "" {
+			prefix += "/"
+		}
+	}
+	return f.writeToZip(zw, prefix)
+}
+
+// writeToZip provides a function to write to zip.Writer, the name of each
+// entry will be prefixed by given prefix.
+func (f *File) writeToZip(zw *zip.Writer, prefix string) error {
 	_ = f.sharedStringsLoader()
-	f.sharedStringsWriter()
-	f.styleSheetWriter()
-	f.themeWriter()
+	f.mu.Lock()
+	err := f.marshalParts("", false, func(path string, content []byte) {
+		f.Pkg.Store(path, content)
+	})
+	// Load the parts in the temporary files into the package, so they could be
+	// read after the temporary files have been removed on closing
+	f.tempFiles.Range(func(path, _ interface{}) bool {
+		_ = f.readBytes(path.(string))
+		return true
+	})
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return f.writeZipEntries(zw, prefix, nil)
+}
 
+// writeZipEntries provides a function to write the streams, the parts in the
+// package and the parts in the temporary files as the entries of the given
+// ZIP archive, the name of each entry will be prefixed by given prefix. The
+// given serialized parts will take the place of the parts with the same names
+// in the package.
+func (f *File) writeZipEntries(zw *zip.Writer, prefix string, parts map[string][]byte) error {
 	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
+		fi, err := zw.Create(prefix + path)
 		if err != nil {
 			return err
 		}
@@ -206,32 +255,342 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		}
 	}
 	var err error
-	f.Pkg.Range(func(path, content interface{}) bool {
-		if err != nil {
-			return false
-		}
-		if _, ok := f.streams[path.(string)]; ok {
+	write := func(path string, content []byte) bool {
+		if _, ok := f.streams[path]; ok {
 			return true
 		}
 		var fi io.Writer
-		fi, err = zw.Create(path.(string))
-		if err != nil {
+		if fi, err = zw.Create(prefix + path); err != nil {
 			return false
 		}
-		_, err = fi.Write(content.([]byte))
-		return true
+		_, err = fi.Write(content)
+		return err == nil
+	}
+	f.Pkg.Range(func(path, content interface{}) bool {
+		if output, ok := parts[path.(string)]; ok {
+			return write(path.(string), output)
+		}
+		output, _ := content.([]byte)
+		return write(path.(string), output)
 	})
-	f.tempFiles.Range(func(path, content interface{}) bool {
-		if _, ok := f.Pkg.Load(path); ok {
+	if err != nil {
+		return err
+	}
+	for path, content := range parts {
+		if _, ok := f.Pkg.Load(path); !ok && !write(path, content) {
+			return err
+		}
+	}
+	f.tempFiles.Range(func(path, _ interface{}) bool {
+		if _, ok := f.Pkg.Load(path); ok || path.(string) == defaultTempFileSST {
+			return true
+		}
+		if _, ok := parts[path.(string)]; ok {
 			return true
 		}
 		var fi io.Writer
-		fi, err = zw.Create(path.(string))
-		if err != nil {
+		if fi, err = zw.Create(prefix + path.(string)); err != nil {
 			return false
 		}
-		_, err = fi.Write(f.readBytes(path.(string)))
+		if file, _ := f.readTemp(path.(string)); file != nil {
+			_, err = io.Copy(fi, file)
+			_ = file.Close()
+		}
+		return err == nil
+	})
+	return err
+}
+
+// marshalParts provides a function to serialize the parts of the workbook
+// loaded in memory in the same way as saving, and pass the name and the
+// serialized content of each part to the given function. The parts which
+// need to be normalized before writing will be serialized from their deep
+// copies if copied is true, so the internal state of the workbook will not be
+// changed. Only the part with given part name will be serialized if the part
+// name is not empty.
+func (f *File) marshalParts(partName string, copied bool, fn func(path string, content []byte)) error {
+	match := func(path string) bool {
+		return partName == "" || partName == path
+	}
+	withHeader := func(content []byte) []byte {
+		return append([]byte(xml.Header), content...)
+	}
+	if match(defaultXMLPathCalcChain) && f.CalcChain != nil && f.CalcChain.C != nil {
+		output, _ := xml.Marshal(f.CalcChain)
+		fn(defaultXMLPathCalcChain, withHeader(output))
+	}
+	for path, c := range f.Comments {
+		if match(path) && c != nil {
+			output, _ := xml.Marshal(c)
+			fn(path, withHeader(output))
+		}
+	}
+	if match(defaultXMLPathContentTypes) && (f.ContentTypes != nil || (copied && len(f.Path) != 0)) {
+		var contentType string
+		if copied && len(f.Path) != 0 {
+			var ok bool
+			if contentType, ok = supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok {
+				return ErrWorkbookFileFormat
+			}
+		}
+		content, err := f.contentTypesReader()
+		if err != nil {
+			return err
+		}
+		content.mu.Lock()
+		contentTypes := content
+		if copied {
+			contentTypes = deepcopy.Copy(content).(*xlsxTypes)
+		}
+		if contentType != "" {
+			contentTypes.setPartProjectExtensions(contentType)
+		}
+		output, _ := xml.Marshal(contentTypes)
+		content.mu.Unlock()
+		fn(defaultXMLPathContentTypes, withHeader(output))
+	}
+	f.Drawings.Range(func(path, d interface{}) bool {
+		if match(path.(string)) && d != nil {
+			drawing := d.(*xlsxWsDr)
+			drawing.mu.Lock()
+			output, _ := xml.Marshal(drawing)
+			drawing.mu.Unlock()
+			fn(path.(string), withHeader(output))
+		}
+		return true
+	})
+	for path, vml := range f.VMLDrawing {
+		if match(path) && vml != nil {
+			output, _ := xml.Marshal(vml)
+			fn(path, output)
+		}
+	}
+	if wbPath := f.getWorkbookPath(); match(wbPath) && f.WorkBook != nil {
+		wb := f.WorkBook
+		if copied {
+			wb = deepcopy.Copy(f.WorkBook).(*xlsxWorkbook)
+		}
+		if wb.DecodeAlternateContent != nil {
+			wb.AlternateContent = &xlsxAlternateContent{
+				Content: wb.DecodeAlternateContent.Content,
+				XMLNSMC: SourceRelationshipCompatibility.Value,
+			}
+		}
+		wb.DecodeAlternateContent = nil
+		output, _ := xml.Marshal(wb)
+		fn(wbPath, withHeader(replaceRelationshipsBytes(f.replaceNameSpaceBytes(wbPath, output))))
+	}
+	f.Sheet.Range(func(p, ws interface{}) bool {
+		if path := p.(string); match(path) && ws != nil {
+			fn(path, withHeader(f.marshalWorksheet(path, ws.(*xlsxWorksheet), copied)))
+			if !copied && f.checked[path] {
+				f.Sheet.Delete(path)
+				f.checked[path] = false
+			}
+		}
+		return true
+	})
+	f.Relationships.Range(func(path, rel interface{}) bool {
+		if match(path.(string)) && rel != nil {
+			rels := rel.(*xlsxRelationships)
+			rels.mu.Lock()
+			output, _ := xml.Marshal(rels)
+			rels.mu.Unlock()
+			if strings.HasPrefix(path.(string), "xl/worksheets/sheet/rels/sheet") {
+				output = f.replaceNameSpaceBytes(path.(string), output)
+			}
+			fn(path.(string), withHeader(replaceRelationshipsBytes(output)))
+		}
 		return true
 	})
+	if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); !ok && match(defaultXMLPathSharedStrings) && f.SharedStrings != nil {
+		f.SharedStrings.mu.Lock()
+		output, _ := xml.Marshal(f.SharedStrings)
+		f.SharedStrings.mu.Unlock()
+		fn(defaultXMLPathSharedStrings, withHeader(f.replaceNameSpaceBytes(defaultXMLPathSharedStrings, output)))
+	}
+	if match(defaultXMLPathStyles) && f.Styles != nil {
+		f.Styles.mu.Lock()
+		output, _ := xml.Marshal(f.Styles)
+		f.Styles.mu.Unlock()
+		fn(defaultXMLPathStyles, withHeader(f.replaceNameSpaceBytes(defaultXMLPathStyles, output)))
+	}
+	if match(defaultXMLPathTheme) && f.Theme != nil {
+		output, _ := xml.Marshal(f.Theme)
+		fn(defaultXMLPathTheme, withHeader(f.replaceNameSpaceBytes(defaultXMLPathTheme, output)))
+	}
+	return nil
+}
+
+// marshalWorksheet provides a function to serialize the worksheet by given
+// part name in the same way as saving. The deep copy of the worksheet will be
+// normalized and serialized if copied is true, so the worksheet and the
+// namespaces of the part will not be changed.
+func (f *File) marshalWorksheet(path string, ws *xlsxWorksheet, copied bool) []byte {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	sheet := ws
+	if copied {
+		attrs, ok := f.xmlAttr[path]
+		defer func() {
+			if f.xmlAttr[path] = attrs; !ok {
+				delete(f.xmlAttr, path)
+			}
+		}()
+		sheet = deepcopy.Copy(ws).(*xlsxWorksheet)
+	}
+	f.prepareWorkSheetWrite(path, sheet)
+	sheet.SheetData.Row = trimRow(&sheet.SheetData)
+	output, _ := xml.Marshal(sheet)
+	return replaceRelationshipsBytes(f.replaceNameSpaceBytes(path, output))
+}
+
+// byteCounter directly counts the bytes written to it and discards them.
+type byteCounter struct {
+	n int64
+}
+
+// Write implements io.Writer to count the written bytes.
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// GetWorkbookSize provides a function to get the size in bytes of the
+// workbook output without writing it. The deep copies of the parts loaded in
+// memory will be serialized in the same way as saving, and be written into a
+// writer which only counts the bytes, so the pending changes will be counted
+// without changing the internal state of the workbook. Note that the
+// encryption of the workbook isn't counted in the result. For example:
+//
+//	size, err := f.GetWorkbookSize()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	if size > 10<<20 {
+//	    fmt.Println("workbook size exceeds the quota")
+//	}
+func (f *File) GetWorkbookSize() (int64, error) {
+	parts := make(map[string][]byte)
+	f.mu.Lock()
+	err := f.marshalParts("", true, func(path string, content []byte) {
+		parts[path] = content
+	})
+	f.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	counter := &byteCounter{}
+	zw := zip.NewWriter(counter)
+	if err = f.writeZipEntries(zw, "", parts); err != nil {
+		_ = zw.Close()
+		return counter.n, err
+	}
+	err = zw.Close()
+	return counter.n, err
+}
+
+// GetWorkbookPart provides a function to get the raw content of the part in
+// the spreadsheet package by given part name, which is the entry name in the
+// ZIP archive. The part loaded in memory will be serialized with the pending
+// changes in the same way as saving. For example, get the content of the
+// workbook part:
+//
+//	content, err := f.GetWorkbookPart("xl/workbook.xml")
+//
+// 根据给定的部件名称（压缩包中的条目名称）获取电子表格文档中部件的原始内容。
+func (f *File) GetWorkbookPart(partName string) ([]byte, error) {
+	partName = strings.TrimPrefix(partName, "/")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var pending []byte
+	if err := f.marshalParts(partName, true, func(_ string, content []byte) {
+		pending = content
+	}); err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return pending, nil
+	}
+	_, inPkg := f.Pkg.Load(partName)
+	_, inTemp := f.tempFiles.Load(partName)
+	_, inStream := f.streams[partName]
+	if !inPkg && !inTemp && !inStream {
+		return nil, newNoExistPartError(partName)
+	}
+	return f.readBytes(partName), nil
+}
+
+// SetWorkbookPart provides a function to replace or create the part in the
+// spreadsheet package by given part name, content type and raw content. The
+// content type override of the part in the [Content_Types].xml will be
+// updated if the content type is not empty, and the structure of the part
+// loaded in memory will be discarded. Note that this function doesn't
+// validate the content of the part, an invalid part may cause a corrupted
+// spreadsheet. For example, add a custom XML part:
+//
+//	err := f.SetWorkbookPart("customXml/item1.xml", "application/xml", []byte(`<root/>`))
+//
+// 根据给定的部件名称、内容类型和原始内容替换或创建电子表格文档中的部件，并更新内容类型定义。
+func (f *File) SetWorkbookPart(partName, contentType string, data []byte) error {
+	partName = strings.TrimPrefix(partName, "/")
+	if partName == "" {
+		return ErrParameterInvalid
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.discardPart(partName)
+	f.Pkg.Store(partName, data)
+	if partName == f.getWorkbookPath() {
+		sheetMap, err := f.getSheetMap()
+		if err != nil {
+			return err
+		}
+		f.sheetMap = sheetMap
+	}
+	if contentType == "" || partName == defaultXMLPathContentTypes {
+		return nil
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for idx, override := range content.Overrides {
+		if override.PartName == "/"+partName {
+			content.Overrides[idx].ContentType = contentType
+			return err
+		}
+	}
+	content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/" + partName, ContentType: contentType})
 	return err
 }
+
+// discardPart provides a function to discard the structure of the part loaded
+// in memory by given part name.
+func (f *File) discardPart(partName string) {
+	switch partName {
+	case defaultXMLPathCalcChain:
+		f.CalcChain = nil
+	case defaultXMLPathContentTypes:
+		f.ContentTypes = nil
+	case defaultXMLPathSharedStrings:
+		f.SharedStrings, f.sharedStringsMap = nil, make(map[string]int)
+	case defaultXMLPathStyles:
+		f.Styles = nil
+	case defaultXMLPathTheme:
+		f.Theme = nil
+	case f.getWorkbookPath():
+		f.WorkBook = nil
+	}
+	f.Sheet.Delete(partName)
+	f.Drawings.Delete(partName)
+	f.Relationships.Delete(partName)
+	delete(f.checked, partName)
+	delete(f.xmlAttr, partName)
+	delete(f.streams, partName)
+	delete(f.Comments, partName)
+	delete(f.VMLDrawing, partName)
+	delete(f.DecodeVMLDrawing, partName)
+}
@@ -1,10 +1,14 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"encoding/xml"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -90,8 +94,234 @@ func TestWriteTo(t *testing.T) {
 	}
 }
 
+func TestWriteToZip(t *testing.T) {
+	f := NewFile(Options{ZipPrefix: "/report/data.xlsx/"})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	fi, err := zw.Create("report/summary.pdf")
+	assert.NoError(t, err)
+	_, err = fi.Write([]byte("%PDF-1.4"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.WriteToZip(zw))
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, f.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	// Extract the workbook entries from the archive and open it
+	workbook := new(bytes.Buffer)
+	inner := zip.NewWriter(workbook)
+	var names []string
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+		if !strings.HasPrefix(file.Name, "report/data.xlsx/") {
+			continue
+		}
+		rc, err := file.Open()
+		assert.NoError(t, err)
+		fi, err := inner.Create(strings.TrimPrefix(file.Name, "report/data.xlsx/"))
+		assert.NoError(t, err)
+		_, err = io.Copy(fi, rc)
+		assert.NoError(t, err)
+		assert.NoError(t, rc.Close())
+	}
+	assert.NoError(t, inner.Close())
+	assert.Contains(t, names, "report/summary.pdf")
+	assert.Contains(t, names, "report/data.xlsx/xl/workbook.xml")
+	assert.Contains(t, names, "report/data.xlsx/[Content_Types].xml")
+	f, err = OpenReader(workbook)
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", val)
+	assert.NoError(t, f.Close())
+	// Test write to ZIP archive without prefix
+	f, buf = NewFile(), new(bytes.Buffer)
+	zw = zip.NewWriter(buf)
+	assert.NoError(t, f.WriteToZip(zw))
+	assert.NoError(t, zw.Close())
+	zr, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	names = names[:0]
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+	}
+	assert.Contains(t, names, "xl/workbook.xml")
+	// Test write to ZIP archive with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.WriteToZip(nil))
+	f.options.Password = "password"
+	assert.Equal(t, ErrWriteToZipPassword, f.WriteToZip(zip.NewWriter(new(bytes.Buffer))))
+	assert.NoError(t, f.Close())
+	// Test write to ZIP archive with too long entry name
+	f = NewFile(Options{ZipPrefix: strings.Repeat("s", 1<<16)})
+	assert.EqualError(t, f.WriteToZip(zip.NewWriter(new(bytes.Buffer))), "zip: FileHeader.Name too long")
+	assert.NoError(t, f.Close())
+}
+
 func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
 }
+
+func TestGetWorkbookSize(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 1000; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{row, "This is test data", float64(row) / 3}))
+	}
+	size, err := f.GetWorkbookSize()
+	assert.NoError(t, err)
+	// Test the workbook content is not changed by getting the size
+	val, err := f.GetCellValue("Sheet1", "B1000")
+	assert.NoError(t, err)
+	assert.Equal(t, "This is test data", val)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), size)
+	assert.NoError(t, f.Close())
+
+	// Test get workbook size with stream writer
+	f = NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	for row := 1; row <= 1000; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{row, "This is test data"}))
+	}
+	assert.NoError(t, sw.Flush())
+	size, err = f.GetWorkbookSize()
+	assert.NoError(t, err)
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), size)
+	assert.NoError(t, f.Close())
+
+	// Test get workbook size of the opened workbook
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Go"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E2"))
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{CodeName: stringPtr("Sheet1")}))
+	content, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	attrs := append([]xml.Attr{}, f.xmlAttr["xl/worksheets/sheet1.xml"]...)
+	_, err = f.GetWorkbookSize()
+	assert.NoError(t, err)
+	assert.Equal(t, attrs, f.xmlAttr["xl/worksheets/sheet1.xml"])
+	// Test the internal state of the workbook is not changed by getting the size
+	_, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	raw, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, content, raw)
+	// Test get workbook size with the content type of the workbook file path
+	f.Path = "Book1.xlsm"
+	size, err = f.GetWorkbookSize()
+	assert.NoError(t, err)
+	buf = new(bytes.Buffer)
+	_, err = f.WriteTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), size)
+	// Test get workbook size with unsupported workbook file format
+	f.Path = "Book1.xls"
+	_, err = f.GetWorkbookSize()
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	assert.NoError(t, f.Close())
+
+	// Test get workbook size with unsupported charset content types
+	f = NewFile()
+	f.Path, f.ContentTypes = "Book1.xlsx", nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookSize()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestWorkbookPart(t *testing.T) {
+	f := NewFile()
+	content, err := f.GetWorkbookPart("/xl/workbook.xml")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `<sheet name="Sheet1"`)
+	// Test get the part with pending changes in memory
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 100))
+	content, err = f.GetWorkbookPart("xl/worksheets/sheet1.xml")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `<c r="A1"><v>100</v></c>`)
+	_, err = f.NewStyle(&Style{NumFmt: 0, CustomNumFmt: stringPtr("0.000")})
+	assert.NoError(t, err)
+	content, err = f.GetWorkbookPart(defaultXMLPathStyles)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `formatCode="0.000"`)
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "comment"}))
+	content, err = f.GetWorkbookPart("xl/comments1.xml")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `<t>comment</t>`)
+	// Test get the worksheet part serialized in the same way as saving
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 1))
+	content, err = f.GetWorkbookPart("xl/worksheets/sheet1.xml")
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), `<c r="A3">`)
+	_, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	saved, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, string(saved.([]byte)), string(content))
+	// Test create a custom part
+	assert.NoError(t, f.SetWorkbookPart("customXml/item1.xml", "application/xml", []byte(`<root/>`)))
+	assert.NoError(t, f.SetWorkbookPart("customXml/item1.xml", "text/xml", []byte(`<root>1</root>`)))
+	content, err = f.GetWorkbookPart("customXml/item1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, `<root>1</root>`, string(content))
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var overrides int
+	for _, override := range contentTypes.Overrides {
+		if override.PartName == "/customXml/item1.xml" {
+			assert.Equal(t, "text/xml", override.ContentType)
+			overrides++
+		}
+	}
+	assert.Equal(t, 1, overrides)
+	// Test replace the worksheet and workbook parts loaded in memory
+	assert.NoError(t, f.SetWorkbookPart("xl/worksheets/sheet1.xml", "", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>42</v></c></row></sheetData></worksheet>`)))
+	workbook, err := f.GetWorkbookPart("xl/workbook.xml")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetWorkbookPart("xl/workbook.xml", "", bytes.ReplaceAll(workbook, []byte(`name="Sheet1"`), []byte(`name="Data"`))))
+	assert.Equal(t, []string{"Data"}, f.GetSheetList())
+	val, err := f.GetCellValue("Data", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", val)
+	// Test replace the content types part
+	contentTypesXML, err := f.GetWorkbookPart(defaultXMLPathContentTypes)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetWorkbookPart(defaultXMLPathContentTypes, "application/xml", contentTypesXML))
+	assert.Nil(t, f.ContentTypes)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWorkbookPart.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestWorkbookPart.xlsx"))
+	assert.NoError(t, err)
+	content, err = f.GetWorkbookPart("customXml/item1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, `<root>1</root>`, string(content))
+	val, err = f.GetCellValue("Data", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", val)
+	// Test get the part with unsupported workbook file format
+	f.Path = "Book1.xls"
+	_, err = f.GetWorkbookPart(defaultXMLPathContentTypes)
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test get and set the part with invalid part name
+	_, err = f.GetWorkbookPart("xl/unknown.xml")
+	assert.EqualError(t, err, newNoExistPartError("xl/unknown.xml").Error())
+	assert.Equal(t, ErrParameterInvalid, f.SetWorkbookPart("/", "application/xml", nil))
+	// Test set the part with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookPart("customXml/item2.xml", "application/xml", []byte(`<root/>`)), "XML syntax error on line 1: invalid UTF-8")
+	// Test set the workbook part with unsupported charset
+	assert.EqualError(t, f.SetWorkbookPart("xl/workbook.xml", "", MacintoshCyrillicCharset), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -0,0 +1,336 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FindOptions directly maps the settings of finding the cells in the
+// worksheet by the FindValue function. By default, the value is matched as a
+// case-insensitive substring of the cell values.
+type FindOptions struct {
+	MatchCase       bool
+	MatchEntireCell bool
+	RegExp          bool
+	InFormulas      bool
+}
+
+// ReplaceOptions directly maps the settings of replacing the cell values in
+// the worksheet by the ReplaceValue function. By default, the value is
+// matched as a case-insensitive substring of the cell values.
+type ReplaceOptions struct {
+	MatchCase       bool
+	MatchEntireCell bool
+	RegExp          bool
+	InFormulas      bool
+}
+
+// findCell directly maps the cell reference and the coordinates of the found
+// cell.
+type findCell struct {
+	cell     string
+	col, row int
+}
+
+// newFindRegexp provides a function to compile the regular expression for
+// matching the text by given value and find settings.
+func newFindRegexp(value string, matchCase, matchEntireCell, regExp bool) (*regexp.Regexp, error) {
+	if value == "" {
+		return nil, ErrParameterRequired
+	}
+	if !regExp {
+		value = regexp.QuoteMeta(value)
+	}
+	if matchEntireCell {
+		value = "^(?:" + value + ")$"
+	}
+	if !matchCase {
+		value = "(?i)" + value
+	}
+	return regexp.Compile(value)
+}
+
+// FindValue provides a function to get the cell references of the cells
+// matched the given value by given worksheet name, value and find options.
+// The formatted cell values, the display text and the link of the hyperlinks
+// will be matched, and the formula text will be matched instead of the value
+// of the formula cells if the InFormulas field of the options is true. The
+// result is ordered by rows, and the cell reference of the upper left cell
+// will be returned for the hyperlink on a range.
+//
+// The options are:
+//
+//	Options         | Description
+//	----------------+-----------------------------------------------------------
+//	MatchCase       | Match the value with case sensitive
+//	MatchEntireCell | Match the entire text instead of a part of the text
+//	RegExp          | Match the value as a regular expression
+//	InFormulas      | Match the formula text of the formula cells
+//
+// For example, find the cells which contain "total" on Sheet1:
+//
+//	cells, err := f.FindValue("Sheet1", "total", nil)
+//
+// Find the cells which value is a number with 3 digits on Sheet1:
+//
+//	cells, err := f.FindValue("Sheet1", "[0-9]{3}", &excelize.FindOptions{
+//	    MatchEntireCell: true,
+//	    RegExp:          true,
+//	})
+//
+// 根据给定的工作表名称、查找值和查找选项获取匹配单元格的坐标，支持查找单元格的值、公式和超链接。
+func (f *File) FindValue(sheet, value string, opts *FindOptions) ([]string, error) {
+	var options FindOptions
+	if opts != nil {
+		options = *opts
+	}
+	re, err := newFindRegexp(value, options.MatchCase, options.MatchEntireCell, options.RegExp)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	cells := map[string]findCell{}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			var text string
+			if c.F != nil && options.InFormulas {
+				text = c.F.Content
+			} else if text, err = c.getValueFrom(f, sst, false); err != nil {
+				return nil, err
+			}
+			if text == "" || !re.MatchString(text) {
+				continue
+			}
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return nil, err
+			}
+			cells[c.R] = findCell{cell: c.R, col: col, row: row}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			for _, text := range []string{link.Display, f.getHyperlinkTarget(sheet, link)} {
+				if text == "" || !re.MatchString(text) {
+					continue
+				}
+				cell := strings.Split(link.Ref, ":")[0]
+				col, row, err := CellNameToCoordinates(cell)
+				if err != nil {
+					return nil, err
+				}
+				cells[cell] = findCell{cell: cell, col: col, row: row}
+			}
+		}
+	}
+	return sortFindCells(cells), nil
+}
+
+// sortFindCells provides a function to get the cell references of the found
+// cells ordered by rows and columns.
+func sortFindCells(cells map[string]findCell) []string {
+	list := make([]findCell, 0, len(cells))
+	for _, c := range cells {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].row == list[j].row {
+			return list[i].col < list[j].col
+		}
+		return list[i].row < list[j].row
+	})
+	result := make([]string, 0, len(list))
+	for _, c := range list {
+		result = append(result, c.cell)
+	}
+	return result
+}
+
+// getHyperlinkTarget provides a function to get the link of the hyperlink,
+// it returns the target of the relationship for the external link, and the
+// location for the link to a place in this workbook.
+func (f *File) getHyperlinkTarget(sheet string, link xlsxHyperlink) string {
+	if link.RID != "" {
+		return f.getSheetRelationshipsTargetByID(sheet, link.RID)
+	}
+	return link.Location
+}
+
+// ReplaceValue provides a function to replace the matched text in the cell
+// values and the hyperlinks by given worksheet name, the value to be
+// replaced, the new value and replace options, and returns the number of the
+// replaced cells and hyperlinks. The text values of the cells, the display
+// text and the link of the hyperlinks will be replaced, and the formula text
+// of the formula cells will be replaced if the InFormulas field of the
+// options is true. The numeric, boolean and formula cells without the
+// InFormulas option will not be changed. The replaced cell will be set with
+// the plain text, and the rich text format of the cell will be removed. The
+// submatches in the new value like "$1" will be expanded if the RegExp field
+// of the options is true. The options are same as the FindValue function.
+// For example, replace "2022" with "2023" in the cell values and formulas on
+// Sheet1:
+//
+//	count, err := f.ReplaceValue("Sheet1", "2022", "2023", &excelize.ReplaceOptions{
+//	    InFormulas: true,
+//	})
+//
+// 根据给定的工作表名称、查找值、替换值和替换选项替换单元格的值、公式和超链接中匹配的文本，并返回替换的数量。
+func (f *File) ReplaceValue(sheet, oldValue, newValue string, opts *ReplaceOptions) (int, error) {
+	var options ReplaceOptions
+	if opts != nil {
+		options = *opts
+	}
+	re, err := newFindRegexp(oldValue, options.MatchCase, options.MatchEntireCell, options.RegExp)
+	if err != nil {
+		return 0, err
+	}
+	return f.replaceValue(sheet, options.InFormulas, func(text string) string {
+		if options.RegExp {
+			return re.ReplaceAllString(text, newValue)
+		}
+		return re.ReplaceAllLiteralString(text, newValue)
+	})
+}
+
+// FindAndReplaceAll provides a function to replace the text in the cell
+// values and the hyperlinks by given worksheet name and the map of the values
+// to be replaced and the new values in a single pass of the worksheet, and
+// returns the number of the replaced cells and hyperlinks. The values are
+// matched as the case-sensitive text, and the longer value will be replaced
+// first when the values overlap. The formula text will not be replaced. For
+// example, replace the region names on Sheet1:
+//
+//	count, err := f.FindAndReplaceAll("Sheet1", map[string]string{
+//	    "East": "North East",
+//	    "West": "North West",
+//	})
+//
+// 根据给定的工作表名称和查找值与替换值的映射，通过单次遍历工作表替换单元格的值和超链接中匹配的文本，并返回替换的数量。
+func (f *File) FindAndReplaceAll(sheet string, replacements map[string]string) (int, error) {
+	var (
+		olds     []string
+		replacer []string
+	)
+	for old := range replacements {
+		if old == "" {
+			return 0, ErrParameterRequired
+		}
+		olds = append(olds, old)
+	}
+	sort.Slice(olds, func(i, j int) bool {
+		if len(olds[i]) == len(olds[j]) {
+			return olds[i] < olds[j]
+		}
+		return len(olds[i]) > len(olds[j])
+	})
+	for _, old := range olds {
+		replacer = append(replacer, old, replacements[old])
+	}
+	r := strings.NewReplacer(replacer...)
+	return f.replaceValue(sheet, false, r.Replace)
+}
+
+// replaceValue provides a function to replace the text in the cell values,
+// formulas and hyperlinks by given worksheet name and the replace function.
+func (f *File) replaceValue(sheet string, inFormulas bool, replace func(text string) string) (int, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return 0, err
+	}
+	var (
+		count  int
+		values = map[string]string{}
+		cells  []string
+	)
+	ws.mu.Lock()
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if c.F != nil {
+				if inFormulas && c.F.Content != "" {
+					if content := replace(c.F.Content); content != c.F.Content {
+						c.F.Content = content
+						c.T, c.V, c.IS = "str", "", nil
+						count++
+					}
+				}
+				continue
+			}
+			if c.T != "s" && c.T != "str" && c.T != "inlineStr" {
+				continue
+			}
+			text, err := c.getValueFrom(f, sst, true)
+			if err != nil {
+				ws.mu.Unlock()
+				return count, err
+			}
+			if value := replace(text); value != text {
+				values[c.R] = value
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	ws.mu.Unlock()
+	for _, cell := range cells {
+		if err = f.SetCellStr(sheet, cell, values[cell]); err != nil {
+			return count, err
+		}
+		count++
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Hyperlinks == nil {
+		return count, err
+	}
+	sheetPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+	for i := range ws.Hyperlinks.Hyperlink {
+		link, replaced := &ws.Hyperlinks.Hyperlink[i], false
+		if display := replace(link.Display); display != link.Display {
+			link.Display, replaced = display, true
+		}
+		if target := f.getHyperlinkTarget(sheet, *link); target != "" {
+			if newTarget := replace(target); newTarget != target {
+				if link.RID != "" {
+					f.setRels(link.RID, sheetRels, SourceRelationshipHyperLink, newTarget, "External")
+				} else {
+					link.Location = newTarget
+				}
+				replaced = true
+			}
+		}
+		if replaced {
+			count++
+		}
+	}
+	return count, err
+}
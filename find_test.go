@@ -0,0 +1,169 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareFindTestBook(t *testing.T) *File {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "Total", "B1": "total sales", "C1": 100, "A2": "Region East", "B2": "EAST", "C2": 2022, "A3": "Sheet2!A1",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(C1:C2)+2022"))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "D2", []RichTextRun{{Text: "East "}, {Text: "Coast", Font: &Font{Bold: true}}}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "https://github.com/xuri/excelize", "External", HyperlinkOpts{Display: stringPtr("Project Home")}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "Sheet1!A2", "Location"))
+	return f
+}
+
+func TestFindValue(t *testing.T) {
+	f := prepareFindTestBook(t)
+	for _, c := range []struct {
+		value    string
+		opts     *FindOptions
+		expected []string
+	}{
+		{"total", nil, []string{"A1", "B1"}},
+		{"total", &FindOptions{MatchCase: true}, []string{"B1"}},
+		{"total", &FindOptions{MatchEntireCell: true}, []string{"A1"}},
+		{"east", nil, []string{"A2", "B2", "D2"}},
+		{"east", &FindOptions{MatchEntireCell: true}, []string{"B2"}},
+		{"^[0-9]{3}$", &FindOptions{RegExp: true}, []string{"C1"}},
+		{"2022", nil, []string{"C2"}},
+		{"2022", &FindOptions{InFormulas: true}, []string{"D1", "C2"}},
+		{"sheet2!", nil, []string{"A3"}},
+		{"Sheet1!A2", nil, []string{"C3"}},
+		{"github.com", nil, []string{"B3"}},
+		{"project home", nil, []string{"B3"}},
+		{"none", nil, []string{}},
+	} {
+		cells, err := f.FindValue("Sheet1", c.value, c.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, cells, c.value)
+	}
+	// Test find value with invalid parameters
+	_, err := f.FindValue("Sheet1", "", nil)
+	assert.Equal(t, ErrParameterRequired, err)
+	_, err = f.FindValue("Sheet1", "[", &FindOptions{RegExp: true})
+	assert.EqualError(t, err, "error parsing regexp: missing closing ]: `[`")
+	// Test find value on not exists worksheet
+	_, err = f.FindValue("SheetN", "total", nil)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test find value with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.FindValue("Sheet1", "total", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestReplaceValue(t *testing.T) {
+	f := prepareFindTestBook(t)
+	count, err := f.ReplaceValue("Sheet1", "east", "West", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	for cell, expected := range map[string]string{"A2": "Region West", "B2": "West", "D2": "West Coast"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test replace value only in the cell values by default
+	count, err = f.ReplaceValue("Sheet1", "2022", "2023", nil)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	value, err := f.EvaluateFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4144", value)
+	count, err = f.ReplaceValue("Sheet1", "2022", "2023", &ReplaceOptions{InFormulas: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	formula, err := f.GetCellFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(C1:C2)+2023", formula)
+	value, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2022", value)
+	// Test the cached value of the replaced formula is cleared
+	value, err = f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+	// Test replace value with regular expression and match case
+	count, err = f.ReplaceValue("Sheet1", "^([a-z]+) (sales)$", "$2 $1", &ReplaceOptions{MatchCase: true, RegExp: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	value, err = f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "sales total", value)
+	count, err = f.ReplaceValue("Sheet1", "TOTAL", "Sum", &ReplaceOptions{MatchCase: true})
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	// Test replace value in the hyperlinks
+	count, err = f.ReplaceValue("Sheet1", "github.com/xuri", "example.com", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = f.ReplaceValue("Sheet1", "Sheet1!A2", "Sheet1!B2", &ReplaceOptions{MatchEntireCell: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = f.ReplaceValue("Sheet1", "Home", "Page", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestReplaceValue.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestReplaceValue.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"B3": "https://example.com/excelize", "C3": "Sheet1!B2"} {
+		ok, link, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, link, cell)
+	}
+	links, err := f.GetAllHyperlinks()
+	assert.NoError(t, err)
+	assert.Equal(t, "Project Page", links["Sheet1"]["B3"].Display)
+	// Test replace value with invalid parameters
+	_, err = f.ReplaceValue("Sheet1", "", "", nil)
+	assert.Equal(t, ErrParameterRequired, err)
+	// Test replace value on not exists worksheet
+	_, err = f.ReplaceValue("SheetN", "total", "sum", nil)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test replace value with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.ReplaceValue("Sheet1", "total", "sum", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestFindAndReplaceAll(t *testing.T) {
+	f := prepareFindTestBook(t)
+	count, err := f.FindAndReplaceAll("Sheet1", map[string]string{
+		"East":        "North East",
+		"Region East": "Area",
+		"EAST":        "NE",
+		"2022":        "2023",
+		"Project":     "Repository",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+	for cell, expected := range map[string]string{"A2": "Area", "B2": "NE", "C2": "2022", "D2": "North East Coast"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(C1:C2)+2022", formula)
+	links, err := f.GetAllHyperlinks()
+	assert.NoError(t, err)
+	assert.Equal(t, "Repository Home", links["Sheet1"]["B3"].Display)
+	// Test find and replace all with empty value
+	_, err = f.FindAndReplaceAll("Sheet1", map[string]string{"": "Empty"})
+	assert.Equal(t, ErrParameterRequired, err)
+	assert.NoError(t, f.Close())
+}
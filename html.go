@@ -0,0 +1,438 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLExportOptions directly maps the settings of exporting a worksheet as
+// an HTML table. RawCellValue specifies if export the cell values without
+// applying the number format. FullDocument specifies if wrap the table in a
+// complete HTML document, the worksheet name will be used as the page title.
+type HTMLExportOptions struct {
+	RawCellValue bool
+	FullDocument bool
+}
+
+// htmlBorderStyles defined the CSS border style mapping of the cell border
+// line styles.
+var htmlBorderStyles = map[string]string{
+	"hair":             "1px solid",
+	"thin":             "1px solid",
+	"dotted":           "1px dotted",
+	"dashed":           "1px dashed",
+	"dashDot":          "1px dashed",
+	"dashDotDot":       "1px dotted",
+	"medium":           "2px solid",
+	"mediumDashed":     "2px dashed",
+	"mediumDashDot":    "2px dashed",
+	"mediumDashDotDot": "2px dotted",
+	"slantDashDot":     "2px dashed",
+	"thick":            "3px solid",
+	"double":           "3px double",
+}
+
+// htmlImageMIMETypes defined the MIME types of the images by extensions for
+// the data URIs.
+var htmlImageMIMETypes = map[string]string{
+	".bmp": "image/bmp", ".emf": "image/x-emf", ".gif": "image/gif",
+	".jpeg": "image/jpeg", ".jpg": "image/jpeg", ".png": "image/png",
+	".svg": "image/svg+xml", ".tif": "image/tiff", ".tiff": "image/tiff",
+	".wmf": "image/x-wmf",
+}
+
+// htmlCell directly maps the cell position and span attributes in the
+// exported HTML table.
+type htmlCell struct {
+	origin           bool
+	colSpan, rowSpan int
+	images           []SheetImage
+}
+
+// ExportToHTML provides a function to export the worksheet as an HTML table
+// by given worksheet name, writer and export options. The fonts, fills,
+// borders and alignments of the cells will be exported as inline CSS, the
+// merged cells will be exported with the colspan and rowspan attributes, the
+// column widths and row heights will be preserved, the images will be
+// embedded as base64 data URIs, and the formula cells will be exported as the
+// cached values. For example, export the worksheet named Sheet1 as a complete
+// HTML document:
+//
+//	file, err := os.Create("Sheet1.html")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	err = f.ExportToHTML("Sheet1", file, &excelize.HTMLExportOptions{
+//	    FullDocument: true,
+//	})
+//
+// 根据给定的工作表名称和导出选项将工作表导出为 HTML 表格。
+func (f *File) ExportToHTML(sheet string, w io.Writer, opts *HTMLExportOptions) error {
+	if opts == nil {
+		opts = &HTMLExportOptions{}
+	}
+	f.mu.Lock()
+	ss, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	rows, err := f.GetRows(sheet, Options{RawCellValue: opts.RawCellValue})
+	if err != nil {
+		return err
+	}
+	mergeCells, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return err
+	}
+	images, err := f.GetAllSheetImages(sheet)
+	if err != nil {
+		return err
+	}
+	maxRow, maxCol := len(rows), 0
+	for _, row := range rows {
+		if len(row) > maxCol {
+			maxCol = len(row)
+		}
+	}
+	var mergeRects [][]int
+	for _, mc := range mergeCells {
+		rect, err := rangeRefToCoordinates(mc[0])
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		mergeRects = append(mergeRects, rect)
+		if rect[2] > maxCol {
+			maxCol = rect[2]
+		}
+		if rect[3] > maxRow {
+			maxRow = rect[3]
+		}
+	}
+	for _, img := range images {
+		col, row, err := CellNameToCoordinates(img.Cell)
+		if err != nil {
+			return err
+		}
+		if col > maxCol {
+			maxCol = col
+		}
+		if row > maxRow {
+			maxRow = row
+		}
+	}
+	cells := make([][]htmlCell, maxRow)
+	for r := range cells {
+		cells[r] = make([]htmlCell, maxCol)
+		for c := range cells[r] {
+			cells[r][c] = htmlCell{origin: true, colSpan: 1, rowSpan: 1}
+		}
+	}
+	for _, rect := range mergeRects {
+		for r := rect[1]; r <= rect[3]; r++ {
+			for c := rect[0]; c <= rect[2]; c++ {
+				cells[r-1][c-1].origin = false
+			}
+		}
+		cells[rect[1]-1][rect[0]-1] = htmlCell{
+			origin: true, colSpan: rect[2] - rect[0] + 1, rowSpan: rect[3] - rect[1] + 1,
+		}
+	}
+	for _, img := range images {
+		col, row, _ := CellNameToCoordinates(img.Cell)
+		for _, rect := range mergeRects {
+			if rect[0] <= col && col <= rect[2] && rect[1] <= row && row <= rect[3] {
+				col, row = rect[0], rect[1]
+				break
+			}
+		}
+		cells[row-1][col-1].images = append(cells[row-1][col-1].images, img)
+	}
+	styleIDs, hiddenRows, hiddenCols, err := f.getHTMLSheetLayout(sheet, maxRow, maxCol)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if opts.FullDocument {
+		buf.WriteString("<!DOCTYPE html><html><head><meta charset=\"utf-8\"><title>")
+		buf.WriteString(html.EscapeString(sheet))
+		buf.WriteString("</title></head><body>")
+	}
+	buf.WriteString("<table style=\"border-collapse:collapse;table-layout:fixed\">")
+	if maxCol > 0 {
+		buf.WriteString("<colgroup>")
+		for c := 1; c <= maxCol; c++ {
+			style := fmt.Sprintf("width:%dpx", f.getColWidth(sheet, c))
+			if hiddenCols[c-1] {
+				style += ";visibility:collapse"
+			}
+			fmt.Fprintf(&buf, "<col style=\"%s\"/>", html.EscapeString(style))
+		}
+		buf.WriteString("</colgroup>")
+	}
+	styles := map[int]string{}
+	for r := 1; r <= maxRow; r++ {
+		style := fmt.Sprintf("height:%dpx", f.getRowHeight(sheet, r))
+		if hiddenRows[r-1] {
+			style += ";display:none"
+		}
+		fmt.Fprintf(&buf, "<tr style=\"%s\">", html.EscapeString(style))
+		for c := 1; c <= maxCol; c++ {
+			cell := cells[r-1][c-1]
+			if !cell.origin {
+				continue
+			}
+			buf.WriteString("<td")
+			if cell.colSpan > 1 {
+				fmt.Fprintf(&buf, " colspan=\"%d\"", cell.colSpan)
+			}
+			if cell.rowSpan > 1 {
+				fmt.Fprintf(&buf, " rowspan=\"%d\"", cell.rowSpan)
+			}
+			styleID := styleIDs[r-1][c-1]
+			if _, ok := styles[styleID]; !ok {
+				styles[styleID] = f.getHTMLCellStyle(ss, styleID)
+			}
+			if styles[styleID] != "" {
+				fmt.Fprintf(&buf, " style=\"%s\"", html.EscapeString(styles[styleID]))
+			}
+			buf.WriteString(">")
+			if r <= len(rows) && c <= len(rows[r-1]) {
+				buf.WriteString(html.EscapeString(rows[r-1][c-1]))
+			}
+			for _, img := range cell.images {
+				mimeType, ok := htmlImageMIMETypes[strings.ToLower(img.Extension)]
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(&buf, "<img src=\"data:%s;base64,%s\" width=\"%d\" height=\"%d\" alt=\"%s\"/>",
+					mimeType, base64.StdEncoding.EncodeToString(img.Data), img.Width, img.Height, html.EscapeString(img.Name))
+			}
+			buf.WriteString("</td>")
+		}
+		buf.WriteString("</tr>")
+	}
+	buf.WriteString("</table>")
+	if opts.FullDocument {
+		buf.WriteString("</body></html>")
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// getHTMLSheetLayout provides a function to get the resolved style index of
+// each cell, and the visibility of the rows and columns in the given range of
+// the worksheet for the HTML export.
+func (f *File) getHTMLSheetLayout(sheet string, maxRow, maxCol int) ([][]int, []bool, []bool, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	styleIDs, hiddenRows, hiddenCols := make([][]int, maxRow), make([]bool, maxRow), make([]bool, maxCol)
+	for r := range styleIDs {
+		styleIDs[r] = make([]int, maxCol)
+		var cells []xlsxC
+		if r < len(ws.SheetData.Row) {
+			cells = ws.SheetData.Row[r].C
+			hiddenRows[r] = ws.SheetData.Row[r].Hidden
+		}
+		for c := range styleIDs[r] {
+			var styleID int
+			if c < len(cells) {
+				styleID = cells[c].S
+			}
+			styleIDs[r][c] = ws.prepareCellStyle(c+1, r+1, styleID)
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			for c := col.Min; c <= col.Max && c <= maxCol; c++ {
+				hiddenCols[c-1] = col.Hidden
+			}
+		}
+	}
+	return styleIDs, hiddenRows, hiddenCols, err
+}
+
+// getHTMLCellStyle provides a function to get the inline CSS of the cell by
+// given style index.
+func (f *File) getHTMLCellStyle(ss *xlsxStyleSheet, styleID int) string {
+	if ss.CellXfs == nil || styleID < 0 || styleID >= len(ss.CellXfs.Xf) {
+		return ""
+	}
+	var (
+		xf     = ss.CellXfs.Xf[styleID]
+		styles []string
+	)
+	if xf.FontID != nil && ss.Fonts != nil && *xf.FontID < len(ss.Fonts.Font) {
+		styles = append(styles, f.getHTMLFontStyle(ss.Fonts.Font[*xf.FontID])...)
+	}
+	if xf.FillID != nil && ss.Fills != nil && *xf.FillID < len(ss.Fills.Fill) {
+		if color := f.getHTMLFillColor(ss.Fills.Fill[*xf.FillID]); color != "" {
+			styles = append(styles, "background-color:"+color)
+		}
+	}
+	if xf.BorderID != nil && ss.Borders != nil && *xf.BorderID < len(ss.Borders.Border) {
+		border := ss.Borders.Border[*xf.BorderID]
+		for _, side := range []struct {
+			name string
+			line xlsxLine
+		}{
+			{"top", border.Top}, {"right", border.Right}, {"bottom", border.Bottom}, {"left", border.Left},
+		} {
+			if style, ok := htmlBorderStyles[side.line.Style]; ok {
+				color := f.getHTMLColor(side.line.Color)
+				if color == "" {
+					color = "#000000"
+				}
+				styles = append(styles, fmt.Sprintf("border-%s:%s %s", side.name, style, color))
+			}
+		}
+	}
+	if xf.Alignment != nil {
+		switch xf.Alignment.Horizontal {
+		case "left", "right", "center", "justify":
+			styles = append(styles, "text-align:"+xf.Alignment.Horizontal)
+		case "centerContinuous":
+			styles = append(styles, "text-align:center")
+		case "distributed":
+			styles = append(styles, "text-align:justify")
+		}
+		switch xf.Alignment.Vertical {
+		case "top", "bottom":
+			styles = append(styles, "vertical-align:"+xf.Alignment.Vertical)
+		case "center", "justify", "distributed":
+			styles = append(styles, "vertical-align:middle")
+		}
+		if xf.Alignment.WrapText {
+			styles = append(styles, "white-space:pre-wrap")
+		}
+	}
+	return strings.Join(styles, ";")
+}
+
+// getHTMLFontStyle provides a function to get the CSS declarations of the
+// given font.
+func (f *File) getHTMLFontStyle(font *xlsxFont) []string {
+	var styles, decorations []string
+	if font == nil {
+		return styles
+	}
+	if font.Name != nil && font.Name.Val != nil {
+		styles = append(styles, fmt.Sprintf("font-family:'%s'", strings.NewReplacer("'", "", "\\", "").Replace(*font.Name.Val)))
+	}
+	if font.Sz != nil && font.Sz.Val != nil {
+		styles = append(styles, fmt.Sprintf("font-size:%gpt", *font.Sz.Val))
+	}
+	isEnabled := func(val *attrValBool) bool {
+		return val != nil && (val.Val == nil || *val.Val)
+	}
+	if isEnabled(font.B) {
+		styles = append(styles, "font-weight:bold")
+	}
+	if isEnabled(font.I) {
+		styles = append(styles, "font-style:italic")
+	}
+	if font.U != nil && (font.U.Val == nil || *font.U.Val != "none") {
+		decorations = append(decorations, "underline")
+	}
+	if isEnabled(font.Strike) {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		styles = append(styles, "text-decoration:"+strings.Join(decorations, " "))
+	}
+	if color := f.getHTMLColor(font.Color); color != "" {
+		styles = append(styles, "color:"+color)
+	}
+	return styles
+}
+
+// getHTMLFillColor provides a function to get the CSS background color of the
+// given fill. The foreground color of the pattern fill and the first stop
+// color of the gradient fill will be used.
+func (f *File) getHTMLFillColor(fill *xlsxFill) string {
+	if fill == nil {
+		return ""
+	}
+	if fill.PatternFill != nil && fill.PatternFill.PatternType != "" && fill.PatternFill.PatternType != "none" {
+		if color := f.getHTMLColor(fill.PatternFill.FgColor); color != "" {
+			return color
+		}
+		return f.getHTMLColor(fill.PatternFill.BgColor)
+	}
+	if fill.GradientFill != nil && len(fill.GradientFill.Stop) > 0 {
+		return f.getHTMLColor(&fill.GradientFill.Stop[0].Color)
+	}
+	return ""
+}
+
+// getHTMLColor provides a function to convert the given color to the CSS
+// color in hex string, the theme colors and indexed colors will be resolved.
+func (f *File) getHTMLColor(clr *xlsxColor) string {
+	if clr == nil || clr.Auto {
+		return ""
+	}
+	var baseColor string
+	switch {
+	case clr.RGB != "":
+		baseColor = strings.TrimPrefix(clr.RGB, "#")
+		if len(baseColor) == 8 {
+			baseColor = baseColor[2:]
+		}
+	case clr.Theme != nil:
+		baseColor = f.getThemeColorRGB(*clr.Theme)
+	case clr.Indexed > 0 && clr.Indexed < len(IndexedColorMapping):
+		baseColor = IndexedColorMapping[clr.Indexed]
+	}
+	if len(baseColor) != 6 {
+		return ""
+	}
+	return "#" + strings.ToUpper(ThemeColor(baseColor, clr.Tint)[2:])
+}
+
+// getThemeColorRGB provides a function to get the RGB color in hex string of
+// the theme color scheme by given theme color index.
+func (f *File) getThemeColorRGB(idx int) string {
+	if f.Theme == nil {
+		return ""
+	}
+	clrScheme := f.Theme.ThemeElements.ClrScheme
+	colors := []xlsxCTColor{
+		clrScheme.Lt1, clrScheme.Dk1, clrScheme.Lt2, clrScheme.Dk2,
+		clrScheme.Accent1, clrScheme.Accent2, clrScheme.Accent3, clrScheme.Accent4,
+		clrScheme.Accent5, clrScheme.Accent6, clrScheme.Hlink, clrScheme.FolHlink,
+	}
+	if idx < 0 || idx >= len(colors) {
+		return ""
+	}
+	if colors[idx].SrgbClr != nil && colors[idx].SrgbClr.Val != nil {
+		return *colors[idx].SrgbClr.Val
+	}
+	if colors[idx].SysClr != nil {
+		return colors[idx].SysClr.LastClr
+	}
+	return ""
+}
@@ -0,0 +1,129 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportToHTML(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "<Title>"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", 2))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "A3+B3"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[2].C[2].V = "3"
+	style, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "single", Strike: true, Family: "Arial", Size: 12, Color: "FF0000"},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:    []Border{{Type: "left", Color: "0000FF", Style: 2}, {Type: "bottom", Style: 6}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", "hidden"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 4, false))
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), nil))
+	img, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportToHTML("Sheet1", &buf, nil))
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "<table style=\"border-collapse:collapse;table-layout:fixed\"><colgroup><col style=\"width:64px\"/><col style=\"width:146px\"/><col style=\"width:64px\"/><col style=\"width:70px;visibility:collapse\"/></colgroup>"))
+	assert.Contains(t, out, "<td colspan=\"3\" rowspan=\"2\" style=\"font-family:&#39;Arial&#39;;font-size:12pt;font-weight:bold;font-style:italic;text-decoration:underline line-through;color:#FF0000;background-color:#FFFF00;border-bottom:3px double #000000;border-left:2px solid #0000FF;text-align:center;vertical-align:middle;white-space:pre-wrap\">&lt;Title&gt;<img src=\"data:image/png;base64,"+base64.StdEncoding.EncodeToString(img)+"\" width=\"")
+	assert.Contains(t, out, "<tr style=\"height:18px\"><td")
+	assert.Contains(t, out, "<tr style=\"height:36px\"><td")
+	assert.Contains(t, out, ">1</td><td")
+	assert.Contains(t, out, ">3</td>")
+	assert.Contains(t, out, "<tr style=\"height:18px;display:none\">")
+	assert.True(t, strings.HasSuffix(out, ">hidden</td></tr></table>"))
+
+	// Test export the worksheet as a complete HTML document
+	buf.Reset()
+	assert.NoError(t, f.ExportToHTML("Sheet1", &buf, &HTMLExportOptions{FullDocument: true}))
+	assert.True(t, strings.HasPrefix(buf.String(), "<!DOCTYPE html><html><head><meta charset=\"utf-8\"><title>Sheet1</title></head><body><table"))
+	assert.True(t, strings.HasSuffix(buf.String(), "</table></body></html>"))
+
+	// Test export the raw cell values
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", 0.5))
+	numFmt, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", numFmt))
+	buf.Reset()
+	assert.NoError(t, f.ExportToHTML("Sheet1", &buf, nil))
+	assert.Contains(t, buf.String(), ">50%</td>")
+	buf.Reset()
+	assert.NoError(t, f.ExportToHTML("Sheet1", &buf, &HTMLExportOptions{RawCellValue: true}))
+	assert.Contains(t, buf.String(), ">0.5</td>")
+
+	// Test export an empty worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.ExportToHTML("Sheet2", &buf, nil))
+	assert.Equal(t, "<table style=\"border-collapse:collapse;table-layout:fixed\"></table>", buf.String())
+	// Test export the worksheet with the picture out of the cell values range
+	assert.NoError(t, f.AddPicture("Sheet2", "B2", filepath.Join("test", "images", "excel.jpg"), nil))
+	buf.Reset()
+	assert.NoError(t, f.ExportToHTML("Sheet2", &buf, nil))
+	assert.Equal(t, 2, strings.Count(buf.String(), "<tr "))
+	assert.Contains(t, buf.String(), "<img src=\"data:image/jpeg;base64,")
+	// Test export the worksheet with the font name contains special characters
+	style, err = f.NewStyle(&Style{Font: &Font{Family: "\"><script>x</script>\\'"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet2", "A1", "A1", style))
+	buf.Reset()
+	assert.NoError(t, f.ExportToHTML("Sheet2", &buf, nil))
+	assert.NotContains(t, buf.String(), "<script>")
+	assert.Contains(t, buf.String(), "<td style=\"font-family:&#39;&#34;&gt;&lt;script&gt;x&lt;/script&gt;&#39;;font-size:11pt")
+	// Test export the worksheet with not exist worksheet
+	assert.EqualError(t, f.ExportToHTML("SheetN", &buf, nil), "sheet SheetN does not exist")
+	// Test export the worksheet with invalid sheet name
+	assert.EqualError(t, f.ExportToHTML("Sheet:1", &buf, nil), ErrSheetNameInvalid.Error())
+	// Test export the worksheet with writer error
+	assert.EqualError(t, f.ExportToHTML("Sheet1", errorWriter{}, nil), "write error")
+	// Test export the worksheet with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExportToHTML("Sheet1", &buf, nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetHTMLColor(t *testing.T) {
+	f := NewFile()
+	assert.Equal(t, "", f.getHTMLColor(nil))
+	assert.Equal(t, "", f.getHTMLColor(&xlsxColor{Auto: true}))
+	assert.Equal(t, "#FF0000", f.getHTMLColor(&xlsxColor{RGB: "FFFF0000"}))
+	assert.Equal(t, "#4472C4", f.getHTMLColor(&xlsxColor{Theme: intPtr(8)}))
+	assert.Equal(t, "#000000", f.getHTMLColor(&xlsxColor{Theme: intPtr(1)}))
+	assert.Equal(t, "#B4C7E7", f.getHTMLColor(&xlsxColor{Theme: intPtr(8), Tint: 0.6}))
+	assert.Equal(t, "#FF0000", f.getHTMLColor(&xlsxColor{Indexed: 10}))
+	assert.Equal(t, "", f.getHTMLColor(&xlsxColor{Theme: intPtr(12)}))
+	assert.Equal(t, "", f.getHTMLColor(&xlsxColor{RGB: "F00"}))
+	assert.Equal(t, "#00FF00", f.getHTMLFillColor(&xlsxFill{GradientFill: &xlsxGradientFill{
+		Stop: []*xlsxGradientFillStop{{Color: xlsxColor{RGB: "FF00FF00"}}},
+	}}))
+	assert.Equal(t, "", f.getHTMLFillColor(nil))
+	f.Theme = nil
+	assert.Equal(t, "", f.getHTMLColor(&xlsxColor{Theme: intPtr(1)}))
+	assert.NoError(t, f.Close())
+}
+
+type errorWriter struct{}
+
+func (errorWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
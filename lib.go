@@ -15,6 +15,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"container/list"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -27,6 +28,12 @@ import (
 
 // ReadZipReader extract spreadsheet with given options.
 func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
+	return f.readZipReader(context.Background(), r)
+}
+
+// readZipReader extract spreadsheet with given options and stop reading when
+// the given context is done.
+func (f *File) readZipReader(ctx context.Context, r *zip.Reader) (map[string][]byte, int, error) {
 	var (
 		err     error
 		docPart = map[string]string{
@@ -38,6 +45,11 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		unzipSize  int64
 	)
 	for _, v := range r.File {
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		default:
+		}
 		fileSize := v.FileInfo().Size()
 		unzipSize += fileSize
 		if unzipSize > f.options.UnzipSizeLimit {
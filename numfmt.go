@@ -1024,6 +1024,51 @@ func (f *File) applyBuiltInNumFmt(c *xlsxC, fmtCode string, numFmtID int, date19
 	return format(c.V, fmtCode, date1904, cellType, f.options)
 }
 
+// isDateTimeNumFmt provides a function to check if the given number format
+// code is a date or time format, it returns true if the first section of the
+// number format contains the date and time tokens.
+func isDateTimeNumFmt(numFmt string) bool {
+	p := nfp.NumberFormatParser()
+	section := p.Parse(numFmt)
+	if len(section) == 0 {
+		return false
+	}
+	for _, token := range section[0].Items {
+		if inStrSlice(supportedDateTimeTokenTypes, token.TType, true) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// getNumFmtCode provides a function to get the number format code by given
+// cell style index, it returns false if the style doesn't exist or the
+// number format is not supported.
+func (f *File) getNumFmtCode(styleIdx int) (string, bool, error) {
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return "", false, err
+	}
+	if styleSheet.CellXfs == nil || styleIdx >= len(styleSheet.CellXfs.Xf) || styleIdx < 0 {
+		return "", false, err
+	}
+	var numFmtID int
+	if styleSheet.CellXfs.Xf[styleIdx].NumFmtID != nil {
+		numFmtID = *styleSheet.CellXfs.Xf[styleIdx].NumFmtID
+	}
+	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
+		return fmtCode, true, err
+	}
+	if styleSheet.NumFmts != nil {
+		for _, xlsxFmt := range styleSheet.NumFmts.NumFmt {
+			if xlsxFmt.NumFmtID == numFmtID {
+				return xlsxFmt.FormatCode, true, err
+			}
+		}
+	}
+	return "", false, err
+}
+
 // langNumFmtFuncEnUS returns number format code by given date and time pattern
 // for country code en-us.
 func (f *File) langNumFmtFuncEnUS(numFmtID int) string {
@@ -1849,16 +1894,17 @@ func (nf *numberFormat) secondsHandler(token nfp.Token) {
 // elapsedDateTimesHandler will be handling elapsed date and times types tokens
 // for a number format expression.
 func (nf *numberFormat) elapsedDateTimesHandler(token nfp.Token) {
+	elapsed := time.Duration(math.Round(nf.number*nanosInADay/float64(time.Second))) * time.Second
 	if strings.Contains(strings.ToUpper(token.TValue), "H") {
-		nf.result += fmt.Sprintf("%.f", nf.t.Sub(excel1900Epoc).Hours())
+		nf.result += fmt.Sprintf("%.f", elapsed.Hours())
 		return
 	}
 	if strings.Contains(strings.ToUpper(token.TValue), "M") {
-		nf.result += fmt.Sprintf("%.f", nf.t.Sub(excel1900Epoc).Minutes())
+		nf.result += fmt.Sprintf("%.f", elapsed.Minutes())
 		return
 	}
 	if strings.Contains(strings.ToUpper(token.TValue), "S") {
-		nf.result += fmt.Sprintf("%.f", nf.t.Sub(excel1900Epoc).Seconds())
+		nf.result += fmt.Sprintf("%.f", elapsed.Seconds())
 		return
 	}
 }
@@ -1869,7 +1915,7 @@ func (nf *numberFormat) hoursNext(i int) int {
 	for idx := i + 1; idx < len(tokens); idx++ {
 		if tokens[idx].TType == nfp.TokenTypeDateTimes {
 			if strings.Contains(strings.ToUpper(tokens[idx].TValue), "H") {
-				t := timeFromExcelTime(nf.number, false)
+				t := timeFromExcelTime(nf.number, nf.date1904)
 				return t.Hour()
 			}
 		}
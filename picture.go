@@ -13,12 +13,14 @@ package excelize
 
 import (
 	"bytes"
-	"encoding/xml"
 	"image"
 	"io"
+	"mime"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -49,6 +51,23 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 	return opts
 }
 
+// GetImageExtensions provides a function to get the lowercase file name
+// extensions of the image types supported by the AddPicture function, sorted
+// in alphabetical order. For example, build the accept attribute of the HTML
+// file input:
+//
+//	accept := strings.Join(excelize.GetImageExtensions(), ",")
+//
+// 获取 AddPicture 函数支持插入的图片格式扩展名列表。
+func GetImageExtensions() []string {
+	extensions := make([]string, 0, len(supportedImageTypes))
+	for ext := range supportedImageTypes {
+		extensions = append(extensions, ext)
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
 // AddPicture provides the method to add picture in a sheet by given picture
 // format set (such as offset, scale, aspect ratio setting and print settings)
 // and file path, supported image types: BMP, EMF, EMZ, GIF, JPEG, JPG, PNG,
@@ -159,6 +178,74 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 	return f.AddPictureFromBytes(sheet, cell, &Picture{Extension: ext, File: file, Format: opts})
 }
 
+// AddPictureFromURL provides the method to add picture in a sheet by given
+// worksheet name, cell reference, the URL of the picture and picture format
+// set. This function downloads the picture by an HTTP GET request, follows up
+// to 5 redirects, and validates the MIME type in the 'Content-Type' header of
+// the response with the supported image types. The size of the picture must
+// be less than or equal to 100 MB. The worksheet name, cell reference and
+// format set will be validated before sending the request. The optional
+// parameter "HTTPTimeout" of the format set specifies the time limit for the
+// request, which can't be negative, the default value of that is 30 seconds.
+// The other format settings are the same with the AddPicture function. For
+// example:
+//
+//	err := f.AddPictureFromURL("Sheet1", "A2", "https://example.com/logo.png",
+//	    &excelize.GraphicOptions{AltText: "Logo", HTTPTimeout: 10 * time.Second})
+//
+// 根据给定的工作表名称、单元格坐标、图片地址和图片格式设置，通过 HTTP 请求下载图片并插入到工作表中。
+func (f *File) AddPictureFromURL(sheet, cell, url string, opts *GraphicOptions) error {
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	timeout := defaultPictureHTTPTimeout
+	if opts != nil {
+		if opts.HTTPTimeout < 0 {
+			return ErrParameterInvalid
+		}
+		if opts.HTTPTimeout > 0 {
+			timeout = opts.HTTPTimeout
+		}
+	}
+	f.mu.Lock()
+	_, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxPictureRedirects {
+				return ErrPictureRedirects
+			}
+			return nil
+		},
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return newUnexpectedHTTPStatusError(resp.Status)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	ext, ok := supportedImageContentTypes[strings.ToLower(mediaType)]
+	if !ok {
+		return newUnsupportedImageContentTypeError(contentType)
+	}
+	file, err := io.ReadAll(io.LimitReader(resp.Body, maxPictureSize+1))
+	if err != nil {
+		return err
+	}
+	if len(file) > maxPictureSize {
+		return ErrMaxPictureSize
+	}
+	return f.AddPictureFromBytes(sheet, cell, &Picture{Extension: ext, File: file, Format: opts})
+}
+
 // AddPictureFromBytes provides the method to add picture in a sheet by given
 // picture format set (such as offset, scale, aspect ratio setting and print
 // settings), file base name, extension name and file bytes, supported image
@@ -497,23 +584,41 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 	}
 	partNames := map[string]string{
 		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartEx":       "/xl/charts/chartEx" + strconv.Itoa(index) + ".xml",
+		"chartDrawing":  "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
 		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"diagramColors": "/xl/diagrams/colors" + strconv.Itoa(index) + ".xml",
+		"diagramData":   "/xl/diagrams/data" + strconv.Itoa(index) + ".xml",
+		"diagramLayout": "/xl/diagrams/layout" + strconv.Itoa(index) + ".xml",
+		"diagramStyle":  "/xl/diagrams/quickStyle" + strconv.Itoa(index) + ".xml",
 		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
 		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
 		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
 		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
 		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"slicer":        "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":   "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
 		"sharedStrings": "/xl/sharedStrings.xml",
+		"theme":         "/" + defaultXMLPathTheme,
 	}
 	contentTypes := map[string]string{
 		"chart":         ContentTypeDrawingML,
+		"chartEx":       ContentTypeDrawingMLChartEx,
+		"chartDrawing":  ContentTypeDrawingMLChartShapes,
 		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
+		"diagramColors": ContentTypeDrawingMLDiagramColors,
+		"diagramData":   ContentTypeDrawingMLDiagramData,
+		"diagramLayout": ContentTypeDrawingMLDiagramLayout,
+		"diagramStyle":  ContentTypeDrawingMLDiagramStyle,
 		"comments":      ContentTypeSpreadSheetMLComments,
 		"drawings":      ContentTypeDrawing,
 		"table":         ContentTypeSpreadSheetMLTable,
 		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
 		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"slicer":        ContentTypeSlicer,
+		"slicerCache":   ContentTypeSlicerCache,
 		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
+		"theme":         ContentTypeTheme,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -612,6 +717,192 @@ func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
 	return f.getPicture(row, col, drawingXML, drawingRelationships)
 }
 
+// GetImageDimensions provides a function to get the display width and height
+// in pixels of the picture embed in spreadsheet by given worksheet name and
+// cell reference. The size of the picture in two cell anchor will be
+// calculated by the width of columns and height of rows that the picture
+// spans, and the size in one cell anchor will be converted from the EMU
+// extents at 96 DPI. This function returns zero width and height if there is
+// no picture in the cell. For example, get the size of the picture in the
+// cell A2 on Sheet1:
+//
+//	width, height, err := f.GetImageDimensions("Sheet1", "A2")
+func (f *File) GetImageDimensions(sheet, cell string) (width, height uint, err error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return
+	}
+	col--
+	row--
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return
+	}
+	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return
+	}
+	wsDr.mu.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.mu.Unlock()
+	for _, anchor := range anchors {
+		deAnchor, err := f.decodeDrawingAnchor(anchor)
+		if err != nil {
+			return 0, 0, err
+		}
+		if deAnchor.From == nil || deAnchor.Pic == nil ||
+			deAnchor.From.Col != col || deAnchor.From.Row != row {
+			continue
+		}
+		w, h := f.getPictureAnchorPixels(sheet, deAnchor)
+		return uint(w), uint(h), err
+	}
+	return
+}
+
+// GetAllSheetImages provides a function to get all pictures embed in the
+// worksheet by given worksheet name. This function returns the anchor cell
+// reference, name, extension, raw content, display size in pixels and
+// hyperlink of each picture. For example, extract all pictures on Sheet1:
+//
+//	images, err := f.GetAllSheetImages("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for idx, img := range images {
+//	    name := fmt.Sprintf("image%d%s", idx+1, img.Extension)
+//	    if err := os.WriteFile(name, img.Data, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetAllSheetImages(sheet string) ([]SheetImage, error) {
+	var images []SheetImage
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return images, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return images, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.ReplaceAll(target, "..", "xl")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return images, err
+	}
+	wsDr.mu.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.mu.Unlock()
+	for _, anchor := range anchors {
+		deAnchor, err := f.decodeDrawingAnchor(anchor)
+		if err != nil {
+			return images, err
+		}
+		if deAnchor.From == nil || deAnchor.Pic == nil {
+			continue
+		}
+		drawRel := f.getDrawingRelationships(drawingRelationships, deAnchor.Pic.BlipFill.Blip.Embed)
+		if drawRel == nil {
+			continue
+		}
+		if _, ok := supportedImageTypes[strings.ToLower(filepath.Ext(drawRel.Target))]; !ok {
+			continue
+		}
+		buffer, _ := f.Pkg.Load(strings.ReplaceAll(drawRel.Target, "..", "xl"))
+		if buffer == nil {
+			continue
+		}
+		cell, err := CoordinatesToCellName(deAnchor.From.Col+1, deAnchor.From.Row+1)
+		if err != nil {
+			return images, err
+		}
+		width, height := f.getPictureAnchorPixels(sheet, deAnchor)
+		img := SheetImage{
+			Cell:      cell,
+			Name:      deAnchor.Pic.NvPicPr.CNvPr.Name,
+			Extension: filepath.Ext(drawRel.Target),
+			Data:      buffer.([]byte),
+			Width:     uint(width),
+			Height:    uint(height),
+		}
+		if hlinkClick := deAnchor.Pic.NvPicPr.CNvPr.HlinkClick; hlinkClick != nil {
+			if hyperlinkRel := f.getDrawingRelationships(drawingRelationships, hlinkClick.RID); hyperlinkRel != nil {
+				img.Hyperlink = hyperlinkRel.Target
+			}
+		}
+		images = append(images, img)
+	}
+	return images, err
+}
+
+// decodeDrawingAnchor provides a function to convert the cell anchor of the
+// drawing part to the decode structure, both the anchor created in memory
+// and the anchor read from the spreadsheet are supported.
+func (f *File) decodeDrawingAnchor(anchor *xdrCellAnchor) (*decodeTwoCellAnchor, error) {
+	deAnchor := new(decodeTwoCellAnchor)
+	if err := f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
+		Decode(deAnchor); err != nil && err != io.EOF {
+		return deAnchor, err
+	}
+	if anchor.From != nil {
+		from := decodeFrom(*anchor.From)
+		deAnchor.From = &from
+	}
+	if anchor.To != nil {
+		to := decodeTo(*anchor.To)
+		deAnchor.To = &to
+	}
+	if anchor.Ext != nil {
+		ext := decodeExt(*anchor.Ext)
+		deAnchor.Ext = &ext
+	}
+	if anchor.Pic != nil {
+		deAnchor.Pic = &decodePic{}
+		deAnchor.Pic.NvPicPr.CNvPr.Name = anchor.Pic.NvPicPr.CNvPr.Name
+		deAnchor.Pic.NvPicPr.CNvPr.Descr = anchor.Pic.NvPicPr.CNvPr.Descr
+		if anchor.Pic.NvPicPr.CNvPr.HlinkClick != nil {
+			deAnchor.Pic.NvPicPr.CNvPr.HlinkClick = &decodeHlinkClick{RID: anchor.Pic.NvPicPr.CNvPr.HlinkClick.RID}
+		}
+		deAnchor.Pic.BlipFill.Blip.Embed = anchor.Pic.BlipFill.Blip.Embed
+		deAnchor.Pic.SpPr.Xfrm.Ext = decodeExt(anchor.Pic.SpPr.Xfrm.Ext)
+	}
+	return deAnchor, nil
+}
+
+// getPictureAnchorPixels provides a function to calculate the width and
+// height in pixels of the picture by given worksheet name and decoded cell
+// anchor.
+func (f *File) getPictureAnchorPixels(sheet string, anchor *decodeTwoCellAnchor) (width, height int) {
+	if anchor.To == nil {
+		if anchor.Ext != nil {
+			return anchor.Ext.Cx / EMU, anchor.Ext.Cy / EMU
+		}
+		return anchor.Pic.SpPr.Xfrm.Ext.Cx / EMU, anchor.Pic.SpPr.Xfrm.Ext.Cy / EMU
+	}
+	for col := anchor.From.Col; col < anchor.To.Col; col++ {
+		width += f.getColWidth(sheet, col+1)
+	}
+	for row := anchor.From.Row; row < anchor.To.Row; row++ {
+		height += f.getRowHeight(sheet, row+1)
+	}
+	width += (anchor.To.ColOff - anchor.From.ColOff) / EMU
+	height += (anchor.To.RowOff - anchor.From.RowOff) / EMU
+	return
+}
+
 // DeletePicture provides a function to delete all pictures in a cell by given
 // worksheet name and cell reference. Note that the image file won't be deleted
 // from the document currently.
@@ -727,18 +1018,6 @@ func (f *File) getDrawingRelationships(rels, rID string) *xlsxRelationship {
 	return nil
 }
 
-// drawingsWriter provides a function to save xl/drawings/drawing%d.xml after
-// serialize structure.
-func (f *File) drawingsWriter() {
-	f.Drawings.Range(func(path, d interface{}) bool {
-		if d != nil {
-			v, _ := xml.Marshal(d.(*xlsxWsDr))
-			f.saveFileList(path.(string), v)
-		}
-		return true
-	})
-}
-
 // drawingResize calculate the height and width after resizing.
 func (f *File) drawingResize(sheet, cell string, width, height float64, opts *GraphicOptions) (w, h, c, r int, err error) {
 	var mergeCells []MergeCell
@@ -7,10 +7,14 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	_ "golang.org/x/image/bmp"
 	_ "golang.org/x/image/tiff"
@@ -32,6 +36,20 @@ func BenchmarkAddPictureFromBytes(b *testing.B) {
 	}
 }
 
+func TestGetImageExtensions(t *testing.T) {
+	extensions := GetImageExtensions()
+	assert.Equal(t, []string{".bmp", ".emf", ".emz", ".gif", ".jpeg", ".jpg", ".png", ".svg", ".tif", ".tiff", ".wmf", ".wmz"}, extensions)
+	// Test the returned extensions are accepted by the AddPicture function
+	f := NewFile()
+	for _, ext := range extensions {
+		assert.NotEqual(t, ErrImgExt, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ext}))
+	}
+	// Test modify the returned slice will not affect the supported image types
+	extensions[0] = ".txt"
+	assert.Equal(t, ".bmp", GetImageExtensions()[0])
+	assert.NoError(t, f.Close())
+}
+
 func TestAddPicture(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -193,6 +211,119 @@ func TestGetPicture(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetImageDimensions(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.png"), &GraphicOptions{ScaleX: 0.5, ScaleY: 0.5}))
+	width, height, err := f.GetImageDimensions("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{200, 128}, []uint{width, height})
+	width, height, err = f.GetImageDimensions("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{100, 64}, []uint{width, height})
+	// Test get image dimensions from a cell that doesn't contain an image
+	width, height, err = f.GetImageDimensions("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{0, 0}, []uint{width, height})
+	// Test get image dimensions from a local storage file
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetImageDimensions.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetImageDimensions.xlsx"))
+	assert.NoError(t, err)
+	width, height, err = f.GetImageDimensions("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{200, 128}, []uint{width, height})
+	// Test get image dimensions from a one cell anchor
+	assert.Equal(t, []int{200, 128}, func() []int {
+		w, h := f.getPictureAnchorPixels("Sheet1", &decodeTwoCellAnchor{
+			From: &decodeFrom{}, Ext: &decodeExt{Cx: 200 * EMU, Cy: 128 * EMU},
+		})
+		return []int{w, h}
+	}())
+	// Test get image dimensions with invalid cell reference
+	_, _, err = f.GetImageDimensions("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get image dimensions with invalid sheet name
+	_, _, err = f.GetImageDimensions("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get image dimensions from worksheet without drawing
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	width, height, err = f.GetImageDimensions("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{0, 0}, []uint{width, height})
+	assert.NoError(t, f.Close())
+	// Test get image dimensions with unsupported charset drawing part
+	f, err = prepareTestBook1()
+	assert.NoError(t, err)
+	_, err = f.decodeDrawingAnchor(&xdrCellAnchor{GraphicFrame: string(MacintoshCyrillicCharset)})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetAllSheetImages(t *testing.T) {
+	f := NewFile()
+	img, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "F2", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{ScaleX: 0.5, ScaleY: 0.5, Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External"}))
+	expected := []SheetImage{
+		{Cell: "A1", Name: "Picture 2", Extension: ".png", Data: img, Width: 200, Height: 128},
+		{Cell: "F2", Name: "Picture 3", Extension: ".png", Data: img, Width: 100, Height: 64, Hyperlink: "https://github.com/xuri/excelize"},
+	}
+	images, err := f.GetAllSheetImages("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, images)
+	// Test get all images from a local storage file
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetAllSheetImages.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetAllSheetImages.xlsx"))
+	assert.NoError(t, err)
+	images, err = f.GetAllSheetImages("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, images)
+	// Test get all images from worksheet without drawing
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	images, err = f.GetAllSheetImages("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, images)
+	// Test get all images with invalid sheet name
+	_, err = f.GetAllSheetImages("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get all images with missing image relationships and media
+	f.Relationships.Store("xl/drawings/_rels/drawing1.xml.rels", &xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Target: "../media/image1.png"},
+		{ID: "rId2", Target: "../media/image2.txt"},
+	}})
+	images, err = f.GetAllSheetImages("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, images, 1)
+	f.Pkg.Delete("xl/media/image1.png")
+	images, err = f.GetAllSheetImages("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, images)
+	// Test get all images with invalid anchor cell
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	wsDr.TwoCellAnchor[0].From = &xlsxFrom{Col: MaxColumns, Row: 0}
+	wsDr.TwoCellAnchor[0].Pic = &xlsxPic{}
+	wsDr.TwoCellAnchor[0].Pic.BlipFill.Blip.Embed = "rId1"
+	f.Pkg.Store("xl/media/image1.png", img)
+	_, err = f.GetAllSheetImages("Sheet1")
+	assert.EqualError(t, err, ErrColumnNumber.Error())
+	// Test get all images with unsupported charset drawing anchor
+	wsDr.TwoCellAnchor[0].GraphicFrame = string(MacintoshCyrillicCharset)
+	_, err = f.GetAllSheetImages("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get all images with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetAllSheetImages("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestAddDrawingPicture(t *testing.T) {
 	// Test addDrawingPicture with illegal cell reference
 	f := NewFile()
@@ -224,6 +355,85 @@ func TestAddPictureFromBytes(t *testing.T) {
 	assert.EqualError(t, f.AddPictureFromBytes("Sheet:1", fmt.Sprint("A", 1), &Picture{Extension: ".png", File: imgFile, Format: &GraphicOptions{AltText: "logo"}}), ErrSheetNameInvalid.Error())
 }
 
+func TestAddPictureFromURL(t *testing.T) {
+	imgFile, err := os.ReadFile("logo.png")
+	assert.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/logo.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(imgFile)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/logo.png", http.StatusFound)
+	})
+	mux.HandleFunc("/hops", func(w http.ResponseWriter, r *http.Request) {
+		hops, _ := strconv.Atoi(r.URL.Query().Get("n"))
+		if hops == 0 {
+			http.Redirect(w, r, "/logo.png", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/hops?n=%d", hops-1), http.StatusFound)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(make([]byte, maxPictureSize+1))
+	})
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("text"))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(imgFile)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := NewFile()
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A1", server.URL+"/logo.png", nil))
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A20", server.URL+"/redirect", &GraphicOptions{AltText: "logo", HTTPTimeout: time.Second}))
+	pics, err := f.GetPictures("Sheet1", "A20")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".png", pics[0].Extension)
+	assert.Equal(t, imgFile, pics[0].File)
+	assert.Equal(t, "logo", pics[0].Format.AltText)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureFromURL.xlsx")))
+	// Test add picture with the maximum number of redirects
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A30", fmt.Sprintf("%s/hops?n=%d", server.URL, maxPictureRedirects-1), nil))
+	// Test add picture with too many redirects
+	assert.ErrorIs(t, f.AddPictureFromURL("Sheet1", "A40", fmt.Sprintf("%s/hops?n=%d", server.URL, maxPictureRedirects), nil), ErrPictureRedirects)
+	// Test add picture with unsupported content type
+	assert.EqualError(t, f.AddPictureFromURL("Sheet1", "A40", server.URL+"/text", nil), newUnsupportedImageContentTypeError("text/plain; charset=utf-8").Error())
+	// Test add picture with unexpected response status
+	assert.EqualError(t, f.AddPictureFromURL("Sheet1", "A40", server.URL+"/notfound", nil), newUnexpectedHTTPStatusError("404 Not Found").Error())
+	// Test add picture with the size exceeds the limit
+	assert.Equal(t, ErrMaxPictureSize, f.AddPictureFromURL("Sheet1", "A40", server.URL+"/large", nil))
+	// Test add picture with request timeout
+	assert.Error(t, f.AddPictureFromURL("Sheet1", "A40", server.URL+"/slow", &GraphicOptions{HTTPTimeout: 50 * time.Millisecond}))
+	// Test add picture with invalid URL
+	assert.Error(t, f.AddPictureFromURL("Sheet1", "A40", "://invalid", nil))
+	// Test add picture on not exists worksheet
+	assert.EqualError(t, f.AddPictureFromURL("SheetN", "A40", server.URL+"/logo.png", nil), "sheet SheetN does not exist")
+	// Test add picture with invalid parameters, the picture should not be
+	// requested
+	var requests int
+	mux.HandleFunc("/count", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(imgFile)
+	})
+	assert.EqualError(t, f.AddPictureFromURL("SheetN", "A40", server.URL+"/count", nil), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddPictureFromURL("Sheet:1", "A40", server.URL+"/count", nil), ErrSheetNameInvalid.Error())
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddPictureFromURL("Sheet1", "A", server.URL+"/count", nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddPictureFromURL("Sheet1", "A40", server.URL+"/count", &GraphicOptions{HTTPTimeout: -time.Second}))
+	assert.Zero(t, requests)
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A40", server.URL+"/count", nil))
+	assert.Equal(t, 1, requests)
+	assert.NoError(t, f.Close())
+}
+
 func TestDeletePicture(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -12,8 +12,11 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"path"
 	"strconv"
 	"strings"
 )
@@ -719,3 +722,293 @@ func (f *File) addWorkbookPivotCache(RID int) int {
 	})
 	return cacheID
 }
+
+// GetPivotCacheData provides a function to get the cached source data of the
+// pivot table by given worksheet name and pivot table name. The first row of
+// the result contains the names of the cache fields, and the following rows
+// contain the raw values in the pivot cache records, which are available even
+// if the source data of the pivot table is not in the workbook. Boolean values
+// are returned as TRUE or FALSE, and date values are returned as ISO 8601
+// date time strings. Only the field names will be returned if the pivot cache
+// doesn't save the records. For example, get the cached data of the pivot
+// table named "PivotTable1" on Sheet1:
+//
+//	rows, err := f.GetPivotCacheData("Sheet1", "PivotTable1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, row := range rows {
+//	    fmt.Println(row)
+//	}
+func (f *File) GetPivotCacheData(sheet, pivotTableName string) ([][]string, error) {
+	rels, err := f.GetWorksheetRelationships(sheet)
+	if err != nil {
+		return nil, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	for _, rel := range rels {
+		if rel.Type != SourceRelationshipPivotTable {
+			continue
+		}
+		pivotTableXML := getPartRelTargetPath(sheetXMLPath, rel.Target)
+		pt := new(xlsxPivotTableDefinition)
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotTableXML)))).
+			Decode(pt); err != nil && err != io.EOF {
+			return nil, err
+		}
+		if pt.Name == pivotTableName {
+			return f.getPivotCacheData(pivotTableXML)
+		}
+	}
+	return nil, newNoExistPivotTableError(pivotTableName)
+}
+
+// GetPivotTables provides the method to get the options of all pivot tables
+// in a worksheet by given worksheet name. The source data range and the pivot
+// table range are returned as absolute range references with the worksheet
+// name, such as Sheet1!$A$1:$E$31, and the fields are matched with the
+// names of the cache fields. For example, get the pivot tables on Sheet1:
+//
+//	pivotTables, err := f.GetPivotTables("Sheet1")
+func (f *File) GetPivotTables(sheet string) ([]PivotTableOptions, error) {
+	var pivotTables []PivotTableOptions
+	rels, err := f.GetWorksheetRelationships(sheet)
+	if err != nil {
+		return pivotTables, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	for _, rel := range rels {
+		if rel.Type != SourceRelationshipPivotTable {
+			continue
+		}
+		opts, err := f.getPivotTable(sheet, getPartRelTargetPath(sheetXMLPath, rel.Target))
+		if err != nil {
+			return pivotTables, err
+		}
+		pivotTables = append(pivotTables, opts)
+	}
+	return pivotTables, err
+}
+
+// getPivotTable provides a function to get the options of the pivot table by
+// given worksheet name and pivot table part name.
+func (f *File) getPivotTable(sheet, pivotTableXML string) (PivotTableOptions, error) {
+	opts := PivotTableOptions{
+		pivotTableSheetName: sheet,
+		RowGrandTotals:      true,
+		ColGrandTotals:      true,
+		ShowDrill:           true,
+		CompactData:         true,
+	}
+	pt := new(xlsxPivotTableDefinition)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotTableXML)))).
+		Decode(pt); err != nil && err != io.EOF {
+		return opts, err
+	}
+	pc := new(decodePivotCacheDefinition)
+	pivotCacheXML, err := f.getPartRelTarget(pivotTableXML, SourceRelationshipPivotCache, "")
+	if err != nil {
+		return opts, err
+	}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotCacheXML)))).
+		Decode(pc); err != nil && err != io.EOF {
+		return opts, err
+	}
+	if pc.CacheSource != nil && pc.CacheSource.WorksheetSource != nil {
+		opts.DataRange = pc.CacheSource.WorksheetSource.Name
+		if opts.DataRange == "" {
+			opts.DataRange = pc.CacheSource.WorksheetSource.Sheet + "!" + getAbsRangeRef(pc.CacheSource.WorksheetSource.Ref)
+		}
+	}
+	if pt.Location != nil {
+		opts.PivotTableRange = sheet + "!" + getAbsRangeRef(pt.Location.Ref)
+	}
+	for _, item := range []struct{ val, ptr *bool }{
+		{pt.RowGrandTotals, &opts.RowGrandTotals}, {pt.ColGrandTotals, &opts.ColGrandTotals},
+		{pt.ShowDrill, &opts.ShowDrill}, {pt.UseAutoFormatting, &opts.UseAutoFormatting},
+		{pt.PageOverThenDown, &opts.PageOverThenDown}, {pt.MergeItem, &opts.MergeItem},
+		{pt.CompactData, &opts.CompactData}, {pt.ShowError, &opts.ShowError},
+	} {
+		if item.val != nil {
+			*item.ptr = *item.val
+		}
+	}
+	if pt.PivotTableStyleInfo != nil {
+		opts.PivotTableStyleName = pt.PivotTableStyleInfo.Name
+		opts.ShowRowHeaders = pt.PivotTableStyleInfo.ShowRowHeaders
+		opts.ShowColHeaders = pt.PivotTableStyleInfo.ShowColHeaders
+		opts.ShowRowStripes = pt.PivotTableStyleInfo.ShowRowStripes
+		opts.ShowColStripes = pt.PivotTableStyleInfo.ShowColStripes
+		opts.ShowLastColumn = pt.PivotTableStyleInfo.ShowLastColumn
+	}
+	fieldName := func(idx int) string {
+		if idx >= 0 && idx < len(pc.CacheFields.CacheField) {
+			return pc.CacheFields.CacheField[idx].Name
+		}
+		return ""
+	}
+	if pt.RowFields != nil {
+		opts.Rows = getPivotTableAxisFields(pt, pt.RowFields.Field, fieldName)
+	}
+	if pt.ColFields != nil {
+		opts.Columns = getPivotTableAxisFields(pt, pt.ColFields.Field, fieldName)
+	}
+	if pt.PageFields != nil {
+		for _, field := range pt.PageFields.PageField {
+			opts.Filter = append(opts.Filter, PivotTableField{Data: fieldName(field.Fld), Name: field.Name})
+		}
+	}
+	if pt.DataFields != nil {
+		for _, field := range pt.DataFields.DataField {
+			subtotal := field.Subtotal
+			if subtotal == "" {
+				subtotal = "sum"
+			}
+			opts.Data = append(opts.Data, PivotTableField{
+				Data: fieldName(field.Fld), Name: field.Name,
+				Subtotal: strings.ToUpper(subtotal[:1]) + subtotal[1:],
+			})
+		}
+	}
+	return opts, err
+}
+
+// getPivotTableAxisFields provides a function to get the options of the row
+// or column fields by given pivot table definition, fields on the axis and
+// the function to get the cache field name by index. The values field with
+// negative index will be skipped.
+func getPivotTableAxisFields(pt *xlsxPivotTableDefinition, fields []*xlsxField, fieldName func(int) string) []PivotTableField {
+	var axisFields []PivotTableField
+	for _, field := range fields {
+		if field.X < 0 {
+			continue
+		}
+		opts := PivotTableField{Data: fieldName(field.X), Compact: true, Outline: true, DefaultSubtotal: true}
+		if pt.PivotFields != nil && field.X < len(pt.PivotFields.PivotField) {
+			pf := pt.PivotFields.PivotField[field.X]
+			opts.Name = pf.Name
+			for _, item := range []struct{ val, ptr *bool }{
+				{pf.Compact, &opts.Compact}, {pf.Outline, &opts.Outline}, {pf.DefaultSubtotal, &opts.DefaultSubtotal},
+			} {
+				if item.val != nil {
+					*item.ptr = *item.val
+				}
+			}
+		}
+		axisFields = append(axisFields, opts)
+	}
+	return axisFields
+}
+
+// getAbsRangeRef returns the absolute reference of the given cell or range
+// reference, such as convert A1:E31 to $A$1:$E$31.
+func getAbsRangeRef(ref string) string {
+	cells := strings.Split(ref, ":")
+	for i, cell := range cells {
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return ref
+		}
+		cells[i], _ = CoordinatesToCellName(col, row, true)
+	}
+	return strings.Join(cells, ":")
+}
+
+// getPivotCacheData provides a function to get the cache field names and the
+// cache records of the pivot table by given pivot table part name.
+func (f *File) getPivotCacheData(pivotTableXML string) ([][]string, error) {
+	var data [][]string
+	pivotCacheXML, err := f.getPartRelTarget(pivotTableXML, SourceRelationshipPivotCache, "")
+	if err != nil || pivotCacheXML == "" {
+		return data, err
+	}
+	pc := new(decodePivotCacheDefinition)
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotCacheXML)))).
+		Decode(pc); err != nil && err != io.EOF {
+		return data, err
+	}
+	var fields []*decodeCacheField
+	header := []string{}
+	for _, field := range pc.CacheFields.CacheField {
+		// Calculated fields are not stored in the cache records
+		if field.DatabaseField == nil || *field.DatabaseField {
+			fields = append(fields, field)
+			header = append(header, field.Name)
+		}
+	}
+	data = append(data, header)
+	if pc.RID == "" {
+		return data, err
+	}
+	recordsXML, err := f.getPartRelTarget(pivotCacheXML, SourceRelationshipPivotCacheRecords, pc.RID)
+	if err != nil || recordsXML == "" {
+		return data, err
+	}
+	records := new(decodePivotCacheRecords)
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(recordsXML)))).
+		Decode(records); err != nil && err != io.EOF {
+		return data, err
+	}
+	for _, record := range records.R {
+		row := make([]string, len(record.Items))
+		for i, item := range record.Items {
+			var sharedItems []decodePivotCacheItem
+			if i < len(fields) {
+				sharedItems = fields[i].SharedItems.Items
+			}
+			row[i] = getPivotCacheItemValue(item, sharedItems)
+		}
+		data = append(data, row)
+	}
+	return data, nil
+}
+
+// getPivotCacheItemValue returns the raw value of the given pivot cache item,
+// the index item will be resolved by given shared items of the cache field.
+func getPivotCacheItemValue(item decodePivotCacheItem, sharedItems []decodePivotCacheItem) string {
+	switch item.XMLName.Local {
+	case "x":
+		idx, err := strconv.Atoi(item.V)
+		if err != nil || idx < 0 || idx >= len(sharedItems) {
+			return ""
+		}
+		return getPivotCacheItemValue(sharedItems[idx], nil)
+	case "b":
+		if item.V == "1" || item.V == "true" {
+			return "TRUE"
+		}
+		return "FALSE"
+	case "m":
+		return ""
+	}
+	return item.V
+}
+
+// getPartRelTarget provides a function to get the part name of the first
+// relationship target by given source part name, relationship type and
+// optional relationship ID. It returns an empty string if the relationship
+// doesn't exist.
+func (f *File) getPartRelTarget(partName, relType, rID string) (string, error) {
+	rels, err := f.relsReader(path.Join(path.Dir(partName), "_rels", path.Base(partName)+".rels"))
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == relType && (rID == "" || rel.ID == rID) {
+			return getPartRelTargetPath(partName, rel.Target), err
+		}
+	}
+	return "", err
+}
+
+// getPartRelTargetPath returns the part name of the relationship target by
+// given source part name and the relative or absolute target.
+func getPartRelTargetPath(partName, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(partName), target)
+}
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math/rand"
 	"path/filepath"
@@ -321,3 +322,168 @@ func TestGetPivotTableFieldName(t *testing.T) {
 	f := NewFile()
 	f.getPivotTableFieldName("-", []PivotTableField{})
 }
+
+func TestGetPivotCacheData(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Region", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"East", 100}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!$A$1:$B$2",
+		PivotTableRange: "Sheet1!$D$1:$E$3",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	// Test get the cache data without saved records
+	data, err := f.GetPivotCacheData("Sheet1", "Pivot Table1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Region", "Sales"}}, data)
+
+	pivotCacheXML := "xl/pivotCache/pivotCacheDefinition1.xml"
+	f.Pkg.Store(pivotCacheXML, []byte(`<pivotCacheDefinition xmlns="`+NameSpaceSpreadSheet.Value+`" xmlns:r="`+SourceRelationship.Value+`" r:id="rId1" saveData="1"><cacheFields count="5">`+
+		`<cacheField name="Region"><sharedItems count="2"><s v="East"/><s v="West"/></sharedItems></cacheField>`+
+		`<cacheField name="Sales"><sharedItems containsNumber="1"/></cacheField>`+
+		`<cacheField name="Bonus" databaseField="0" formula="Sales*0.1"><sharedItems/></cacheField>`+
+		`<cacheField name="Paid"><sharedItems/></cacheField>`+
+		`<cacheField name="Date"><sharedItems/></cacheField></cacheFields></pivotCacheDefinition>`))
+	f.addRels("xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels", SourceRelationshipPivotCacheRecords, "pivotCacheRecords1.xml", "")
+	f.Pkg.Store("xl/pivotCache/pivotCacheRecords1.xml", []byte(`<pivotCacheRecords xmlns="`+NameSpaceSpreadSheet.Value+`" count="3">`+
+		`<r><x v="1"/><n v="200.5"/><b v="1"/><d v="2023-01-02T00:00:00"/></r>`+
+		`<r><x v="0"/><n v="100"/><b v="0"/><m/></r>`+
+		`<r><x v="2"/><e v="#N/A"/></r></pivotCacheRecords>`))
+	data, err = f.GetPivotCacheData("Sheet1", "Pivot Table1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Region", "Sales", "Paid", "Date"},
+		{"West", "200.5", "TRUE", "2023-01-02T00:00:00"},
+		{"East", "100", "FALSE", ""},
+		{"", "#N/A"},
+	}, data)
+
+	// Test get the cache data with not exist pivot table
+	_, err = f.GetPivotCacheData("Sheet1", "PivotTable2")
+	assert.EqualError(t, err, newNoExistPivotTableError("PivotTable2").Error())
+	// Test get the cache data with not exist worksheet
+	_, err = f.GetPivotCacheData("SheetN", "Pivot Table1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get the cache data with unsupported charset pivot cache records
+	f.Pkg.Store("xl/pivotCache/pivotCacheRecords1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetPivotCacheData("Sheet1", "Pivot Table1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get the cache data with unsupported charset pivot cache definition
+	f.Pkg.Store(pivotCacheXML, MacintoshCyrillicCharset)
+	_, err = f.GetPivotCacheData("Sheet1", "Pivot Table1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get the cache data with unsupported charset pivot table
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetPivotCacheData("Sheet1", "Pivot Table1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get the cache data without pivot cache relationship
+	f.Relationships.Delete("xl/pivotTables/_rels/pivotTable1.xml.rels")
+	f.Pkg.Delete("xl/pivotTables/_rels/pivotTable1.xml.rels")
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", []byte(`<pivotTableDefinition xmlns="`+NameSpaceSpreadSheet.Value+`" name="Pivot Table1"/>`))
+	data, err = f.GetPivotCacheData("Sheet1", "Pivot Table1")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetPivotTables(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Month", "Year", "Type", "Sales", "Region"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Jan", 2017, "Meat", 100, "East"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "dataRange", RefersTo: "Sheet1!$A$1:$E$2"}))
+	expected := []PivotTableOptions{
+		{
+			pivotTableSheetName: "Sheet1",
+			DataRange:           "Sheet1!$A$1:$E$2",
+			PivotTableRange:     "Sheet1!$G$2:$M$34",
+			Rows:                []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year", Name: "Years", Compact: true, Outline: true}},
+			Filter:              []PivotTableField{{Data: "Region", Name: "Area"}},
+			Columns:             []PivotTableField{{Data: "Type", DefaultSubtotal: true}},
+			Data:                []PivotTableField{{Data: "Sales", Name: "Summarize", Subtotal: "Sum"}, {Data: "Sales", Name: "Total", Subtotal: "CountNums"}},
+			RowGrandTotals:      true,
+			ColGrandTotals:      true,
+			ShowDrill:           true,
+			ShowRowHeaders:      true,
+			ShowColHeaders:      true,
+			ShowLastColumn:      true,
+			PivotTableStyleName: "PivotStyleLight16",
+		},
+		{
+			pivotTableSheetName: "Sheet1",
+			DataRange:           "dataRange",
+			PivotTableRange:     "Sheet1!$O$2:$P$10",
+			Rows:                []PivotTableField{{Data: "Region"}},
+			Data:                []PivotTableField{{Data: "Sales", Subtotal: "Average"}},
+			CompactData:         true,
+			ShowError:           true,
+			PivotTableStyleName: "PivotStyleMedium2",
+		},
+	}
+	for _, opts := range expected {
+		opts := opts
+		assert.NoError(t, f.AddPivotTable(&opts))
+	}
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pivotTables)
+	// Test get the pivot tables after saving and reopening the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPivotTables.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetPivotTables.xlsx"))
+	assert.NoError(t, err)
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pivotTables)
+	// Test get the pivot tables without pivot tables
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	pivotTables, err = f.GetPivotTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, pivotTables)
+	// Test get the pivot tables on not exists worksheet
+	_, err = f.GetPivotTables("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get the pivot tables with default settings
+	f.Pkg.Store("xl/pivotTables/pivotTable2.xml", []byte(`<pivotTableDefinition xmlns="`+NameSpaceSpreadSheet.Value+`" name="PivotTable2" cacheId="1">`+
+		`<pivotFields count="1"><pivotField axis="axisRow"/></pivotFields><rowFields count="2"><field x="0"/><field x="5"/></rowFields><dataFields count="1"><dataField fld="1"/></dataFields></pivotTableDefinition>`))
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition2.xml", []byte(`<pivotCacheDefinition xmlns="`+NameSpaceSpreadSheet.Value+`"><cacheFields count="2">`+
+		`<cacheField name="Month"/><cacheField name="Sales"/></cacheFields></pivotCacheDefinition>`))
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PivotTableOptions{
+		pivotTableSheetName: "Sheet1",
+		Rows:                []PivotTableField{{Data: "Month", Compact: true, Outline: true, DefaultSubtotal: true}, {Compact: true, Outline: true, DefaultSubtotal: true}},
+		Data:                []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+		RowGrandTotals:      true,
+		ColGrandTotals:      true,
+		ShowDrill:           true,
+		CompactData:         true,
+	}, pivotTables[1])
+	// Test get the pivot tables with unsupported charset pivot cache definition
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition2.xml", MacintoshCyrillicCharset)
+	_, err = f.GetPivotTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get the pivot tables with unsupported charset pivot table relationships
+	f.Relationships.Delete("xl/pivotTables/_rels/pivotTable2.xml.rels")
+	f.Pkg.Store("xl/pivotTables/_rels/pivotTable2.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetPivotTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get the pivot tables with unsupported charset pivot table
+	f.Pkg.Store("xl/pivotTables/pivotTable2.xml", MacintoshCyrillicCharset)
+	_, err = f.GetPivotTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetAbsRangeRef(t *testing.T) {
+	assert.Equal(t, "$A$1:$E$31", getAbsRangeRef("A1:E31"))
+	assert.Equal(t, "$B$2", getAbsRangeRef("B2"))
+	assert.Equal(t, "A:B", getAbsRangeRef("A:B"))
+}
+
+func TestGetPivotCacheItemValue(t *testing.T) {
+	assert.Equal(t, "", getPivotCacheItemValue(decodePivotCacheItem{XMLName: xml.Name{Local: "x"}, V: "a"}, nil))
+	assert.Equal(t, "TRUE", getPivotCacheItemValue(decodePivotCacheItem{XMLName: xml.Name{Local: "b"}, V: "true"}, nil))
+	assert.Equal(t, "text", getPivotCacheItemValue(decodePivotCacheItem{XMLName: xml.Name{Local: "s"}, V: "text"}, nil))
+}
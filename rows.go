@@ -19,6 +19,7 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/mohae/deepcopy"
 )
@@ -50,7 +51,7 @@ import (
 // 如果可以将单元格格式应用于单元格的值，将使用应用后的值，否则将使用原始值。
 // GetRows 获取带有值或公式单元格的行，行尾连续为空的单元格将被跳过，每行中的单元格数目可能不同。
 func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
-	rows, err := f.Rows(sheet)
+	rows, err := f.Rows(sheet, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +74,9 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 type Rows struct {
 	err                     error
 	curRow, seekRow         int
+	maxRows                 int
+	colWidths               []float64
+	measurer                *textMeasurer
 	needClose, rawCellValue bool
 	sheet                   string
 	f                       *File
@@ -86,6 +90,9 @@ type Rows struct {
 // Next will return true if find the next row element.
 // 如果下一行有值存在将返回 true。
 func (rows *Rows) Next() bool {
+	if rows.maxRows > 0 && rows.seekRow >= rows.maxRows {
+		return false
+	}
 	rows.seekRow++
 	if rows.curRow >= rows.seekRow {
 		rows.curRowOpts = rows.seekRowOpts
@@ -242,13 +249,66 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 		blank := rowIterator.cellCol - len(rowIterator.cells)
 		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
 			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
+			if rows.measurer != nil {
+				rows.setColWidth(rowIterator.cellCol, rows.measurer.measure(colCell.S, val))
+			}
 		}
 	}
 }
 
+// setColWidth provides a function to update the maximum content width of the
+// column by given column number and the width of the cell value.
+func (rows *Rows) setColWidth(col int, width float64) {
+	for len(rows.colWidths) < col {
+		rows.colWidths = append(rows.colWidths, 0)
+	}
+	if width > rows.colWidths[col-1] {
+		rows.colWidths[col-1] = width
+	}
+}
+
+// CollectColWidths enables collecting the maximum content width of each
+// column of the rows which will be read by the Columns function, call it
+// before reading the rows. For example:
+//
+//	rows, err := f.Rows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err = rows.CollectColWidths(); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// 启用收集此后通过 Columns 函数读取的行中各列内容的最大宽度。
+func (rows *Rows) CollectColWidths() error {
+	rows.f.mu.Lock()
+	styles, err := rows.f.stylesReader()
+	rows.f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	rows.measurer = newTextMeasurer(styles)
+	return err
+}
+
+// GetColWidths return the maximum content width of each column of the rows
+// which have been read by the Columns function, the first element is the
+// width of the column A. The widths will be collected only after calling the
+// CollectColWidths function, and the width of the column without value is 0.
+// The result could be used to set the column width by the SetColWidth
+// function, the cell styles are applied in the same way as the
+// AutoFitColumnWidth function, but the merged cells will not be ignored.
+//
+// 返回通过 Columns 函数读取过的行中各列内容的最大宽度。
+func (rows *Rows) GetColWidths() []float64 {
+	return rows.colWidths
+}
+
 // Rows returns a rows iterator, used for streaming reading data for a
-// worksheet with a large data. This function is concurrency safe. For
-// example:
+// worksheet with a large data. This function is concurrency safe. The
+// optional MaxRows option limits the number of rows returned by the iterator.
+// For example:
 //
 //	rows, err := f.Rows("Sheet1")
 //	if err != nil {
@@ -270,7 +330,7 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 //	}
 //
 // 根据给定的工作表名称获取该工作表的行迭代器。此功能是并发安全的。
-func (f *File) Rows(sheet string) (*Rows, error) {
+func (f *File) Rows(sheet string, opts ...Options) (*Rows, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -287,11 +347,152 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
 	}
 	var err error
-	rows := Rows{f: f, sheet: name}
+	options := getOptions(opts...)
+	rows := Rows{f: f, sheet: name, maxRows: options.MaxRows}
 	rows.needClose, rows.decoder, rows.tempFile, err = f.xmlDecoder(name)
 	return &rows, err
 }
 
+// RowIterator defines an iterator to a sheet which supports both forward and
+// backward traversal. The rows are streamed from the worksheet on the first
+// forward pass and cached, so that moving backward or seeking to a row which
+// has been visited doesn't need to parse the worksheet again.
+type RowIterator struct {
+	err    error
+	cursor int
+	opts   []Options
+	rows   *Rows
+	cache  []rowIteratorItem
+}
+
+// rowIteratorItem directly maps the cached cell values and row element
+// attributes of a row for the row iterator.
+type rowIteratorItem struct {
+	columns []string
+	rowOpts RowOpts
+}
+
+// GetRowIterator returns a row iterator by given worksheet name, which
+// supports moving forward with Next, moving backward with Prev and jumping to
+// a row with Seek. For example, traverse the rows of a worksheet named
+// 'Sheet1' in reverse order:
+//
+//	iter, err := f.GetRowIterator("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for iter.Next() {
+//	}
+//	for ok := iter.CurrentRow() > 0; ok; ok = iter.Prev() {
+//	    fmt.Println(iter.CurrentRow(), iter.Columns())
+//	}
+//	if err = iter.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// 根据给定的工作表名称获取该工作表支持向前、向后遍历和定位的行迭代器。
+func (f *File) GetRowIterator(sheet string, opts ...Options) (*RowIterator, error) {
+	rows, err := f.Rows(sheet, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &RowIterator{rows: rows, opts: opts}, nil
+}
+
+// fetch reads the next row from the worksheet stream into the cache, and
+// returns false if there are no more rows.
+func (iter *RowIterator) fetch() bool {
+	if iter.err != nil || !iter.rows.Next() {
+		return false
+	}
+	item := rowIteratorItem{rowOpts: iter.rows.GetRowOpts()}
+	if iter.rows.curRow > iter.rows.seekRow {
+		item.rowOpts = extractRowOpts(nil)
+	}
+	if item.columns, iter.err = iter.rows.Columns(iter.opts...); iter.err != nil {
+		return false
+	}
+	iter.cache = append(iter.cache, item)
+	return true
+}
+
+// Next will move the iterator to the next row and return true if the row
+// exists.
+// 移动到下一行，如果下一行存在将返回 true。
+func (iter *RowIterator) Next() bool {
+	if iter.cursor >= len(iter.cache) && !iter.fetch() {
+		return false
+	}
+	iter.cursor++
+	return true
+}
+
+// Prev will move the iterator to the previous row and return true if the row
+// exists.
+// 移动到上一行，如果上一行存在将返回 true。
+func (iter *RowIterator) Prev() bool {
+	if iter.cursor <= 1 {
+		return false
+	}
+	iter.cursor--
+	return true
+}
+
+// Seek will move the iterator to the given row number, and return true if the
+// row exists. The row number starts from 1.
+// 移动到给定的行号（从 1 开始），如果该行存在将返回 true。
+func (iter *RowIterator) Seek(row int) bool {
+	if row < 1 {
+		return false
+	}
+	for len(iter.cache) < row {
+		if !iter.fetch() {
+			return false
+		}
+	}
+	iter.cursor = row
+	return true
+}
+
+// CurrentRow returns the row number of the current row, or 0 if the iterator
+// hasn't been moved to any row yet.
+// 返回当前行的行号，迭代器尚未移动时返回 0。
+func (iter *RowIterator) CurrentRow() int {
+	return iter.cursor
+}
+
+// Columns return the current row's column values.
+// 返回当前行中各列单元格的值。
+func (iter *RowIterator) Columns() []string {
+	if iter.cursor < 1 {
+		return nil
+	}
+	return iter.cache[iter.cursor-1].columns
+}
+
+// GetRowOpts will return the RowOpts of the current row.
+// 返回当前行的行高、可见性和样式 ID 属性。
+func (iter *RowIterator) GetRowOpts() RowOpts {
+	if iter.cursor < 1 {
+		return RowOpts{}
+	}
+	return iter.cache[iter.cursor-1].rowOpts
+}
+
+// Error will return the error when the error occurs.
+// 当读取行出现错误时将返回 error。
+func (iter *RowIterator) Error() error {
+	return iter.err
+}
+
+// Close closes the open worksheet XML file in the system temporary
+// directory.
+// 关闭数据流并清理打开工作表时可能产生的系统磁盘缓存。
+func (iter *RowIterator) Close() error {
+	return iter.rows.Close()
+}
+
 // getFromStringItem build shared string item offset list from system temporary
 // file at one time, and return value by given to string index.
 func (f *File) getFromStringItem(index int) string {
@@ -381,6 +582,140 @@ func (f *File) SetRowHeight(sheet string, row int, height float64) error {
 	return nil
 }
 
+// lineHeightFactor defined the ratio of the line height to the font size, the
+// default row height is 15 points for the default font size of 11 points.
+const lineHeightFactor = 15.0 / 11
+
+// AutoFitRowHeight provides a function to set the height of the row by given
+// worksheet name and row number, to fit the cell values in the row. The
+// number of lines of each cell with wrapped text is counted by the new line
+// characters and the estimated text width in the column, and the height of
+// the row is the maximum of the number of lines multiplied by the font size
+// and the line spacing. The maximum font size of the runs will be used for
+// the rich text cells, and the merged cells will be ignored. The rows without
+// any value keep the original height. Note that the text width is based on
+// an internal character width table instead of the actual font metrics, so
+// the height may be slightly different with the height in the spreadsheet
+// application. For example, auto fit the height of the first row in Sheet1:
+//
+//	err := f.AutoFitRowHeight("Sheet1", 1)
+//
+// 根据给定的工作表名称和行号，按照行中单元格显示内容的行数自动调整行高。
+func (f *File) AutoFitRowHeight(sheet string, row int) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	if row > TotalRows {
+		return ErrMaxRows
+	}
+	return f.autoFitRowHeights(sheet, row)
+}
+
+// AutoFitAllRowHeights provides a function to set the height of all rows with
+// values in the worksheet by given worksheet name, to fit the cell values in
+// each row. The height of each row is calculated in the same way as the
+// AutoFitRowHeight function. For example, auto fit the height of all rows in
+// Sheet1:
+//
+//	err := f.AutoFitAllRowHeights("Sheet1")
+//
+// 根据给定的工作表名称，按照单元格显示内容的行数自动调整工作表中全部行的行高。
+func (f *File) AutoFitAllRowHeights(sheet string) error {
+	return f.autoFitRowHeights(sheet, 0)
+}
+
+// autoFitRowHeights provides a function to set the height of the row by given
+// worksheet name and row number to fit the cell values, set the height of all
+// rows if the row number is 0.
+func (f *File) autoFitRowHeights(sheet string, row int) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	styles, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	measurer := newTextMeasurer(styles)
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	merged := ws.getMergeCellsCoordinates(func(coordinates []int) bool { return true })
+	for idx := range ws.SheetData.Row {
+		r := &ws.SheetData.Row[idx]
+		if row != 0 && r.R != row {
+			continue
+		}
+		height, err := f.getRowFitHeight(ws, measurer, sst, r, merged)
+		if err != nil {
+			return err
+		}
+		if height > 0 {
+			r.Ht, r.CustomHeight = float64Ptr(height), false
+		}
+	}
+	return err
+}
+
+// getRowFitHeight returns the height of the row to fit the cell values in the
+// row, returns 0 if the row doesn't contain any value.
+func (f *File) getRowFitHeight(ws *xlsxWorksheet, m *textMeasurer, sst *xlsxSST, r *xlsxRow, merged [][]int) (float64, error) {
+	var height float64
+	for _, c := range r.C {
+		col, _, err := CellNameToCoordinates(c.R)
+		if err != nil || cellInRanges(col, r.R, merged) {
+			continue
+		}
+		if c.S == 0 {
+			c.S = ws.getRowColStyle(r, col)
+		}
+		runs := getCellRuns(&c, sst)
+		val, err := c.getValueFrom(f, sst, false)
+		if err != nil {
+			return height, err
+		}
+		if val == "" {
+			continue
+		}
+		font, lines := m.getFont(c.S), 1
+		for _, run := range runs {
+			if run.RPr != nil && run.RPr.Sz != nil && run.RPr.Sz.Val != nil && *run.RPr.Sz.Val > font.size {
+				font.size = *run.RPr.Sz.Val
+			}
+		}
+		if font.wrap {
+			lines = m.countLines(font, val, ws.getColWidth(col)-cellPaddingWidth)
+		}
+		height = math.Max(height, float64(lines)*font.size*lineHeightFactor)
+	}
+	return math.Min(math.Ceil(height*100)/100, MaxRowHeight), nil
+}
+
+// getCellRuns returns the rich text runs of the shared string or inline
+// string cell.
+func getCellRuns(c *xlsxC, sst *xlsxSST) []xlsxR {
+	switch c.T {
+	case "inlineStr":
+		if c.IS != nil {
+			return c.IS.R
+		}
+	case "s":
+		idx, err := strconv.Atoi(strings.TrimSpace(c.V))
+		sst.mu.Lock()
+		defer sst.mu.Unlock()
+		if err == nil && idx >= 0 && idx < len(sst.SI) {
+			return sst.SI[idx].R
+		}
+	}
+	return nil
+}
+
 // getRowHeight provides a function to get row height in pixels by given sheet
 // name and row number.
 func (f *File) getRowHeight(sheet string, row int) int {
@@ -430,6 +765,73 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 	return ht, nil
 }
 
+// RowHeightOptions can be passed to GetSheetRowHeights to set optional
+// settings of getting the row heights. SparseFallback specifies if only
+// return the rows with data or explicit heights, the default value is false.
+type RowHeightOptions struct {
+	SparseFallback bool
+}
+
+// GetSheetRowHeights provides a function to get the heights of the rows by
+// given worksheet name, returns a map keyed by the row number. The rows
+// without custom height will be filled with the default row height of the
+// worksheet. By default, the map contains every row from the first row to the
+// last row of the worksheet, and only the rows with data or explicit heights
+// will be returned if the 'SparseFallback' option is true. This function is
+// concurrency safe. For example:
+//
+//	heights, err := f.GetSheetRowHeights("Sheet1", excelize.RowHeightOptions{SparseFallback: true})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(heights[1])
+//
+// 根据给定的工作表名称获取工作表中全部行的高度，返回以行号为键的映射。此功能是并发安全的。
+func (f *File) GetSheetRowHeights(sheet string, opts ...RowHeightOptions) (map[int]float64, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ht := defaultRowHeight
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultRowHeight > 0 {
+		ht = ws.SheetFormatPr.DefaultRowHeight
+	}
+	heights := make(map[int]float64)
+	var maxRow int
+	for idx, row := range ws.SheetData.Row {
+		r := row.R
+		if r == 0 {
+			r = idx + 1
+		}
+		if r > maxRow {
+			maxRow = r
+		}
+		if row.Ht != nil {
+			heights[r] = *row.Ht
+			continue
+		}
+		if len(row.C) > 0 {
+			heights[r] = ht
+		}
+	}
+	for _, opt := range opts {
+		if opt.SparseFallback {
+			return heights, err
+		}
+	}
+	for r := 1; r <= maxRow; r++ {
+		if _, ok := heights[r]; !ok {
+			heights[r] = ht
+		}
+	}
+	return heights, err
+}
+
 // sharedStringsReader provides a function to get the pointer to the structure
 // after deserialization of xl/sharedStrings.xml.
 func (f *File) sharedStringsReader() (*xlsxSST, error) {
@@ -561,6 +963,262 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	return ws.SheetData.Row[row-1].OutlineLevel, nil
 }
 
+// GroupRows provides a function to group the rows by given worksheet name,
+// the first and last row number of the group, and if the group is collapsed.
+// The outline level of each row in the group will be increased by 1, so the
+// groups could be nested up to 7 levels. The rows of the collapsed group will
+// be hidden, and the summary row, which is below or above the group depends
+// on the outline properties of the worksheet, will be marked as collapsed.
+// For example, group the rows 2 to 5 in Sheet1 and collapse the group:
+//
+//	err := f.GroupRows("Sheet1", 2, 5, true)
+//
+// 根据给定的工作表名称、起止行号和折叠状态创建行分组。
+func (f *File) GroupRows(sheet string, firstRow, lastRow int, collapsed bool) error {
+	firstRow, lastRow, err := checkRowGroupRange(firstRow, lastRow)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for row := firstRow; row <= lastRow && row <= len(ws.SheetData.Row); row++ {
+		if ws.SheetData.Row[row-1].OutlineLevel >= 7 {
+			return ErrOutlineLevel
+		}
+	}
+	ws.prepareSheetXML(0, lastRow)
+	for row := firstRow; row <= lastRow; row++ {
+		ws.SheetData.Row[row-1].OutlineLevel++
+		if collapsed {
+			ws.SheetData.Row[row-1].Hidden = true
+		}
+	}
+	if summaryRow := ws.getOutlineSummaryRow(firstRow, lastRow); collapsed && summaryRow >= 1 && summaryRow <= TotalRows {
+		ws.prepareSheetXML(0, summaryRow)
+		ws.SheetData.Row[summaryRow-1].Collapsed = true
+	}
+	ws.updateOutlineLevel()
+	return err
+}
+
+// UngroupRows provides a function to ungroup the rows by given worksheet name,
+// the first and last row number of the group. The outline level of each row
+// in the range will be decreased by 1, and the rows will be shown if the
+// group was collapsed. For example, ungroup the rows 2 to 5 in Sheet1:
+//
+//	err := f.UngroupRows("Sheet1", 2, 5)
+//
+// 根据给定的工作表名称和起止行号取消行分组。
+func (f *File) UngroupRows(sheet string, firstRow, lastRow int) error {
+	firstRow, lastRow, err := checkRowGroupRange(firstRow, lastRow)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var expand bool
+	if summaryRow := ws.getOutlineSummaryRow(firstRow, lastRow); summaryRow >= 1 && summaryRow <= len(ws.SheetData.Row) {
+		expand = ws.SheetData.Row[summaryRow-1].Collapsed
+		ws.SheetData.Row[summaryRow-1].Collapsed = false
+	}
+	for row := firstRow; row <= lastRow && row <= len(ws.SheetData.Row); row++ {
+		if ws.SheetData.Row[row-1].OutlineLevel > 0 {
+			ws.SheetData.Row[row-1].OutlineLevel--
+		}
+		if expand {
+			ws.SheetData.Row[row-1].Hidden = false
+		}
+	}
+	ws.updateOutlineLevel()
+	return err
+}
+
+// checkRowGroupRange provides a function to check the first and last row
+// number of the rows group, and returns the row numbers in ascending order.
+func checkRowGroupRange(firstRow, lastRow int) (int, int, error) {
+	if firstRow > lastRow {
+		firstRow, lastRow = lastRow, firstRow
+	}
+	if firstRow < 1 {
+		return firstRow, lastRow, newInvalidRowNumberError(firstRow)
+	}
+	if lastRow > TotalRows {
+		return firstRow, lastRow, ErrMaxRows
+	}
+	return firstRow, lastRow, nil
+}
+
+// SetRowOutlineGroup provides a function to set the outline level and the
+// collapsed state of the rows group by given worksheet name, the first and
+// last row number of the group, the outline level and if the group is
+// collapsed. The outline level of each row in the group will be set to the
+// given level, the value of parameter 'level' is 1-7. The rows of the
+// collapsed group will be hidden, and the summary row will be marked as
+// collapsed. The maximum outline level of the rows in the worksheet will be
+// updated. For example, create the rows group of rows 2 to 9 at level 1 and
+// the nested group of rows 3 to 5 at level 2, and collapse the nested group:
+//
+//	err := f.SetRowOutlineGroup("Sheet1", 2, 9, 1, false)
+//	err = f.SetRowOutlineGroup("Sheet1", 3, 5, 2, true)
+//
+// 根据给定的工作表名称、起止行号、分级和折叠状态设置行分组。
+func (f *File) SetRowOutlineGroup(sheet string, fromRow, toRow, level int, collapsed bool) error {
+	fromRow, toRow, err := checkRowGroupRange(fromRow, toRow)
+	if err != nil {
+		return err
+	}
+	if level > 7 || level < 1 {
+		return ErrOutlineLevel
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.prepareSheetXML(0, toRow)
+	for row := fromRow; row <= toRow; row++ {
+		ws.SheetData.Row[row-1].OutlineLevel = uint8(level)
+		ws.SheetData.Row[row-1].Hidden = collapsed
+	}
+	ws.setOutlineSummaryRow(fromRow, toRow, collapsed)
+	ws.updateOutlineLevel()
+	return err
+}
+
+// CollapseRowGroup provides a function to collapse the rows group by given
+// worksheet name, the first and last row number of the group. The rows in
+// the group will be hidden, and the summary row will be marked as collapsed.
+// For example, collapse the rows group of rows 3 to 5 in Sheet1:
+//
+//	err := f.CollapseRowGroup("Sheet1", 3, 5)
+//
+// 根据给定的工作表名称和起止行号折叠行分组。
+func (f *File) CollapseRowGroup(sheet string, fromRow, toRow int) error {
+	fromRow, toRow, err := checkRowGroupRange(fromRow, toRow)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.prepareSheetXML(0, toRow)
+	for row := fromRow; row <= toRow; row++ {
+		ws.SheetData.Row[row-1].Hidden = true
+	}
+	ws.setOutlineSummaryRow(fromRow, toRow, true)
+	return err
+}
+
+// ExpandRowGroup provides a function to expand the rows group by given
+// worksheet name, the first and last row number of the group. The rows in
+// the group will be shown, except the rows in the collapsed nested groups,
+// and the collapsed state of the summary row will be cleared. For example,
+// expand the rows group of rows 2 to 9 in Sheet1:
+//
+//	err := f.ExpandRowGroup("Sheet1", 2, 9)
+//
+// 根据给定的工作表名称和起止行号展开行分组。
+func (f *File) ExpandRowGroup(sheet string, fromRow, toRow int) error {
+	fromRow, toRow, err := checkRowGroupRange(fromRow, toRow)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if toRow > len(ws.SheetData.Row) {
+		toRow = len(ws.SheetData.Row)
+	}
+	if fromRow <= toRow {
+		level := ws.SheetData.Row[fromRow-1].OutlineLevel
+		for row := fromRow; row <= toRow; row++ {
+			if ws.SheetData.Row[row-1].OutlineLevel < level {
+				level = ws.SheetData.Row[row-1].OutlineLevel
+			}
+		}
+		ws.expandRows(fromRow, toRow, level)
+	}
+	ws.setOutlineSummaryRow(fromRow, toRow, false)
+	return err
+}
+
+// expandRows provides a function to show the rows by given the first and
+// last row number and the outline level of the group, the rows in the
+// collapsed nested groups with a higher outline level will be kept hidden.
+func (ws *xlsxWorksheet) expandRows(firstRow, lastRow int, level uint8) {
+	for row := firstRow; row <= lastRow; row++ {
+		if ws.SheetData.Row[row-1].OutlineLevel <= level {
+			ws.SheetData.Row[row-1].Hidden = false
+			continue
+		}
+		end := row
+		for end < lastRow && ws.SheetData.Row[end].OutlineLevel > level {
+			end++
+		}
+		if summaryRow := ws.getOutlineSummaryRow(row, end); summaryRow < 1 || summaryRow > len(ws.SheetData.Row) ||
+			!ws.SheetData.Row[summaryRow-1].Collapsed {
+			ws.expandRows(row, end, level+1)
+		}
+		row = end
+	}
+}
+
+// setOutlineSummaryRow provides a function to set the collapsed state of the
+// summary row of the rows group by given the first and last row number of
+// the group.
+func (ws *xlsxWorksheet) setOutlineSummaryRow(firstRow, lastRow int, collapsed bool) {
+	summaryRow := ws.getOutlineSummaryRow(firstRow, lastRow)
+	if summaryRow < 1 || summaryRow > TotalRows {
+		return
+	}
+	if collapsed {
+		ws.prepareSheetXML(0, summaryRow)
+	}
+	if summaryRow <= len(ws.SheetData.Row) {
+		ws.SheetData.Row[summaryRow-1].Collapsed = collapsed
+	}
+}
+
+// getOutlineSummaryRow returns the summary row number of the rows group by
+// given the first and last row number of the group.
+func (ws *xlsxWorksheet) getOutlineSummaryRow(firstRow, lastRow int) int {
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryBelow != nil && !*ws.SheetPr.OutlinePr.SummaryBelow {
+		return firstRow - 1
+	}
+	return lastRow + 1
+}
+
+// updateOutlineLevel provides a function to update the maximum outline level
+// of the rows and columns in the sheet format properties of the worksheet.
+func (ws *xlsxWorksheet) updateOutlineLevel() {
+	var rowLevel, colLevel uint8
+	for _, row := range ws.SheetData.Row {
+		if row.OutlineLevel > rowLevel {
+			rowLevel = row.OutlineLevel
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if col.OutlineLevel > colLevel {
+				colLevel = col.OutlineLevel
+			}
+		}
+	}
+	if ws.SheetFormatPr == nil {
+		if rowLevel == 0 && colLevel == 0 {
+			return
+		}
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	ws.SheetFormatPr.OutlineLevelRow, ws.SheetFormatPr.OutlineLevelCol = rowLevel, colLevel
+}
+
 // RemoveRow provides a function to remove single row by given worksheet name
 // and Excel row number. For example, remove row 3 in Sheet1:
 //
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,76 @@ func TestGetRows(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetRowsWithMaxRows(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		if row == 2 {
+			continue
+		}
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), row))
+	}
+	rows, err := f.GetRows("Sheet1", Options{MaxRows: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, nil, {"3"}}, rows)
+	rows, err = f.GetRows("Sheet1", Options{MaxRows: 20})
+	assert.NoError(t, err)
+	assert.Len(t, rows, 10)
+	// Test rows iterator with maximum number of rows
+	iter, err := f.Rows("Sheet1", Options{MaxRows: 5})
+	assert.NoError(t, err)
+	var count int
+	for iter.Next() {
+		count++
+	}
+	assert.Equal(t, 5, count)
+	assert.False(t, iter.Next())
+	assert.NoError(t, iter.Close())
+	// Test row iterator with maximum number of rows
+	rowIter, err := f.GetRowIterator("Sheet1", Options{MaxRows: 4})
+	assert.NoError(t, err)
+	for rowIter.Next() {
+	}
+	assert.Equal(t, 4, rowIter.CurrentRow())
+	assert.NoError(t, rowIter.Close())
+	assert.NoError(t, f.Close())
+}
+
+func TestRowsGetColWidths(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"0000000000", nil, "000"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"00000", "0"}))
+	style, err := f.NewStyle(&Style{Font: &Font{Size: 22}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", "000"))
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, rows.CollectColWidths())
+	for rows.Next() {
+		_, err := rows.Columns()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []float64{10.72, 1.72, 6.72}, rows.GetColWidths())
+	assert.NoError(t, rows.Close())
+	// Test rows iterator without collecting the column widths
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	for rows.Next() {
+		_, err := rows.Columns()
+		assert.NoError(t, err)
+	}
+	assert.Nil(t, rows.GetColWidths())
+	assert.NoError(t, rows.Close())
+	// Test collecting the column widths with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.EqualError(t, rows.CollectColWidths(), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -214,6 +285,38 @@ func TestRowHeight(t *testing.T) {
 	assert.Equal(t, 0.0, convertColWidthToPixels(0))
 }
 
+func TestGetSheetRowHeights(t *testing.T) {
+	f := NewFile()
+	heights, err := f.GetSheetRowHeights("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, heights)
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "A4"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 6, 12.5))
+	heights, err = f.GetSheetRowHeights("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]float64{1: defaultRowHeight, 2: 30, 3: defaultRowHeight, 4: defaultRowHeight, 5: defaultRowHeight, 6: 12.5}, heights)
+	for row, height := range heights {
+		expected, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	heights, err = f.GetSheetRowHeights("Sheet1", RowHeightOptions{SparseFallback: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]float64{2: 30, 4: defaultRowHeight, 6: 12.5}, heights)
+	// Test get row heights with default row height of the worksheet
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: 20, CustomHeight: true}
+	ws.(*xlsxWorksheet).SheetData.Row[3].R = 0
+	heights, err = f.GetSheetRowHeights("Sheet1", RowHeightOptions{SparseFallback: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]float64{2: 30, 4: 20, 6: 12.5}, heights)
+	// Test get row heights on not exists worksheet
+	_, err = f.GetSheetRowHeights("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestColumns(t *testing.T) {
 	f := NewFile()
 	rows, err := f.Rows("Sheet1")
@@ -296,6 +399,132 @@ func TestRowVisibility(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowVisibility.xlsx")))
 }
 
+func TestGroupRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.GroupRows("Sheet1", 5, 2, true))
+	for row := 2; row <= 5; row++ {
+		level, err := f.GetRowOutlineLevel("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(1), level)
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.False(t, visible)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[5].Collapsed)
+	assert.Equal(t, uint8(1), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	// Test nested group and ungroup rows
+	assert.NoError(t, f.GroupRows("Sheet1", 3, 4, false))
+	level, err := f.GetRowOutlineLevel("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), level)
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	assert.NoError(t, f.UngroupRows("Sheet1", 3, 4))
+	assert.NoError(t, f.UngroupRows("Sheet1", 2, 5))
+	for row := 2; row <= 5; row++ {
+		level, err := f.GetRowOutlineLevel("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0), level)
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.True(t, visible)
+	}
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[5].Collapsed)
+	assert.Equal(t, uint8(0), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	// Test group rows with summary row above the group
+	assert.NoError(t, f.SetSheetOutlineProps("Sheet1", OutlineProps{SummaryBelow: false, SummaryRight: true}))
+	assert.NoError(t, f.GroupRows("Sheet1", 1, 2, true))
+	assert.NoError(t, f.GroupRows("Sheet1", 8, 9, true))
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[6].Collapsed)
+	assert.NoError(t, f.UngroupRows("Sheet1", 8, 9))
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[6].Collapsed)
+	visible, err := f.GetRowVisible("Sheet1", 8)
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	// Test group rows exceeds the maximum outline level
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 11, 7))
+	assert.EqualError(t, f.GroupRows("Sheet1", 10, 11, false), ErrOutlineLevel.Error())
+	// Test group and ungroup rows with invalid row number
+	assert.EqualError(t, f.GroupRows("Sheet1", 0, 1, false), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.UngroupRows("Sheet1", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.GroupRows("Sheet1", 1, TotalRows+1, false), ErrMaxRows.Error())
+	assert.EqualError(t, f.UngroupRows("Sheet1", 1, TotalRows+1), ErrMaxRows.Error())
+	// Test group and ungroup rows on not exists worksheet
+	assert.EqualError(t, f.GroupRows("SheetN", 1, 2, false), "sheet SheetN does not exist")
+	assert.EqualError(t, f.UngroupRows("SheetN", 1, 2), "sheet SheetN does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupRows.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestSetRowOutlineGroup(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetRowOutlineGroup("Sheet1", 9, 2, 1, false))
+	assert.NoError(t, f.SetRowOutlineGroup("Sheet1", 3, 5, 2, true))
+	assert.NoError(t, f.SetRowOutlineGroup("Sheet1", 4, 4, 3, true))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	checkRows := func(levels []uint8, visibles []bool) {
+		for idx := range levels {
+			row := idx + 2
+			level, err := f.GetRowOutlineLevel("Sheet1", row)
+			assert.NoError(t, err)
+			assert.Equal(t, levels[idx], level, row)
+			visible, err := f.GetRowVisible("Sheet1", row)
+			assert.NoError(t, err)
+			assert.Equal(t, visibles[idx], visible, row)
+		}
+	}
+	levels := []uint8{1, 2, 3, 2, 1, 1, 1, 1}
+	checkRows(levels, []bool{true, false, false, false, true, true, true, true})
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[4].Collapsed)
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[5].Collapsed)
+	assert.Equal(t, uint8(3), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	// Test expand the group with the collapsed nested group
+	assert.NoError(t, f.ExpandRowGroup("Sheet1", 3, 5))
+	checkRows(levels, []bool{true, true, false, true, true, true, true, true})
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[5].Collapsed)
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[4].Collapsed)
+	assert.NoError(t, f.ExpandRowGroup("Sheet1", 4, 4))
+	checkRows(levels, []bool{true, true, true, true, true, true, true, true})
+	// Test collapse and expand the outer group
+	assert.NoError(t, f.CollapseRowGroup("Sheet1", 9, 2))
+	checkRows(levels, []bool{false, false, false, false, false, false, false, false})
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[9].Collapsed)
+	assert.NoError(t, f.CollapseRowGroup("Sheet1", 4, 4))
+	assert.NoError(t, f.ExpandRowGroup("Sheet1", 2, 9))
+	checkRows(levels, []bool{true, true, false, true, true, true, true, true})
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[9].Collapsed)
+	// Test update the maximum outline level
+	assert.NoError(t, f.SetRowOutlineGroup("Sheet1", 4, 4, 1, false))
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	// Test expand the rows group without rows
+	assert.NoError(t, f.ExpandRowGroup("Sheet1", 20, 30))
+	// Test set outline group with summary row above the group
+	assert.NoError(t, f.SetSheetOutlineProps("Sheet1", OutlineProps{SummaryBelow: false, SummaryRight: true}))
+	assert.NoError(t, f.SetRowOutlineGroup("Sheet1", 1, 1, 1, true))
+	assert.NoError(t, f.SetRowOutlineGroup("Sheet1", 13, 14, 1, true))
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[11].Collapsed)
+	assert.NoError(t, f.ExpandRowGroup("Sheet1", 13, 14))
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[11].Collapsed)
+	// Test set outline group with invalid parameters
+	for _, level := range []int{0, 8} {
+		assert.Equal(t, ErrOutlineLevel, f.SetRowOutlineGroup("Sheet1", 1, 2, level, false))
+	}
+	assert.Equal(t, newInvalidRowNumberError(0), f.SetRowOutlineGroup("Sheet1", 0, 1, 1, false))
+	assert.Equal(t, newInvalidRowNumberError(0), f.CollapseRowGroup("Sheet1", 0, 1))
+	assert.Equal(t, newInvalidRowNumberError(0), f.ExpandRowGroup("Sheet1", 0, 1))
+	assert.Equal(t, ErrMaxRows, f.SetRowOutlineGroup("Sheet1", 1, TotalRows+1, 1, false))
+	assert.Equal(t, ErrMaxRows, f.CollapseRowGroup("Sheet1", 1, TotalRows+1))
+	assert.Equal(t, ErrMaxRows, f.ExpandRowGroup("Sheet1", 1, TotalRows+1))
+	// Test set outline group on not exists worksheet
+	assert.EqualError(t, f.SetRowOutlineGroup("SheetN", 1, 2, 1, false), "sheet SheetN does not exist")
+	assert.EqualError(t, f.CollapseRowGroup("SheetN", 1, 2), "sheet SheetN does not exist")
+	assert.EqualError(t, f.ExpandRowGroup("SheetN", 1, 2), "sheet SheetN does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowOutlineGroup.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestRemoveRow(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -1149,6 +1378,99 @@ func BenchmarkRows(b *testing.B) {
 	}
 }
 
+func TestAutoFitRowHeight(t *testing.T) {
+	f := NewFile()
+	wrap, err := f.NewStyle(&Style{Alignment: &Alignment{WrapText: true}})
+	assert.NoError(t, err)
+	large, err := f.NewStyle(&Style{Font: &Font{Size: 20}})
+	assert.NoError(t, err)
+	for cell, value := range map[string]string{
+		"A1": "single line",
+		"A2": "first\nsecond\nthird",
+		"A3": "the quick brown fox jumps over the lazy dog",
+		"A4": "large",
+		"A5": "merged\nmerged\nmerged\nmerged",
+		"A7": strings.Repeat("W", 30),
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A3", wrap))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "A5", wrap))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A7", "A7", wrap))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A4", "A4", large))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "A6"))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "B8", []RichTextRun{
+		{Text: "small", Font: &Font{Size: 9}},
+		{Text: "large", Font: &Font{Size: 22}},
+	}))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 9, 40))
+
+	assert.NoError(t, f.AutoFitRowHeight("Sheet1", 2))
+	height, err := f.GetRowHeight("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 45.0, height)
+	height, err = f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRowHeight, height)
+
+	assert.NoError(t, f.AutoFitAllRowHeights("Sheet1"))
+	for row, expected := range map[int]float64{1: 15, 2: 45, 3: 75, 4: 27.28, 5: defaultRowHeight, 7: 105, 8: 30, 9: 40} {
+		height, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	// Test auto fit row height with the wider column
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 50))
+	assert.NoError(t, f.AutoFitRowHeight("Sheet1", 3))
+	height, err = f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, height)
+	// Test auto fit row height with invalid row number
+	assert.EqualError(t, f.AutoFitRowHeight("Sheet1", 0), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.AutoFitRowHeight("Sheet1", TotalRows+1), ErrMaxRows.Error())
+	// Test auto fit row height on not exists worksheet
+	assert.EqualError(t, f.AutoFitRowHeight("SheetN", 1), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AutoFitAllRowHeights("SheetN"), "sheet SheetN does not exist")
+	// Test auto fit row height with invalid sheet name
+	assert.EqualError(t, f.AutoFitAllRowHeights("Sheet:1"), ErrSheetNameInvalid.Error())
+	// Test auto fit row height with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitAllRowHeights("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	// Test auto fit row height with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitAllRowHeights("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkAutoFitAllRowHeights(b *testing.B) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Alignment: &Alignment{WrapText: true}})
+	if err != nil {
+		b.Error(err)
+	}
+	for row := 1; row <= 20000; row++ {
+		if err := f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{
+			row, "the quick brown fox jumps over the lazy dog", "first\nsecond",
+		}); err != nil {
+			b.Error(err)
+		}
+	}
+	if err := f.SetCellStyle("Sheet1", "B1", "C20000", style); err != nil {
+		b.Error(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := f.AutoFitAllRowHeights("Sheet1"); err != nil {
+			b.Error(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		b.Error(err)
+	}
+}
+
 // trimSliceSpace trim continually blank element in the tail of slice.
 func trimSliceSpace(s []string) []string {
 	for {
@@ -1160,3 +1482,66 @@ func trimSliceSpace(s []string) []string {
 	}
 	return s
 }
+
+func TestGetRowIterator(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]string{"A1": "a", "B1": "b", "A3": "c", "C4": "d"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	iter, err := f.GetRowIterator("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, iter.Prev())
+	assert.Nil(t, iter.Columns())
+	assert.Equal(t, RowOpts{}, iter.GetRowOpts())
+	var rows [][]string
+	for iter.Next() {
+		rows = append(rows, iter.Columns())
+	}
+	assert.NoError(t, iter.Error())
+	assert.Equal(t, [][]string{{"a", "b"}, nil, {"c"}, {"", "", "d"}}, rows)
+	assert.Equal(t, 4, iter.CurrentRow())
+	// Test traverse rows backward
+	assert.True(t, iter.Prev())
+	assert.Equal(t, 3, iter.CurrentRow())
+	assert.Equal(t, []string{"c"}, iter.Columns())
+	assert.Equal(t, RowOpts{Height: 30}, iter.GetRowOpts())
+	assert.True(t, iter.Prev())
+	assert.Nil(t, iter.Columns())
+	assert.True(t, iter.Prev())
+	assert.Equal(t, []string{"a", "b"}, iter.Columns())
+	assert.False(t, iter.Prev())
+	assert.Equal(t, 1, iter.CurrentRow())
+	// Test seek rows
+	assert.True(t, iter.Seek(4))
+	assert.Equal(t, []string{"", "", "d"}, iter.Columns())
+	assert.False(t, iter.Seek(5))
+	assert.False(t, iter.Seek(0))
+	assert.Equal(t, 4, iter.CurrentRow())
+	assert.NoError(t, iter.Close())
+
+	// Test seek rows before traverse forward
+	iter, err = f.GetRowIterator("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, iter.Seek(3))
+	assert.Equal(t, []string{"c"}, iter.Columns())
+	assert.True(t, iter.Next())
+	assert.Equal(t, 4, iter.CurrentRow())
+	assert.NoError(t, iter.Close())
+
+	// Test get row iterator with not exist worksheet
+	_, err = f.GetRowIterator("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get row iterator with invalid sheet name
+	_, err = f.GetRowIterator("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get row iterator with invalid cell reference
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A" t="str"><v>1</v></c></row></sheetData></worksheet>`))
+	iter, err = f.GetRowIterator("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, iter.Next())
+	assert.EqualError(t, iter.Error(), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, iter.Close())
+	assert.NoError(t, f.Close())
+}
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"image"
 	"io"
 	"os"
 	"path"
@@ -24,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf16"
 	"unicode/utf8"
 
@@ -81,15 +83,6 @@ func (f *File) contentTypesReader() (*xlsxTypes, error) {
 	return f.ContentTypes, nil
 }
 
-// contentTypesWriter provides a function to save [Content_Types].xml after
-// serialize structure.
-func (f *File) contentTypesWriter() {
-	if f.ContentTypes != nil {
-		output, _ := xml.Marshal(f.ContentTypes)
-		f.saveFileList(defaultXMLPathContentTypes, output)
-	}
-}
-
 // getWorksheetPath construct a target XML as xl/worksheets/sheet%d by split
 // path, compatible with different types of relative paths in
 // workbook.xml.rels, for example: worksheets/sheet%d.xml
@@ -134,46 +127,26 @@ func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
 	ws.Cols.Col = columns
 }
 
-// workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
-// serialize structure.
-func (f *File) workSheetWriter() {
-	var (
-		arr     []byte
-		buffer  = bytes.NewBuffer(arr)
-		encoder = xml.NewEncoder(buffer)
-	)
-	f.Sheet.Range(func(p, ws interface{}) bool {
-		if ws != nil {
-			sheet := ws.(*xlsxWorksheet)
-			if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
-				_ = f.mergeOverlapCells(sheet)
-			}
-			if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
-				f.mergeExpandedCols(sheet)
-			}
-			sheet.SheetData.Row = trimRow(&sheet.SheetData)
-			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
-				f.addNameSpaces(p.(string), SourceRelationship)
-			}
-			if sheet.DecodeAlternateContent != nil {
-				sheet.AlternateContent = &xlsxAlternateContent{
-					Content: sheet.DecodeAlternateContent.Content,
-					XMLNSMC: SourceRelationshipCompatibility.Value,
-				}
-			}
-			sheet.DecodeAlternateContent = nil
-			// reusing buffer
-			_ = encoder.Encode(sheet)
-			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
-			ok := f.checked[p.(string)]
-			if ok {
-				f.Sheet.Delete(p.(string))
-				f.checked[p.(string)] = false
-			}
-			buffer.Reset()
+// prepareWorkSheetWrite provides a function to normalize the merged cells,
+// columns, namespaces and alternate content of the worksheet before
+// serializing it.
+func (f *File) prepareWorkSheetWrite(path string, ws *xlsxWorksheet) {
+	if ws.MergeCells != nil && len(ws.MergeCells.Cells) > 0 {
+		_ = f.mergeOverlapCells(ws)
+	}
+	if ws.Cols != nil && len(ws.Cols.Col) > 0 {
+		f.mergeExpandedCols(ws)
+	}
+	if ws.SheetPr != nil || ws.Drawing != nil || ws.Hyperlinks != nil || ws.Picture != nil || ws.TableParts != nil {
+		f.addNameSpaces(path, SourceRelationship)
+	}
+	if ws.DecodeAlternateContent != nil {
+		ws.AlternateContent = &xlsxAlternateContent{
+			Content: ws.DecodeAlternateContent.Content,
+			XMLNSMC: SourceRelationshipCompatibility.Value,
 		}
-		return true
-	})
+	}
+	ws.DecodeAlternateContent = nil
 }
 
 // trimRow provides a function to trim empty rows.
@@ -241,21 +214,6 @@ func (f *File) setSheet(index int, name string) {
 	f.xmlAttr[sheetXMLPath] = []xml.Attr{NameSpaceSpreadSheet}
 }
 
-// relsWriter provides a function to save relationships after
-// serialize structure.
-func (f *File) relsWriter() {
-	f.Relationships.Range(func(path, rel interface{}) bool {
-		if rel != nil {
-			output, _ := xml.Marshal(rel.(*xlsxRelationships))
-			if strings.HasPrefix(path.(string), "xl/worksheets/sheet/rels/sheet") {
-				output = f.replaceNameSpaceBytes(path.(string), output)
-			}
-			f.saveFileList(path.(string), replaceRelationshipsBytes(output))
-		}
-		return true
-	})
-}
-
 // replaceRelationshipsBytes; Some tools that read spreadsheet files have very
 // strict requirements about the structure of the input XML. This function is
 // a horrible hack to fix that after the XML marshalling is completed.
@@ -374,6 +332,7 @@ func (f *File) SetSheetName(source, target string) error {
 			wb.Sheets.Sheet[k].Name = target
 			f.sheetMap[target] = f.sheetMap[source]
 			delete(f.sheetMap, source)
+			err = f.adjustCellMetadataSheet(source, target)
 		}
 	}
 	return err
@@ -601,6 +560,9 @@ func (f *File) DeleteSheet(sheet string) error {
 		f.Sheet.Delete(sheetXML)
 		delete(f.xmlAttr, sheetXML)
 		f.SheetCount--
+		if err := f.adjustCellMetadataSheet(v.Name, ""); err != nil {
+			return err
+		}
 	}
 	index, err := f.GetSheetIndex(activeSheetName)
 	f.SetActiveSheet(index)
@@ -627,6 +589,66 @@ func deleteAndAdjustDefinedNames(wb *xlsxWorkbook, deleteLocalSheetID int) {
 	}
 }
 
+// SetWorksheetTabOrderIndex provides a function to move the worksheet tab to
+// the given position by given worksheet name and the zero-based index of the
+// new position in the sheet tabs. The active tab of the workbook view and the
+// scope of the defined names will be updated with the new sheet positions, so
+// the active sheet keeps active after moving. For example, move Sheet3 to the
+// first tab:
+//
+//	err := f.SetWorksheetTabOrderIndex("Sheet3", 0)
+//
+// 根据给定的工作表名称和目标位置索引（从 0 开始）移动工作表标签的位置。
+func (f *File) SetWorksheetTabOrderIndex(sheet string, index int) error {
+	if err := checkSheetName(sheet); err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	from, _ := f.GetSheetIndex(sheet)
+	if from == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	if index < 0 || index >= len(wb.Sheets.Sheet) {
+		return ErrSheetIdx
+	}
+	if from == index {
+		return err
+	}
+	moved := wb.Sheets.Sheet[from]
+	sheets := append(wb.Sheets.Sheet[:from:from], wb.Sheets.Sheet[from+1:]...)
+	wb.Sheets.Sheet = append(sheets[:index:index], append([]xlsxSheet{moved}, sheets[index:]...)...)
+	position := func(pos int) int {
+		switch {
+		case pos == from:
+			return index
+		case from < index && pos > from && pos <= index:
+			return pos - 1
+		case from > index && pos >= index && pos < from:
+			return pos + 1
+		}
+		return pos
+	}
+	if wb.BookViews != nil {
+		for idx, view := range wb.BookViews.WorkBookView {
+			wb.BookViews.WorkBookView[idx].ActiveTab = position(view.ActiveTab)
+			if view.FirstSheet > wb.BookViews.WorkBookView[idx].ActiveTab {
+				wb.BookViews.WorkBookView[idx].FirstSheet = wb.BookViews.WorkBookView[idx].ActiveTab
+			}
+		}
+	}
+	if wb.DefinedNames != nil {
+		for idx, dn := range wb.DefinedNames.DefinedName {
+			if dn.LocalSheetID != nil {
+				wb.DefinedNames.DefinedName[idx].LocalSheetID = intPtr(position(*dn.LocalSheetID))
+			}
+		}
+	}
+	return err
+}
+
 // deleteSheetFromWorkbookRels provides a function to remove worksheet
 // relationships by given relationships ID in the file workbook.xml.rels.
 func (f *File) deleteSheetFromWorkbookRels(rID string) string {
@@ -662,6 +684,96 @@ func (f *File) deleteSheetFromContentTypes(target string) error {
 	return err
 }
 
+// ClearSheet provides a function to remove all content of the worksheet by
+// given worksheet name without deleting the worksheet. This function removes
+// all rows, merged cells, hyperlinks, drawings (including the charts in the
+// drawings), data validations and conditional formats of the worksheet, and
+// resets the dimension of the worksheet to A1. The worksheet name, index, tab
+// color, visibility, columns and print settings will be kept. For example:
+//
+//	err := f.ClearSheet("Sheet1")
+//
+// 根据给定的工作表名称清除工作表中的全部内容（包括行、合并单元格、超链接、绘图、数据验证与条件格式），
+// 并保留工作表名称、序号、标签颜色、可见性、列与打印设置。
+func (f *File) ClearSheet(sheet string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Drawing != nil {
+		drawingXML := strings.TrimPrefix(strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl"), "/")
+		if err = f.deleteDrawingPart(drawingXML); err != nil {
+			return err
+		}
+		f.deleteSheetRelationships(sheet, ws.Drawing.RID)
+		ws.Drawing = nil
+	}
+	if ws.Hyperlinks != nil {
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			if link.RID != "" {
+				f.deleteSheetRelationships(sheet, link.RID)
+			}
+		}
+		ws.Hyperlinks = nil
+	}
+	ws.SheetData.Row = nil
+	ws.Dimension = &xlsxDimension{Ref: "A1"}
+	ws.MergeCells, ws.DataValidations, ws.ConditionalFormatting = nil, nil, nil
+	if ws.ExtLst != nil {
+		decodeExtLst := new(decodeWorksheetExt)
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+		var exts []*xlsxWorksheetExt
+		for _, ext := range decodeExtLst.Ext {
+			if ext.URI != ExtURIConditionalFormattings && ext.URI != ExtURIDataValidations {
+				exts = append(exts, ext)
+			}
+		}
+		ws.ExtLst = nil
+		if len(exts) > 0 {
+			decodeExtLst.Ext = exts
+			extLstBytes, _ := xml.Marshal(decodeExtLst)
+			ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+		}
+	}
+	return f.deleteCalcChain(f.getSheetID(sheet), "")
+}
+
+// deleteDrawingPart provides a function to remove the drawing part and the
+// charts referenced by the drawing from the spreadsheet by given drawing part
+// path.
+func (f *File) deleteDrawingPart(drawingXML string) error {
+	drawingRels := strings.ReplaceAll(strings.ReplaceAll(drawingXML, "xl/drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	if rels, err := f.relsReader(drawingRels); err != nil {
+		return err
+	} else if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipChart {
+				chartXML := strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/")
+				f.Pkg.Delete(chartXML)
+				f.Pkg.Delete(strings.ReplaceAll(strings.ReplaceAll(chartXML, "xl/charts", "xl/charts/_rels"), ".xml", ".xml.rels"))
+				if err = f.deleteSheetFromContentTypes("/" + chartXML); err != nil {
+					rels.mu.Unlock()
+					return err
+				}
+			}
+		}
+		rels.mu.Unlock()
+	}
+	f.Drawings.Delete(drawingXML)
+	f.Pkg.Delete(drawingXML)
+	f.Pkg.Delete(drawingRels)
+	f.Relationships.Delete(drawingRels)
+	return f.deleteSheetFromContentTypes("/" + drawingXML)
+}
+
 // CopySheet provides a function to duplicate a worksheet by gave source and
 // target worksheet index. Note that currently doesn't support duplicate
 // workbooks that contain tables, charts or pictures. For Example:
@@ -712,6 +824,380 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// CopySheetFrom provides a function to copy a worksheet from another workbook
+// by given source workbook, source worksheet name and the new worksheet name
+// in the workbook. The cell values, formulas, styles, merged cells, column
+// widths, row heights, pictures, comments, hyperlinks, data validations,
+// conditional formats and print settings will be copied. The shared strings
+// and styles used by the worksheet will be added into the shared strings
+// table and style sheet of the workbook. The source worksheet will be checked
+// before changing the workbook. Note that currently doesn't support copy
+// tables, charts, shapes, slicers and form controls, and the target worksheet
+// must not exist. For example, copy the worksheet named Template in the
+// workbook src as Sheet2:
+//
+//	err := f.CopySheetFrom(src, "Template", "Sheet2")
+//
+// 根据给定的源工作簿、源工作表名称和目标工作表名称，从其他工作簿复制工作表。
+func (f *File) CopySheetFrom(src *File, srcSheet, dstSheet string) error {
+	if src == nil {
+		return ErrParameterInvalid
+	}
+	if err := checkSheetName(dstSheet); err != nil {
+		return err
+	}
+	index, err := f.GetSheetIndex(dstSheet)
+	if err != nil {
+		return err
+	}
+	if index != -1 {
+		return ErrExistsSheet
+	}
+	src.mu.Lock()
+	ws, err := src.workSheetReader(srcSheet)
+	if err != nil {
+		src.mu.Unlock()
+		return err
+	}
+	src.mu.Unlock()
+	ws.mu.Lock()
+	worksheet := deepcopy.Copy(ws).(*xlsxWorksheet)
+	ws.mu.Unlock()
+	if err = f.importSheetExtLst(worksheet); err != nil {
+		return err
+	}
+	objects, err := src.getSheetObjects(srcSheet)
+	if err != nil {
+		return err
+	}
+	if err = f.importSheetData(src, worksheet); err != nil {
+		return err
+	}
+	if _, err = f.NewSheet(dstSheet); err != nil {
+		return err
+	}
+	if worksheet.SheetViews != nil && len(worksheet.SheetViews.SheetView) > 0 {
+		worksheet.SheetViews.SheetView[0].TabSelected = false
+	}
+	if worksheet.PageSetUp != nil {
+		worksheet.PageSetUp.RID = ""
+	}
+	worksheet.Drawing, worksheet.LegacyDrawing, worksheet.LegacyDrawingHF = nil, nil, nil
+	worksheet.DrawingHF, worksheet.Picture, worksheet.TableParts = nil, nil, nil
+	worksheet.OleObjects, worksheet.Controls, worksheet.CustomProperties = nil, nil, nil
+	worksheet.AlternateContent, worksheet.DecodeAlternateContent = nil, nil
+	sheetXMLPath, _ := f.getSheetXMLPath(dstSheet)
+	srcSheetXMLPath, _ := src.getSheetXMLPath(srcSheet)
+	if attrs := src.xmlAttr[srcSheetXMLPath]; len(attrs) > 0 {
+		f.xmlAttr[sheetXMLPath] = append([]xml.Attr{}, attrs...)
+	}
+	if worksheet.Hyperlinks != nil {
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		for idx, link := range worksheet.Hyperlinks.Hyperlink {
+			if link.RID == "" {
+				continue
+			}
+			target := src.getSheetRelationshipsTargetByID(srcSheet, link.RID)
+			rID := f.addRels(sheetRels, SourceRelationshipHyperLink, target, "External")
+			worksheet.Hyperlinks.Hyperlink[idx].RID = "rId" + strconv.Itoa(rID)
+			f.addSheetNameSpace(dstSheet, SourceRelationship)
+		}
+	}
+	f.Sheet.Store(sheetXMLPath, worksheet)
+	f.checked[sheetXMLPath] = true
+	if err = f.importDefinedNames(src, srcSheet, dstSheet); err != nil {
+		return err
+	}
+	return f.importSheetObjects(dstSheet, objects)
+}
+
+// importSheetData provides a function to remap the shared strings, cell
+// styles and conditional formats differential styles used by the given
+// worksheet from the source workbook to the workbook.
+func (f *File) importSheetData(src *File, ws *xlsxWorksheet) error {
+	src.mu.Lock()
+	srcStyles, err := src.stylesReader()
+	src.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	srcSST, err := src.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	styles, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = f.sharedStringsLoader(); err != nil {
+		return err
+	}
+	if _, err = f.sharedStringsReader(); err != nil {
+		return err
+	}
+	styleIDs, stringIDs := map[int]int{}, map[string]string{}
+	importer := &styleImporter{src: srcStyles, dst: styles}
+	importStyle := func(styleID int) int {
+		if styleID == 0 {
+			return 0
+		}
+		if ID, ok := styleIDs[styleID]; ok {
+			return ID
+		}
+		styles.mu.Lock()
+		styleIDs[styleID] = importer.importCellStyle(styleID)
+		styles.mu.Unlock()
+		return styleIDs[styleID]
+	}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		row.S = importStyle(row.S)
+		for colIdx := range row.C {
+			c := &row.C[colIdx]
+			c.S = importStyle(c.S)
+			if c.T != "s" {
+				continue
+			}
+			if ID, ok := stringIDs[c.V]; ok {
+				c.V = ID
+				continue
+			}
+			ID, err := f.importSharedString(srcSST, c.V)
+			if err != nil {
+				return err
+			}
+			stringIDs[c.V], c.V = ID, ID
+		}
+	}
+	if ws.Cols != nil {
+		for idx := range ws.Cols.Col {
+			ws.Cols.Col[idx].Style = importStyle(ws.Cols.Col[idx].Style)
+		}
+	}
+	styles.mu.Lock()
+	defer styles.mu.Unlock()
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.DxfID != nil {
+				rule.DxfID = intPtr(importer.importDxfStyle(*rule.DxfID))
+			}
+		}
+	}
+	return err
+}
+
+// importSharedString provides a function to add the shared string item of the
+// source workbook into the shared strings table by given source shared strings
+// table and shared string index, and returns the new shared string index.
+func (f *File) importSharedString(srcSST *xlsxSST, idx string) (string, error) {
+	i, err := strconv.Atoi(idx)
+	if err != nil || i < 0 || i >= len(srcSST.SI) {
+		return idx, nil
+	}
+	si := srcSST.SI[i]
+	if len(si.R) == 0 {
+		var val string
+		if si.T != nil {
+			val = si.T.Val
+		}
+		ID, err := f.setSharedString(val)
+		return strconv.Itoa(ID), err
+	}
+	if err = f.sharedStringsLoader(); err != nil {
+		return idx, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return idx, err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	sst.SI = append(sst.SI, deepcopy.Copy(si).(xlsxSI))
+	sst.Count++
+	sst.UniqueCount++
+	return strconv.Itoa(sst.UniqueCount - 1), err
+}
+
+// importSheetExtLst provides a function to keep the worksheet extensions which
+// not depends on other parts of the workbook in the worksheet copy.
+func (f *File) importSheetExtLst(ws *xlsxWorksheet) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeWorksheetExt)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	var exts []*xlsxWorksheetExt
+	for _, ext := range decodeExtLst.Ext {
+		if inStrSlice([]string{
+			ExtURIConditionalFormattings, ExtURIDataValidations, ExtURIIgnoredErrors,
+			ExtURIProtectedRanges, ExtURISparklineGroups,
+		}, ext.URI, false) != -1 {
+			exts = append(exts, ext)
+		}
+	}
+	if decodeExtLst.Ext = exts; len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// importDefinedNames provides a function to copy the built-in defined names,
+// such as print area, print titles and auto filter database, of the source
+// worksheet to the worksheet.
+func (f *File) importDefinedNames(src *File, srcSheet, dstSheet string) error {
+	srcWb, err := src.workbookReader()
+	if err != nil || srcWb.DefinedNames == nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	srcIndex, _ := src.GetSheetIndex(srcSheet)
+	index, _ := f.GetSheetIndex(dstSheet)
+	for _, dn := range srcWb.DefinedNames.DefinedName {
+		if dn.LocalSheetID == nil || *dn.LocalSheetID != srcIndex || !strings.HasPrefix(dn.Name, "_xlnm.") {
+			continue
+		}
+		dn.LocalSheetID, dn.Data = intPtr(index), replaceSheetRefs(dn.Data, srcSheet, dstSheet)
+		if wb.DefinedNames == nil {
+			wb.DefinedNames = &xlsxDefinedNames{}
+		}
+		wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, dn)
+	}
+	return err
+}
+
+// replaceSheetRefs provides a function to replace the worksheet name of the
+// references in the formula by given formula, source and target worksheet
+// name. Only the whole worksheet name followed by the exclamation mark will be
+// replaced, the text in the double quotes will be kept.
+func replaceSheetRefs(formula, source, target string) string {
+	var (
+		b        strings.Builder
+		quoted   = "'" + strings.ReplaceAll(target, "'", "''") + "'!"
+		nameChar = func(c byte) bool {
+			return c == '_' || c == '.' || c >= 0x80 || ('0' <= c && c <= '9') ||
+				('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')
+		}
+	)
+	for i := 0; i < len(formula); {
+		switch c := formula[i]; {
+		case c == '"':
+			j := strings.IndexByte(formula[i+1:], '"') + i + 2
+			if j == i+1 {
+				j = len(formula)
+			}
+			b.WriteString(formula[i:j])
+			i = j
+		case c == '\'':
+			j := i + 1
+			for ; j < len(formula); j++ {
+				if formula[j] == '\'' {
+					if j+1 < len(formula) && formula[j+1] == '\'' {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j+1 < len(formula) && formula[j+1] == '!' &&
+				strings.EqualFold(strings.ReplaceAll(formula[i+1:j], "''", "'"), source) {
+				b.WriteString(quoted)
+				i = j + 2
+				continue
+			}
+			if j++; j > len(formula) {
+				j = len(formula)
+			}
+			b.WriteString(formula[i:j])
+			i = j
+		case nameChar(c):
+			j := i
+			for j < len(formula) && nameChar(formula[j]) {
+				j++
+			}
+			if j < len(formula) && formula[j] == '!' && strings.EqualFold(formula[i:j], source) {
+				b.WriteString(quoted)
+				i = j + 1
+				continue
+			}
+			b.WriteString(formula[i:j])
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// sheetObjects directly maps the pictures and comments of the worksheet which
+// will be copied to another worksheet.
+type sheetObjects struct {
+	cells    []string
+	pictures map[string][]Picture
+	comments []Comment
+}
+
+// getSheetObjects provides a function to get the pictures and comments of the
+// worksheet by given worksheet name, and check the pictures could be added
+// into the worksheet.
+func (f *File) getSheetObjects(sheet string) (*sheetObjects, error) {
+	images, err := f.GetAllSheetImages(sheet)
+	if err != nil {
+		return nil, err
+	}
+	objects := &sheetObjects{pictures: map[string][]Picture{}}
+	for _, img := range images {
+		if _, ok := objects.pictures[img.Cell]; ok {
+			continue
+		}
+		pics, err := f.GetPictures(sheet, img.Cell)
+		if err != nil {
+			return nil, err
+		}
+		for _, pic := range pics {
+			if _, ok := supportedImageTypes[strings.ToLower(pic.Extension)]; !ok {
+				return nil, ErrImgExt
+			}
+			if _, _, err = image.DecodeConfig(bytes.NewReader(pic.File)); err != nil {
+				return nil, err
+			}
+		}
+		objects.cells, objects.pictures[img.Cell] = append(objects.cells, img.Cell), pics
+	}
+	objects.comments, err = f.GetComments(sheet)
+	return objects, err
+}
+
+// importSheetObjects provides a function to add the pictures and comments of
+// the source worksheet to the worksheet by given worksheet name and sheet
+// objects.
+func (f *File) importSheetObjects(sheet string, objects *sheetObjects) error {
+	for _, cell := range objects.cells {
+		for idx := range objects.pictures[cell] {
+			if err := f.AddPictureFromBytes(sheet, cell, &objects.pictures[cell][idx]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, comment := range objects.comments {
+		if err := f.AddComment(sheet, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -1210,6 +1696,11 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 // that same page
 //
 // - No footer on the first page
+//
+// This function will return an error if any of these fields contains a
+// malformed formatting code, such as an unknown code or a trailing "&". Use
+// HeaderFooterBuilder to build these fields without writing the formatting
+// codes by hand.
 // 根据给定的工作表名称和控制字符设置工作表的页眉和页脚。
 func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 	ws, err := f.workSheetReader(sheet)
@@ -1228,6 +1719,9 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 		if len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
 			return newFieldLengthError(v.Type().Field(i).Name)
 		}
+		if !checkHeaderFooterFormatCode(v.Field(i).String()) {
+			return newHeaderFooterFormatCodeError(v.Type().Field(i).Name)
+		}
 	}
 	ws.HeaderFooter = &xlsxHeaderFooter{
 		AlignWithMargins: opts.AlignWithMargins,
@@ -1244,6 +1738,145 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 	return err
 }
 
+// checkHeaderFooterFormatCode checks if the formatting codes in the given
+// header or footer text are well-formed.
+func checkHeaderFooterFormatCode(text string) bool {
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '&' {
+			continue
+		}
+		if i++; i == len(runes) {
+			return false
+		}
+		switch r := runes[i]; {
+		case r == '"':
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+			}
+			if i == len(runes) {
+				return false
+			}
+		case r == 'K':
+			if i+6 >= len(runes) {
+				return false
+			}
+			i += 6
+		case r == '&' || (r >= '0' && r <= '9'):
+		case strings.ContainsRune("ABCDEFGHILNOPRSTUXYZ", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// HeaderFooterBuilder directly maps the left, center and right sections of a
+// header or footer, and provides chainable functions to build its text with
+// the correctly encoded formatting codes. Text and fields are appended to the
+// center section until another section is selected. For example, build an
+// odd page footer with the workbook's file name on the left, and the current
+// page number and total number of pages on the right:
+//
+//	footer := new(excelize.HeaderFooterBuilder).
+//	    Left("").FileName().
+//	    Right("Page ").PageNumber().Right(" of ").TotalPages().
+//	    Build()
+//	err := f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+//	    OddFooter: footer,
+//	})
+type HeaderFooterBuilder struct {
+	selected bool
+	section  int
+	sections [3]string
+}
+
+// headerFooterSectionCodes defined the formatting codes of the left, center
+// and right sections of the header or footer.
+var headerFooterSectionCodes = [3]string{"&L", "&C", "&R"}
+
+// write appends the given formatting code or escaped text to the current
+// section of the header or footer.
+func (b *HeaderFooterBuilder) write(section int, code string) *HeaderFooterBuilder {
+	if section == -1 {
+		if section = b.section; !b.selected {
+			section = 1
+		}
+	}
+	b.selected, b.section = true, section
+	b.sections[section] += code
+	return b
+}
+
+// escape returns the given text with the "&" characters escaped.
+func (b *HeaderFooterBuilder) escape(text string) string {
+	return strings.ReplaceAll(text, "&", "&&")
+}
+
+// Left provides a function to select the left section and append the given
+// text to it.
+func (b *HeaderFooterBuilder) Left(text string) *HeaderFooterBuilder {
+	return b.write(0, b.escape(text))
+}
+
+// Center provides a function to select the center section and append the
+// given text to it.
+func (b *HeaderFooterBuilder) Center(text string) *HeaderFooterBuilder {
+	return b.write(1, b.escape(text))
+}
+
+// Right provides a function to select the right section and append the given
+// text to it.
+func (b *HeaderFooterBuilder) Right(text string) *HeaderFooterBuilder {
+	return b.write(2, b.escape(text))
+}
+
+// PageNumber provides a function to append the current page number to the
+// current section.
+func (b *HeaderFooterBuilder) PageNumber() *HeaderFooterBuilder {
+	return b.write(-1, "&P")
+}
+
+// TotalPages provides a function to append the total number of pages to the
+// current section.
+func (b *HeaderFooterBuilder) TotalPages() *HeaderFooterBuilder {
+	return b.write(-1, "&N")
+}
+
+// Date provides a function to append the current date to the current section.
+func (b *HeaderFooterBuilder) Date() *HeaderFooterBuilder {
+	return b.write(-1, "&D")
+}
+
+// Time provides a function to append the current time to the current section.
+func (b *HeaderFooterBuilder) Time() *HeaderFooterBuilder {
+	return b.write(-1, "&T")
+}
+
+// FileName provides a function to append the workbook's file name to the
+// current section.
+func (b *HeaderFooterBuilder) FileName() *HeaderFooterBuilder {
+	return b.write(-1, "&F")
+}
+
+// SheetName provides a function to append the worksheet's tab name to the
+// current section.
+func (b *HeaderFooterBuilder) SheetName() *HeaderFooterBuilder {
+	return b.write(-1, "&A")
+}
+
+// Build provides a function to return the header or footer text, which can be
+// used as the value of the header and footer fields of the
+// HeaderFooterOptions. Empty sections will be omitted.
+func (b *HeaderFooterBuilder) Build() string {
+	var text string
+	for i, section := range b.sections {
+		if section != "" {
+			text += headerFooterSectionCodes[i] + section
+		}
+	}
+	return text
+}
+
 // ProtectSheet provides a function to prevent other users from accidentally or
 // deliberately changing, moving, or deleting data in a worksheet. The
 // optional field AlgorithmName specified hash algorithm, support XOR, MD4,
@@ -1572,9 +2205,107 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 	return opts, err
 }
 
+// SetPrintFitToPage provides a function to scale the worksheet to fit on the
+// given number of pages wide and tall when printing. Passing 0 for either
+// dimension means fit to as many pages as needed in that direction. This
+// function enables the fit to page print option of the worksheet, and removes
+// the custom height of the default row format which conflicts with scaling.
+// For example, fit the worksheet Sheet1 on one page wide:
+//
+//	err := f.SetPrintFitToPage("Sheet1", 1, 0)
+//
+// 根据给定的工作表名称、页宽与页高设置打印时将工作表缩放到指定的页数内，设置为 0 表示该方向上不限页数。
+func (f *File) SetPrintFitToPage(sheet string, fitToWidth, fitToHeight int) error {
+	if fitToWidth < 0 || fitToWidth > 32767 || fitToHeight < 0 || fitToHeight > 32767 {
+		return ErrFitToPages
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.prepareSheetPr()
+	if ws.SheetPr.PageSetUpPr == nil {
+		ws.SheetPr.PageSetUpPr = new(xlsxPageSetUpPr)
+	}
+	ws.SheetPr.PageSetUpPr.FitToPage = true
+	ws.newPageSetUp()
+	ws.PageSetUp.FitToWidth, ws.PageSetUp.FitToHeight = intPtr(fitToWidth), intPtr(fitToHeight)
+	if ws.SheetFormatPr != nil {
+		ws.SheetFormatPr.CustomHeight = false
+	}
+	return err
+}
+
+// GetPrintFitToPage provides a function to get the number of pages wide and
+// tall to fit the worksheet on when printing by given worksheet name. The
+// default number of pages in each direction is 1.
+//
+// 根据给定的工作表名称获取打印时工作表缩放的页宽与页高。
+func (f *File) GetPrintFitToPage(sheet string) (fitToWidth, fitToHeight int, err error) {
+	fitToWidth, fitToHeight = 1, 1
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.PageSetUp != nil {
+		if ws.PageSetUp.FitToWidth != nil {
+			fitToWidth = *ws.PageSetUp.FitToWidth
+		}
+		if ws.PageSetUp.FitToHeight != nil {
+			fitToHeight = *ws.PageSetUp.FitToHeight
+		}
+	}
+	return
+}
+
+// definedNameRefExp is the regular expression to match the cell, column or
+// row reference of the area in the defined name.
+var definedNameRefExp = regexp.MustCompile(`^(\$?[A-Za-z]{1,3}\$?\d+(:\$?[A-Za-z]{1,3}\$?\d+)?|\$?[A-Za-z]{1,3}:\$?[A-Za-z]{1,3}|\$?\d+:\$?\d+)$`)
+
+// quoteDefinedNameRef provides a function to quote the worksheet names which
+// contain spaces or special characters in the references of the defined
+// name, such as "My Sheet!$A$1:$B$2,My Sheet!$D$5". The formula or the
+// reference which has been quoted will be returned as it is, and the leading
+// equal sign of the reference will be kept.
+func quoteDefinedNameRef(refersTo string) string {
+	if strings.HasPrefix(refersTo, "=") {
+		return "=" + quoteDefinedNameRef(refersTo[1:])
+	}
+	areas := strings.Split(refersTo, ",")
+	for i, area := range areas {
+		idx := strings.LastIndex(area, "!")
+		if idx < 1 || !definedNameRefExp.MatchString(area[idx+1:]) {
+			return refersTo
+		}
+		sheet := area[:idx]
+		if strings.HasPrefix(sheet, "'") {
+			if !strings.HasSuffix(sheet, "'") || len(sheet) < 2 {
+				return refersTo
+			}
+			continue
+		}
+		if strings.IndexFunc(sheet, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+		}) != -1 || (sheet[0] >= '0' && sheet[0] <= '9') {
+			areas[i] = "'" + strings.ReplaceAll(sheet, "'", "''") + "'" + area[idx:]
+		}
+	}
+	return strings.Join(areas, ",")
+}
+
 // SetDefinedName provides a function to set the defined names of the workbook
-// or worksheet. If not specified scope, the default scope is workbook.
-// For example:
+// or worksheet. If not specified scope, the default scope is workbook. The
+// reference could be a formula or the references of multiple areas separated
+// by commas, and the worksheet names which contain spaces or special
+// characters in the references will be quoted. For example:
 //
 //	err := f.SetDefinedName(&excelize.DefinedName{
 //	    Name:     "Amount",
@@ -1598,7 +2329,7 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	d := xlsxDefinedName{
 		Name:    definedName.Name,
 		Comment: definedName.Comment,
-		Data:    definedName.RefersTo,
+		Data:    quoteDefinedNameRef(definedName.RefersTo),
 	}
 	if definedName.Scope != "" {
 		if sheetIndex, _ := f.GetSheetIndex(definedName.Scope); sheetIndex >= 0 {
@@ -1658,6 +2389,95 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 	return ErrDefinedNameScope
 }
 
+// UpdateDefinedName provides a function to update the reference and comment
+// of the existing defined name of the workbook or worksheet by given name
+// and scope. If not specified scope, the default scope is workbook. For
+// example, change the reference of the defined name "Amount" on Sheet2:
+//
+//	err := f.UpdateDefinedName(&excelize.DefinedName{
+//	    Name:     "Amount",
+//	    RefersTo: "Sheet1!$A$2:$D$10,Sheet1!$F$2",
+//	    Scope:    "Sheet2",
+//	})
+//
+// 根据给定的名称和名称作用范围更新已定义名称的引用和注释，默认名称的作用范围为工作簿。
+func (f *File) UpdateDefinedName(definedName *DefinedName) error {
+	if definedName.Name == "" || definedName.RefersTo == "" {
+		return ErrParameterInvalid
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for idx, dn := range wb.DefinedNames.DefinedName {
+			scope := "Workbook"
+			updateScope := definedName.Scope
+			if updateScope == "" {
+				updateScope = "Workbook"
+			}
+			if dn.LocalSheetID != nil {
+				scope = f.GetSheetName(*dn.LocalSheetID)
+			}
+			if scope == updateScope && dn.Name == definedName.Name {
+				wb.DefinedNames.DefinedName[idx].Data = quoteDefinedNameRef(definedName.RefersTo)
+				wb.DefinedNames.DefinedName[idx].Comment = definedName.Comment
+				return err
+			}
+		}
+	}
+	return ErrDefinedNameScope
+}
+
+// AddNamedRange provides a function to add a named range by given name,
+// worksheet name of the scope and the formula which the name refers to. The
+// scope is the workbook if the worksheet name is empty. This function is a
+// short form of the function SetDefinedName without the comment. For
+// example, add a workbook level named range and a worksheet level named range
+// on Sheet2:
+//
+//	err := f.AddNamedRange("Amount", "", "Sheet1!$A$2:$D$5")
+//	err = f.AddNamedRange("Total", "Sheet2", "SUM(Sheet2!$B$2:$B$5)")
+//
+// 根据给定的名称、作用范围工作表名称和引用公式添加名称，工作表名称为空时作用范围为工作簿。
+func (f *File) AddNamedRange(name, sheet, formula string) error {
+	if err := f.checkNamedRangeScope(sheet); err != nil {
+		return err
+	}
+	return f.SetDefinedName(&DefinedName{Name: name, RefersTo: formula, Scope: sheet})
+}
+
+// DeleteNamedRange provides a function to delete the named range by given
+// name and worksheet name of the scope. The scope is the workbook if the
+// worksheet name is empty. For example, delete the named range "Total" on
+// Sheet2:
+//
+//	err := f.DeleteNamedRange("Total", "Sheet2")
+//
+// 根据给定的名称和作用范围工作表名称删除名称，工作表名称为空时作用范围为工作簿。
+func (f *File) DeleteNamedRange(name, sheet string) error {
+	if err := f.checkNamedRangeScope(sheet); err != nil {
+		return err
+	}
+	return f.DeleteDefinedName(&DefinedName{Name: name, Scope: sheet})
+}
+
+// checkNamedRangeScope provides a function to check the worksheet name of the
+// named range scope is exists if it's not empty.
+func (f *File) checkNamedRangeScope(sheet string) error {
+	if sheet == "" {
+		return nil
+	}
+	idx, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if idx == -1 {
+		return newNoExistSheetError(sheet)
+	}
+	return nil
+}
+
 // GetDefinedName provides a function to get the defined names of the workbook
 // or worksheet.
 // 获取作用范围内的工作簿和工作表的名称列表。
@@ -1681,6 +2501,87 @@ func (f *File) GetDefinedName() []DefinedName {
 	return definedNames
 }
 
+// GetAllDefinedNames provides a function to get the defined names of the
+// workbook and worksheets grouped by the name. Defined names are compared
+// case-insensitive, all the scope variants of a name are grouped under the
+// spelling of its first occurrence in the workbook. The Scope field of each
+// defined name is the worksheet name for the worksheet level defined name,
+// and empty for the workbook level defined name. For example:
+//
+//	names, err := f.GetAllDefinedNames()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, definedName := range names["Amount"] {
+//	    fmt.Println(definedName.Scope, definedName.RefersTo)
+//	}
+//
+// 获取工作簿与工作表中的全部名称，并按名称（不区分大小写）分组返回，工作簿范围名称的作用范围为空字符串。
+func (f *File) GetAllDefinedNames() (map[string][]DefinedName, error) {
+	definedNames := map[string][]DefinedName{}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return definedNames, err
+	}
+	if wb.DefinedNames == nil {
+		return definedNames, err
+	}
+	keys := map[string]string{}
+	for _, dn := range wb.DefinedNames.DefinedName {
+		definedName := DefinedName{
+			Name:     dn.Name,
+			Comment:  dn.Comment,
+			RefersTo: dn.Data,
+		}
+		if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
+			definedName.Scope = f.GetSheetName(*dn.LocalSheetID)
+		}
+		key, ok := keys[strings.ToLower(dn.Name)]
+		if !ok {
+			key = dn.Name
+			keys[strings.ToLower(dn.Name)] = key
+		}
+		definedNames[key] = append(definedNames[key], definedName)
+	}
+	return definedNames, err
+}
+
+// GetAllNamedRanges provides a function to get the defined names of the
+// workbook and worksheets with one defined name for each name. Defined names
+// are compared case-insensitive, and the workbook level defined name wins
+// over the worksheet level defined names with the same name, which follows
+// the lookup rules of the name used outside the worksheet scope. If a name is
+// only defined at the worksheet level, the first of them in the workbook will
+// be returned. Use GetAllDefinedNames to get all the scope variants of the
+// names. For example:
+//
+//	names, err := f.GetAllNamedRanges()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(names["Amount"].RefersTo)
+//
+// 获取工作簿与工作表中的全部名称，每个名称（不区分大小写）仅返回一项，工作簿范围的名称优先于工作表范围的同名名称。
+func (f *File) GetAllNamedRanges() (map[string]DefinedName, error) {
+	namedRanges := map[string]DefinedName{}
+	definedNames, err := f.GetAllDefinedNames()
+	if err != nil {
+		return namedRanges, err
+	}
+	for key, names := range definedNames {
+		namedRanges[key] = names[0]
+		for _, definedName := range names {
+			if definedName.Scope == "" {
+				namedRanges[key] = definedName
+				break
+			}
+		}
+	}
+	return namedRanges, err
+}
+
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 // 根据给定的工作表名称对工作表进行分组，给定的工作表中需包含默认工作表。
@@ -1863,6 +2764,48 @@ func (f *File) RemovePageBreak(sheet, cell string) error {
 	return err
 }
 
+// GetWorksheetRelationships provides a function to get the relationships of
+// the worksheet by given worksheet name, such as the relationships to the
+// drawings, tables, comments, pivot tables and hyperlinks. Each relationship
+// includes the ID, the full URI of the relationship type, the target and the
+// target mode. For example, get the relationships of Sheet1:
+//
+//	rels, err := f.GetWorksheetRelationships("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, rel := range rels {
+//	    fmt.Println(rel.ID, rel.Type, rel.Target, rel.TargetMode)
+//	}
+//
+// 根据给定的工作表名称获取工作表的关系部件列表。
+func (f *File) GetWorksheetRelationships(sheet string) ([]Relationship, error) {
+	var relationships []Relationship
+	if err := checkSheetName(sheet); err != nil {
+		return relationships, err
+	}
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return relationships, ErrSheetNotExist{sheet}
+	}
+	rels, err := f.relsReader("xl/worksheets/_rels/" + strings.TrimPrefix(name, "xl/worksheets/") + ".rels")
+	if err != nil || rels == nil {
+		return relationships, err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		relationships = append(relationships, Relationship{
+			ID:         rel.ID,
+			Type:       rel.Type,
+			Target:     rel.Target,
+			TargetMode: rel.TargetMode,
+		})
+	}
+	return relationships, err
+}
+
 // relsReader provides a function to get the pointer to the structure
 // after deserialization of xl/worksheets/_rels/sheet%d.xml.rels.
 func (f *File) relsReader(path string) (*xlsxRelationships, error) {
@@ -1980,3 +2923,172 @@ func (f *File) GetSheetDimension(sheet string) (string, error) {
 	}
 	return ref, err
 }
+
+// GetSheetExtList provides a function to get the extensions of the worksheet
+// by given worksheet name. Features such as sparklines, slicers, data
+// validations and conditional formats which introduced in the newer versions
+// of Excel are stored in the extensions, and the raw inner XML of each
+// extension will be returned without parsing. Note that the namespace
+// prefixes in the content are declared on the worksheet element. For
+// example, check if Sheet1 contains sparklines:
+//
+//	exts, err := f.GetSheetExtList("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, ext := range exts {
+//	    if ext.URI == excelize.ExtURISparklineGroups {
+//	        fmt.Println("Sheet1 contains sparklines")
+//	    }
+//	}
+//
+// 根据给定的工作表名称获取工作表中的扩展元素。
+func (f *File) GetSheetExtList(sheet string) ([]ExtEntry, error) {
+	var exts []ExtEntry
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return exts, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.ExtLst == nil {
+		return exts, err
+	}
+	decodeExtLst := new(decodeWorksheetExt)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return exts, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		exts = append(exts, ExtEntry{URI: ext.URI, Content: []byte(ext.Content)})
+	}
+	return exts, nil
+}
+
+// GetSheetXMLSize provides a function to get the byte length of the
+// uncompressed worksheet XML by given worksheet name. A copy of the worksheet
+// with the pending cell changes is serialized into memory without writing the
+// spreadsheet, so the result could be used to decide whether to switch to
+// the stream writer. For the worksheet created by the stream writer, the
+// size of the data which has been written to the stream will be returned.
+// For example, get the XML size of Sheet1:
+//
+//	size, err := f.GetSheetXMLSize("Sheet1")
+//
+// 根据给定的工作表名称获取未压缩的工作表 XML 字节长度。
+func (f *File) GetSheetXMLSize(sheet string) (int64, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return 0, err
+	}
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return 0, newNoExistSheetError(sheet)
+	}
+	if sw, ok := f.streams[name]; ok {
+		return sw.rawData.Size()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(xml.Header) + len(f.marshalWorksheet(name, ws, true))), err
+}
+
+// CleanupXML provides a function to remove the blank cell and row elements of
+// the worksheet by given worksheet name. The cells without value, formula,
+// inline string and style will be removed, the rows which become empty will
+// be removed unless they have the custom height, style or other row
+// properties, and the dimension of the worksheet will be updated to the used
+// range of the remaining cells. For example, clean up the blank cells and
+// rows of Sheet1:
+//
+//	err := f.CleanupXML("Sheet1")
+//
+// 根据给定的工作表名称移除工作表中的空白单元格和空行，并更新工作表的已用区域。
+func (f *File) CleanupXML(sheet string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		for colIdx, c := range row.C {
+			if c.V == "" && c.F == nil && c.IS == nil && c.S == 0 {
+				row.C[colIdx] = xlsxC{R: c.R}
+			}
+		}
+		for len(row.C) > 0 && !row.C[len(row.C)-1].hasValue() {
+			row.C = row.C[:len(row.C)-1]
+		}
+		if len(row.C) == 0 {
+			row.Spans = ""
+		}
+	}
+	for len(ws.SheetData.Row) > 0 {
+		if row := ws.SheetData.Row[len(ws.SheetData.Row)-1]; len(row.C) > 0 || row.hasAttr() {
+			break
+		}
+		ws.SheetData.Row = ws.SheetData.Row[:len(ws.SheetData.Row)-1]
+	}
+	ws.Dimension = &xlsxDimension{Ref: ws.getUsedRange()}
+	return err
+}
+
+// GetMaxRowCol provides the method to get the last row number and column
+// number of the used range of the worksheet. This function reads the used
+// range from the dimension of the worksheet if it has been specified as a
+// range reference, otherwise collects the maximum row and column number of
+// the cells in a single pass over the rows of the worksheet. Note that the
+// dimension of the worksheet was recorded by the spreadsheet application or
+// the SetSheetDimension function, and it will not be updated by writing
+// cells. This function returns 0 for both of the row and column number if
+// the worksheet is empty.
+//
+// 根据给定的工作表名称获取已用区域的最大行号和最大列号。
+func (f *File) GetMaxRowCol(sheet string) (maxRow, maxCol int, err error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Dimension != nil && strings.Contains(ws.Dimension.Ref, ":") {
+		var coordinates []int
+		if coordinates, err = rangeRefToCoordinates(ws.Dimension.Ref); err == nil {
+			_ = sortCoordinates(coordinates)
+			return coordinates[3], coordinates[2], err
+		}
+	}
+	for rowIdx, row := range ws.SheetData.Row {
+		if len(row.C) == 0 {
+			continue
+		}
+		if maxRow = rowIdx + 1; row.R != 0 {
+			maxRow = row.R
+		}
+		for colIdx, c := range row.C {
+			col := colIdx + 1
+			if c.R != "" {
+				if col, _, err = CellNameToCoordinates(c.R); err != nil {
+					return 0, 0, err
+				}
+			}
+			if col > maxCol {
+				maxCol = col
+			}
+		}
+	}
+	return maxRow, maxCol, nil
+}
@@ -206,6 +206,35 @@ func TestGetPageLayout(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestSetPrintFitToPage(t *testing.T) {
+	f := NewFile()
+	fitToWidth, fitToHeight, err := f.GetPrintFitToPage("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1}, []int{fitToWidth, fitToHeight})
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{Size: intPtr(9), Orientation: stringPtr("landscape")}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetFormatPr.CustomHeight = true
+	assert.NoError(t, f.SetPrintFitToPage("Sheet1", 2, 0))
+	fitToWidth, fitToHeight, err = f.GetPrintFitToPage("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 0}, []int{fitToWidth, fitToHeight})
+	assert.True(t, ws.(*xlsxWorksheet).SheetPr.PageSetUpPr.FitToPage)
+	assert.False(t, ws.(*xlsxWorksheet).SheetFormatPr.CustomHeight)
+	opts, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, *opts.Size)
+	assert.Equal(t, "landscape", *opts.Orientation)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPrintFitToPage.xlsx")))
+	// Test set fit to page with invalid number of pages
+	assert.EqualError(t, f.SetPrintFitToPage("Sheet1", -1, 1), ErrFitToPages.Error())
+	assert.EqualError(t, f.SetPrintFitToPage("Sheet1", 1, 32768), ErrFitToPages.Error())
+	// Test set and get fit to page on not exists worksheet
+	assert.EqualError(t, f.SetPrintFitToPage("SheetN", 1, 1), "sheet SheetN does not exist")
+	_, _, err = f.GetPrintFitToPage("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestSetHeaderFooter(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "Test SetHeaderFooter"))
@@ -236,9 +265,36 @@ func TestSetHeaderFooter(t *testing.T) {
 		EvenFooter:       "&L&D&R&T",
 		FirstHeader:      `&CCenter &"-,Bold"Bold&"-,Regular"HeaderU+000A&D`,
 	}))
+	// Test set header and footer with invalid formatting codes
+	for _, text := range []string{"&", "&Q", "&\"-,Bold", "&K00FF0", "Page &P&"} {
+		assert.EqualError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+			EvenFooter: text,
+		}), newHeaderFooterFormatCodeError("EvenFooter").Error())
+	}
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		OddHeader: "&L&12&K00FF00Left&&Right&R&\"Arial,Italic\"&P+1",
+	}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
 }
 
+func TestHeaderFooterBuilder(t *testing.T) {
+	assert.Equal(t, "", new(HeaderFooterBuilder).Build())
+	assert.Equal(t, "&CPage &P of &N", new(HeaderFooterBuilder).
+		Center("Page ").PageNumber().Center(" of ").TotalPages().Build())
+	assert.Equal(t, "&C&A", new(HeaderFooterBuilder).SheetName().Build())
+	assert.Equal(t, "&L&F&CR&&D&R&D &T", new(HeaderFooterBuilder).
+		Right("").Date().Right(" ").Time().
+		Left("").FileName().
+		Center("R&D").Build())
+	f := NewFile()
+	footer := new(HeaderFooterBuilder).Left("").FileName().Right("Page ").PageNumber().Build()
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{OddFooter: footer}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "&L&F&RPage &P", ws.HeaderFooter.OddFooter)
+	assert.NoError(t, f.Close())
+}
+
 func TestDefinedName(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetDefinedName(&DefinedName{
@@ -288,6 +344,149 @@ func TestDefinedName(t *testing.T) {
 		"XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestUpdateDefinedName(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("My Sheet")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "My Sheet!$A$1:$B$2,My Sheet!$D$5"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1", Scope: "My Sheet"}))
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", RefersTo: "'My Sheet'!$A$1:$B$2,'My Sheet'!$D$5", Scope: "Workbook"},
+		{Name: "Amount", RefersTo: "Sheet1!$A$1", Scope: "My Sheet"},
+	}, f.GetDefinedName())
+	// Test update the workbook and worksheet level defined names
+	assert.NoError(t, f.UpdateDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1:$A$10,Sheet1!$C:$C", Comment: "comment"}))
+	assert.NoError(t, f.UpdateDefinedName(&DefinedName{Name: "Amount", RefersTo: "'My Sheet'!$1:$2", Scope: "My Sheet"}))
+	path := filepath.Join("test", "TestUpdateDefinedName.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", RefersTo: "Sheet1!$A$1:$A$10,Sheet1!$C:$C", Comment: "comment", Scope: "Workbook"},
+		{Name: "Amount", RefersTo: "'My Sheet'!$1:$2", Scope: "My Sheet"},
+	}, f.GetDefinedName())
+	// Test update defined name by the defined name of the workbook scope
+	definedName := f.GetDefinedName()[0]
+	definedName.RefersTo = "=Sheet1!$A$1"
+	assert.NoError(t, f.UpdateDefinedName(&definedName))
+	assert.Equal(t, "=Sheet1!$A$1", f.GetDefinedName()[0].RefersTo)
+	// Test update defined name with invalid parameters and not exists name
+	assert.Equal(t, ErrParameterInvalid, f.UpdateDefinedName(&DefinedName{Name: "Amount"}))
+	assert.Equal(t, ErrDefinedNameScope, f.UpdateDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1", Scope: "Sheet1"}))
+	// Test update defined name with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.UpdateDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test update defined name without defined names
+	f = NewFile()
+	assert.Equal(t, ErrDefinedNameScope, f.UpdateDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1"}))
+	assert.NoError(t, f.Close())
+}
+
+func TestQuoteDefinedNameRef(t *testing.T) {
+	for ref, expected := range map[string]string{
+		"Sheet1!$A$1":                   "Sheet1!$A$1",
+		"My Sheet!$A$1:$B$2,Sheet1!A:B": "'My Sheet'!$A$1:$B$2,Sheet1!A:B",
+		"'My Sheet'!$A$1":               "'My Sheet'!$A$1",
+		"=Sheet1!$A$1":                  "=Sheet1!$A$1",
+		"=My Sheet!$A$1,Sheet1!$B$1":    "='My Sheet'!$A$1,Sheet1!$B$1",
+		"2023!$A$1":                     "'2023'!$A$1",
+		"Bob's Sheet!$1:$2":             "'Bob''s Sheet'!$1:$2",
+		"SUM(My Sheet!$A$1,1)":          "SUM(My Sheet!$A$1,1)",
+		"'My Sheet!$A$1":                "'My Sheet!$A$1",
+		"My Sheet!$A$1,1":               "My Sheet!$A$1,1",
+		"10":                            "10",
+	} {
+		assert.Equal(t, expected, quoteDefinedNameRef(ref), ref)
+	}
+}
+
+func TestNamedRange(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddNamedRange("Amount", "", "Sheet1!$A$2:$D$5"))
+	assert.NoError(t, f.AddNamedRange("Amount", "Sheet2", "Sheet2!$A$2:$D$5"))
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Scope: "Workbook"},
+		{Name: "Amount", RefersTo: "Sheet2!$A$2:$D$5", Scope: "Sheet2"},
+	}, f.GetDefinedName())
+	assert.Equal(t, ErrDefinedNameDuplicate, f.AddNamedRange("Amount", "Sheet2", "Sheet2!$A$1"))
+	assert.NoError(t, f.DeleteNamedRange("Amount", "Sheet2"))
+	assert.Equal(t, ErrDefinedNameScope, f.DeleteNamedRange("Amount", "Sheet2"))
+	assert.NoError(t, f.DeleteNamedRange("Amount", ""))
+	assert.Empty(t, f.GetDefinedName())
+	// Test add and delete named range with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.AddNamedRange("", "", "Sheet1!$A$1"))
+	assert.Equal(t, ErrParameterInvalid, f.AddNamedRange("Amount", "", ""))
+	assert.EqualError(t, f.AddNamedRange("Amount", "SheetN", "Sheet1!$A$1"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteNamedRange("Amount", "SheetN"), "sheet SheetN does not exist")
+	assert.Equal(t, ErrSheetNameInvalid, f.AddNamedRange("Amount", "Sheet:1", "Sheet1!$A$1"))
+	assert.Equal(t, ErrSheetNameInvalid, f.DeleteNamedRange("Amount", "Sheet:1"))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetAllDefinedNames(t *testing.T) {
+	f := NewFile()
+	definedNames, err := f.GetAllDefinedNames()
+	assert.NoError(t, err)
+	assert.Empty(t, definedNames)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, definedName := range []*DefinedName{
+		{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5"},
+		{Name: "amount", RefersTo: "Sheet1!$A$2:$A$5", Comment: "defined name comment", Scope: "Sheet2"},
+		{Name: "Total", RefersTo: "Sheet2!$B$1", Scope: "Sheet1"},
+	} {
+		assert.NoError(t, f.SetDefinedName(definedName))
+	}
+	definedNames, err = f.GetAllDefinedNames()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]DefinedName{
+		"Amount": {
+			{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5"},
+			{Name: "amount", RefersTo: "Sheet1!$A$2:$A$5", Comment: "defined name comment", Scope: "Sheet2"},
+		},
+		"Total": {{Name: "Total", RefersTo: "Sheet2!$B$1", Scope: "Sheet1"}},
+	}, definedNames)
+	// Test get all defined names with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetAllDefinedNames()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetAllNamedRanges(t *testing.T) {
+	f := NewFile()
+	namedRanges, err := f.GetAllNamedRanges()
+	assert.NoError(t, err)
+	assert.Empty(t, namedRanges)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, definedName := range []*DefinedName{
+		{Name: "amount", RefersTo: "Sheet1!$A$2:$A$5", Scope: "Sheet2"},
+		{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5"},
+		{Name: "Total", RefersTo: "Sheet2!$B$1", Scope: "Sheet1"},
+		{Name: "Total", RefersTo: "Sheet1!$B$1", Scope: "Sheet2"},
+	} {
+		assert.NoError(t, f.SetDefinedName(definedName))
+	}
+	namedRanges, err = f.GetAllNamedRanges()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]DefinedName{
+		"amount": {Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5"},
+		"Total":  {Name: "Total", RefersTo: "Sheet2!$B$1", Scope: "Sheet1"},
+	}, namedRanges)
+	// Test get all named ranges with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetAllNamedRanges()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGroupSheets(t *testing.T) {
 	f := NewFile()
 	sheets := []string{"Sheet2", "Sheet3"}
@@ -430,7 +629,9 @@ func TestWorksheetWriter(t *testing.T) {
 	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(worksheet, 1)))
 	f.checked = nil
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
-	f.workSheetWriter()
+	assert.NoError(t, f.marshalParts("", false, func(path string, content []byte) {
+		f.Pkg.Store(path, content)
+	}))
 	value, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
 	assert.Equal(t, fmt.Sprintf(worksheet, 2), string(value.([]byte)))
@@ -478,6 +679,87 @@ func TestDeleteSheet(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteSheet2.xlsx")))
 }
 
+func TestClearSheet(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", "B", "C"}, {1, 2, 3}, {4, 5, 6}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "SUM(A2:C2)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "B6"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddChart("Sheet1", "F10", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$C$1", Values: "Sheet1!$B$2:$C$2"}}}))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:B2"
+	assert.NoError(t, dv.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarBorderColor: "#0000FF", BarColor: "#638EC6"}}))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{Location: []string{"E2"}, Range: []string{"Sheet1!A2:C2"}}))
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorRGB: stringPtr("FFFF00")}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{Orientation: stringPtr("landscape")}))
+
+	assert.NoError(t, f.ClearSheet("Sheet1"))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, mergeCells)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, dvs)
+	condFmts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, condFmts)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "A1", ws.(*xlsxWorksheet).Dimension.Ref)
+	assert.Nil(t, ws.(*xlsxWorksheet).Drawing)
+	assert.Nil(t, ws.(*xlsxWorksheet).Hyperlinks)
+	assert.NotContains(t, ws.(*xlsxWorksheet).ExtLst.Ext, ExtURIConditionalFormattings)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, ExtURISparklineGroups)
+	_, ok = f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/charts/chart1.xml")
+	assert.False(t, ok)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, rels.Relationships)
+	sheetProps, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "FFFF00", *sheetProps.TabColorRGB)
+	pageLayout, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "landscape", *pageLayout.Orientation)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClearSheet.xlsx")))
+
+	// Test clear sheet without other extension lists
+	sheet, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	sheet.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s"></ext>`, ExtURIDataValidations)}
+	assert.NoError(t, f.ClearSheet("Sheet1"))
+	assert.Nil(t, sheet.ExtLst)
+	// Test clear sheet on not exists worksheet
+	assert.EqualError(t, f.ClearSheet("SheetN"), "sheet SheetN does not exist")
+	// Test clear sheet with invalid extension list
+	sheet.ExtLst = &xlsxExtLst{Ext: "<ext><x14:conditionalFormattings></x14:conditionalFormatting></ext>"}
+	assert.EqualError(t, f.ClearSheet("Sheet1"), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
+	// Test clear sheet with unsupported charset drawing relationships
+	f = NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	f.Relationships.Delete("xl/drawings/_rels/drawing1.xml.rels")
+	f.Pkg.Store("xl/drawings/_rels/drawing1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearSheet("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	// Test clear sheet with unsupported charset content types
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$C$1", Values: "Sheet1!$B$2:$C$2"}}}))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearSheet("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDeleteAndAdjustDefinedNames(t *testing.T) {
 	deleteAndAdjustDefinedNames(nil, 0)
 	deleteAndAdjustDefinedNames(&xlsxWorkbook{}, 0)
@@ -692,3 +974,247 @@ func TestSheetDimension(t *testing.T) {
 	assert.Empty(t, dimension)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestGetMaxRowCol(t *testing.T) {
+	f := NewFile()
+	// Test get the last row and column number of an empty worksheet
+	maxRow, maxCol, err := f.GetMaxRowCol("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, maxRow)
+	assert.Equal(t, 0, maxCol)
+	// Test get the last row and column number without the dimension
+	assert.NoError(t, f.SetCellValue("Sheet1", "D2", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", 1))
+	maxRow, maxCol, err = f.GetMaxRowCol("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, maxRow)
+	assert.Equal(t, 4, maxCol)
+	// Test get the last row and column number with the dimension
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "B2:F10"))
+	maxRow, maxCol, err = f.GetMaxRowCol("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, maxRow)
+	assert.Equal(t, 6, maxCol)
+	// Test get the last row and column number with invalid dimension
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).Dimension.Ref = "A1:B"
+	maxRow, maxCol, err = f.GetMaxRowCol("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, maxRow)
+	assert.Equal(t, 4, maxCol)
+	// Test get the last row and column number with the cells without reference
+	ws.(*xlsxWorksheet).Dimension = nil
+	ws.(*xlsxWorksheet).SheetData = xlsxSheetData{Row: []xlsxRow{{C: []xlsxC{{}, {}, {}}}, {}, {C: []xlsxC{{}}}}}
+	maxRow, maxCol, err = f.GetMaxRowCol("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, maxRow)
+	assert.Equal(t, 3, maxCol)
+	// Test get the last row and column number with invalid cell reference
+	ws.(*xlsxWorksheet).SheetData = xlsxSheetData{Row: []xlsxRow{{R: 1, C: []xlsxC{{R: "A"}}}}}
+	_, _, err = f.GetMaxRowCol("Sheet1")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get the last row and column number on not exists worksheet
+	_, _, err = f.GetMaxRowCol("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get the last row and column number with invalid sheet name
+	_, _, err = f.GetMaxRowCol("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
+
+func TestGetSheetExtList(t *testing.T) {
+	f := NewFile()
+	exts, err := f.GetSheetExtList("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, exts)
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1"},
+		Range:    []string{"Sheet1!B1:J1"},
+	}))
+	exts, err = f.GetSheetExtList("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, exts, 1)
+	assert.Equal(t, ExtURISparklineGroups, exts[0].URI)
+	assert.True(t, strings.HasPrefix(string(exts[0].Content), "<x14:sparklineGroups"))
+	assert.Contains(t, string(exts[0].Content), "<xm:f>Sheet1!B1:J1</xm:f>")
+	// Test get the extensions on not exists worksheet
+	_, err = f.GetSheetExtList("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get the extensions with invalid extension list
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst.Ext = "<ext><x14:sparklineGroups>"
+	_, err = f.GetSheetExtList("Sheet1")
+	assert.Error(t, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetSheetXMLSize(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 100))
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{CodeName: stringPtr("Sheet1")}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "D1:E2"}, {Ref: "E2:F3"}}}
+	size, err := f.GetSheetXMLSize("Sheet1")
+	assert.NoError(t, err)
+	// Test the worksheet in memory will not be changed
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 3)
+	assert.Len(t, ws.(*xlsxWorksheet).MergeCells.Cells, 2)
+	assert.NoError(t, f.marshalParts("", false, func(path string, content []byte) {
+		f.Pkg.Store(path, content)
+	}))
+	assert.Equal(t, int64(len(f.readXML("xl/worksheets/sheet1.xml"))), size)
+
+	// Test get the XML size of the worksheet created by the stream writer
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Hello", 1}))
+	size, err = f.GetSheetXMLSize("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(sw.rawData.buf.Len()), size)
+	// Test get the XML size of the stream which has been written to the temp file
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	tmpSize, err := f.GetSheetXMLSize("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, size, tmpSize)
+	assert.Zero(t, sw.rawData.buf.Len())
+	assert.NoError(t, sw.Flush())
+	// Test get the XML size of the stream with closed temp file
+	assert.NoError(t, sw.rawData.tmp.Close())
+	_, err = sw.rawData.Size()
+	assert.Error(t, err)
+	assert.NoError(t, os.Remove(sw.rawData.tmp.Name()))
+	sw.rawData.tmp = nil
+
+	// Test get the XML size with invalid sheet name
+	_, err = f.GetSheetXMLSize("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get the XML size on not exists worksheet
+	_, err = f.GetSheetXMLSize("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get the XML size with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSheetXMLSize("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCleanupXML(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><dimension ref="A1:D6"/><sheetData>`+
+		`<row r="1" spans="1:3"><c r="A1"><v>1</v></c><c r="B1"/><c r="C1" t="s"/></row>`+
+		`<row r="2"><c r="A2" s="1"/></row>`+
+		`<row r="3" spans="1:3"><c r="A3"/></row>`+
+		`<row r="4" ht="30" customHeight="1"><c r="A4"/></row>`+
+		`<row r="5"><c r="B5"><f>A1*2</f></c></row>`+
+		`<row r="6"><c r="D6"/></row></sheetData></worksheet>`))
+	f.checked = nil
+	assert.NoError(t, f.CleanupXML("Sheet1"))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B5", dimension)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 5)
+	assert.Len(t, ws.SheetData.Row[0].C, 1)
+	assert.Empty(t, ws.SheetData.Row[2].Spans)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCleanupXML.xlsx")))
+	sheetXML := string(f.readXML("xl/worksheets/sheet1.xml"))
+	for _, elem := range []string{`r="B1"`, `r="C1"`, `r="A3"`, `<row r="3"`, `r="A4"`, `r="D6"`, `<row r="6"`} {
+		assert.NotContains(t, sheetXML, elem)
+	}
+	for _, elem := range []string{`<c r="A1"><v>1</v></c>`, `<c r="A2" s="1"></c>`, `<row r="4" ht="30" customHeight="true"></row>`, `<c r="B5"><f>A1*2</f></c>`} {
+		assert.Contains(t, sheetXML, elem)
+	}
+	// Test clean up the worksheet without any cells
+	assert.NoError(t, f.CleanupXML("Sheet1"))
+	f2 := NewFile()
+	assert.NoError(t, f2.CleanupXML("Sheet1"))
+	dimension, err = f2.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", dimension)
+	assert.NoError(t, f2.Close())
+	// Test clean up not exists worksheet
+	assert.EqualError(t, f.CleanupXML("SheetN"), "sheet SheetN does not exist")
+	// Test clean up worksheet with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = nil
+	assert.EqualError(t, f.CleanupXML("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetWorksheetRelationships(t *testing.T) {
+	f := NewFile()
+	// Test get relationships of the worksheet without relationships
+	rels, err := f.GetWorksheetRelationships("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, rels)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com/xuri/excelize", "External"))
+	rels, err = f.GetWorksheetRelationships("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Relationship{
+		{ID: "rId1", Type: SourceRelationshipDrawingML, Target: "../drawings/drawing1.xml"},
+		{ID: "rId2", Type: SourceRelationshipHyperLink, Target: "https://github.com/xuri/excelize", TargetMode: "External"},
+	}, rels)
+	// Test get relationships with invalid sheet name
+	_, err = f.GetWorksheetRelationships("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get relationships on not exists worksheet
+	_, err = f.GetWorksheetRelationships("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get relationships with unsupported charset relationships part
+	f.Relationships.Delete("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetWorksheetRelationships("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetWorksheetTabOrderIndex(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3", "Sheet4"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	f.SetActiveSheet(1)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet4!$A$1", Scope: "Sheet4"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$A$1"}))
+
+	assert.NoError(t, f.SetWorksheetTabOrderIndex("Sheet4", 0))
+	assert.Equal(t, []string{"Sheet4", "Sheet1", "Sheet2", "Sheet3"}, f.GetSheetList())
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	assert.Equal(t, "Sheet2", f.GetSheetName(f.GetActiveSheetIndex()))
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", RefersTo: "Sheet4!$A$1", Scope: "Sheet4"},
+		{Name: "Total", RefersTo: "Sheet1!$A$1", Scope: "Workbook"},
+	}, f.GetDefinedName())
+
+	assert.NoError(t, f.SetWorksheetTabOrderIndex("sheet2", 3))
+	assert.Equal(t, []string{"Sheet4", "Sheet1", "Sheet3", "Sheet2"}, f.GetSheetList())
+	assert.Equal(t, "Sheet2", f.GetSheetName(f.GetActiveSheetIndex()))
+	// Test move worksheet to the same position
+	assert.NoError(t, f.SetWorksheetTabOrderIndex("Sheet1", 1))
+	assert.Equal(t, []string{"Sheet4", "Sheet1", "Sheet3", "Sheet2"}, f.GetSheetList())
+	// Test move worksheet to the invalid position
+	assert.EqualError(t, f.SetWorksheetTabOrderIndex("Sheet1", -1), ErrSheetIdx.Error())
+	assert.EqualError(t, f.SetWorksheetTabOrderIndex("Sheet1", 4), ErrSheetIdx.Error())
+	// Test move not exists worksheet
+	assert.EqualError(t, f.SetWorksheetTabOrderIndex("SheetN", 0), "sheet SheetN does not exist")
+	// Test move worksheet with invalid sheet name
+	assert.EqualError(t, f.SetWorksheetTabOrderIndex("Sheet:1", 0), ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetWorksheetTabOrderIndex.xlsx")))
+	// Test move worksheet with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorksheetTabOrderIndex("Sheet1", 0), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "reflect"
+import (
+	"reflect"
+	"sort"
+)
 
 // SetPageMargins provides a function to set worksheet page margins.
 //根据给定的工作表名称和页边距参数设置工作表的页边距。
@@ -228,3 +231,169 @@ func (f *File) GetSheetProps(sheet string) (SheetPropsOptions, error) {
 	}
 	return opts, err
 }
+
+// SetSheetDefaultStyle provides a function to set the default style of the
+// empty cells in the worksheet by given worksheet name and style ID. The
+// default style will be stored in the column definitions which cover the
+// columns without custom style, and the format of the rows without custom
+// style, the existing cells will keep their styles. The column definitions
+// and rows with the style of the column range ending at the last column XFD
+// will be updated as the previous default style. Set style ID as 0 to remove
+// the default style. For example, set the default style of the worksheet
+// 'Sheet1':
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetSheetDefaultStyle("Sheet1", style)
+//
+// 根据给定的工作表名称和样式索引设置工作表中空白单元格的默认样式。
+func (f *File) SetSheetDefaultStyle(sheet string, styleID int) error {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	s.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.setDefaultStyle(styleID)
+	return err
+}
+
+// GetSheetDefaultStyle provides a function to get the default style ID of the
+// empty cells in the worksheet by given worksheet name. The worksheet has no
+// dedicated storage for the default style, so this function returns the style
+// of the column range ending at the last column XFD, which is the column
+// definition set by the SetSheetDefaultStyle function. Note that the style set
+// on the column range ending at XFD by the SetColStyle function, such as
+// "A:XFD", will be returned as well. This function returns 0 if no column
+// range ending at XFD has a style.
+//
+// 根据给定的工作表名称获取工作表中空白单元格的默认样式索引。
+func (f *File) GetSheetDefaultStyle(sheet string) (int, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.getLastColRangeStyle(), err
+}
+
+// getLastColRangeStyle provides a function to get the style ID of the column
+// range ending at the last column XFD of the worksheet, which is used as the
+// default style of the worksheet.
+func (ws *xlsxWorksheet) getLastColRangeStyle() int {
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= MaxColumns && MaxColumns <= c.Max {
+				return c.Style
+			}
+		}
+	}
+	return 0
+}
+
+// setDefaultStyle provides a function to replace the default style of the
+// worksheet by given style ID. The columns not covered by any column
+// definition will be filled with new column definitions, and the column
+// definitions and rows with the previous default style will be updated.
+func (ws *xlsxWorksheet) setDefaultStyle(styleID int) {
+	prevStyleID, width, next := ws.getLastColRangeStyle(), defaultColWidth, 1
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth != 0 {
+		width = ws.SheetFormatPr.DefaultColWidth
+	}
+	var cols []xlsxCol
+	if ws.Cols != nil {
+		cols = ws.Cols.Col
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i].Min < cols[j].Min })
+	fillCols := func(min, max int) []xlsxCol {
+		if min > max || styleID == 0 {
+			return nil
+		}
+		return []xlsxCol{{Min: min, Max: max, Width: float64Ptr(width), Style: styleID}}
+	}
+	var fc []xlsxCol
+	for _, c := range cols {
+		fc = append(fc, fillCols(next, c.Min-1)...)
+		if c.Max+1 > next {
+			next = c.Max + 1
+		}
+		if c.Style != prevStyleID {
+			fc = append(fc, c)
+			continue
+		}
+		if c.Style = styleID; styleID == 0 && !c.BestFit && !c.Collapsed &&
+			!c.CustomWidth && !c.Hidden && c.OutlineLevel == 0 && !c.Phonetic &&
+			(c.Width == nil || *c.Width == width) {
+			continue
+		}
+		fc = append(fc, c)
+	}
+	fc = append(fc, fillCols(next, MaxColumns)...)
+	ws.Cols = nil
+	if len(fc) > 0 {
+		ws.Cols = &xlsxCols{Col: fc}
+	}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		if row.S == prevStyleID && row.CustomFormat == (prevStyleID != 0) {
+			row.S, row.CustomFormat = styleID, styleID != 0
+		}
+	}
+}
+
+// SetSheetOutlineProps provides a function to set the outline summary
+// properties of the worksheet by given worksheet name and options, which
+// control whether the summary rows appear below or above the grouped rows,
+// and whether the summary columns appear to the right or left of the grouped
+// columns. For example, show the summary rows above and the summary columns
+// on the left of the groups in the worksheet 'Sheet1':
+//
+//	err := f.SetSheetOutlineProps("Sheet1", excelize.OutlineProps{
+//	    SummaryBelow: false,
+//	    SummaryRight: false,
+//	})
+func (f *File) SetSheetOutlineProps(sheet string, opts OutlineProps) error {
+	return f.SetSheetProps(sheet, &SheetPropsOptions{
+		OutlineSummaryBelow: boolPtr(opts.SummaryBelow),
+		OutlineSummaryRight: boolPtr(opts.SummaryRight),
+	})
+}
+
+// GetSheetOutlineProps provides a function to get the outline summary
+// properties of the worksheet by given worksheet name. The summary rows appear
+// below and the summary columns appear to the right of the groups by default.
+func (f *File) GetSheetOutlineProps(sheet string) (OutlineProps, error) {
+	opts := OutlineProps{SummaryBelow: true, SummaryRight: true}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.SheetPr == nil || ws.SheetPr.OutlinePr == nil {
+		return opts, err
+	}
+	if ws.SheetPr.OutlinePr.SummaryBelow != nil {
+		opts.SummaryBelow = *ws.SheetPr.OutlinePr.SummaryBelow
+	}
+	if ws.SheetPr.OutlinePr.SummaryRight != nil {
+		opts.SummaryRight = *ws.SheetPr.OutlinePr.SummaryRight
+	}
+	return opts, err
+}
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -100,3 +101,108 @@ func TestGetSheetProps(t *testing.T) {
 	_, err = f.GetSheetProps("Sheet:1")
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
+
+func TestSetSheetDefaultStyle(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	colStyleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "D", 20))
+	assert.NoError(t, f.SetColStyle("Sheet1", "F", colStyleID))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, colStyleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "Go"))
+	assert.NoError(t, f.SetSheetDefaultStyle("Sheet1", styleID))
+	style, err := f.GetSheetDefaultStyle("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, style)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []xlsxCol{
+		{Min: 1, Max: 2, Width: float64Ptr(defaultColWidth), Style: styleID},
+		{Min: 3, Max: 3, Width: float64Ptr(20), CustomWidth: true, Style: styleID},
+		{Min: 4, Max: 4, Width: float64Ptr(20), CustomWidth: true, Style: styleID},
+		{Min: 5, Max: 5, Width: float64Ptr(defaultColWidth), Style: styleID},
+		{Min: 6, Max: 6, Width: float64Ptr(defaultColWidth), Style: colStyleID},
+		{Min: 7, Max: MaxColumns, Width: float64Ptr(defaultColWidth), Style: styleID},
+	}, ws.(*xlsxWorksheet).Cols.Col)
+	for _, row := range []xlsxRow{ws.(*xlsxWorksheet).SheetData.Row[0], ws.(*xlsxWorksheet).SheetData.Row[1]} {
+		assert.Equal(t, []interface{}{styleID, true}, []interface{}{row.S, row.CustomFormat})
+	}
+	assert.Equal(t, colStyleID, ws.(*xlsxWorksheet).SheetData.Row[2].S)
+	// Test the existing cells keep their styles
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).SheetData.Row[1].C[0].S)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetDefaultStyle.xlsx")))
+	// Test remove the default style of the worksheet
+	assert.NoError(t, f.SetSheetDefaultStyle("Sheet1", 0))
+	style, err = f.GetSheetDefaultStyle("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, style)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []xlsxCol{
+		{Min: 3, Max: 4, Width: float64Ptr(20), CustomWidth: true},
+		{Min: 6, Max: 6, Width: float64Ptr(defaultColWidth), Style: colStyleID},
+	}, ws.(*xlsxWorksheet).Cols.Col)
+	assert.Equal(t, []interface{}{0, false}, []interface{}{ws.(*xlsxWorksheet).SheetData.Row[0].S, ws.(*xlsxWorksheet).SheetData.Row[0].CustomFormat})
+	assert.Equal(t, colStyleID, ws.(*xlsxWorksheet).SheetData.Row[2].S)
+	// Test set and remove the default style on the worksheet without columns
+	ws.(*xlsxWorksheet).Cols = nil
+	assert.NoError(t, f.SetSheetDefaultStyle("Sheet1", styleID))
+	assert.Equal(t, []xlsxCol{{Min: 1, Max: MaxColumns, Width: float64Ptr(defaultColWidth), Style: styleID}}, ws.(*xlsxWorksheet).Cols.Col)
+	assert.NoError(t, f.SetSheetDefaultStyle("Sheet1", 0))
+	assert.Nil(t, ws.(*xlsxWorksheet).Cols)
+	// Test get the default style from the column range ending at XFD
+	assert.NoError(t, f.SetColStyle("Sheet1", "Z:XFD", colStyleID))
+	style, err = f.GetSheetDefaultStyle("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, colStyleID, style)
+	// Test set the default style with invalid style ID
+	assert.EqualError(t, f.SetSheetDefaultStyle("Sheet1", -1), newInvalidStyleID(-1).Error())
+	assert.EqualError(t, f.SetSheetDefaultStyle("Sheet1", 10), newInvalidStyleID(10).Error())
+	// Test set and get the default style on not exists worksheet
+	assert.EqualError(t, f.SetSheetDefaultStyle("SheetN", styleID), "sheet SheetN does not exist")
+	_, err = f.GetSheetDefaultStyle("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set and get the default style with invalid sheet name
+	assert.EqualError(t, f.SetSheetDefaultStyle("Sheet:1", styleID), ErrSheetNameInvalid.Error())
+	_, err = f.GetSheetDefaultStyle("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test set the default style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetDefaultStyle("Sheet1", styleID), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSheetOutlineProps(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetSheetOutlineProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, OutlineProps{SummaryBelow: true, SummaryRight: true}, opts)
+	for _, expected := range []OutlineProps{
+		{}, {SummaryBelow: true}, {SummaryRight: true}, {SummaryBelow: true, SummaryRight: true},
+	} {
+		assert.NoError(t, f.SetSheetOutlineProps("Sheet1", expected))
+		opts, err = f.GetSheetOutlineProps("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, opts)
+	}
+	assert.NoError(t, f.SetSheetOutlineProps("Sheet1", OutlineProps{SummaryRight: true}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxOutlinePr{SummaryBelow: boolPtr(false), SummaryRight: boolPtr(true)}, ws.(*xlsxWorksheet).SheetPr.OutlinePr)
+	// Test get the outline properties with only one attribute specified
+	ws.(*xlsxWorksheet).SheetPr.OutlinePr = &xlsxOutlinePr{SummaryRight: boolPtr(false)}
+	opts, err = f.GetSheetOutlineProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, OutlineProps{SummaryBelow: true}, opts)
+	// Test set and get the outline properties on not exists worksheet
+	assert.EqualError(t, f.SetSheetOutlineProps("SheetN", opts), "sheet SheetN does not exist")
+	_, err = f.GetSheetOutlineProps("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set and get the outline properties with invalid sheet name
+	assert.EqualError(t, f.SetSheetOutlineProps("Sheet:1", opts), ErrSheetNameInvalid.Error())
+	_, err = f.GetSheetOutlineProps("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.Close())
+}
@@ -11,6 +11,16 @@
 
 package excelize
 
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// customSheetViewIDExp is the regular expression to match the GUID of the
+// custom sheet view, the braces are optional.
+var customSheetViewIDExp = regexp.MustCompile(`^\{?[0-9A-Fa-f]{8}(-[0-9A-Fa-f]{4}){3}-[0-9A-Fa-f]{12}\}?$`)
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -135,3 +145,310 @@ func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	}
 	return opts, err
 }
+
+// SetSheetNamedView provides a function to add or update the sheet view at
+// the given view index of the worksheet by given worksheet name, view index
+// and view options. The view index should be an existing view index for
+// updating the view, or equal to the number of the views of the worksheet
+// for adding a new view. The new view will be associated with the first
+// workbook view which not used by the other views of the worksheet, the
+// workbook view will be created by copying the first workbook view if it
+// doesn't exist. For example, add the second view of Sheet1 with 120% zoom:
+//
+//	zoomScale := 120.0
+//	err := f.SetSheetNamedView("Sheet1", 1, &excelize.ViewOptions{
+//	    ZoomScale: &zoomScale,
+//	})
+//
+// 根据给定的工作表名称、视图索引和视图参数添加或更新工作表视图。
+func (f *File) SetSheetNamedView(sheet string, viewIndex int, opts *ViewOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetViews == nil {
+		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{WorkbookViewID: 0}}}
+	}
+	if viewIndex < 0 || viewIndex > len(ws.SheetViews.SheetView) {
+		return newViewIdxError(viewIndex)
+	}
+	if viewIndex == len(ws.SheetViews.SheetView) {
+		used := make(map[int]bool, len(ws.SheetViews.SheetView))
+		for _, view := range ws.SheetViews.SheetView {
+			used[view.WorkbookViewID] = true
+		}
+		var workbookViewID int
+		for used[workbookViewID] {
+			workbookViewID++
+		}
+		if wb.BookViews == nil {
+			wb.BookViews = &xlsxBookViews{WorkBookView: []xlsxWorkBookView{{}}}
+		}
+		for len(wb.BookViews.WorkBookView) <= workbookViewID {
+			wb.BookViews.WorkBookView = append(wb.BookViews.WorkBookView, wb.BookViews.WorkBookView[0])
+		}
+		ws.SheetViews.SheetView = append(ws.SheetViews.SheetView, xlsxSheetView{WorkbookViewID: workbookViewID})
+	}
+	if opts != nil {
+		ws.SheetViews.SheetView[viewIndex].setSheetView(opts)
+	}
+	return err
+}
+
+// DeleteSheetView provides a function to delete the sheet view at the given
+// view index of the worksheet by given worksheet name and view index. The
+// first view of the worksheet is required and can not be deleted. The
+// workbook views are shared by all worksheets and will be kept, so the other
+// sheet views keep their associated workbook views. For example, delete the
+// second view of Sheet1:
+//
+//	err := f.DeleteSheetView("Sheet1", 1)
+//
+// 根据给定的工作表名称和视图索引删除工作表视图，工作表的第一个视图不可删除。
+func (f *File) DeleteSheetView(sheet string, viewIndex int) error {
+	if viewIndex == 0 {
+		return ErrDeleteFirstSheetView
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetViews == nil || viewIndex < 0 || viewIndex >= len(ws.SheetViews.SheetView) {
+		return newViewIdxError(viewIndex)
+	}
+	ws.SheetViews.SheetView = append(ws.SheetViews.SheetView[:viewIndex], ws.SheetViews.SheetView[viewIndex+1:]...)
+	return err
+}
+
+// AddCustomSheetView provides a function to add or update the custom sheet
+// view of the worksheet by given worksheet name, custom view ID and the
+// visibility of the worksheet in this view. The custom view ID should be a
+// GUID, and the braces are optional. Visibility set by this function only
+// applies when the custom view is shown, and the workbook level visibility
+// of the worksheet is not changed. The custom workbook view with the same ID
+// will be created if it doesn't exist. For example, hide Sheet2 in the custom
+// view:
+//
+//	err := f.AddCustomSheetView("Sheet2", "{5C3F7A2D-1B4E-4F6A-9C8D-2E7B1A3C4D5E}", true)
+//
+// 根据给定的工作表名称、自定义视图 ID 和可见性添加或更新工作表的自定义视图。
+func (f *File) AddCustomSheetView(sheet, viewID string, hidden bool) error {
+	if !customSheetViewIDExp.MatchString(viewID) {
+		return ErrCustomSheetViewID
+	}
+	GUID := "{" + strings.ToUpper(strings.Trim(viewID, "{}")) + "}"
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	sheetID := f.getSheetID(sheet)
+	f.mu.Unlock()
+	if wb.CustomWorkbookViews == nil {
+		wb.CustomWorkbookViews = &xlsxCustomWorkbookViews{}
+	}
+	var exist bool
+	for _, view := range wb.CustomWorkbookViews.CustomWorkbookView {
+		exist = exist || (view.GUID != nil && strings.EqualFold(*view.GUID, GUID))
+	}
+	if !exist {
+		view := xlsxCustomWorkbookView{
+			ActiveSheetID: intPtr(sheetID), GUID: stringPtr(GUID), Name: stringPtr(GUID),
+			WindowWidth: intPtr(0), WindowHeight: intPtr(0),
+		}
+		if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
+			view.WindowWidth = intPtr(wb.BookViews.WorkBookView[0].WindowWidth)
+			view.WindowHeight = intPtr(wb.BookViews.WorkBookView[0].WindowHeight)
+		}
+		wb.CustomWorkbookViews.CustomWorkbookView = append(wb.CustomWorkbookViews.CustomWorkbookView, view)
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var state string
+	if hidden {
+		state = "hidden"
+	}
+	if ws.CustomSheetViews == nil {
+		ws.CustomSheetViews = &xlsxCustomSheetViews{}
+	}
+	for _, view := range ws.CustomSheetViews.CustomSheetView {
+		if strings.EqualFold(view.GUID, GUID) {
+			view.State = state
+			return err
+		}
+	}
+	ws.CustomSheetViews.CustomSheetView = append(ws.CustomSheetViews.CustomSheetView, &xlsxCustomSheetView{GUID: GUID, State: state})
+	return err
+}
+
+// GetCustomSheetViews provides a function to get the custom sheet views of
+// the worksheet by given worksheet name. For example, get the custom views
+// of Sheet2:
+//
+//	views, err := f.GetCustomSheetViews("Sheet2")
+//
+// 根据给定的工作表名称获取工作表的全部自定义视图。
+func (f *File) GetCustomSheetViews(sheet string) ([]CustomSheetView, error) {
+	var views []CustomSheetView
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return views, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.CustomSheetViews == nil {
+		return views, err
+	}
+	for _, view := range ws.CustomSheetViews.CustomSheetView {
+		views = append(views, CustomSheetView{
+			ViewID: view.GUID,
+			Hidden: view.State != "" && view.State != "visible",
+		})
+	}
+	return views, err
+}
+
+// SetSheetGridColor provides a function to set the color of the grid lines
+// for all views of the worksheet by given worksheet name and RGB color in
+// hex string. The grid lines color in the sheet view is specified by the
+// index of the legacy indexed color palette, so the nearest indexed color of
+// the given color will be used. For example, set the grid lines color of
+// Sheet1 to red:
+//
+//	err := f.SetSheetGridColor("Sheet1", "FF0000")
+func (f *File) SetSheetGridColor(sheet, hexRGB string) error {
+	colorID, err := getNearestIndexedColor(hexRGB)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.SheetViews == nil {
+		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{WorkbookViewID: 0}}}
+	}
+	for idx := range ws.SheetViews.SheetView {
+		ws.SheetViews.SheetView[idx].DefaultGridColor = boolPtr(false)
+		ws.SheetViews.SheetView[idx].ColorID = colorID
+	}
+	return err
+}
+
+// ResetSheetGridColor provides a function to restore the default color of the
+// grid lines for all views of the worksheet by given worksheet name.
+func (f *File) ResetSheetGridColor(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.SheetViews == nil {
+		return err
+	}
+	for idx := range ws.SheetViews.SheetView {
+		ws.SheetViews.SheetView[idx].DefaultGridColor = nil
+		ws.SheetViews.SheetView[idx].ColorID = 0
+	}
+	return err
+}
+
+// SelectionMode is the type of the default selection of the worksheet when
+// the spreadsheet opened.
+type SelectionMode byte
+
+// Worksheet selection modes enumeration.
+const (
+	SelectionModeCell SelectionMode = iota
+	SelectionModeRow
+	SelectionModeColumn
+)
+
+// SetSheetSelectionMode provides a function to set the default selection for
+// all views of the worksheet by given worksheet name and selection mode. The
+// selection starts from the top-left cell of the active pane, which is A1 if
+// the worksheet has no freeze or split panes. The supported selection modes
+// are:
+//
+//	 Mode                | Selection
+//	---------------------+-----------------------------
+//	 SelectionModeCell   | the single cell, such as A1
+//	 SelectionModeRow    | the entire row, such as 1:1
+//	 SelectionModeColumn | the entire column, such as A:A
+//
+// For example, select the entire first row of Sheet1 when opening it:
+//
+//	err := f.SetSheetSelectionMode("Sheet1", excelize.SelectionModeRow)
+func (f *File) SetSheetSelectionMode(sheet string, mode SelectionMode) error {
+	if mode > SelectionModeColumn {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.SheetViews == nil {
+		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{WorkbookViewID: 0}}}
+	}
+	for idx := range ws.SheetViews.SheetView {
+		view := &ws.SheetViews.SheetView[idx]
+		col, row, selection := 1, 1, &xlsxSelection{}
+		if view.Pane != nil {
+			selection.Pane = view.Pane.ActivePane
+			if view.Pane.TopLeftCell != "" {
+				if col, row, err = CellNameToCoordinates(view.Pane.TopLeftCell); err != nil {
+					return err
+				}
+			}
+		}
+		colName, _ := ColumnNumberToName(col)
+		selection.ActiveCell = colName + strconv.Itoa(row)
+		selection.SQRef = map[SelectionMode]string{
+			SelectionModeCell:   selection.ActiveCell,
+			SelectionModeRow:    strconv.Itoa(row) + ":" + strconv.Itoa(row),
+			SelectionModeColumn: colName + ":" + colName,
+		}[mode]
+		view.Selection = []*xlsxSelection{selection}
+	}
+	return err
+}
+
+// getNearestIndexedColor provides a function to get the index of the nearest
+// color in the legacy indexed color palette by given RGB color in hex string.
+// The redundant indexes 0-7 are excluded.
+func getNearestIndexedColor(hexRGB string) (int, error) {
+	rgb := strings.TrimPrefix(hexRGB, "#")
+	if len(rgb) != 6 {
+		return 0, ErrParameterInvalid
+	}
+	val, err := strconv.ParseUint(rgb, 16, 32)
+	if err != nil {
+		return 0, ErrParameterInvalid
+	}
+	r, g, b := int(val>>16), int(val>>8&0xFF), int(val&0xFF)
+	colorID, minDistance := 8, -1
+	for idx := 8; idx < 64; idx++ {
+		c, _ := strconv.ParseUint(IndexedColorMapping[idx], 16, 32)
+		dr, dg, db := r-int(c>>16), g-int(c>>8&0xFF), b-int(c&0xFF)
+		if distance := dr*dr + dg*dg + db*db; minDistance == -1 || distance < minDistance {
+			colorID, minDistance = idx, distance
+		}
+	}
+	return colorID, nil
+}
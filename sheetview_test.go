@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +49,189 @@ func TestGetView(t *testing.T) {
 	_, err = f.GetSheetView("SheetN", 0)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestSetSheetGridColor(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "#FF0000"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	view := ws.(*xlsxWorksheet).SheetViews.SheetView[0]
+	assert.Equal(t, boolPtr(false), view.DefaultGridColor)
+	assert.Equal(t, 10, view.ColorID)
+	// Test set grid color with the nearest indexed color
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "3365FE"))
+	assert.Equal(t, 48, ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	opts, err := f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.False(t, *opts.DefaultGridColor)
+	// Test reset grid color
+	assert.NoError(t, f.ResetSheetGridColor("Sheet1"))
+	view = ws.(*xlsxWorksheet).SheetViews.SheetView[0]
+	assert.Nil(t, view.DefaultGridColor)
+	assert.Equal(t, 0, view.ColorID)
+	// Test set grid color for the worksheet without sheet views
+	ws.(*xlsxWorksheet).SheetViews = nil
+	assert.NoError(t, f.ResetSheetGridColor("Sheet1"))
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "000080"))
+	assert.Equal(t, 18, ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	// Test set grid color with invalid color
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetGridColor("Sheet1", "FF00"))
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetGridColor("Sheet1", "GG0000"))
+	// Test set and reset grid color on not exists worksheet
+	assert.EqualError(t, f.SetSheetGridColor("SheetN", "FF0000"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.ResetSheetGridColor("SheetN"), "sheet SheetN does not exist")
+}
+
+func TestSetSheetSelectionMode(t *testing.T) {
+	f := NewFile()
+	for mode, expected := range map[SelectionMode]xlsxSelection{
+		SelectionModeCell:   {ActiveCell: "A1", SQRef: "A1"},
+		SelectionModeRow:    {ActiveCell: "A1", SQRef: "1:1"},
+		SelectionModeColumn: {ActiveCell: "A1", SQRef: "A:A"},
+	} {
+		assert.NoError(t, f.SetSheetSelectionMode("Sheet1", mode))
+		ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		assert.Equal(t, []*xlsxSelection{&expected}, ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection)
+	}
+	// Test set selection mode on the worksheet with freeze panes
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, YSplit: 2, TopLeftCell: "A3", ActivePane: "bottomLeft"}))
+	assert.NoError(t, f.SetSheetSelectionMode("Sheet1", SelectionModeRow))
+	sheet, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "A3", Pane: "bottomLeft", SQRef: "3:3"}}, sheet.(*xlsxWorksheet).SheetViews.SheetView[0].Selection)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetSelectionMode.xlsx")))
+	// Test set selection mode on the worksheet without sheet views
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetViews = nil
+	assert.NoError(t, f.SetSheetSelectionMode("Sheet1", SelectionModeColumn))
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "A1", SQRef: "A:A"}}, ws.SheetViews.SheetView[0].Selection)
+	// Test set selection mode with invalid top-left cell of the pane
+	ws.SheetViews.SheetView[0].Pane = &xlsxPane{TopLeftCell: "A"}
+	assert.EqualError(t, f.SetSheetSelectionMode("Sheet1", SelectionModeRow), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set selection mode with invalid mode
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetSelectionMode("Sheet1", SelectionModeColumn+1))
+	// Test set selection mode on not exists worksheet
+	assert.EqualError(t, f.SetSheetSelectionMode("SheetN", SelectionModeRow), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetSheetNamedView(t *testing.T) {
+	f := NewFile()
+	// Test update the first view of the worksheet
+	assert.NoError(t, f.SetSheetNamedView("Sheet1", 0, &ViewOptions{ZoomScale: float64Ptr(80)}))
+	opts, err := f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, float64Ptr(80), opts.ZoomScale)
+	// Test add a new view of the worksheet
+	assert.NoError(t, f.SetSheetNamedView("Sheet1", 1, &ViewOptions{ZoomScale: float64Ptr(120), View: stringPtr("pageLayout")}))
+	opts, err = f.GetSheetView("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, float64Ptr(120), opts.ZoomScale)
+	assert.Equal(t, stringPtr("pageLayout"), opts.View)
+	assert.NoError(t, f.SetSheetNamedView("Sheet1", 2, nil))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Len(t, wb.BookViews.WorkBookView, 3)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ws.SheetViews.SheetView[2].WorkbookViewID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetNamedView.xlsx")))
+	// Test add a new view of the worksheet and workbook without views
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	ws, err = f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	ws.SheetViews = nil
+	wb.BookViews = nil
+	assert.NoError(t, f.SetSheetNamedView("Sheet2", 1, nil))
+	assert.Len(t, wb.BookViews.WorkBookView, 2)
+	// Test set sheet view with invalid view index
+	assert.EqualError(t, f.SetSheetNamedView("Sheet1", 4, nil), "view index 4 out of range")
+	assert.EqualError(t, f.SetSheetNamedView("Sheet1", -1, nil), "view index -1 out of range")
+	// Test set sheet view on not exists worksheet
+	assert.EqualError(t, f.SetSheetNamedView("SheetN", 0, nil), "sheet SheetN does not exist")
+	// Test set sheet view with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetNamedView("Sheet1", 0, nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteSheetView(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetNamedView("Sheet1", 1, &ViewOptions{ZoomScale: float64Ptr(120)}))
+	assert.NoError(t, f.SetSheetNamedView("Sheet1", 2, &ViewOptions{ZoomScale: float64Ptr(150)}))
+	assert.NoError(t, f.DeleteSheetView("Sheet1", 1))
+	opts, err := f.GetSheetView("Sheet1", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, float64Ptr(150), opts.ZoomScale)
+	// Test the workbook views are kept and re-add the deleted view
+	assert.Len(t, f.WorkBook.BookViews.WorkBookView, 3)
+	assert.NoError(t, f.SetSheetNamedView("Sheet1", 2, nil))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2, 1}, []int{ws.SheetViews.SheetView[0].WorkbookViewID, ws.SheetViews.SheetView[1].WorkbookViewID, ws.SheetViews.SheetView[2].WorkbookViewID})
+	assert.Len(t, f.WorkBook.BookViews.WorkBookView, 3)
+	assert.NoError(t, f.DeleteSheetView("Sheet1", 2))
+	// Test delete the first view of the worksheet
+	assert.Equal(t, ErrDeleteFirstSheetView, f.DeleteSheetView("Sheet1", 0))
+	// Test delete sheet view with invalid view index
+	assert.EqualError(t, f.DeleteSheetView("Sheet1", 2), "view index 2 out of range")
+	assert.EqualError(t, f.DeleteSheetView("Sheet1", -1), "view index -1 out of range")
+	// Test delete sheet view on not exists worksheet
+	assert.EqualError(t, f.DeleteSheetView("SheetN", 1), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestCustomSheetView(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	views, err := f.GetCustomSheetViews("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, views)
+	viewID := "{5C3F7A2D-1B4E-4F6A-9C8D-2E7B1A3C4D5E}"
+	assert.NoError(t, f.AddCustomSheetView("Sheet1", viewID, false))
+	assert.NoError(t, f.AddCustomSheetView("Sheet2", "5c3f7a2d-1b4e-4f6a-9c8d-2e7b1a3c4d5e", true))
+	assert.NoError(t, f.AddCustomSheetView("Sheet2", "{00000000-0000-0000-0000-000000000001}", false))
+	views, err = f.GetCustomSheetViews("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomSheetView{{ViewID: viewID, Hidden: true}, {ViewID: "{00000000-0000-0000-0000-000000000001}"}}, views)
+	// Test the custom workbook views will be created once for each view ID
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Len(t, wb.CustomWorkbookViews.CustomWorkbookView, 2)
+	assert.Equal(t, stringPtr(viewID), wb.CustomWorkbookViews.CustomWorkbookView[0].Name)
+	assert.Equal(t, intPtr(1), wb.CustomWorkbookViews.CustomWorkbookView[0].ActiveSheetID)
+	// Test update the existing custom sheet view
+	assert.NoError(t, f.AddCustomSheetView("Sheet2", viewID, false))
+	path := filepath.Join("test", "TestCustomSheetView.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	views, err = f.GetCustomSheetViews("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomSheetView{{ViewID: viewID}, {ViewID: "{00000000-0000-0000-0000-000000000001}"}}, views)
+	visible, err := f.GetSheetVisible("Sheet2")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	// Test add custom sheet view with invalid view ID
+	for _, viewID := range []string{"", "ABC", "{5C3F7A2D-1B4E-4F6A-9C8D}", "5C3F7A2D-1B4E-4F6A-9C8D-2E7B1A3C4D5G"} {
+		assert.Equal(t, ErrCustomSheetViewID, f.AddCustomSheetView("Sheet1", viewID, true))
+	}
+	// Test add and get custom sheet view on not exists worksheet
+	assert.EqualError(t, f.AddCustomSheetView("SheetN", viewID, true), "sheet SheetN does not exist")
+	_, err = f.GetCustomSheetViews("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test add custom sheet view with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddCustomSheetView("Sheet1", viewID, true), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -0,0 +1,676 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// slicerSource directly maps the table column or the pivot table field which
+// the slicer associated with.
+type slicerSource struct {
+	tableID           int
+	columnID          int
+	pivotTableName    string
+	pivotTableSheetID int
+	pivotCacheXML     string
+	sourceName        string
+}
+
+// AddSlicer provides the method to insert a slicer for the table or pivot
+// table by given worksheet name and slicer settings. The slicer will be
+// placed on the worksheet with the top-left corner at the given cell, and
+// the table or pivot table could be located on any worksheet of the
+// workbook. For example, create a table and insert a slicer for the column
+// "Region" of the table on Sheet1:
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{Range: "A1:C5", Name: "Table1"})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddSlicer("Sheet1", &excelize.SlicerOptions{
+//	    TableName:  "Table1",
+//	    ColumnName: "Region",
+//	    Cell:       "E1",
+//	    Caption:    "Region",
+//	    Style:      "SlicerStyleLight6",
+//	    Width:      200,
+//	    Height:     200,
+//	})
+//
+// Each slicer uses its own slicer cache, which is named as "Slicer_" followed
+// by the column name. Note that the slicer of the table requires Excel 2013
+// or later, and the slicer of the pivot table requires Excel 2010 or later.
+//
+// 根据给定的工作表名称和切片器设置，为表格或数据透视表插入切片器。
+func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
+	options, err := parseSlicerOptions(opts)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if _, _, err = CellNameToCoordinates(options.Cell); err != nil {
+		return err
+	}
+	source, err := f.getSlicerSource(options)
+	if err != nil {
+		return err
+	}
+	if options.Caption == "" {
+		options.Caption = source.sourceName
+	}
+	slicerName, cacheName, err := f.getSlicerNames(options, source)
+	if err != nil {
+		return err
+	}
+	if err = f.addSlicerCache(cacheName, source); err != nil {
+		return err
+	}
+	if err = f.addSheetSlicer(ws, sheet, slicerName, cacheName, source, options); err != nil {
+		return err
+	}
+	return f.addDrawingSlicer(ws, sheet, slicerName, source, options)
+}
+
+// parseSlicerOptions provides a function to validate the slicer settings and
+// set the default values.
+func parseSlicerOptions(opts *SlicerOptions) (*SlicerOptions, error) {
+	if opts == nil || opts.TableName == "" || opts.ColumnName == "" || opts.Cell == "" {
+		return nil, ErrParameterRequired
+	}
+	options := *opts
+	if options.Style == "" {
+		options.Style = defaultSlicerStyle
+	}
+	if options.Width == 0 {
+		options.Width = defaultSlicerWidth
+	}
+	if options.Height == 0 {
+		options.Height = defaultSlicerHeight
+	}
+	return &options, nil
+}
+
+// getSlicerSource provides a function to find the table column or the pivot
+// table field by given slicer settings. The tables will be matched first,
+// and then the pivot tables on each worksheet.
+func (f *File) getSlicerSource(opts *SlicerOptions) (*slicerSource, error) {
+	var sheets []string
+	for _, sheet := range f.GetSheetList() {
+		if sheetXMLPath, _ := f.getSheetXMLPath(sheet); strings.HasPrefix(sheetXMLPath, "xl/worksheets/") {
+			sheets = append(sheets, sheet)
+		}
+	}
+	_, tbl, err := f.getTable(opts.TableName)
+	if err != nil {
+		return nil, err
+	}
+	if tbl != nil {
+		if tbl.TableColumns != nil {
+			for _, column := range tbl.TableColumns.TableColumn {
+				if strings.EqualFold(column.Name, opts.ColumnName) {
+					return &slicerSource{tableID: tbl.ID, columnID: column.ID, sourceName: column.Name}, nil
+				}
+			}
+		}
+		return nil, ErrColumnNotExist
+	}
+	for _, sheet := range sheets {
+		rels, err := f.GetWorksheetRelationships(sheet)
+		if err != nil {
+			return nil, err
+		}
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		for _, rel := range rels {
+			if rel.Type != SourceRelationshipPivotTable {
+				continue
+			}
+			pivotTableXML := getPartRelTargetPath(sheetXMLPath, rel.Target)
+			pt := new(xlsxPivotTableDefinition)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotTableXML)))).
+				Decode(pt); err != nil && err != io.EOF {
+				return nil, err
+			}
+			if !strings.EqualFold(pt.Name, opts.TableName) {
+				continue
+			}
+			pivotCacheXML, err := f.getPartRelTarget(pivotTableXML, SourceRelationshipPivotCache, "")
+			if err != nil {
+				return nil, err
+			}
+			pc := new(decodePivotCacheDefinition)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotCacheXML)))).
+				Decode(pc); err != nil && err != io.EOF {
+				return nil, err
+			}
+			for _, field := range pc.CacheFields.CacheField {
+				if strings.EqualFold(field.Name, opts.ColumnName) {
+					return &slicerSource{
+						pivotTableName:    pt.Name,
+						pivotTableSheetID: f.getSheetID(sheet),
+						pivotCacheXML:     pivotCacheXML,
+						sourceName:        field.Name,
+					}, nil
+				}
+			}
+			return nil, ErrColumnNotExist
+		}
+	}
+	return nil, newNoExistTableError(opts.TableName)
+}
+
+// getSlicerNames provides a function to get the unique slicer name and slicer
+// cache name in the workbook by given slicer settings and the source of the
+// slicer.
+func (f *File) getSlicerNames(opts *SlicerOptions, source *slicerSource) (string, string, error) {
+	var (
+		err         error
+		slicerNames []string
+		cacheNames  []string
+	)
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/slicers/slicer") {
+			slicers := new(xlsxSlicers)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(slicers); err != nil && err != io.EOF {
+				return false
+			}
+			err = nil
+			for _, slicer := range slicers.Slicer {
+				slicerNames = append(slicerNames, slicer.Name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", "", err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return "", "", err
+	}
+	if wb.DefinedNames != nil {
+		for _, definedName := range wb.DefinedNames.DefinedName {
+			cacheNames = append(cacheNames, definedName.Name)
+		}
+	}
+	slicerName := opts.Name
+	if slicerName != "" && inStrSlice(slicerNames, slicerName, false) != -1 {
+		return "", "", ErrExistsSlicerName
+	}
+	if slicerName == "" {
+		slicerName = source.sourceName
+		for i := 1; inStrSlice(slicerNames, slicerName, false) != -1; i++ {
+			slicerName = fmt.Sprintf("%s %d", source.sourceName, i)
+		}
+	}
+	baseName := "Slicer_" + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, source.sourceName)
+	cacheName := baseName
+	for i := 1; inStrSlice(cacheNames, cacheName, false) != -1; i++ {
+		cacheName = baseName + strconv.Itoa(i)
+	}
+	return slicerName, cacheName, err
+}
+
+// addSlicerCache provides a function to create the slicer cache part, and add
+// the slicer cache into the workbook by given slicer cache name and the
+// source of the slicer.
+func (f *File) addSlicerCache(cacheName string, source *slicerSource) error {
+	slicerCacheID := f.countSlicerCaches() + 1
+	slicerCache := xlsxSlicerCacheDefinition{Name: cacheName, SourceName: source.sourceName}
+	extURI := ExtURISlicerCachesListX14
+	if source.pivotCacheXML == "" {
+		extURI = ExtURISlicerCachesListX15
+		tableSlicerCache, _ := xml.Marshal(xlsxTableSlicerCache{TableID: source.tableID, Column: source.columnID})
+		slicerCache.XMLNSMC = SourceRelationshipCompatibility.Value
+		slicerCache.MCIgnorable = "x"
+		slicerCache.XMLNSX = NameSpaceSpreadSheet.Value
+		slicerCache.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<x:ext uri="%s" xmlns:x15="%s">%s</x:ext>`,
+			ExtURISlicerCacheDefinition, NameSpaceSpreadSheetX15.Value, tableSlicerCache)}
+	} else {
+		pivotCacheID, err := f.setPivotCacheSlicerID(source.pivotCacheXML)
+		if err != nil {
+			return err
+		}
+		slicerCache.PivotTables = &xlsxSlicerCachePivotTables{
+			PivotTable: []xlsxSlicerCachePivotTable{{TabID: source.pivotTableSheetID, Name: source.pivotTableName}},
+		}
+		slicerCache.Data = &xlsxSlicerCacheData{Tabular: &xlsxTabularSlicerCache{PivotCacheID: pivotCacheID}}
+	}
+	content, _ := xml.Marshal(slicerCache)
+	f.saveFileList("xl/slicerCaches/slicerCache"+strconv.Itoa(slicerCacheID)+".xml", content)
+	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSlicerCache, "slicerCaches/slicerCache"+strconv.Itoa(slicerCacheID)+".xml", "")
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.ExtLst, err = f.addSlicerListExt(wb.ExtLst, extURI, "rId"+strconv.Itoa(rID), workbookExtURIPriority); err != nil {
+		return err
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{Name: cacheName, Data: "#N/A"})
+	return f.addContentTypePart(slicerCacheID, "slicerCache")
+}
+
+// setPivotCacheSlicerID provides a function to get the identifier of the
+// pivot cache used by the slicer cache by given pivot cache part path. The
+// identifier will be added into the extension list of the pivot cache
+// definition if it doesn't exist, which is the same with the cache ID of the
+// pivot cache in the workbook.
+func (f *File) setPivotCacheSlicerID(pivotCacheXML string) (int, error) {
+	content := f.readXML(pivotCacheXML)
+	pc := struct {
+		ExtLst *decodeWorksheetExt `xml:"extLst"`
+	}{}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(&pc); err != nil && err != io.EOF {
+		return 0, err
+	}
+	var ext *xlsxWorksheetExt
+	if pc.ExtLst != nil {
+		for _, e := range pc.ExtLst.Ext {
+			if e.URI != ExtURIPivotCacheDefinition {
+				continue
+			}
+			definition := new(decodeX14SlicerList)
+			if err := f.xmlNewDecoder(strings.NewReader(e.Content)).
+				Decode(definition); err != nil && err != io.EOF {
+				return 0, err
+			}
+			if definition.PivotCacheID != nil {
+				return *definition.PivotCacheID, nil
+			}
+			ext = e
+		}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return 0, err
+	}
+	var pivotCacheID int
+	if wb.PivotCaches != nil {
+		for _, pivotCache := range wb.PivotCaches.PivotCache {
+			target, err := f.getPartRelTarget(f.getWorkbookPath(), SourceRelationshipPivotCache, pivotCache.RID)
+			if err != nil {
+				return 0, err
+			}
+			if target == pivotCacheXML {
+				pivotCacheID = pivotCache.CacheID
+			}
+		}
+	}
+	definition := fmt.Sprintf(`<x14:pivotCacheDefinition pivotCacheId="%d"/>`, pivotCacheID)
+	switch idx := bytes.LastIndex(content, []byte("</extLst>")); {
+	case ext != nil && ext.Content != "":
+		content = bytes.Replace(content, []byte(ext.Content), []byte(definition), 1)
+	case idx != -1:
+		content = append(content[:idx:idx], append([]byte(fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s">%s</ext>`,
+			ExtURIPivotCacheDefinition, NameSpaceSpreadSheetX14.Value, definition)), content[idx:]...)...)
+	default:
+		idx = bytes.LastIndex(content, []byte("</"))
+		content = append(content[:idx:idx], append([]byte(fmt.Sprintf(`<extLst><ext uri="%s" xmlns:x14="%s">%s</ext></extLst>`,
+			ExtURIPivotCacheDefinition, NameSpaceSpreadSheetX14.Value, definition)), content[idx:]...)...)
+	}
+	f.Pkg.Store(pivotCacheXML, content)
+	return pivotCacheID, err
+}
+
+// addSheetSlicer provides a function to create the slicers part, and add the
+// slicers part into the worksheet by given worksheet, worksheet name, slicer
+// name, slicer cache name, the source of the slicer and slicer settings.
+func (f *File) addSheetSlicer(ws *xlsxWorksheet, sheet, slicerName, cacheName string, source *slicerSource, opts *SlicerOptions) error {
+	slicerID := f.countSlicers() + 1
+	content, _ := xml.Marshal(xlsxSlicers{Slicer: []*xlsxSlicer{{
+		Name:           slicerName,
+		Cache:          cacheName,
+		Caption:        opts.Caption,
+		Style:          opts.Style,
+		LockedPosition: opts.LockedPosition,
+		RowHeight:      defaultSlicerRowHeight,
+	}}})
+	f.saveFileList("xl/slicers/slicer"+strconv.Itoa(slicerID)+".xml", content)
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipSlicer, "../slicers/slicer"+strconv.Itoa(slicerID)+".xml", "")
+	extURI := ExtURISlicerListX14
+	if source.pivotCacheXML == "" {
+		extURI = ExtURISlicerListX15
+	}
+	var err error
+	if ws.ExtLst, err = f.addSlicerListExt(ws.ExtLst, extURI, "rId"+strconv.Itoa(rID), extensionURIPriority); err != nil {
+		return err
+	}
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return f.addContentTypePart(slicerID, "slicer")
+}
+
+// addSlicerListExt provides a function to append the relationship ID of the
+// slicers part or slicer cache part into the extension list by given
+// extension list, extension URI, relationship ID and the priority of the
+// extensions.
+func (f *File) addSlicerListExt(extLst *xlsxExtLst, extURI, rID string, priority []string) (*xlsxExtLst, error) {
+	decodeExtLst := new(decodeWorksheetExt)
+	if extLst != nil {
+		if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + extLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return extLst, err
+		}
+	}
+	var (
+		ext   *xlsxWorksheetExt
+		parts []*xlsxX14SlicerPart
+	)
+	for _, e := range decodeExtLst.Ext {
+		if e.URI != extURI {
+			continue
+		}
+		list := new(decodeX14SlicerList)
+		if err := f.xmlNewDecoder(strings.NewReader(e.Content)).
+			Decode(list); err != nil && err != io.EOF {
+			return extLst, err
+		}
+		for _, part := range list.Slicer {
+			parts = append(parts, &xlsxX14SlicerPart{RID: part.RID})
+		}
+		ext = e
+	}
+	parts = append(parts, &xlsxX14SlicerPart{RID: rID})
+	var content []byte
+	switch extURI {
+	case ExtURISlicerCachesListX14:
+		content, _ = xml.Marshal(xlsxX14SlicerCaches{
+			XMLName: xml.Name{Local: "x14:slicerCaches"}, XMLNSX14: NameSpaceSpreadSheetX14.Value, SlicerCache: parts,
+		})
+	case ExtURISlicerCachesListX15:
+		content, _ = xml.Marshal(xlsxX14SlicerCaches{
+			XMLName: xml.Name{Local: "x15:slicerCaches"}, XMLNSX14: NameSpaceSpreadSheetX14.Value,
+			XMLNSX15: NameSpaceSpreadSheetX15.Value, SlicerCache: parts,
+		})
+	default:
+		content, _ = xml.Marshal(xlsxX14SlicerList{XMLNS: NameSpaceSpreadSheetX14.Value, Slicer: parts})
+	}
+	if ext == nil {
+		ext = &xlsxWorksheetExt{URI: extURI}
+		decodeExtLst.Ext = append(decodeExtLst.Ext, ext)
+	}
+	ext.Content = string(content)
+	sort.SliceStable(decodeExtLst.Ext, func(i, j int) bool {
+		return inStrSlice(priority, decodeExtLst.Ext[i].URI, false) <
+			inStrSlice(priority, decodeExtLst.Ext[j].URI, false)
+	})
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	return &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}, err
+}
+
+// addDrawingSlicer provides a function to add the graphic frame of the slicer
+// into the drawing part of the worksheet by given worksheet, worksheet name,
+// slicer name, the source of the slicer and slicer settings. The graphic
+// frame was wrapped by the alternate content with a shape fallback for the
+// applications which doesn't support slicers.
+func (f *File) addDrawingSlicer(ws *xlsxWorksheet, sheet, slicerName string, source *slicerSource, opts *SlicerOptions) error {
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	format := parseGraphicOptions(nil)
+	format.Positioning = "oneCell"
+	if err := f.addDrawingChartFrame(sheet, drawingXML, opts.Cell, int(opts.Width), int(opts.Height), format, func(cNvPrID int) string {
+		cNvPr := &xlsxCNvPr{ID: cNvPrID, Name: slicerName}
+		choice := &xdrChoice{XMLNSA14: NameSpaceDrawingMLA14.Value, Requires: NameSpaceDrawingMLA14.Name.Local}
+		text := "This shape represents a slicer. Slicers are supported in Excel 2010 or later.\n\n" +
+			"If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."
+		if source.pivotCacheXML == "" {
+			choice = &xdrChoice{XMLNSSle15: NameSpaceDrawingMLSlicerX15.Value, Requires: NameSpaceDrawingMLSlicerX15.Name.Local}
+			text = "This shape represents a table slicer. Table slicers are supported in Excel 2013 or later.\n\n" +
+				"If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2010 or earlier, the slicer can't be used."
+		}
+		choice.GraphicFrame = &xlsxGraphicFrame{
+			NvGraphicFramePr: xlsxNvGraphicFramePr{CNvPr: cNvPr},
+			Graphic: &xlsxGraphic{
+				GraphicData: &xlsxGraphicData{
+					URI:    NameSpaceDrawingMLSlicer.Value,
+					Slicer: &sleSlicer{XMLNSSle: NameSpaceDrawingMLSlicer.Value, Name: slicerName},
+				},
+			},
+		}
+		alternateContent := xdrAlternateContent{
+			XMLNSMC: SourceRelationshipCompatibility.Value,
+			Choice:  choice,
+			Fallback: &xdrFallback{
+				Sp: &xdrSp{
+					NvSpPr: &xdrNvSpPr{CNvPr: cNvPr, CNvSpPr: &xdrCNvSpPr{TxBox: true}},
+					SpPr:   &xlsxSpPr{PrstGeom: xlsxPrstGeom{Prst: "rect"}},
+					TxBody: &xdrTxBody{
+						BodyPr: &aBodyPr{},
+						P:      []*aP{{R: &aR{RPr: aRPr{Lang: "en-US", Sz: 1100}, T: text}}},
+					},
+				},
+			},
+		}
+		graphic, _ := xml.Marshal(alternateContent)
+		return string(graphic)
+	}); err != nil {
+		return err
+	}
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return f.addContentTypePart(drawingID, "drawings")
+}
+
+// countSlicers provides a function to get the largest number of the
+// slicer parts storage in the folder xl/slicers, the number of the deleted
+// parts will not be reused.
+func (f *File) countSlicers() int {
+	count, maxNum := 0, 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/slicers/slicer") {
+			count++
+			if num, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), "xl/slicers/slicer"), ".xml")); num > maxNum {
+				maxNum = num
+			}
+		}
+		return true
+	})
+	if maxNum > count {
+		return maxNum
+	}
+	return count
+}
+
+// countSlicerCaches provides a function to get the largest number of the
+// slicer cache parts storage in the folder xl/slicerCaches, the number of the deleted
+// parts will not be reused.
+func (f *File) countSlicerCaches() int {
+	count, maxNum := 0, 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/slicerCaches/slicerCache") {
+			count++
+			if num, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), "xl/slicerCaches/slicerCache"), ".xml")); num > maxNum {
+				maxNum = num
+			}
+		}
+		return true
+	})
+	if maxNum > count {
+		return maxNum
+	}
+	return count
+}
+
+// GetSlicers provides the method to get the settings of all slicers on the
+// worksheet by given worksheet name. For example, get the slicers on Sheet1:
+//
+//	slicers, err := f.GetSlicers("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, slicer := range slicers {
+//	    fmt.Println(slicer.Name, slicer.TableName, slicer.ColumnName, slicer.Cell)
+//	}
+//
+// 根据给定的工作表名称获取工作表中的全部切片器设置。
+func (f *File) GetSlicers(sheet string) ([]SlicerOptions, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var slicers []SlicerOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ExtLst == nil {
+		return slicers, err
+	}
+	decodeExtLst := new(decodeWorksheetExt)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return slicers, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISlicerListX14 && ext.URI != ExtURISlicerListX15 {
+			continue
+		}
+		list := new(decodeX14SlicerList)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(list); err != nil && err != io.EOF {
+			return slicers, err
+		}
+		for _, part := range list.Slicer {
+			slicerXML := getPartRelTargetPath(sheetXMLPath, f.getSheetRelationshipsTargetByID(sheet, part.RID))
+			content := new(xlsxSlicers)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(slicerXML)))).
+				Decode(content); err != nil && err != io.EOF {
+				return slicers, err
+			}
+			for _, slicer := range content.Slicer {
+				opts := SlicerOptions{
+					Name:           slicer.Name,
+					Caption:        slicer.Caption,
+					Style:          slicer.Style,
+					LockedPosition: slicer.LockedPosition,
+				}
+				if err = f.getSlicerCacheSource(slicer.Cache, &opts); err != nil {
+					return slicers, err
+				}
+				if err = f.getSlicerAnchor(ws, sheet, &opts); err != nil {
+					return slicers, err
+				}
+				slicers = append(slicers, opts)
+			}
+		}
+	}
+	return slicers, nil
+}
+
+// getSlicerCacheSource provides a function to set the table name and column
+// name of the slicer settings by given slicer cache name.
+func (f *File) getSlicerCacheSource(cacheName string, opts *SlicerOptions) error {
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return err
+	}
+	rels.mu.Lock()
+	var targets []string
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipSlicerCache {
+			targets = append(targets, getPartRelTargetPath(f.getWorkbookPath(), rel.Target))
+		}
+	}
+	rels.mu.Unlock()
+	for _, slicerCacheXML := range targets {
+		slicerCache := new(decodeSlicerCacheDefinition)
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(slicerCacheXML)))).
+			Decode(slicerCache); err != nil && err != io.EOF {
+			return err
+		}
+		if slicerCache.Name != cacheName {
+			continue
+		}
+		opts.ColumnName = slicerCache.SourceName
+		if slicerCache.PivotTables != nil && len(slicerCache.PivotTables.PivotTable) > 0 {
+			opts.TableName = slicerCache.PivotTables.PivotTable[0].Name
+			return nil
+		}
+		if slicerCache.ExtLst == nil {
+			return nil
+		}
+		for _, ext := range slicerCache.ExtLst.Ext {
+			if ext.URI != ExtURISlicerCacheDefinition {
+				continue
+			}
+			tableSlicerCache := new(decodeTableSlicerCache)
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(tableSlicerCache); err != nil && err != io.EOF {
+				return err
+			}
+			for _, sheet := range f.GetSheetList() {
+				tables, _ := f.getSheetTables(sheet)
+				for _, tbl := range tables {
+					if tbl.table.ID == tableSlicerCache.TableID {
+						opts.TableName = tbl.table.Name
+						return nil
+					}
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// getSlicerAnchor provides a function to set the cell reference and the size
+// of the slicer settings by given worksheet, worksheet name and the slicer
+// settings with name.
+func (f *File) getSlicerAnchor(ws *xlsxWorksheet, sheet string, opts *SlicerOptions) error {
+	if ws.Drawing == nil {
+		return nil
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	drawingXML := getPartRelTargetPath(sheetXMLPath, f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID))
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	for _, anchor := range wsDr.TwoCellAnchor {
+		deAnchor, err := f.decodeDrawingAnchor(anchor)
+		if err != nil {
+			return err
+		}
+		if deAnchor.From == nil || deAnchor.AlternateContent == nil || deAnchor.AlternateContent.Choice == nil {
+			continue
+		}
+		graphicFrame := deAnchor.AlternateContent.Choice.GraphicFrame
+		if graphicFrame == nil || graphicFrame.Graphic == nil || graphicFrame.Graphic.GraphicData == nil ||
+			graphicFrame.Graphic.GraphicData.Slicer == nil || graphicFrame.Graphic.GraphicData.Slicer.Name != opts.Name {
+			continue
+		}
+		opts.Cell, _ = CoordinatesToCellName(deAnchor.From.Col+1, deAnchor.From.Row+1)
+		width, height := f.getPictureAnchorPixels(sheet, deAnchor)
+		opts.Width, opts.Height = uint(width), uint(height)
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package excelize
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSlicer(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Type", "Sales"}))
+	for row, region := range []string{"East", "West", "North", "South"} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+2), &[]interface{}{region, "Meat", row * 100}))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:C5", Name: "Table1"}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		TableName:  "Table1",
+		ColumnName: "Region",
+		Cell:       "E1",
+		Style:      "SlicerStyleLight6",
+		Width:      150,
+		Height:     180,
+	}))
+	// Test add a slicer with the same column name
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		TableName:      "table1",
+		ColumnName:     "region",
+		Cell:           "H1",
+		Caption:        "Sales Region",
+		LockedPosition: true,
+	}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!$A$1:$C$5",
+		PivotTableRange: "Sheet1!$A$10:$C$20",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+	}))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddSlicer("Sheet2", &SlicerOptions{
+		Name:       "Pivot Type",
+		TableName:  "Pivot Table1",
+		ColumnName: "Type",
+		Cell:       "B2",
+	}))
+	// Test add a slicer with the same slicer name
+	assert.Equal(t, ErrExistsSlicerName, f.AddSlicer("Sheet2", &SlicerOptions{
+		Name: "Pivot Type", TableName: "Pivot Table1", ColumnName: "Region", Cell: "F2",
+	}))
+	assert.NoError(t, f.AddSlicer("Sheet2", &SlicerOptions{
+		TableName: "Pivot Table1", ColumnName: "Region", Cell: "F2",
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSlicer.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddSlicer.xlsx"))
+	assert.NoError(t, err)
+	slicers, err := f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []SlicerOptions{
+		{Name: "Region", TableName: "Table1", ColumnName: "Region", Cell: "E1", Caption: "Region", Style: "SlicerStyleLight6", Width: 150, Height: 180},
+		{Name: "Region 1", TableName: "Table1", ColumnName: "Region", Cell: "H1", Caption: "Sales Region", Style: defaultSlicerStyle, Width: 200, Height: 200, LockedPosition: true},
+	}, slicers)
+	slicers, err = f.GetSlicers("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []SlicerOptions{
+		{Name: "Pivot Type", TableName: "Pivot Table1", ColumnName: "Type", Cell: "B2", Caption: "Type", Style: defaultSlicerStyle, Width: 200, Height: 200},
+		{Name: "Region 2", TableName: "Pivot Table1", ColumnName: "Region", Cell: "F2", Caption: "Region", Style: defaultSlicerStyle, Width: 200, Height: 200},
+	}, slicers)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	var definedNames []string
+	for _, definedName := range wb.DefinedNames.DefinedName {
+		definedNames = append(definedNames, definedName.Name)
+	}
+	assert.Equal(t, []string{"Slicer_Region", "Slicer_Region1", "Slicer_Type", "Slicer_Region2"}, definedNames)
+	// Test the pivot cache identifier was added into the pivot cache definition
+	pivotCache, ok := f.Pkg.Load("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(pivotCache.([]byte)), `<x14:pivotCacheDefinition pivotCacheId="2"/>`)
+	// Test get slicers on the worksheet without slicers
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	slicers, err = f.GetSlicers("Sheet3")
+	assert.NoError(t, err)
+	assert.Empty(t, slicers)
+	// Test add slicer with invalid options
+	for _, opts := range []*SlicerOptions{
+		nil,
+		{ColumnName: "Region", Cell: "E1"},
+		{TableName: "Table1", Cell: "E1"},
+		{TableName: "Table1", ColumnName: "Region"},
+	} {
+		assert.Equal(t, ErrParameterRequired, f.AddSlicer("Sheet1", opts))
+	}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")),
+		f.AddSlicer("Sheet1", &SlicerOptions{TableName: "Table1", ColumnName: "Region", Cell: "A"}))
+	assert.Equal(t, newNoExistTableError("Table2"),
+		f.AddSlicer("Sheet1", &SlicerOptions{TableName: "Table2", ColumnName: "Region", Cell: "E1"}))
+	assert.Equal(t, ErrColumnNotExist,
+		f.AddSlicer("Sheet1", &SlicerOptions{TableName: "Table1", ColumnName: "Month", Cell: "E1"}))
+	assert.Equal(t, ErrColumnNotExist,
+		f.AddSlicer("Sheet1", &SlicerOptions{TableName: "Pivot Table1", ColumnName: "Month", Cell: "E1"}))
+	// Test add slicer on not exists worksheet
+	assert.EqualError(t, f.AddSlicer("SheetN", &SlicerOptions{TableName: "Table1", ColumnName: "Region", Cell: "E1"}), "sheet SheetN does not exist")
+	// Test get slicers on not exists worksheet
+	_, err = f.GetSlicers("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test the number of the deleted slicer parts will not be reused
+	f = NewFile()
+	f.Pkg.Store("xl/slicers/slicer3.xml", nil)
+	f.Pkg.Store("xl/slicerCaches/slicerCache3.xml", nil)
+	assert.Equal(t, 3, f.countSlicers())
+	assert.Equal(t, 3, f.countSlicerCaches())
+	assert.NoError(t, f.Close())
+}
+
+func TestAddSlicerUnsupportedCharset(t *testing.T) {
+	newFile := func() *File {
+		f := NewFile()
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Sales"}))
+		assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3", Name: "Table1"}))
+		return f
+	}
+	opts := &SlicerOptions{TableName: "Table1", ColumnName: "Region", Cell: "E1"}
+	// Test add slicer with unsupported charset slicers part
+	f := newFile()
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddSlicer("Sheet1", opts), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test add slicer with unsupported charset pivot table part
+	f = NewFile()
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(fmt.Sprintf(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="%s" Target="../pivotTables/pivotTable1.xml"/></Relationships>`, SourceRelationshipPivotTable)))
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{TableName: "Pivot Table1", ColumnName: "Region", Cell: "E1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get slicers with unsupported charset slicers part
+	f = newFile()
+	assert.NoError(t, f.AddSlicer("Sheet1", opts))
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	_, err := f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get slicers with unsupported charset slicer cache part
+	f = newFile()
+	assert.NoError(t, f.AddSlicer("Sheet1", opts))
+	f.Pkg.Store("xl/slicerCaches/slicerCache1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -0,0 +1,247 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// SmartArtType is the type of supported SmartArt graphic types.
+type SmartArtType byte
+
+// This section defines the currently supported SmartArt graphic types
+// enumeration.
+const (
+	SmartArtList SmartArtType = iota
+	SmartArtProcess
+	SmartArtCycle
+	SmartArtHierarchy
+)
+
+// smartArtLayout directly maps the predefined layout settings of the SmartArt
+// graphic.
+type smartArtLayout struct {
+	uniqueID, category, layoutDef string
+}
+
+// smartArtLayouts defined the layout settings for each type of the SmartArt
+// graphic, the unique ID references the built-in layout of the application,
+// and the layout definition arranges the nodes in the same way as it.
+var smartArtLayouts = map[SmartArtType]smartArtLayout{
+	SmartArtList:      {"urn:microsoft.com/office/officeart/2005/8/layout/default", "list", templateSmartArtLayoutList},
+	SmartArtProcess:   {"urn:microsoft.com/office/officeart/2005/8/layout/process1", "process", templateSmartArtLayoutProcess},
+	SmartArtCycle:     {"urn:microsoft.com/office/officeart/2005/8/layout/cycle2", "cycle", templateSmartArtLayoutCycle},
+	SmartArtHierarchy: {"urn:microsoft.com/office/officeart/2005/8/layout/orgChart1", "hierarchy", templateSmartArtLayoutHierarchy},
+}
+
+// AddSmartArt provides the method to add a SmartArt graphic in a worksheet by
+// given worksheet name, cell reference, SmartArt type and format sets. The
+// SmartArt graphic will be created from a predefined template, and the text
+// of each node is specified by the Items field of the options. For the
+// hierarchy type, the first item is the root node and the other items are
+// its child nodes. The SmartArt types supported by excelize are:
+//
+//	 ID | Enumeration       | SmartArt
+//	----+-------------------+--------------------
+//	 0  | SmartArtList      | Basic block list
+//	 1  | SmartArtProcess   | Basic process
+//	 2  | SmartArtCycle     | Basic cycle
+//	 3  | SmartArtHierarchy | Organization chart
+//
+// The default width and height of the SmartArt graphic are 480 and 288
+// pixels. For example, add a basic process SmartArt graphic at the cell B2 of
+// Sheet1:
+//
+//	err := f.AddSmartArt("Sheet1", "B2", excelize.SmartArtProcess, &excelize.SmartArtOptions{
+//	    Items: []string{"Plan", "Build", "Release"},
+//	})
+func (f *File) AddSmartArt(sheet, cell string, smartArtType SmartArtType, opts *SmartArtOptions) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	layout, ok := smartArtLayouts[smartArtType]
+	if !ok {
+		return newUnsupportedSmartArtType(smartArtType)
+	}
+	options, err := parseSmartArtOptions(opts)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	smartArtID := f.countSmartArts() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
+	relIDs := &xlsxRelIds{DGM: NameSpaceDrawingMLDiagram.Value, R: SourceRelationship.Value}
+	for _, rel := range []struct {
+		rID           *string
+		relType, name string
+	}{
+		{&relIDs.DM, SourceRelationshipDiagramData, "data"},
+		{&relIDs.LO, SourceRelationshipDiagramLayout, "layout"},
+		{&relIDs.QS, SourceRelationshipDiagramQuickStyle, "quickStyle"},
+		{&relIDs.CS, SourceRelationshipDiagramColors, "colors"},
+	} {
+		*rel.rID = "rId" + strconv.Itoa(f.addRels(drawingRels, rel.relType, "../diagrams/"+rel.name+strconv.Itoa(smartArtID)+".xml", ""))
+	}
+	if err = f.addDrawingSmartArt(sheet, drawingXML, cell, options, relIDs); err != nil {
+		return err
+	}
+	if err = f.addSmartArt(smartArtID, smartArtType, layout, options.Items); err != nil {
+		return err
+	}
+	for _, contentType := range []string{"diagramData", "diagramLayout", "diagramStyle", "diagramColors"} {
+		if err = f.addContentTypePart(smartArtID, contentType); err != nil {
+			return err
+		}
+	}
+	_ = f.addContentTypePart(drawingID, "drawings")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// parseSmartArtOptions provides a function to parse the format settings of
+// the SmartArt graphic with default value.
+func parseSmartArtOptions(opts *SmartArtOptions) (*SmartArtOptions, error) {
+	if opts == nil || len(opts.Items) == 0 {
+		return opts, ErrSmartArtItems
+	}
+	if opts.Width == 0 {
+		opts.Width = defaultSmartArtWidth
+	}
+	if opts.Height == 0 {
+		opts.Height = defaultSmartArtHeight
+	}
+	if opts.Format.PrintObject == nil {
+		opts.Format.PrintObject = boolPtr(true)
+	}
+	if opts.Format.Locked == nil {
+		opts.Format.Locked = boolPtr(false)
+	}
+	if opts.Format.ScaleX == 0 {
+		opts.Format.ScaleX = defaultPictureScale
+	}
+	if opts.Format.ScaleY == 0 {
+		opts.Format.ScaleY = defaultPictureScale
+	}
+	return opts, nil
+}
+
+// addDrawingSmartArt provides a function to add the SmartArt graphic frame by
+// given sheet, drawingXML, cell, format sets and the relationship IDs of the
+// SmartArt parts.
+func (f *File) addDrawingSmartArt(sheet, drawingXML, cell string, opts *SmartArtOptions, relIDs *xlsxRelIds) error {
+	return f.addDrawingChartFrame(sheet, drawingXML, cell, int(opts.Width), int(opts.Height), &opts.Format, func(cNvPrID int) string {
+		graphicFrame := xlsxGraphicFrame{
+			NvGraphicFramePr: xlsxNvGraphicFramePr{
+				CNvPr: &xlsxCNvPr{
+					ID:   cNvPrID,
+					Name: "Diagram " + strconv.Itoa(cNvPrID),
+				},
+			},
+			Graphic: &xlsxGraphic{
+				GraphicData: &xlsxGraphicData{
+					URI:    NameSpaceDrawingMLDiagram.Value,
+					RelIds: relIDs,
+				},
+			},
+		}
+		graphic, _ := xml.Marshal(graphicFrame)
+		return string(graphic)
+	})
+}
+
+// addSmartArt provides a function to create the data, layout, quick style
+// and colors parts of the SmartArt graphic by given SmartArt ID, type, layout
+// settings and the text of the nodes.
+func (f *File) addSmartArt(smartArtID int, smartArtType SmartArtType, layout smartArtLayout, items []string) error {
+	n := len(items)
+	emptyText := func() *xlsxDgmT {
+		return &xlsxDgmT{P: []*aP{{EndParaRPr: &aEndParaRPr{Lang: "en-US"}}}}
+	}
+	dataModel := xlsxDataModel{
+		DGM: NameSpaceDrawingMLDiagram.Value,
+		A:   NameSpaceDrawingML.Value,
+		PtLst: xlsxDgmPtLst{Pt: []*xlsxDgmPt{{
+			ModelID: "0",
+			Type:    "doc",
+			PrSet: &xlsxDgmPrSet{
+				LoTypeID: layout.uniqueID,
+				LoCatID:  layout.category,
+				QsTypeID: "urn:microsoft.com/office/officeart/2005/8/quickstyle/simple1",
+				QsCatID:  "simple",
+				CsTypeID: "urn:microsoft.com/office/officeart/2005/8/colors/accent1_2",
+				CsCatID:  "accent1",
+			},
+			T: emptyText(),
+		}}},
+		CxnLst: &xlsxDgmCxnLst{},
+	}
+	for i, item := range items {
+		nodeID, parTransID, sibTransID, cxnID := strconv.Itoa(i+1), strconv.Itoa(n+i+1), strconv.Itoa(2*n+i+1), strconv.Itoa(3*n+i+1)
+		dataModel.PtLst.Pt = append(dataModel.PtLst.Pt, &xlsxDgmPt{
+			ModelID: nodeID,
+			PrSet:   &xlsxDgmPrSet{PhldrT: "[Text]"},
+			T:       &xlsxDgmT{P: []*aP{{R: &aR{RPr: aRPr{Lang: "en-US"}, T: item}}}},
+		})
+		for _, transType := range []string{"parTrans", "sibTrans"} {
+			transID := parTransID
+			if transType == "sibTrans" {
+				transID = sibTransID
+			}
+			dataModel.PtLst.Pt = append(dataModel.PtLst.Pt, &xlsxDgmPt{
+				ModelID: transID,
+				Type:    transType,
+				CxnID:   cxnID,
+				PrSet:   &xlsxDgmPrSet{},
+				T:       emptyText(),
+			})
+		}
+		srcID, srcOrd := "0", i
+		if smartArtType == SmartArtHierarchy && i > 0 {
+			srcID, srcOrd = "1", i-1
+		}
+		dataModel.CxnLst.Cxn = append(dataModel.CxnLst.Cxn, &xlsxDgmCxn{
+			ModelID:    cxnID,
+			SrcID:      srcID,
+			DestID:     nodeID,
+			SrcOrd:     srcOrd,
+			ParTransID: parTransID,
+			SibTransID: sibTransID,
+		})
+	}
+	data, err := xml.Marshal(dataModel)
+	if err != nil {
+		return err
+	}
+	f.saveFileList("xl/diagrams/data"+strconv.Itoa(smartArtID)+".xml", data)
+	f.saveFileList("xl/diagrams/layout"+strconv.Itoa(smartArtID)+".xml", []byte(layout.layoutDef))
+	f.saveFileList("xl/diagrams/quickStyle"+strconv.Itoa(smartArtID)+".xml", []byte(templateSmartArtQuickStyle))
+	f.saveFileList("xl/diagrams/colors"+strconv.Itoa(smartArtID)+".xml", []byte(templateSmartArtColors))
+	return err
+}
+
+// countSmartArts provides a function to get the SmartArt data files count
+// storage in the folder xl/diagrams.
+func (f *File) countSmartArts() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/diagrams/data") {
+			count++
+		}
+		return true
+	})
+	return count
+}
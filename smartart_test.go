@@ -0,0 +1,111 @@
+package excelize
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSmartArt(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddSmartArt("Sheet1", "B2", SmartArtProcess, &SmartArtOptions{
+		Items: []string{"Plan", "Build & Test", "Release"},
+	}))
+	assert.NoError(t, f.AddSmartArt("Sheet1", "B20", SmartArtHierarchy, &SmartArtOptions{
+		Items:  []string{"CEO", "CTO", "CFO"},
+		Width:  320,
+		Height: 200,
+		Format: GraphicOptions{OffsetX: 10, Positioning: "oneCell"},
+	}))
+	for _, smartArtType := range []SmartArtType{SmartArtList, SmartArtCycle} {
+		assert.NoError(t, f.AddSmartArt("Sheet1", "J2", smartArtType, &SmartArtOptions{Items: []string{"A"}}))
+	}
+	assert.Equal(t, 4, f.countSmartArts())
+
+	// Test the data model of the SmartArt graphic
+	dataModel := new(decodeSmartArtDataModel)
+	data, ok := f.Pkg.Load("xl/diagrams/data2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(data.([]byte), dataModel))
+	assert.Equal(t, "urn:microsoft.com/office/officeart/2005/8/layout/orgChart1", dataModel.Pt[0].PrSet.LoTypeID)
+	var texts []string
+	for _, pt := range dataModel.Pt {
+		if pt.Type == "" {
+			texts = append(texts, pt.T)
+		}
+	}
+	assert.Equal(t, []string{"CEO", "CTO", "CFO"}, texts)
+	assert.Len(t, dataModel.Cxn, 3)
+	for i, expected := range [][]string{{"0", "1"}, {"1", "2"}, {"1", "3"}} {
+		assert.Equal(t, expected, []string{dataModel.Cxn[i].SrcID, dataModel.Cxn[i].DestID})
+	}
+	data, ok = f.Pkg.Load("xl/diagrams/data1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(data.([]byte)), "<a:t>Build &amp; Test</a:t>")
+	data, ok = f.Pkg.Load("xl/diagrams/layout3.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(data.([]byte)), `uniqueId="urn:microsoft.com/office/officeart/2005/8/layout/default"`)
+	// Test each type of the SmartArt graphic has its own layout definition
+	for layoutID, alg := range map[int]string{1: "lin", 2: "hierRoot", 3: "snake", 4: "cycle"} {
+		data, ok = f.Pkg.Load("xl/diagrams/layout" + strconv.Itoa(layoutID) + ".xml")
+		assert.True(t, ok)
+		assert.Contains(t, string(data.([]byte)), `<dgm:alg type="`+alg+`"`)
+	}
+
+	// Test the graphic frame and relationships of the SmartArt graphic
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchors := drawing.(*xlsxWsDr).TwoCellAnchor
+	assert.Len(t, anchors, 4)
+	assert.Equal(t, "oneCell", anchors[1].EditAs)
+	assert.Contains(t, anchors[0].GraphicFrame, `<dgm:relIds xmlns:dgm="http://schemas.openxmlformats.org/drawingml/2006/diagram" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:dm="rId1" r:lo="rId2" r:qs="rId3" r:cs="rId4">`)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 16)
+	assert.Equal(t, "../diagrams/colors4.xml", rels.Relationships[15].Target)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var diagramParts int
+	for _, override := range content.Overrides {
+		if strings.HasPrefix(override.PartName, "/xl/diagrams/") {
+			diagramParts++
+		}
+	}
+	assert.Equal(t, 16, diagramParts)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSmartArt.xlsx")))
+
+	// Test add SmartArt graphic without items
+	assert.Equal(t, ErrSmartArtItems, f.AddSmartArt("Sheet1", "A1", SmartArtList, nil))
+	assert.Equal(t, ErrSmartArtItems, f.AddSmartArt("Sheet1", "A1", SmartArtList, &SmartArtOptions{}))
+	// Test add SmartArt graphic with unsupported type
+	assert.EqualError(t, f.AddSmartArt("Sheet1", "A1", 0xFF, &SmartArtOptions{Items: []string{"A"}}), "unsupported SmartArt type 255")
+	// Test add SmartArt graphic with invalid cell reference
+	assert.EqualError(t, f.AddSmartArt("Sheet1", "A", SmartArtList, &SmartArtOptions{Items: []string{"A"}}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test add SmartArt graphic on not exists worksheet
+	assert.EqualError(t, f.AddSmartArt("SheetN", "A1", SmartArtList, &SmartArtOptions{Items: []string{"A"}}), "sheet SheetN does not exist")
+	// Test add SmartArt graphic with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddSmartArt("Sheet1", "A1", SmartArtList, &SmartArtOptions{Items: []string{"A"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+// decodeSmartArtDataModel directly maps the points and connections in the
+// SmartArt data part for testing.
+type decodeSmartArtDataModel struct {
+	Pt []struct {
+		Type  string `xml:"type,attr"`
+		PrSet struct {
+			LoTypeID string `xml:"loTypeId,attr"`
+		} `xml:"prSet"`
+		T string `xml:"t>p>r>t"`
+	} `xml:"ptLst>pt"`
+	Cxn []struct {
+		SrcID  string `xml:"srcId,attr"`
+		DestID string `xml:"destId,attr"`
+	} `xml:"cxnLst>cxn"`
+}
@@ -0,0 +1,307 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SortOrder is the type of sort order.
+type SortOrder byte
+
+// This section defines the currently supported sort orders.
+const (
+	SortAscending SortOrder = iota
+	SortDescending
+)
+
+// SortKey directly maps the settings of a sort key of the SortRange function.
+type SortKey struct {
+	Column        string
+	Order         SortOrder
+	CaseSensitive bool
+}
+
+// SortRangeOptions directly maps the settings of the SortRange function.
+type SortRangeOptions struct {
+	HasHeader   bool
+	NumericSort bool
+}
+
+// This section defines the categories of the cell values in the sort order.
+const (
+	sortCategoryNumber = iota
+	sortCategoryText
+	sortCategoryBool
+	sortCategoryError
+	sortCategoryBlank
+)
+
+// sortValue directly maps the category, the numeric and the text value of a
+// cell for comparing in sorting, the categories are number, text, boolean,
+// error and blank in the sort order.
+type sortValue struct {
+	category int
+	number   float64
+	text     string
+}
+
+// SortRange provides a function to sort the rows in the range by given
+// worksheet name, range reference, sort keys and optional settings. The rows
+// are sorted by the keys in order with a stable sort, the rows with the same
+// values of all keys keep their original order. The whole rows, including
+// the cells beyond the columns of the range, the cell styles and the row
+// properties, will be moved with the sorted rows. The values are sorted in
+// the order of numbers, text, logical values and errors, and the blank cells
+// are always placed last. The text values are compared case-insensitively
+// unless the CaseSensitive field of the key is true, and the lowercase text
+// will be placed before the uppercase text with the same letters in this
+// case. The shared formulas used by the cells in the sorted rows will be
+// converted to the normal formulas of each cell before sorting. Note that
+// the references in the formulas, merged cells, hyperlinks and other
+// references to the cells in the sorted rows will not be updated, and the
+// range with merged cells is not supported.
+//
+// The optional settings are:
+//
+//	Options     | Description
+//	------------+----------------------------------------------------------------
+//	HasHeader   | The first row of the range is a header which will not be sorted
+//	NumericSort | Sort the text values that can be parsed as numbers as numbers
+//
+// For example, sort the range A1:D10 with a header row on Sheet1 by the
+// column B in descending order, then by the column A in ascending order:
+//
+//	err := f.SortRange("Sheet1", "A1:D10", []excelize.SortKey{
+//	    {Column: "B", Order: excelize.SortDescending},
+//	    {Column: "A"},
+//	}, excelize.SortRangeOptions{HasHeader: true})
+//
+// 根据给定的工作表名称、区域引用、排序关键字和可选设置对区域中的行进行排序。
+func (f *File) SortRange(sheet, rangeRef string, keys []SortKey, opts ...SortRangeOptions) error {
+	if len(keys) == 0 {
+		return ErrParameterRequired
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	var options SortRangeOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	cols := make([]int, len(keys))
+	for i, key := range keys {
+		if cols[i], err = ColumnNameToNumber(key.Column); err != nil {
+			return err
+		}
+		if cols[i] < coordinates[0] || cols[i] > coordinates[2] {
+			return newSortKeyOutOfRangeError(key.Column, rangeRef)
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	firstRow, lastRow := coordinates[1], coordinates[3]
+	if options.HasHeader {
+		firstRow++
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			rect, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(rect)
+			if rect[1] <= lastRow && firstRow <= rect[3] {
+				return ErrSortRangeMergedCells
+			}
+		}
+	}
+	if lastRow > len(ws.SheetData.Row) {
+		lastRow = len(ws.SheetData.Row)
+	}
+	if firstRow >= lastRow {
+		return err
+	}
+	rows := ws.SheetData.Row[firstRow-1 : lastRow]
+	values := make([][]sortValue, len(rows))
+	for i := range rows {
+		values[i] = make([]sortValue, len(keys))
+		for k, col := range cols {
+			if values[i][k], err = f.getSortValue(&rows[i], col, sst, options.NumericSort); err != nil {
+				return err
+			}
+		}
+	}
+	indexes := make([]int, len(rows))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		for k, key := range keys {
+			if result := compareSortValue(values[indexes[i]][k], values[indexes[j]][k], key); result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+	expandSharedFormulas(ws, rows)
+	sorted := make([]xlsxRow, len(rows))
+	for i, idx := range indexes {
+		sorted[i] = rows[idx]
+		f.adjustSingleRowDimensions(&sorted[i], firstRow+i)
+	}
+	copy(rows, sorted)
+	return err
+}
+
+// expandSharedFormulas provides a function to convert the shared formulas
+// used by the cells in the given rows to the normal formulas of each cell,
+// including the cells of these shared formulas outside the rows, which makes
+// the formulas independent of the position of the cells.
+func expandSharedFormulas(ws *xlsxWorksheet, rows []xlsxRow) {
+	masters := map[int]*xlsxC{}
+	for i := range rows {
+		for j := range rows[i].C {
+			if c := &rows[i].C[j]; c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				masters[*c.F.Si] = nil
+			}
+		}
+	}
+	if len(masters) == 0 {
+		return
+	}
+	var cells []*xlsxC
+	for i := range ws.SheetData.Row {
+		for j := range ws.SheetData.Row[i].C {
+			c := &ws.SheetData.Row[i].C[j]
+			if c.F == nil || c.F.T != STCellFormulaTypeShared || c.F.Si == nil {
+				continue
+			}
+			if _, ok := masters[*c.F.Si]; !ok {
+				continue
+			}
+			if c.F.Ref != "" {
+				masters[*c.F.Si] = c
+			}
+			cells = append(cells, c)
+		}
+	}
+	formulas := make([]string, len(cells))
+	for i, c := range cells {
+		master := masters[*c.F.Si]
+		if master == nil {
+			continue
+		}
+		col, row, _ := CellNameToCoordinates(c.R)
+		masterCol, masterRow, _ := CellNameToCoordinates(master.R)
+		orig := []byte(master.F.Content)
+		res, start := parseSharedFormula(col-masterCol, row-masterRow, orig)
+		formulas[i] = res + string(orig[start:])
+	}
+	for i, c := range cells {
+		if masters[*c.F.Si] != nil {
+			c.F = &xlsxF{Content: formulas[i]}
+		}
+	}
+}
+
+// getSortValue provides a function to get the value for sorting of the cell
+// by given row and column number.
+func (f *File) getSortValue(row *xlsxRow, col int, sst *xlsxSST, numericSort bool) (sortValue, error) {
+	var value sortValue
+	if col > len(row.C) {
+		return sortValue{category: sortCategoryBlank}, nil
+	}
+	c := &row.C[col-1]
+	text, err := c.getValueFrom(f, sst, true)
+	if err != nil || text == "" {
+		return sortValue{category: sortCategoryBlank}, err
+	}
+	switch c.T {
+	case "b":
+		value.category = sortCategoryBool
+		if text == "1" {
+			value.number = 1
+		}
+	case "e":
+		value.category, value.text = sortCategoryError, text
+	case "", "n":
+		if value.number, err = strconv.ParseFloat(text, 64); err != nil {
+			value.category, value.text, err = sortCategoryText, text, nil
+		}
+	default:
+		value.category, value.text = sortCategoryText, text
+		if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); numericSort && err == nil {
+			value.category, value.number = sortCategoryNumber, number
+		}
+	}
+	return value, err
+}
+
+// compareSortValue provides a function to compare two values for sorting by
+// given sort key, it returns a negative number if the value a should be
+// placed before the value b, a positive number if after, and 0 if equal. The
+// blank values are always placed last regardless of the sort order.
+func compareSortValue(a, b sortValue, key SortKey) int {
+	if a.category == sortCategoryBlank || b.category == sortCategoryBlank {
+		if a.category == b.category {
+			return 0
+		}
+		if a.category == sortCategoryBlank {
+			return 1
+		}
+		return -1
+	}
+	result := a.category - b.category
+	if result == 0 {
+		switch {
+		case a.category == sortCategoryNumber || a.category == sortCategoryBool:
+			if a.number < b.number {
+				result = -1
+			} else if a.number > b.number {
+				result = 1
+			}
+		default:
+			if result = strings.Compare(strings.ToLower(a.text), strings.ToLower(b.text)); result == 0 && key.CaseSensitive {
+				result = strings.Compare(strings.Map(swapCase, a.text), strings.Map(swapCase, b.text))
+			}
+		}
+	}
+	if key.Order == SortDescending {
+		return -result
+	}
+	return result
+}
+
+// swapCase provides a function to swap the case of the letter, which makes
+// the lowercase letters sorted before the uppercase letters.
+func swapCase(r rune) rune {
+	if unicode.IsUpper(r) {
+		return unicode.ToLower(r)
+	}
+	return unicode.ToUpper(r)
+}
@@ -0,0 +1,151 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortRange(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	for idx, row := range [][]interface{}{
+		{"Name", "Region", "Sales", "Note"},
+		{"apple", "East", 30, "a"},
+		{"Banana", "West", 10, "b"},
+		{"cherry", "East", 20, "c"},
+		{"Apple", "West", 30, "d"},
+		{"date", nil, 5, "e"},
+		{"elder", "east", true, "f"},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D3", "D3", styleID))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F3", "beyond"))
+	getCols := func(cols ...string) [][]string {
+		var result [][]string
+		for row := 2; row <= 7; row++ {
+			var values []string
+			for _, col := range cols {
+				value, err := f.GetCellValue("Sheet1", col+strconv.Itoa(row))
+				assert.NoError(t, err)
+				values = append(values, value)
+			}
+			result = append(result, values)
+		}
+		return result
+	}
+	// Test sort range by multiple keys with header
+	assert.NoError(t, f.SortRange("Sheet1", "A1:D7", []SortKey{
+		{Column: "B"},
+		{Column: "C", Order: SortDescending},
+	}, SortRangeOptions{HasHeader: true}))
+	assert.Equal(t, [][]string{
+		{"elder", "east", "TRUE"}, {"apple", "East", "30"}, {"cherry", "East", "20"},
+		{"Apple", "West", "30"}, {"Banana", "West", "10"}, {"date", "", "5"},
+	}, getCols("A", "B", "C"))
+	header, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", header)
+	// Test the cells beyond the range, the cell styles and the row properties
+	// were moved with the sorted rows
+	value, err := f.GetCellValue("Sheet1", "F6")
+	assert.NoError(t, err)
+	assert.Equal(t, "beyond", value)
+	cellStyleID, err := f.GetCellStyle("Sheet1", "D6")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyleID)
+	height, err := f.GetRowHeight("Sheet1", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	// Test sort range with case sensitive
+	assert.NoError(t, f.SortRange("Sheet1", "D7:A2", []SortKey{{Column: "A", CaseSensitive: true}}))
+	assert.Equal(t, [][]string{{"apple"}, {"Apple"}, {"Banana"}, {"cherry"}, {"date"}, {"elder"}}, getCols("A"))
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D7", []SortKey{{Column: "A", Order: SortDescending, CaseSensitive: true}}))
+	assert.Equal(t, [][]string{{"elder"}, {"date"}, {"cherry"}, {"Banana"}, {"Apple"}, {"apple"}}, getCols("A"))
+	// Test sort range with the blank cells always placed last
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D7", []SortKey{{Column: "B", Order: SortDescending}}))
+	assert.Equal(t, [][]string{{"West"}, {"West"}, {"east"}, {"East"}, {"East"}, {""}}, getCols("B"))
+	// Test sort range with numbers, text, logical values and errors
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", false))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C4", "1/0"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[3].C[2].T, ws.(*xlsxWorksheet).SheetData.Row[3].C[2].V = "e", "#DIV/0!"
+	ws.(*xlsxWorksheet).SheetData.Row[4].C[2].V = "invalid"
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D7", []SortKey{{Column: "C"}}))
+	assert.Equal(t, [][]string{{"5"}, {"30"}, {"invalid"}, {"text"}, {"FALSE"}, {"#DIV/0!"}}, getCols("C"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSortRange.xlsx")))
+	// Test sort range without rows to be sorted
+	assert.NoError(t, f.SortRange("Sheet1", "A1:D1", []SortKey{{Column: "A"}}, SortRangeOptions{HasHeader: true}))
+	assert.NoError(t, f.SortRange("Sheet1", "A10:D20", []SortKey{{Column: "A"}}))
+	// Test sort range with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.SortRange("Sheet1", "A1:D7", nil))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SortRange("Sheet1", "A:D7", []SortKey{{Column: "A"}}))
+	assert.Equal(t, newInvalidColumnNameError("-"), f.SortRange("Sheet1", "A1:D7", []SortKey{{Column: "-"}}))
+	assert.EqualError(t, f.SortRange("Sheet1", "A1:D7", []SortKey{{Column: "E"}}), "sort key column E is out of range A1:D7")
+	// Test sort range on not exists worksheet
+	assert.EqualError(t, f.SortRange("SheetN", "A1:D7", []SortKey{{Column: "A"}}), "sheet SheetN does not exist")
+	// Test sort range with merged cells
+	assert.NoError(t, f.MergeCell("Sheet1", "E3", "F4"))
+	assert.Equal(t, ErrSortRangeMergedCells, f.SortRange("Sheet1", "A2:D7", []SortKey{{Column: "A"}}))
+	assert.NoError(t, f.SortRange("Sheet1", "A5:D7", []SortKey{{Column: "A"}}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "E"
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A2:D7", []SortKey{{Column: "A"}}))
+	// Test sort range with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SortRange("Sheet1", "A2:D7", []SortKey{{Column: "A"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSortRangeSharedFormula(t *testing.T) {
+	f := NewFile()
+	for idx, value := range []int{3, 1, 2, 4} {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(idx+1), value))
+	}
+	formulaType, ref := STCellFormulaTypeShared, "B1:B4"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SortRange("Sheet1", "A1:B3", []SortKey{{Column: "A"}}))
+	for cell, expected := range map[string]string{"B1": "A2*2", "B2": "A3*2", "B3": "A1*2", "B4": "A4*2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for _, row := range ws.(*xlsxWorksheet).SheetData.Row {
+		assert.Equal(t, &xlsxF{Content: row.C[1].F.Content}, row.C[1].F)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestSortRangeNumericSort(t *testing.T) {
+	f := NewFile()
+	for idx, value := range []string{"10", "9", "B2", "100", "a1", " 2.5 ", "B10"} {
+		assert.NoError(t, f.SetCellStr("Sheet1", "A"+strconv.Itoa(idx+1), value))
+	}
+	getValues := func() []string {
+		cols, err := f.GetCols("Sheet1")
+		assert.NoError(t, err)
+		return cols[0]
+	}
+	// Test sort the numeric strings as text by default
+	assert.NoError(t, f.SortRange("Sheet1", "A1:A7", []SortKey{{Column: "A"}}))
+	assert.Equal(t, []string{" 2.5 ", "10", "100", "9", "a1", "B10", "B2"}, getValues())
+	// Test sort the numeric strings as numbers
+	assert.NoError(t, f.SortRange("Sheet1", "A1:A7", []SortKey{{Column: "A"}}, SortRangeOptions{NumericSort: true}))
+	assert.Equal(t, []string{" 2.5 ", "9", "10", "100", "a1", "B10", "B2"}, getValues())
+	assert.NoError(t, f.SortRange("Sheet1", "A1:A7", []SortKey{{Column: "A", Order: SortDescending}}, SortRangeOptions{NumericSort: true}))
+	assert.Equal(t, []string{"B2", "B10", "a1", "100", "10", "9", " 2.5 "}, getValues())
+	assert.NoError(t, f.Close())
+}
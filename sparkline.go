@@ -374,21 +374,31 @@ func (f *File) addSparklineGroupByStyle(ID int) *xlsxX14SparklineGroup {
 //
 // The following shows the formatting options of sparkline supported by excelize:
 //
-//	 Parameter   | Description
-//	-------------+--------------------------------------------
-//	 Location    | Required, must have the same number with 'Range' parameter
-//	 Range       | Required, must have the same number with 'Location' parameter
-//	 Type        | Enumeration value: line, column, win_loss
-//	 Style       | Value range: 0 - 35
-//	 Hight       | Toggle sparkline high points
-//	 Low         | Toggle sparkline low points
-//	 First       | Toggle sparkline first points
-//	 Last        | Toggle sparkline last points
-//	 Negative    | Toggle sparkline negative points
-//	 Markers     | Toggle sparkline markers
-//	 Axis        | Used to specify if show horizontal axis
-//	 Reverse     | Used to specify if enable plot data right-to-left
-//	 SeriesColor | An RGB Color is specified as RRGGBB
+//	 Parameter     | Description
+//	---------------+--------------------------------------------
+//	 Location      | Required, must have the same number with 'Range' parameter
+//	 Range         | Required, must have the same number with 'Location' parameter
+//	 Type          | Enumeration value: line, column, win_loss
+//	 Style         | Value range: 0 - 35
+//	 Hight         | Toggle sparkline high points
+//	 Low           | Toggle sparkline low points
+//	 First         | Toggle sparkline first points
+//	 Last          | Toggle sparkline last points
+//	 Negative      | Toggle sparkline negative points
+//	 Markers       | Toggle sparkline markers
+//	 Axis          | Used to specify if show horizontal axis
+//	 Reverse       | Used to specify if enable plot data right-to-left
+//	 DateAxis      | Used to specify if use the date axis
+//	 Hidden        | Used to specify if show data in hidden rows and columns
+//	 Weight        | Line weight of the line sparkline in points
+//	 EmptyCells    | Show empty cells as: gap, zero, span
+//	 SeriesColor   | An RGB Color is specified as RRGGBB
+//	 NegativeColor | An RGB Color of the negative points
+//	 MarkersColor  | An RGB Color of the markers
+//	 FirstColor    | An RGB Color of the first point
+//	 LastColor     | An RGB Color of the last point
+//	 HightColor    | An RGB Color of the high point
+//	 LowColor      | An RGB Color of the low point
 func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 	var (
 		err                 error
@@ -426,9 +436,23 @@ func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 	group.Negative = opts.Negative
 	group.DisplayXAxis = opts.Axis
 	group.Markers = opts.Markers
-	if opts.SeriesColor != "" {
-		group.ColorSeries = &xlsxColor{
-			RGB: getPaletteColor(opts.SeriesColor),
+	group.DateAxis = opts.DateAxis
+	group.DisplayHidden = opts.Hidden
+	group.LineWeight = opts.Weight
+	if inStrSlice([]string{"gap", "zero", "span"}, opts.EmptyCells, true) != -1 {
+		group.DisplayEmptyCellsAs = opts.EmptyCells
+	}
+	for _, item := range []struct {
+		color **xlsxColor
+		value string
+	}{
+		{&group.ColorSeries, opts.SeriesColor}, {&group.ColorNegative, opts.NegativeColor},
+		{&group.ColorMarkers, opts.MarkersColor}, {&group.ColorFirst, opts.FirstColor},
+		{&group.ColorLast, opts.LastColor}, {&group.ColorHigh, opts.HightColor},
+		{&group.ColorLow, opts.LowColor},
+	} {
+		if item.value != "" {
+			*item.color = &xlsxColor{RGB: getPaletteColor(item.value)}
 		}
 	}
 	if opts.Reverse {
@@ -535,3 +559,231 @@ func (f *File) appendSparkline(ws *xlsxWorksheet, group *xlsxX14SparklineGroup,
 	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
 }
+
+// GetSparklines provides a function to get sparkline groups of the worksheet
+// by given worksheet name. Each sparkline group will be returned as a
+// sparkline options, the 'Style' field will be returned when the colors of
+// the group match one of the preset styles. For example, get sparklines in
+// Sheet1:
+//
+//	sparklines, err := f.GetSparklines("Sheet1")
+//
+// 根据给定的工作表名称获取迷你图组设置。
+func (f *File) GetSparklines(sheet string) ([]SparklineOptions, error) {
+	var sparklines []SparklineOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return sparklines, err
+	}
+	_, groups, err := f.getSparklineGroups(ws)
+	if err != nil {
+		return sparklines, err
+	}
+	sparkTypes := map[string]string{"line": "line", "column": "column", "stacked": "win_loss"}
+	for _, group := range groups {
+		opts := SparklineOptions{
+			Type:          sparkTypes[group.Type],
+			Weight:        group.LineWeight,
+			DateAxis:      group.DateAxis,
+			Markers:       group.Markers,
+			High:          group.High,
+			Low:           group.Low,
+			First:         group.First,
+			Last:          group.Last,
+			Negative:      group.Negative,
+			Axis:          group.DisplayXAxis,
+			Hidden:        group.DisplayHidden,
+			Reverse:       group.RightToLeft,
+			Style:         getSparklineStyle(group),
+			SeriesColor:   getSparklineColor(group.ColorSeries),
+			NegativeColor: getSparklineColor(group.ColorNegative),
+			MarkersColor:  getSparklineColor(group.ColorMarkers),
+			FirstColor:    getSparklineColor(group.ColorFirst),
+			LastColor:     getSparklineColor(group.ColorLast),
+			HightColor:    getSparklineColor(group.ColorHigh),
+			LowColor:      getSparklineColor(group.ColorLow),
+			EmptyCells:    group.DisplayEmptyCellsAs,
+		}
+		if opts.Type == "" {
+			opts.Type = "line"
+		}
+		for _, sparkline := range group.Sparklines.Sparkline {
+			opts.Location = append(opts.Location, sparkline.Sqref)
+			opts.Range = append(opts.Range, sparkline.F)
+		}
+		sparklines = append(sparklines, opts)
+	}
+	return sparklines, err
+}
+
+// DeleteSparkline provides a function to delete sparklines by given worksheet
+// name and the cell references where the sparklines are located. The sparkline
+// group will be removed when all of its sparklines are deleted. Delete all
+// sparklines in the worksheet if no cell reference is given. For example,
+// delete the sparklines in the cells A1 and A2 of Sheet1:
+//
+//	err := f.DeleteSparkline("Sheet1", "A1", "A2")
+//
+// 根据给定的工作表名称和单元格坐标删除迷你图。
+func (f *File) DeleteSparkline(sheet string, location ...string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	decodeExtLst, groups, err := f.getSparklineGroups(ws)
+	if err != nil || len(groups) == 0 {
+		return err
+	}
+	var exts []*xlsxWorksheetExt
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURISparklineGroups {
+			var count int
+			if ext.Content, count, err = deleteSparklines(ext.Content, location); err != nil {
+				return err
+			}
+			if count == 0 {
+				continue
+			}
+		}
+		exts = append(exts, ext)
+	}
+	if decodeExtLst.Ext = exts; len(exts) == 0 {
+		ws.ExtLst = nil
+		return err
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// getSparklineGroups provides a function to get the decoded worksheet
+// extension list and the sparkline groups in the worksheet.
+func (f *File) getSparklineGroups(ws *xlsxWorksheet) (*decodeWorksheetExt, []*decodeX14SparklineGroup, error) {
+	var (
+		decodeExtLst = new(decodeWorksheetExt)
+		groups       []*decodeX14SparklineGroup
+		err          error
+	)
+	if ws.ExtLst == nil {
+		return decodeExtLst, groups, err
+	}
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return decodeExtLst, groups, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURISparklineGroups {
+			decodeSparklineGroups := new(decodeX14SparklineGroups)
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(decodeSparklineGroups); err != nil && err != io.EOF {
+				return decodeExtLst, groups, err
+			}
+			groups = append(groups, decodeSparklineGroups.SparklineGroups...)
+		}
+	}
+	return decodeExtLst, groups, nil
+}
+
+// getSparklineColor returns the RGB color in RRGGBB format by given sparkline
+// color settings, returns empty string if the color isn't an RGB color.
+func getSparklineColor(color *xlsxColor) string {
+	if color == nil || color.RGB == "" {
+		return ""
+	}
+	if len(color.RGB) == 8 {
+		return color.RGB[2:]
+	}
+	return color.RGB
+}
+
+// getSparklineStyle returns the preset style ID by given sparkline group which
+// has the same colors with the preset style, the series color will be skipped
+// if it has been customized. Returns 0 if no matched preset style.
+func getSparklineStyle(group *decodeX14SparklineGroup) int {
+	sameColor := func(a, b *xlsxColor) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.RGB == b.RGB && a.Tint == b.Tint && a.Indexed == b.Indexed &&
+			(a.Theme == nil) == (b.Theme == nil) && (a.Theme == nil || *a.Theme == *b.Theme)
+	}
+	for ID := 0; ID <= 35; ID++ {
+		preset := (&File{}).addSparklineGroupByStyle(ID)
+		if (group.ColorSeries != nil && group.ColorSeries.RGB != "" || sameColor(group.ColorSeries, preset.ColorSeries)) &&
+			sameColor(group.ColorNegative, preset.ColorNegative) && sameColor(group.ColorMarkers, preset.ColorMarkers) &&
+			sameColor(group.ColorFirst, preset.ColorFirst) && sameColor(group.ColorLast, preset.ColorLast) &&
+			sameColor(group.ColorHigh, preset.ColorHigh) && sameColor(group.ColorLow, preset.ColorLow) {
+			return ID
+		}
+	}
+	return 0
+}
+
+// deleteSparklines provides a function to remove the x14:sparkline elements
+// located in the given cell references from the sparkline groups extension
+// content, and remove all sparklines if no cell reference is given. The
+// sparkline group without sparklines will be removed. Other elements and
+// attributes in the content are kept as is. It returns the remaining content
+// and the number of the kept sparklines.
+func deleteSparklines(content string, location []string) (string, int, error) {
+	var (
+		decoder                = xml.NewDecoder(strings.NewReader(content))
+		cuts, groupCuts        [][2]int64
+		groupStart, sparkStart int64
+		count, kept            int
+		inSqref                bool
+		sqref                  strings.Builder
+	)
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return content, count, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "sparklineGroup":
+				groupStart, groupCuts, kept = offset, nil, 0
+			case "sparkline":
+				sparkStart = offset
+				sqref.Reset()
+			case "sqref":
+				inSqref = true
+			}
+		case xml.CharData:
+			if inSqref {
+				sqref.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "sqref":
+				inSqref = false
+			case "sparkline":
+				if len(location) == 0 || inStrSlice(location, strings.TrimSpace(sqref.String()), false) != -1 {
+					groupCuts = append(groupCuts, [2]int64{sparkStart, decoder.InputOffset()})
+					continue
+				}
+				kept++
+			case "sparklineGroup":
+				if kept == 0 {
+					groupCuts = [][2]int64{{groupStart, decoder.InputOffset()}}
+				}
+				cuts, count = append(cuts, groupCuts...), count+kept
+			}
+		}
+	}
+	var (
+		buf  strings.Builder
+		last int64
+	)
+	for _, cut := range cuts {
+		buf.WriteString(content[last:cut[0]])
+		last = cut[1]
+	}
+	buf.WriteString(content[last:])
+	return buf.String(), count, nil
+}
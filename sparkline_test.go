@@ -3,6 +3,7 @@ package excelize
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -292,6 +293,133 @@ func TestAppendSparkline(t *testing.T) {
 	assert.EqualError(t, f.appendSparkline(ws, &xlsxX14SparklineGroup{}, &xlsxX14SparklineGroups{}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetSparklines(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	sparklines, err := f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, sparklines)
+
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1", "A2"},
+		Range:    []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+		Markers:  true,
+		Style:    12,
+	}))
+	expected := SparklineOptions{
+		Location:      []string{"A3"},
+		Range:         []string{"Sheet3!A3:J3"},
+		Type:          "win_loss",
+		Weight:        1.25,
+		DateAxis:      true,
+		High:          true,
+		Low:           true,
+		First:         true,
+		Last:          true,
+		Negative:      true,
+		Axis:          true,
+		Hidden:        true,
+		Reverse:       true,
+		SeriesColor:   "FF0000",
+		NegativeColor: "00FF00",
+		MarkersColor:  "0000FF",
+		FirstColor:    "FFFF00",
+		LastColor:     "00FFFF",
+		HightColor:    "FF00FF",
+		LowColor:      "000000",
+		EmptyCells:    "zero",
+	}
+	assert.NoError(t, f.AddSparkline("Sheet1", &expected))
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 2)
+	assert.Equal(t, SparklineOptions{
+		Location:   []string{"A1", "A2"},
+		Range:      []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+		Type:       "line",
+		Markers:    true,
+		Style:      12,
+		EmptyCells: "gap",
+	}, sparklines[0])
+	assert.Equal(t, expected, sparklines[1])
+	// Test get sparklines on not exists worksheet
+	_, err = f.GetSparklines("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sparklines with invalid sheet name
+	_, err = f.GetSparklines("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get sparklines with unsupported charset extension list
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	_, err = f.GetSparklines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get sparklines with invalid sparkline groups
+	ws.(*xlsxWorksheet).ExtLst.Ext = `<ext uri="{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"><x14:sparklineGroups><x14:sparklineGroup></x14:sparklines></x14:sparklineGroup></x14:sparklineGroups></ext>`
+	_, err = f.GetSparklines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <sparklineGroup> closed by </sparklines>")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteSparkline(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	// Test delete sparkline on the worksheet without sparklines
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "A1"))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1", "A2"},
+		Range:    []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+	}))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A3"},
+		Range:    []string{"Sheet3!A3:J3"},
+		Type:     "column",
+	}))
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "a1", "A3"))
+	sparklines, err := f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 1)
+	assert.Equal(t, []string{"A2"}, sparklines[0].Location)
+	assert.Equal(t, []string{"Sheet3!A2:J2"}, sparklines[0].Range)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteSparkline.xlsx")))
+	// Test delete all sparklines in the worksheet
+	assert.NoError(t, f.DeleteSparkline("Sheet1"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).ExtLst)
+	// Test delete sparklines and keep other extensions
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: `<ext uri="{A8765BA9-456A-4dab-B4F3-ACF838C121DE}"><x14:slicerList /></ext>`}
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1"},
+		Range:    []string{"Sheet3!A1:J1"},
+	}))
+	assert.NoError(t, f.DeleteSparkline("Sheet1"))
+	assert.Equal(t, `<ext uri="{A8765BA9-456A-4dab-B4F3-ACF838C121DE}"><x14:slicerList /></ext>`, ws.(*xlsxWorksheet).ExtLst.Ext)
+	// Test delete sparkline and keep the unknown attributes of the group
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1", "A2"},
+		Range:    []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+	}))
+	ws.(*xlsxWorksheet).ExtLst.Ext = strings.Replace(ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:sparklineGroup ", `<x14:sparklineGroup xr2:uid="{6F57B9C4-3B4C-4F4C-9E5B-0C5A3C8E1D2A}" `, 1)
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "A1"))
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, `xr2:uid="{6F57B9C4-3B4C-4F4C-9E5B-0C5A3C8E1D2A}"`)
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 1)
+	assert.Equal(t, []string{"A2"}, sparklines[0].Location)
+	// Test delete sparkline with invalid sparkline groups extension content
+	_, _, err = deleteSparklines("<x14:sparklineGroup a=>", nil)
+	assert.EqualError(t, err, "XML syntax error on line 1: unquoted or missing attribute value in element")
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURISparklineGroups + `"><x14:sparklineGroups><x14:sparklineGroup></x14:sparklineGroups></ext>`}
+	assert.EqualError(t, f.DeleteSparkline("Sheet1", "A1"), "XML syntax error on line 1: element <sparklineGroup> closed by </sparklineGroups>")
+	// Test delete sparkline on not exists worksheet
+	assert.EqualError(t, f.DeleteSparkline("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test delete sparkline with unsupported charset extension list
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	assert.EqualError(t, f.DeleteSparkline("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func prepareSparklineDataset() (*File, error) {
 	f := NewFile()
 	sheet2 := [][]int{
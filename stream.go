@@ -12,11 +12,13 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"reflect"
 	"strconv"
 	"strings"
@@ -29,11 +31,11 @@ type StreamWriter struct {
 	Sheet           string
 	SheetID         int
 	sheetWritten    bool
-	cols            strings.Builder
 	worksheet       *xlsxWorksheet
 	rawData         bufferedWriter
 	rows            int
 	mergeCellsCount int
+	sharedFormulas  map[string]int
 	mergeCells      strings.Builder
 	tableParts      string
 }
@@ -312,12 +314,41 @@ func getRowElement(token xml.Token, hRow int) (startElement xml.StartElement, ok
 	return
 }
 
-// Cell can be used directly in StreamWriter.SetRow to specify a style and
-// a value.
+// Cell can be used directly in StreamWriter.SetRow to specify a style, a
+// formula and a value. The value of the cell with formula will be used as the
+// cached result of the formula.
+//
+// SharedFormula specifies the formula of the cell is a shared formula, and
+// SharedFormulaRef specifies the range reference of the cells which share the
+// formula, the first cell of the range must be written first and set with
+// the formula, and the formula of the other cells in the range could be
+// omitted. For example, share the formula of the cell C1 over C1:C10:
+//
+//	err := sw.SetRow("A1", []interface{}{1, 2, excelize.Cell{
+//	    Formula:          "A1+B1",
+//	    SharedFormula:    true,
+//	    SharedFormulaRef: "C1:C10",
+//	}})
+//	err = sw.SetRow("A2", []interface{}{3, 4, excelize.Cell{
+//	    SharedFormula:    true,
+//	    SharedFormulaRef: "C1:C10",
+//	}})
+//
+// ArrayFormula specifies the range reference of the array formula, which
+// should contain the cell. For example, set the array formula of the cell
+// D1 over D1:D3:
+//
+//	err := sw.SetRow("D1", []interface{}{excelize.Cell{
+//	    Formula:      "A1:A3*B1:B3",
+//	    ArrayFormula: "D1:D3",
+//	}})
 type Cell struct {
-	StyleID int
-	Formula string
-	Value   interface{}
+	StyleID          int
+	Formula          string
+	Value            interface{}
+	SharedFormula    bool
+	SharedFormulaRef string
+	ArrayFormula     string
 }
 
 // RowOpts define the options for the set row, it can be used directly in
@@ -411,15 +442,23 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 		if err != nil {
 			return err
 		}
-		c := xlsxC{R: ref, S: options.StyleID}
+		var cell *Cell
+		c := xlsxC{R: ref, S: sw.worksheet.prepareCellStyle(col+i, row, options.StyleID)}
 		if v, ok := val.(Cell); ok {
-			c.S = v.StyleID
-			val = v.Value
-			setCellFormula(&c, v.Formula)
-		} else if v, ok := val.(*Cell); ok && v != nil {
-			c.S = v.StyleID
-			val = v.Value
-			setCellFormula(&c, v.Formula)
+			cell = &v
+		} else if v, ok := val.(*Cell); ok {
+			if v == nil {
+				continue
+			}
+			cell = v
+		}
+		if cell != nil {
+			c.S = cell.StyleID
+			val = cell.Value
+			if err = sw.setCellFormula(&c, cell); err != nil {
+				_, _ = sw.rawData.WriteString(`</row>`)
+				return err
+			}
 		}
 		if err = sw.setCellValFunc(&c, val); err != nil {
 			_, _ = sw.rawData.WriteString(`</row>`)
@@ -431,6 +470,22 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 	return sw.rawData.Sync()
 }
 
+// AddRow writes the values to the stream row by given row number, the values
+// will be written from the column A. It's a variadic version of the SetRow
+// function, and the Cell or *Cell value will be handled as same as SetRow.
+// Note that you must call the 'Flush' function to end the streaming writing
+// process. For example, write a header row with the bold style for the cell
+// A1:
+//
+//	err := sw.AddRow(1, excelize.Cell{StyleID: styleID, Value: "Name"}, "Age", "Score")
+func (sw *StreamWriter) AddRow(rowIdx int, values ...interface{}) error {
+	cell, err := CoordinatesToCellName(1, rowIdx)
+	if err != nil {
+		return err
+	}
+	return sw.SetRow(cell, values)
+}
+
 // SetColWidth provides a function to set the width of a single column or
 // multiple columns for the StreamWriter. Note that you must call
 // the 'SetColWidth' function before the 'SetRow' function. For example set
@@ -450,17 +505,65 @@ func (sw *StreamWriter) SetColWidth(min, max int, width float64) error {
 	if min > max {
 		min, max = max, min
 	}
+	sw.setCols(xlsxCol{Min: min, Max: max, Width: float64Ptr(width), CustomWidth: true}, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		return fc
+	})
+	return nil
+}
 
-	sw.cols.WriteString(`<col min="`)
-	sw.cols.WriteString(strconv.Itoa(min))
-	sw.cols.WriteString(`" max="`)
-	sw.cols.WriteString(strconv.Itoa(max))
-	sw.cols.WriteString(`" width="`)
-	sw.cols.WriteString(strconv.FormatFloat(width, 'f', -1, 64))
-	sw.cols.WriteString(`" customWidth="1"/>`)
+// SetColStyle provides a function to set the style of a single column or
+// multiple columns for the StreamWriter, the cells of the columns without
+// the style specified in the SetRow function will inherit the column style.
+// Note that you must call the 'SetColStyle' function before the 'SetRow'
+// function. For example set the style of the column B:C:
+//
+//	err := sw.SetColStyle(2, 3, styleID)
+func (sw *StreamWriter) SetColStyle(min, max, styleID int) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColStyle
+	}
+	if min < MinColumns || min > MaxColumns || max < MinColumns || max > MaxColumns {
+		return ErrColumnNumber
+	}
+	if min > max {
+		min, max = max, min
+	}
+	s, err := sw.file.stylesReader()
+	if err != nil {
+		return err
+	}
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return newInvalidStyleID(styleID)
+	}
+	sw.setCols(xlsxCol{Min: min, Max: max, Width: float64Ptr(defaultColWidth), Style: styleID}, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.CustomWidth = c.CustomWidth
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Width = c.Width
+		return fc
+	})
 	return nil
 }
 
+// setCols provides a function to merge the given column settings into the
+// columns of the worksheet, the replacer keeps the existing settings which
+// not specified by the given column.
+func (sw *StreamWriter) setCols(col xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) {
+	if sw.worksheet.Cols == nil {
+		sw.worksheet.Cols = &xlsxCols{}
+	}
+	sw.worksheet.Cols.Col = flatCols(col, sw.worksheet.Cols.Col, replacer)
+}
+
 // InsertPageBreak creates a page break to determine where the printed page ends
 // and where begins the next one by a given cell reference, the content before
 // the page break will be printed on one page and after the page break on
@@ -479,6 +582,34 @@ func (sw *StreamWriter) SetPanes(panes *Panes) error {
 	return sw.worksheet.setPanes(panes)
 }
 
+// AddPictureFromBytes provides a function to add a picture to the worksheet
+// of the StreamWriter by given cell reference and the picture settings. The
+// picture will be written into the drawing part of the worksheet when calling
+// the 'Flush' function, so it can be added before or after the rows were
+// written. Note that the picture is positioned by the column widths and the
+// default row height, and the custom row heights set by the SetRow function
+// will not be taken into account. For example, add a logo picture at the cell
+// A1:
+//
+//	file, err := os.ReadFile("logo.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = sw.AddPictureFromBytes("A1", &excelize.Picture{
+//	    Extension: ".png",
+//	    File:      file,
+//	    Format:    &excelize.GraphicOptions{AltText: "Excel Logo"},
+//	})
+//
+// See File.AddPictureFromBytes for details on the picture settings.
+func (sw *StreamWriter) AddPictureFromBytes(cell string, pic *Picture) error {
+	if pic == nil {
+		return ErrParameterRequired
+	}
+	return sw.file.AddPictureFromBytes(sw.Sheet, cell, pic)
+}
+
 // MergeCell provides a function to merge cells by a given range reference for
 // the StreamWriter. Don't create a merged cell that overlaps with another
 // existing merged cell.
@@ -496,11 +627,57 @@ func (sw *StreamWriter) MergeCell(hCell, vCell string) error {
 	return nil
 }
 
-// setCellFormula provides a function to set formula of a cell.
-func setCellFormula(c *xlsxC, formula string) {
-	if formula != "" {
-		c.T, c.F = "str", &xlsxF{Content: formula}
+// setCellFormula provides a function to set the normal, shared or array
+// formula of a cell.
+func (sw *StreamWriter) setCellFormula(c *xlsxC, cell *Cell) error {
+	if cell.SharedFormula {
+		coordinates, err := checkStreamFormulaRef(c.R, cell.SharedFormulaRef)
+		if err != nil {
+			return err
+		}
+		si, ok := sw.sharedFormulas[cell.SharedFormulaRef]
+		if ok {
+			c.T, c.F = "str", &xlsxF{T: STCellFormulaTypeShared, Si: &si}
+			return err
+		}
+		if topLeftCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1]); cell.Formula == "" || c.R != topLeftCell {
+			return ErrStreamSharedFormula
+		}
+		if sw.sharedFormulas == nil {
+			sw.sharedFormulas = make(map[string]int)
+		}
+		si = len(sw.sharedFormulas)
+		sw.sharedFormulas[cell.SharedFormulaRef] = si
+		c.T, c.F = "str", &xlsxF{Content: cell.Formula, T: STCellFormulaTypeShared, Ref: cell.SharedFormulaRef, Si: &si}
+		return err
+	}
+	if cell.Formula == "" {
+		return nil
+	}
+	c.T, c.F = "str", &xlsxF{Content: cell.Formula}
+	if cell.ArrayFormula != "" {
+		if _, err := checkStreamFormulaRef(c.R, cell.ArrayFormula); err != nil {
+			return err
+		}
+		c.F.T, c.F.Ref = STCellFormulaTypeArray, cell.ArrayFormula
+	}
+	return nil
+}
+
+// checkStreamFormulaRef provides a function to check if the range reference
+// of the shared or array formula contains the given cell, and returns the
+// sorted coordinates of the range.
+func checkStreamFormulaRef(cell, ref string) ([]int, error) {
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return coordinates, err
+	}
+	_ = sortCoordinates(coordinates)
+	col, row, _ := CellNameToCoordinates(cell)
+	if col < coordinates[0] || col > coordinates[2] || row < coordinates[1] || row > coordinates[3] {
+		return coordinates, newStreamFormulaRefError(cell, ref)
 	}
+	return coordinates, err
 }
 
 // setCellTime provides a function to set number of a cell with a time.
@@ -604,7 +781,23 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 	}
 	_, _ = buf.WriteString(`>`)
 	if c.F != nil {
-		_, _ = buf.WriteString(`<f>`)
+		_, _ = buf.WriteString(`<f`)
+		if c.F.T != "" {
+			_, _ = buf.WriteString(` t="`)
+			_, _ = buf.WriteString(c.F.T)
+			_, _ = buf.WriteString(`"`)
+		}
+		if c.F.Ref != "" {
+			_, _ = buf.WriteString(` ref="`)
+			_, _ = buf.WriteString(c.F.Ref)
+			_, _ = buf.WriteString(`"`)
+		}
+		if c.F.Si != nil {
+			_, _ = buf.WriteString(` si="`)
+			_, _ = buf.WriteString(strconv.Itoa(*c.F.Si))
+			_, _ = buf.WriteString(`"`)
+		}
+		_, _ = buf.WriteString(`>`)
 		_ = xml.EscapeText(buf, []byte(c.F.Content))
 		_, _ = buf.WriteString(`</f>`)
 	}
@@ -641,12 +834,13 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 // sheetData XML start element to the buffer.
 func (sw *StreamWriter) writeSheetData() {
 	if !sw.sheetWritten {
-		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 5)
-		if sw.cols.Len() > 0 {
-			_, _ = sw.rawData.WriteString("<cols>")
-			_, _ = sw.rawData.WriteString(sw.cols.String())
-			_, _ = sw.rawData.WriteString("</cols>")
+		if sw.worksheet.Cols != nil && len(sw.worksheet.Cols.Col) == 0 {
+			sw.worksheet.Cols = nil
+		}
+		if sw.worksheet.Cols != nil {
+			sw.file.mergeExpandedCols(sw.worksheet)
 		}
+		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 6)
 		_, _ = sw.rawData.WriteString(`<sheetData>`)
 		sw.sheetWritten = true
 	}
@@ -729,6 +923,21 @@ func (bw *bufferedWriter) Reader() (io.Reader, error) {
 	return io.NewSectionReader(bw.tmp, 0, fi.Size()), nil
 }
 
+// Size returns the number of bytes written to the underlying buffer/file.
+func (bw *bufferedWriter) Size() (int64, error) {
+	if bw.tmp == nil {
+		return int64(bw.buf.Len()), nil
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	fi, err := bw.tmp.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
 // Sync will write the in-memory buffer to a temp file, if the in-memory
 // buffer has grown large enough. Any error will be returned.
 func (bw *bufferedWriter) Sync() (err error) {
@@ -769,3 +978,341 @@ func (bw *bufferedWriter) Close() error {
 	defer os.Remove(bw.tmp.Name())
 	return bw.tmp.Close()
 }
+
+// StreamReader defined the type of stream reader, which reads the rows of
+// the worksheets one by one in the order of the workbook without loading the
+// worksheets into memory.
+type StreamReader struct {
+	err        error
+	file       *File
+	zipReader  *zip.Reader
+	worksheets map[string]*zip.File
+	sheets     []string
+	sheetIdx   int
+	rc         io.ReadCloser
+	decoder    *xml.Decoder
+	sst        *xlsxSST
+	row        int
+	tempFile   *os.File
+}
+
+// OpenStreamingReader provides a function to open the spreadsheet from the
+// data stream with a stream reader for reading the rows of the worksheets
+// with low memory usage. Only the workbook, relationships, styles and theme
+// parts are loaded, and the worksheet parts are decompressed and parsed on
+// demand when reading the rows. The compressed data stream will be saved to
+// the system temporary directory, and the shared strings table larger than
+// the 'UnzipXMLSizeLimit' option will be extracted into the system temporary
+// directory and read lazily. The 'Password', 'UnzipSizeLimit' and
+// 'UnzipXMLSizeLimit' options are supported. Note that the stream reader
+// must be closed by the Close function after reading. For example, read all
+// cell values of each worksheet:
+//
+//	sr, err := excelize.OpenStreamingReader(r)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer func() {
+//	    if err := sr.Close(); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}()
+//	for sheet, ok := sr.NextSheet(); ok; sheet, ok = sr.NextSheet() {
+//	    for {
+//	        cells, err := sr.NextRow()
+//	        if err == io.EOF {
+//	            break
+//	        }
+//	        if err != nil {
+//	            fmt.Println(err)
+//	            return
+//	        }
+//	        for col, cell := range cells {
+//	            fmt.Println(sheet, col+1, sr.CurrentRow(), cell.Value, cell.StyleID)
+//	        }
+//	    }
+//	}
+//	if err := sr.Error(); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// OpenStreamingReader 从 io.Reader 读取数据流，并返回用于逐行读取工作表的流式读取器。
+func OpenStreamingReader(r io.Reader, opts ...Options) (*StreamReader, error) {
+	f := newFile()
+	f.options = getOptions(opts...)
+	if err := f.checkOpenReaderOptions(); err != nil {
+		return nil, err
+	}
+	sr := &StreamReader{file: f, worksheets: make(map[string]*zip.File)}
+	readerAt, size, err := sr.spool(r)
+	if err != nil {
+		_ = sr.Close()
+		return nil, err
+	}
+	if sr.zipReader, err = zip.NewReader(readerAt, size); err != nil {
+		_ = sr.Close()
+		if len(f.options.Password) > 0 {
+			return nil, ErrWorkbookPassword
+		}
+		return nil, err
+	}
+	if err = sr.readParts(); err != nil {
+		_ = sr.Close()
+		return nil, err
+	}
+	if sr.sst, err = f.sharedStringsReader(); err != nil {
+		_ = sr.Close()
+		return nil, err
+	}
+	if err = sr.readSheetMap(); err != nil {
+		_ = sr.Close()
+		return nil, err
+	}
+	return sr, nil
+}
+
+// readSheetMap provides a function to get the worksheet names in the order of
+// the workbook, and the map of the worksheet names and the paths of the
+// worksheet parts in the archive.
+func (sr *StreamReader) readSheetMap() error {
+	f := sr.file
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return err
+	}
+	for _, v := range wb.Sheets.Sheet {
+		for _, rel := range rels.Relationships {
+			if sheetXMLPath := f.getWorksheetPath(rel.Target); rel.ID == v.ID && sr.worksheets[sheetXMLPath] != nil {
+				f.sheetMap[v.Name] = sheetXMLPath
+				sr.sheets = append(sr.sheets, v.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// spool provides a function to save the data stream into the system temporary
+// directory, and returns the reader with random access and the size of the
+// data. The encrypted data will be decrypted in memory.
+func (sr *StreamReader) spool(r io.Reader) (io.ReaderAt, int64, error) {
+	var err error
+	if sr.tempFile, err = os.CreateTemp(os.TempDir(), "excelize-"); err != nil {
+		return nil, 0, err
+	}
+	size, err := io.Copy(sr.tempFile, r)
+	if err != nil {
+		return nil, 0, err
+	}
+	header := make([]byte, len(oleIdentifier))
+	if n, _ := sr.tempFile.ReadAt(header, 0); n != len(header) || !bytes.Equal(header, oleIdentifier) {
+		return sr.tempFile, size, err
+	}
+	raw := make([]byte, size)
+	if _, err = sr.tempFile.ReadAt(raw, 0); err != nil {
+		return nil, 0, err
+	}
+	if raw, err = Decrypt(raw, sr.file.options); err != nil {
+		if err == ErrWorkbookPassword {
+			return nil, 0, err
+		}
+		return nil, 0, ErrWorkbookFileFormat
+	}
+	return bytes.NewReader(raw), int64(len(raw)), err
+}
+
+// readParts provides a function to load the parts required for reading the
+// worksheets, including the content types, relationships, workbook, styles,
+// theme and shared strings table. The worksheet parts are kept in the archive
+// and will be read on demand.
+func (sr *StreamReader) readParts() error {
+	var (
+		f         = sr.file
+		unzipSize int64
+		files     = make(map[string]*zip.File, len(sr.zipReader.File))
+	)
+	for _, v := range sr.zipReader.File {
+		if unzipSize += v.FileInfo().Size(); unzipSize > f.options.UnzipSizeLimit {
+			return newUnzipSizeLimitError(f.options.UnzipSizeLimit)
+		}
+		fileName := strings.ReplaceAll(v.Name, "\\", "/")
+		switch strings.ToLower(fileName) {
+		case strings.ToLower(defaultXMLPathContentTypes):
+			fileName = defaultXMLPathContentTypes
+		case strings.ToLower(defaultXMLPathSharedStrings):
+			fileName = defaultXMLPathSharedStrings
+		}
+		files[fileName] = v
+	}
+	load := func(fileName string, v *zip.File) (err error) {
+		if fileName == defaultXMLPathSharedStrings && v.FileInfo().Size() > f.options.UnzipXMLSizeLimit {
+			tempFile, err := f.unzipToTemp(v)
+			f.tempFiles.Store(fileName, tempFile)
+			return err
+		}
+		content, err := readFile(v)
+		f.Pkg.Store(fileName, content)
+		return err
+	}
+	for fileName, v := range files {
+		if dir := path.Dir(fileName); dir == "." || dir == "_rels" {
+			if err := load(fileName, v); err != nil {
+				return err
+			}
+		}
+	}
+	wbDir := path.Dir(f.getWorkbookPath())
+	for fileName, v := range files {
+		switch dir := path.Dir(fileName); dir {
+		case wbDir, path.Join(wbDir, "_rels"), path.Join(wbDir, "theme"):
+			if err := load(fileName, v); err != nil {
+				return err
+			}
+		case path.Join(wbDir, "worksheets"):
+			sr.worksheets[fileName] = v
+		}
+	}
+	return nil
+}
+
+// NextSheet provides a function to advance the stream reader to the next
+// worksheet in the order of the workbook, and returns the worksheet name and
+// true if the worksheet exists. The chart sheets and dialog sheets will be
+// skipped. It returns false when there are no more worksheets or an error
+// occurred, and the error can be got by the Error function.
+func (sr *StreamReader) NextSheet() (string, bool) {
+	if sr.err = sr.closeSheet(); sr.err != nil || sr.sheetIdx >= len(sr.sheets) {
+		return "", false
+	}
+	sheet := sr.sheets[sr.sheetIdx]
+	sr.sheetIdx++
+	name, _ := sr.file.getSheetXMLPath(sheet)
+	if sr.rc, sr.err = sr.worksheets[name].Open(); sr.err != nil {
+		return "", false
+	}
+	sr.decoder, sr.row = sr.file.xmlNewDecoder(sr.rc), 0
+	return sheet, true
+}
+
+// NextRow provides a function to read the next row of the current worksheet,
+// it returns io.EOF when there are no more rows in the worksheet. The cells
+// of the row are ordered by the column number, the cell of column A at the
+// index 0, and the missing cells between are empty. The raw value of each
+// cell is set to the Value field as a string, with the shared strings and
+// inline strings resolved, and the style index of the cell is set to the
+// StyleID field, which can be used with the GetStyle function of the stream
+// reader. The empty rows are skipped, use the CurrentRow function to get the
+// row number of the returned row.
+func (sr *StreamReader) NextRow() ([]Cell, error) {
+	if sr.decoder == nil {
+		return nil, io.EOF
+	}
+	var (
+		cells []Cell
+		col   int
+	)
+	for {
+		token, err := sr.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "row":
+				row, err := attrValToInt("r", element.Attr)
+				if err != nil {
+					return nil, err
+				}
+				if row == 0 {
+					row = sr.row + 1
+				}
+				sr.row, cells, col = row, []Cell{}, 0
+			case "c":
+				var c xlsxC
+				if err = sr.decoder.DecodeElement(&c, &element); err != nil {
+					return nil, err
+				}
+				if col++; c.R != "" {
+					if col, _, err = CellNameToCoordinates(c.R); err != nil {
+						return nil, err
+					}
+				}
+				cell := Cell{StyleID: c.S}
+				if cell.Value, err = c.getValueFrom(sr.file, sr.sst, true); err != nil {
+					return nil, err
+				}
+				if c.F != nil {
+					cell.Formula = c.F.Content
+					cell.SharedFormula, cell.SharedFormulaRef = c.F.T == STCellFormulaTypeShared, c.F.Ref
+					if c.F.T == STCellFormulaTypeArray {
+						cell.ArrayFormula, cell.SharedFormulaRef = c.F.Ref, ""
+					}
+				}
+				for len(cells) < col-1 {
+					cells = append(cells, Cell{})
+				}
+				if len(cells) < col {
+					cells = append(cells, cell)
+				}
+			}
+		case xml.EndElement:
+			if element.Name.Local == "row" {
+				return cells, nil
+			}
+		}
+	}
+}
+
+// CurrentRow provides a function to get the row number of the last row read
+// by the NextRow function in the current worksheet.
+func (sr *StreamReader) CurrentRow() int {
+	return sr.row
+}
+
+// GetStyle provides a function to get the style definition by given style
+// index of the cell, which was read from the StyleID field of the cell.
+func (sr *StreamReader) GetStyle(idx int) (*Style, error) {
+	return sr.file.GetStyle(idx)
+}
+
+// Error provides a function to get the error occurred when advancing to the
+// next worksheet.
+func (sr *StreamReader) Error() error {
+	return sr.err
+}
+
+// closeSheet provides a function to close the reader of the current
+// worksheet.
+func (sr *StreamReader) closeSheet() error {
+	sr.decoder = nil
+	if sr.rc == nil {
+		return nil
+	}
+	err := sr.rc.Close()
+	sr.rc = nil
+	return err
+}
+
+// Close provides a function to close the stream reader, and remove the
+// temporary files created by the stream reader.
+func (sr *StreamReader) Close() error {
+	err := sr.closeSheet()
+	if sr.tempFile != nil {
+		if err := sr.tempFile.Close(); err != nil {
+			return err
+		}
+		if err := os.Remove(sr.tempFile.Name()); err != nil {
+			return err
+		}
+		sr.tempFile = nil
+	}
+	if err != nil {
+		return err
+	}
+	return sr.file.Close()
+}
@@ -1,8 +1,11 @@
 package excelize
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -165,6 +168,81 @@ func TestStreamSetColWidth(t *testing.T) {
 	assert.ErrorIs(t, streamWriter.SetColWidth(2, 3, 20), ErrStreamSetColWidth)
 }
 
+func TestStreamSetColStyle(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(2, 3, 20))
+	assert.NoError(t, sw.SetColStyle(3, 2, styleID))
+	assert.NoError(t, sw.SetColStyle(4, 4, styleID))
+	assert.ErrorIs(t, sw.SetColStyle(0, 3, styleID), ErrColumnNumber)
+	assert.ErrorIs(t, sw.SetColStyle(MaxColumns+1, 3, styleID), ErrColumnNumber)
+	assert.Equal(t, newInvalidStyleID(10), sw.SetColStyle(1, 1, 10))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", "B", "C"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"A", "B"}, RowOpts{StyleID: styleID}))
+	assert.ErrorIs(t, sw.SetColStyle(2, 3, styleID), ErrStreamSetColStyle)
+	assert.NoError(t, sw.Flush())
+	for cell, expected := range map[string]int{"A1": 0, "B1": styleID, "C1": styleID, "A2": styleID, "B2": styleID} {
+		cellStyleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellStyleID, cell)
+	}
+	// Test the cells inherit the column style
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, ws.SheetData.Row[0].C[1].S)
+	for col, expected := range map[string]float64{"A": defaultColWidth, "B": 20, "C": 20, "D": defaultColWidth} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	for col, expected := range map[string]int{"A": 0, "B": styleID, "C": styleID, "D": styleID} {
+		colStyleID, err := f.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, colStyleID, col)
+	}
+	assert.NoError(t, f.Close())
+	// Test set column style with unsupported charset style sheet
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetColStyle(1, 1, 0), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamAddPictureFromBytes(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(1, 1, 30))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Name"}))
+	assert.NoError(t, sw.AddPictureFromBytes("B2", &Picture{Extension: ".png", File: file, Format: &GraphicOptions{AltText: "Excel Logo"}}))
+	assert.Equal(t, ErrParameterRequired, sw.AddPictureFromBytes("B2", nil))
+	assert.Equal(t, ErrImgExt, sw.AddPictureFromBytes("B2", &Picture{Extension: ".txt", File: file}))
+	assert.NoError(t, sw.AddRow(2, "Logo"))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAddPictureFromBytes.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamAddPictureFromBytes.xlsx"))
+	assert.NoError(t, err)
+	pics, err := f.GetPictures("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, file, pics[0].File)
+	assert.Equal(t, "Excel Logo", pics[0].Format.AltText)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name"}, {"Logo"}}, rows)
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamSetPanes(t *testing.T) {
 	file, paneOpts := NewFile(), &Panes{
 		Freeze:      true,
@@ -298,6 +376,34 @@ func TestStreamSetRow(t *testing.T) {
 	assert.EqualError(t, streamWriter.SetRow("A2", []interface{}{time.Now()}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestStreamAddRow(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.AddRow(1, &Cell{StyleID: styleID, Value: "Name"}, "Age", "Score"))
+	assert.NoError(t, sw.AddRow(2, "Tom", 20, (*Cell)(nil), Cell{Formula: "B2*5"}))
+	// Test add row with invalid row number
+	assert.EqualError(t, sw.AddRow(0, "Name"), "invalid cell reference [1, 0]")
+	// Test add row with non-ascending row number
+	assert.Equal(t, newStreamSetRowError(2), sw.AddRow(2, "Name"))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Age", "Score"}, {"Tom", "20", "", ""}}, rows)
+	cellStyleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyleID)
+	formula, err := f.GetCellFormula("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2*5", formula)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "c", ws.SheetData.Row[1].C[2].XMLName.Local)
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamSetRowNilValues(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -344,6 +450,60 @@ func TestStreamSetRowWithStyle(t *testing.T) {
 	assert.Equal(t, blueStyleID, ws.SheetData.Row[0].C[4].S)
 }
 
+func TestStreamSetRowWithFormula(t *testing.T) {
+	file := NewFile()
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{1, 2,
+		Cell{Formula: "A1+B1", Value: 3, SharedFormula: true, SharedFormulaRef: "C1:C3"},
+		Cell{Formula: "SUM(A1:A3)", Value: 55.0},
+		Cell{Formula: "A1:A3*B1:B3", Value: 2, ArrayFormula: "E1:E3"},
+	}))
+	assert.NoError(t, streamWriter.SetRow("A2", []interface{}{3, 4,
+		&Cell{Value: 7, SharedFormula: true, SharedFormulaRef: "C1:C3"},
+	}))
+	assert.NoError(t, streamWriter.SetRow("A3", []interface{}{5, 6,
+		Cell{Formula: "A1+B1", SharedFormula: true, SharedFormulaRef: "C1:C3"},
+	}))
+	// Test set shared formula without the formula on the first cell
+	assert.Equal(t, ErrStreamSharedFormula, streamWriter.SetRow("C4", []interface{}{
+		Cell{SharedFormula: true, SharedFormulaRef: "C4:C5"},
+	}))
+	// Test set shared formula which is not starting from the first cell
+	assert.Equal(t, ErrStreamSharedFormula, streamWriter.SetRow("C6", []interface{}{
+		Cell{Formula: "A6", SharedFormula: true, SharedFormulaRef: "C5:C7"},
+	}))
+	// Test set shared and array formula with the range which doesn't contain the cell
+	assert.EqualError(t, streamWriter.SetRow("C8", []interface{}{
+		Cell{Formula: "A8", SharedFormula: true, SharedFormulaRef: "D8:D9"},
+	}), "cell C8 is outside of the formula range D8:D9")
+	assert.EqualError(t, streamWriter.SetRow("C9", []interface{}{
+		Cell{Formula: "A8", ArrayFormula: "D9:D10"},
+	}), "cell C9 is outside of the formula range D9:D10")
+	// Test set shared and array formula with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), streamWriter.SetRow("C10", []interface{}{
+		Cell{Formula: "A10", SharedFormula: true, SharedFormulaRef: "A:C10"},
+	}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), streamWriter.SetRow("C11", []interface{}{
+		Cell{Formula: "A11", ArrayFormula: "A:C11"},
+	}))
+	assert.True(t, strings.Contains(streamWriter.rawData.buf.String(), `<c r="C1"><f t="shared" ref="C1:C3" si="0">A1+B1</f><v>3</v></c><c r="D1"><f>SUM(A1:A3)</f><v>55</v></c><c r="E1"><f t="array" ref="E1:E3">A1:A3*B1:B3</f><v>2</v></c>`))
+	assert.True(t, strings.Contains(streamWriter.rawData.buf.String(), `<c r="C2"><f t="shared" si="0"></f><v>7</v></c>`))
+	assert.NoError(t, streamWriter.Flush())
+
+	for cell, expected := range map[string]string{"C1": "A1+B1", "C2": "A2+B2", "C3": "A3+B3", "D1": "SUM(A1:A3)", "E1": "A1:A3*B1:B3"} {
+		formula, err := file.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	cellValue, err := file.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", cellValue)
+}
+
 func TestStreamSetCellValFunc(t *testing.T) {
 	f := NewFile()
 	defer func() {
@@ -400,3 +560,172 @@ func TestStreamWriterOutlineLevel(t *testing.T) {
 	assert.Equal(t, uint8(0), level)
 	assert.NoError(t, file.Close())
 }
+
+func TestStreamReader(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", 100))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "C1*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", true))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Name"))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$C$1"}},
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	sr, err := OpenStreamingReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	sheet, ok := sr.NextSheet()
+	assert.True(t, ok)
+	assert.Equal(t, "Sheet1", sheet)
+	cells, err := sr.NextRow()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sr.CurrentRow())
+	assert.Equal(t, []Cell{
+		{StyleID: styleID, Value: "Name"}, {}, {Value: "100"}, {Formula: "C1*2", Value: ""},
+	}, cells)
+	style, err := sr.GetStyle(cells[0].StyleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	cells, err = sr.NextRow()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sr.CurrentRow())
+	assert.Equal(t, []Cell{{}, {Value: "1"}}, cells)
+	_, err = sr.NextRow()
+	assert.Equal(t, io.EOF, err)
+	sheet, ok = sr.NextSheet()
+	assert.True(t, ok)
+	assert.Equal(t, "Sheet2", sheet)
+	cells, err = sr.NextRow()
+	assert.NoError(t, err)
+	assert.Equal(t, []Cell{{Value: "Name"}}, cells)
+	// Test the chart sheet was skipped
+	_, ok = sr.NextSheet()
+	assert.False(t, ok)
+	assert.NoError(t, sr.Error())
+	_, err = sr.NextRow()
+	assert.Equal(t, io.EOF, err)
+	assert.NoError(t, sr.Close())
+
+	// Test read the shared strings table from the temporary file
+	sr, err = OpenStreamingReader(bytes.NewReader(buf.Bytes()), Options{UnzipXMLSizeLimit: 10})
+	assert.NoError(t, err)
+	_, ok = sr.file.tempFiles.Load(defaultXMLPathSharedStrings)
+	assert.True(t, ok)
+	sheet, ok = sr.NextSheet()
+	assert.True(t, ok)
+	assert.Equal(t, "Sheet1", sheet)
+	cells, err = sr.NextRow()
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", cells[0].Value)
+	assert.NoError(t, sr.Close())
+
+	// Test open streaming reader with invalid options
+	_, err = OpenStreamingReader(bytes.NewReader(buf.Bytes()), Options{UnzipSizeLimit: 1, UnzipXMLSizeLimit: 2})
+	assert.Equal(t, ErrOptionsUnzipSizeLimit, err)
+	// Test open streaming reader with exceeds unzip size limit
+	_, err = OpenStreamingReader(bytes.NewReader(buf.Bytes()), Options{UnzipSizeLimit: 100})
+	assert.EqualError(t, err, newUnzipSizeLimitError(100).Error())
+	// Test open streaming reader with invalid data
+	_, err = OpenStreamingReader(strings.NewReader("invalid"))
+	assert.EqualError(t, err, zip.ErrFormat.Error())
+	_, err = OpenStreamingReader(strings.NewReader("invalid"), Options{Password: "passwd"})
+	assert.Equal(t, ErrWorkbookPassword, err)
+	// Test open streaming reader with encrypted workbook
+	file, err := os.Open(filepath.Join("test", "encryptSHA1.xlsx"))
+	assert.NoError(t, err)
+	_, err = OpenStreamingReader(file, Options{Password: "passwd"})
+	assert.Equal(t, ErrWorkbookPassword, err)
+	_, err = file.Seek(0, io.SeekStart)
+	assert.NoError(t, err)
+	sr, err = OpenStreamingReader(file, Options{Password: "password"})
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+	_, ok = sr.NextSheet()
+	assert.True(t, ok)
+	cells, err = sr.NextRow()
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", cells[0].Value)
+	assert.NoError(t, sr.Close())
+}
+
+func TestStreamReaderFormula(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	formulaType, ref := STCellFormulaTypeShared, "C1:C2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	formulaType, ref = STCellFormulaTypeArray, "D1:D1"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(A1:B1)", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	sr, err := OpenStreamingReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	_, ok := sr.NextSheet()
+	assert.True(t, ok)
+	cells, err := sr.NextRow()
+	assert.NoError(t, err)
+	assert.Equal(t, Cell{Formula: "A1+B1", Value: "", SharedFormula: true, SharedFormulaRef: "C1:C2"}, cells[2])
+	assert.Equal(t, Cell{Formula: "SUM(A1:B1)", Value: "", ArrayFormula: "D1:D1"}, cells[3])
+	assert.NoError(t, sr.Close())
+}
+
+func TestStreamReaderInvalidSheetData(t *testing.T) {
+	newStreamReader := func(sheetData string) *StreamReader {
+		f := NewFile()
+		f.Sheet.Delete("xl/worksheets/sheet1.xml")
+		f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData>%s</sheetData></worksheet>`, NameSpaceSpreadSheet.Value, sheetData)))
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		sr, err := OpenStreamingReader(bytes.NewReader(buf.Bytes()))
+		assert.NoError(t, err)
+		_, ok := sr.NextSheet()
+		assert.True(t, ok)
+		return sr
+	}
+	for sheetData, expected := range map[string]string{
+		`<row r="A"><c r="A1"/></row>`: `strconv.Atoi: parsing "A": invalid syntax`,
+		`<row><c r="A"/></row>`:        newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error(),
+		`<row><c t="n"><v>x</v></row>`: "XML syntax error on line 1: element <c> closed by </row>",
+		`<row><c t="n"><v>x</v></c>`:   "XML syntax error on line 1: element <row> closed by </sheetData>",
+	} {
+		sr := newStreamReader(sheetData)
+		_, err := sr.NextRow()
+		assert.EqualError(t, err, expected, sheetData)
+		assert.NoError(t, sr.Close())
+	}
+	// Test read the rows with the unsupported charset shared strings table
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	for _, file := range zr.File {
+		w, err := zw.Create(file.Name)
+		assert.NoError(t, err)
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		if file.Name == defaultXMLPathSharedStrings {
+			content = MacintoshCyrillicCharset
+		}
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	_, err = OpenStreamingReader(bytes.NewReader(out.Bytes()))
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
@@ -21,8 +21,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/mohae/deepcopy"
 )
 
+// maxCellIndent defined the maximum indent level of the cell alignment.
+const maxCellIndent = 250
+
 // validType defined the list of valid validation types.
 var validType = map[string]string{
 	"cell":          "cellIs",
@@ -33,7 +38,7 @@ var validType = map[string]string{
 	"unique":        "uniqueValues",
 	"top":           "top10",
 	"bottom":        "top10",
-	"text":          "text",              // Doesn't support currently
+	"text":          "text",
 	"time_period":   "timePeriod",        // Doesn't support currently
 	"blanks":        "containsBlanks",    // Doesn't support currently
 	"no_blanks":     "notContainsBlanks", // Doesn't support currently
@@ -117,33 +122,6 @@ func (f *File) stylesReader() (*xlsxStyleSheet, error) {
 	return f.Styles, nil
 }
 
-// styleSheetWriter provides a function to save xl/styles.xml after serialize
-// structure.
-func (f *File) styleSheetWriter() {
-	if f.Styles != nil {
-		output, _ := xml.Marshal(f.Styles)
-		f.saveFileList(defaultXMLPathStyles, f.replaceNameSpaceBytes(defaultXMLPathStyles, output))
-	}
-}
-
-// themeWriter provides a function to save xl/theme/theme1.xml after serialize
-// structure.
-func (f *File) themeWriter() {
-	if f.Theme != nil {
-		output, _ := xml.Marshal(f.Theme)
-		f.saveFileList(defaultXMLPathTheme, f.replaceNameSpaceBytes(defaultXMLPathTheme, output))
-	}
-}
-
-// sharedStringsWriter provides a function to save xl/sharedStrings.xml after
-// serialize structure.
-func (f *File) sharedStringsWriter() {
-	if f.SharedStrings != nil {
-		output, _ := xml.Marshal(f.SharedStrings)
-		f.saveFileList(defaultXMLPathSharedStrings, f.replaceNameSpaceBytes(defaultXMLPathSharedStrings, output))
-	}
-}
-
 // parseFormatStyleSet provides a function to parse the format settings of the
 // cells and conditional formats.
 func parseFormatStyleSet(style *Style) (*Style, error) {
@@ -156,6 +134,14 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 			return style, ErrFontSize
 		}
 	}
+	if style.Fill.GradientType != "" && style.Fill.GradientType != "linear" && style.Fill.GradientType != "path" {
+		return style, ErrGradientType
+	}
+	for _, stop := range style.Fill.GradientStops {
+		if stop.Position < 0 || stop.Position > 1 {
+			return style, ErrGradientStopPosition
+		}
+	}
 	if style.CustomNumFmt != nil && len(*style.CustomNumFmt) == 0 {
 		err = ErrCustomNumFmt
 	}
@@ -180,25 +166,29 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 diagonalDown | Diagonal down border
 //	 diagonalUp   | Diagonal up border
 //
+// The diagonal down and diagonal up borders share the same diagonal line
+// settings in the spreadsheet, so the style and color of the last given
+// diagonal border will be used if both of them are specified.
+//
 // The following table shows the border styles used in 'Border.Style' supported
 // by excelize index number:
 //
-//	 Index | Name          | Weight | Style
-//	-------+---------------+--------+-------------
-//	 0     | None          | 0      |
-//	 1     | Continuous    | 1      | -----------
-//	 2     | Continuous    | 2      | -----------
-//	 3     | Dash          | 1      | - - - - - -
-//	 4     | Dot           | 1      | . . . . . .
-//	 5     | Continuous    | 3      | -----------
-//	 6     | Double        | 3      | ===========
-//	 7     | Continuous    | 0      | -----------
-//	 8     | Dash          | 2      | - - - - - -
-//	 9     | Dash Dot      | 1      | - . - . - .
-//	 10    | Dash Dot      | 2      | - . - . - .
-//	 11    | Dash Dot Dot  | 1      | - . . - . .
-//	 12    | Dash Dot Dot  | 2      | - . . - . .
-//	 13    | SlantDash Dot | 2      | / - . / - .
+//	 Index | Name          | Weight | Style       | OOXML Style
+//	-------+---------------+--------+-------------+------------------
+//	 0     | None          | 0      |             | none
+//	 1     | Continuous    | 1      | ----------- | thin
+//	 2     | Continuous    | 2      | ----------- | medium
+//	 3     | Dash          | 1      | - - - - - - | dashed
+//	 4     | Dot           | 1      | . . . . . . | dotted
+//	 5     | Continuous    | 3      | ----------- | thick
+//	 6     | Double        | 3      | =========== | double
+//	 7     | Continuous    | 0      | ----------- | hair
+//	 8     | Dash          | 2      | - - - - - - | mediumDashed
+//	 9     | Dash Dot      | 1      | - . - . - . | dashDot
+//	 10    | Dash Dot      | 2      | - . - . - . | mediumDashDot
+//	 11    | Dash Dot Dot  | 1      | - . . - . . | dashDotDot
+//	 12    | Dash Dot Dot  | 2      | - . . - . . | mediumDashDotDot
+//	 13    | SlantDash Dot | 2      | / - . / - . | slantDashDot
 //
 // The following table shows the border styles used in 'Border.Style' in the
 // order shown in the Excel dialog:
@@ -222,6 +212,31 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 3-5   | Vertical        | 12-15 | From corner
 //	 6-8   | Diagonal Up     | 16    | From center
 //
+// The gradient fill could also be created with custom stops by the
+// 'Fill.GradientStops' field, the 'Fill.Shading' and 'Fill.Color' fields will
+// be ignored in this case. The 'Fill.GradientType' field specifies the type of
+// the gradient, "linear" (default) or "path", the 'Fill.GradientDegree' field
+// specifies the angle of the linear gradient, and the position of each stop
+// must be between 0 and 1. For example:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "gradient", GradientDegree: 90, GradientStops: []excelize.GradientStop{
+//	        {Position: 0, Color: "FFFFFF"}, {Position: 0.5, Color: "4472C4"}, {Position: 1, Color: "FFFFFF"},
+//	    }},
+//	})
+//
+// The 'ColorTheme' and 'ColorTint' fields of the 'Font', 'Fill' and 'Border'
+// specify the theme color index and the tint value of the color, the color
+// will be updated when the workbook theme changed. The theme color will be
+// used instead of the RGB color if the 'ColorTheme' field was specified, and
+// the fill theme color only works for the pattern fill. For example, create a
+// style with the accent 1 color with 40% lighter fill:
+//
+//	accent1 := 4
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Pattern: 1, ColorTheme: &accent1, ColorTint: 0.4},
+//	})
+//
 // The following table shows the pattern styles used in 'Fill.Pattern' supported
 // by excelize index number:
 //
@@ -965,10 +980,9 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // Cell Sheet1!A6 in the Excel Application: martes, 04 de Julio de 2017
 func (f *File) NewStyle(style *Style) (int, error) {
 	var (
-		fs                                  *Style
-		font                                *xlsxFont
-		err                                 error
-		cellXfsID, fontID, borderID, fillID int
+		fs        *Style
+		err       error
+		cellXfsID int
 	)
 	if style == nil {
 		return cellXfsID, err
@@ -977,9 +991,6 @@ func (f *File) NewStyle(style *Style) (int, error) {
 	if err != nil {
 		return cellXfsID, err
 	}
-	if fs.DecimalPlaces == 0 {
-		fs.DecimalPlaces = 2
-	}
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
@@ -989,6 +1000,68 @@ func (f *File) NewStyle(style *Style) (int, error) {
 	f.mu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return f.newStyle(s, fs)
+}
+
+// BatchNewStyle provides a function to create multiple styles for cells in
+// one call by given styles format set, and returns the style index in the
+// same order as the given styles. All styles are validated before the style
+// sheet is changed, and the same styles will share the same style index. A
+// nil style in the list gets the default style index 0. This function is
+// helpful to create a style palette before writing cells by stream writer. For
+// example, create a bold style and a red fill style:
+//
+//	styles, err := f.BatchNewStyle([]*excelize.Style{
+//	    {Font: &excelize.Font{Bold: true}},
+//	    {Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}},
+//	})
+//
+// 根据给定的样式格式集合批量创建单元格样式，按给定的顺序返回样式索引。
+func (f *File) BatchNewStyle(styles []*Style) ([]int, error) {
+	formatSets := make([]*Style, len(styles))
+	for i, style := range styles {
+		if style == nil {
+			continue
+		}
+		fs, err := parseFormatStyleSet(style)
+		if err != nil {
+			return nil, err
+		}
+		formatSets[i] = fs
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	styleIDs := make([]int, len(styles))
+	for i, fs := range formatSets {
+		if fs == nil {
+			continue
+		}
+		if styleIDs[i], err = f.newStyle(s, fs); err != nil {
+			return nil, err
+		}
+	}
+	return styleIDs, err
+}
+
+// newStyle provides a function to create the style for cells by given style
+// sheet and the parsed style format set. This function is concurrency unsafe,
+// the caller should hold the lock of the style sheet.
+func (f *File) newStyle(s *xlsxStyleSheet, fs *Style) (int, error) {
+	var (
+		font                                *xlsxFont
+		err                                 error
+		cellXfsID, fontID, borderID, fillID int
+	)
+	if fs.DecimalPlaces == 0 {
+		fs.DecimalPlaces = 2
+	}
 	// check given style already exist.
 	if cellXfsID, err = f.getStyleID(s, fs); err != nil || cellXfsID != -1 {
 		return cellXfsID, err
@@ -1032,6 +1105,413 @@ func (f *File) NewStyle(style *Style) (int, error) {
 	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
 }
 
+// GetStyle provides a function to get the style definition by given style
+// index, the returned style could be modified and used to create a new style
+// by the NewStyle function. The fonts, fills, borders, number formats,
+// alignment and protection settings which applied by the style will be
+// returned. For example, get the style definition of the cell Sheet1!A1:
+//
+//	idx, err := f.GetCellStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	style, err := f.GetStyle(idx)
+//
+// 根据给定的样式索引获取样式定义。
+func (f *File) GetStyle(idx int) (*Style, error) {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || idx < 0 || idx >= len(s.CellXfs.Xf) {
+		return nil, newInvalidStyleID(idx)
+	}
+	style, xf := &Style{}, s.CellXfs.Xf[idx]
+	if xf.NumFmtID != nil {
+		extractNumFmt(s, *xf.NumFmtID, style)
+	}
+	applied := func(ID *int, apply *bool) bool {
+		return ID != nil && (*ID != 0 || (apply != nil && *apply))
+	}
+	if applied(xf.FontID, xf.ApplyFont) && s.Fonts != nil && *xf.FontID < len(s.Fonts.Font) {
+		style.Font = extractFont(s.Fonts.Font[*xf.FontID])
+	}
+	if applied(xf.FillID, xf.ApplyFill) && s.Fills != nil && *xf.FillID < len(s.Fills.Fill) {
+		style.Fill = extractFill(s.Fills.Fill[*xf.FillID])
+	}
+	if applied(xf.BorderID, xf.ApplyBorder) && s.Borders != nil && *xf.BorderID < len(s.Borders.Border) {
+		style.Border = extractBorders(s.Borders.Border[*xf.BorderID])
+	}
+	if xf.Alignment != nil && xf.ApplyAlignment != nil && *xf.ApplyAlignment {
+		style.Alignment = extractAlignment(xf.Alignment)
+	}
+	if xf.Protection != nil {
+		style.Protection = extractProtection(xf.Protection)
+	}
+	return style, err
+}
+
+// extractAlignment provides a function to convert the alignment settings of
+// the cell format to the style alignment.
+func extractAlignment(alignment *xlsxAlignment) *Alignment {
+	return &Alignment{
+		Horizontal:      alignment.Horizontal,
+		Indent:          alignment.Indent,
+		JustifyLastLine: alignment.JustifyLastLine,
+		ReadingOrder:    alignment.ReadingOrder,
+		RelativeIndent:  alignment.RelativeIndent,
+		ShrinkToFit:     alignment.ShrinkToFit,
+		TextRotation:    alignment.TextRotation,
+		Vertical:        alignment.Vertical,
+		WrapText:        alignment.WrapText,
+	}
+}
+
+// extractProtection provides a function to convert the protection settings
+// of the cell format to the style protection.
+func extractProtection(protection *xlsxProtection) *Protection {
+	return &Protection{
+		Hidden: protection.Hidden != nil && *protection.Hidden,
+		Locked: protection.Locked == nil || *protection.Locked,
+	}
+}
+
+// GetCellStyleByAddress provides a function to get the effective style of
+// the cell by given worksheet name and cell reference. The style index of the
+// cell is resolved from the cell, row and column styles in order, and the
+// number format, font, fill, border, alignment and protection which are not
+// applied by the cell format will be inherited from its base cell style
+// format (the cell style such as "Normal"). The Font, Alignment and
+// Protection of the returned style are always not nil, so that the callers
+// could get the exact appearance of the cell. For example, get the effective
+// font of cell A1 on Sheet1:
+//
+//	style, err := f.GetCellStyleByAddress("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(style.Font.Family, style.Font.Size)
+//
+// 根据给定的工作表名称和单元格坐标获取单元格经过行、列样式和基础单元格样式继承后的实际样式。
+func (f *File) GetCellStyleByAddress(sheet, cell string) (*Style, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	xf, base := s.CellXfs.Xf[styleID], xlsxXf{}
+	if xf.XfID != nil && s.CellStyleXfs != nil && *xf.XfID >= 0 && *xf.XfID < len(s.CellStyleXfs.Xf) {
+		base = s.CellStyleXfs.Xf[*xf.XfID]
+	}
+	baseID := func(ID *int) int {
+		if ID == nil || *ID < 0 {
+			return 0
+		}
+		return *ID
+	}
+	if style.NumFmt == 0 && style.CustomNumFmt == nil && base.NumFmtID != nil {
+		extractNumFmt(s, *base.NumFmtID, style)
+	}
+	if style.Font == nil && s.Fonts != nil && baseID(base.FontID) < len(s.Fonts.Font) {
+		style.Font = extractFont(s.Fonts.Font[baseID(base.FontID)])
+	}
+	if style.Font == nil {
+		style.Font = &Font{}
+	}
+	if style.Fill.Type == "" && s.Fills != nil && baseID(base.FillID) < len(s.Fills.Fill) {
+		style.Fill = extractFill(s.Fills.Fill[baseID(base.FillID)])
+	}
+	if style.Border == nil && s.Borders != nil && baseID(base.BorderID) < len(s.Borders.Border) {
+		style.Border = extractBorders(s.Borders.Border[baseID(base.BorderID)])
+	}
+	if style.Alignment == nil {
+		style.Alignment = &Alignment{}
+		if xf.Alignment != nil && xf.ApplyAlignment == nil {
+			style.Alignment = extractAlignment(xf.Alignment)
+		} else if base.Alignment != nil {
+			style.Alignment = extractAlignment(base.Alignment)
+		}
+	}
+	if style.Protection == nil {
+		style.Protection = &Protection{Locked: true}
+		if base.Protection != nil {
+			style.Protection = extractProtection(base.Protection)
+		}
+	}
+	return style, err
+}
+
+// extractNumFmt provides a function to set the built-in number format index
+// or the custom number format code of the style by given number format ID.
+func extractNumFmt(s *xlsxStyleSheet, numFmtID int, style *Style) {
+	if _, ok := builtInNumFmt[numFmtID]; ok || setLangNumFmt(s, &Style{NumFmt: numFmtID}) != 0 {
+		style.NumFmt = numFmtID
+		return
+	}
+	if s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmt.NumFmtID == numFmtID {
+				style.CustomNumFmt = stringPtr(numFmt.FormatCode)
+				return
+			}
+		}
+	}
+}
+
+// extractColor returns the RGB color in 'RRGGBB' hexadecimal notation by
+// given color, the alpha channel of the color will be removed.
+func extractColor(color *xlsxColor) string {
+	if color == nil {
+		return ""
+	}
+	if len(color.RGB) == 8 {
+		return color.RGB[2:]
+	}
+	return color.RGB
+}
+
+// extractFont provides a function to convert the font of the style sheet to
+// the font settings.
+func extractFont(fnt *xlsxFont) *Font {
+	font := &Font{}
+	isTrue := func(val *attrValBool) bool {
+		return val != nil && (val.Val == nil || *val.Val)
+	}
+	font.Bold, font.Italic, font.Strike = isTrue(fnt.B), isTrue(fnt.I), isTrue(fnt.Strike)
+	if fnt.U != nil {
+		font.Underline = "single"
+		if fnt.U.Val != nil {
+			font.Underline = *fnt.U.Val
+		}
+	}
+	if fnt.Name != nil && fnt.Name.Val != nil {
+		font.Family = *fnt.Name.Val
+	}
+	if fnt.Sz != nil && fnt.Sz.Val != nil {
+		font.Size = *fnt.Sz.Val
+	}
+	if fnt.Color != nil {
+		font.Color = extractColor(fnt.Color)
+		font.ColorIndexed = fnt.Color.Indexed
+		font.ColorTheme = fnt.Color.Theme
+		font.ColorTint = fnt.Color.Tint
+	}
+	return font
+}
+
+// extractFill provides a function to convert the fill of the style sheet to
+// the fill settings, the gradient fill will be converted to the gradient
+// type, degree and stops.
+func extractFill(fill *xlsxFill) Fill {
+	var settings Fill
+	if gradient := fill.GradientFill; gradient != nil {
+		settings.Type, settings.GradientType, settings.GradientDegree = "gradient", "linear", gradient.Degree
+		if gradient.Type == "path" {
+			settings.GradientType = gradient.Type
+		}
+		for _, stop := range gradient.Stop {
+			settings.GradientStops = append(settings.GradientStops, GradientStop{
+				Position: stop.Position, Color: extractColor(&stop.Color),
+			})
+		}
+		return settings
+	}
+	if pattern := fill.PatternFill; pattern != nil {
+		settings.Type = "pattern"
+		if idx := inStrSlice(styleFillPatterns, pattern.PatternType, true); idx != -1 {
+			settings.Pattern = idx
+		}
+		color := pattern.FgColor
+		if color == nil || (color.RGB == "" && color.Theme == nil) {
+			color = pattern.BgColor
+		}
+		if rgb := extractColor(color); rgb != "" {
+			settings.Color = []string{rgb}
+		}
+		if color != nil && color.Theme != nil {
+			settings.ColorTheme, settings.ColorTint = intPtr(*color.Theme), color.Tint
+		}
+	}
+	return settings
+}
+
+// extractBorders provides a function to convert the border of the style
+// sheet to the borders settings.
+func extractBorders(border *xlsxBorder) []Border {
+	var borders []Border
+	for _, line := range []struct {
+		Type string
+		Line xlsxLine
+		Set  bool
+	}{
+		{"left", border.Left, true},
+		{"right", border.Right, true},
+		{"top", border.Top, true},
+		{"bottom", border.Bottom, true},
+		{"diagonalUp", border.Diagonal, border.DiagonalUp},
+		{"diagonalDown", border.Diagonal, border.DiagonalDown},
+	} {
+		if idx := inStrSlice(styleBorders, line.Line.Style, true); line.Set && idx > 0 {
+			b := Border{Type: line.Type, Color: extractColor(line.Line.Color), Style: idx}
+			if line.Line.Color != nil && line.Line.Color.Theme != nil {
+				b.ColorTheme, b.ColorTint = intPtr(*line.Line.Color.Theme), line.Line.Color.Tint
+			}
+			borders = append(borders, b)
+		}
+	}
+	return borders
+}
+
+// styleImporter directly maps the source and destination style sheets for
+// importing the styles, and the indexes of the elements in the destination
+// style sheet by the XML serialization.
+type styleImporter struct {
+	src, dst                             *xlsxStyleSheet
+	fonts, fills, borders, cellXfs, dxfs xmlIndex
+}
+
+// importCellStyle provides a function to add the cell style of the source
+// style sheet into the style sheet by given style index, the fonts, fills,
+// borders and custom number formats used by the style will be reused if
+// exists in the style sheet. Returns the style index in the style sheet.
+func (si *styleImporter) importCellStyle(styleID int) int {
+	src, dst := si.src, si.dst
+	if src.CellXfs == nil || styleID < 0 || styleID >= len(src.CellXfs.Xf) {
+		return 0
+	}
+	xf := deepcopy.Copy(src.CellXfs.Xf[styleID]).(xlsxXf)
+	xf.XfID = intPtr(0)
+	if xf.NumFmtID != nil && *xf.NumFmtID >= 164 {
+		xf.NumFmtID = intPtr(importNumFmt(src, dst, *xf.NumFmtID))
+	}
+	if xf.FontID != nil && src.Fonts != nil && *xf.FontID < len(src.Fonts.Font) {
+		if dst.Fonts == nil {
+			dst.Fonts = &xlsxFonts{}
+		}
+		fontID := si.fonts.get(src.Fonts.Font[*xf.FontID], len(dst.Fonts.Font), func(i int) interface{} { return dst.Fonts.Font[i] })
+		if fontID == -1 {
+			dst.Fonts.Font = append(dst.Fonts.Font, deepcopy.Copy(src.Fonts.Font[*xf.FontID]).(*xlsxFont))
+			dst.Fonts.Count, fontID = len(dst.Fonts.Font), len(dst.Fonts.Font)-1
+		}
+		xf.FontID = intPtr(fontID)
+	}
+	if xf.FillID != nil && src.Fills != nil && *xf.FillID < len(src.Fills.Fill) {
+		if dst.Fills == nil {
+			dst.Fills = &xlsxFills{}
+		}
+		fillID := si.fills.get(src.Fills.Fill[*xf.FillID], len(dst.Fills.Fill), func(i int) interface{} { return dst.Fills.Fill[i] })
+		if fillID == -1 {
+			dst.Fills.Fill = append(dst.Fills.Fill, deepcopy.Copy(src.Fills.Fill[*xf.FillID]).(*xlsxFill))
+			dst.Fills.Count, fillID = len(dst.Fills.Fill), len(dst.Fills.Fill)-1
+		}
+		xf.FillID = intPtr(fillID)
+	}
+	if xf.BorderID != nil && src.Borders != nil && *xf.BorderID < len(src.Borders.Border) {
+		if dst.Borders == nil {
+			dst.Borders = &xlsxBorders{}
+		}
+		borderID := si.borders.get(src.Borders.Border[*xf.BorderID], len(dst.Borders.Border), func(i int) interface{} { return dst.Borders.Border[i] })
+		if borderID == -1 {
+			dst.Borders.Border = append(dst.Borders.Border, deepcopy.Copy(src.Borders.Border[*xf.BorderID]).(*xlsxBorder))
+			dst.Borders.Count, borderID = len(dst.Borders.Border), len(dst.Borders.Border)-1
+		}
+		xf.BorderID = intPtr(borderID)
+	}
+	if dst.CellXfs == nil {
+		dst.CellXfs = &xlsxCellXfs{}
+	}
+	if ID := si.cellXfs.get(xf, len(dst.CellXfs.Xf), func(i int) interface{} { return dst.CellXfs.Xf[i] }); ID != -1 {
+		return ID
+	}
+	dst.CellXfs.Xf = append(dst.CellXfs.Xf, xf)
+	dst.CellXfs.Count = len(dst.CellXfs.Xf)
+	return dst.CellXfs.Count - 1
+}
+
+// importNumFmt provides a function to add the custom number format of the
+// source style sheet into the style sheet by given number format ID, and
+// returns the number format ID in the style sheet.
+func importNumFmt(src, dst *xlsxStyleSheet, numFmtID int) int {
+	if src.NumFmts == nil {
+		return 0
+	}
+	for _, numFmt := range src.NumFmts.NumFmt {
+		if numFmt.NumFmtID == numFmtID {
+			style := &Style{CustomNumFmt: stringPtr(numFmt.FormatCode)}
+			if ID := getCustomNumFmtID(dst, style); ID != -1 {
+				return ID
+			}
+			return setCustomNumFmt(dst, style)
+		}
+	}
+	return 0
+}
+
+// importDxfStyle provides a function to add the differential formatting of the
+// source style sheet into the style sheet by given format ID, and returns the
+// format ID in the style sheet.
+func (si *styleImporter) importDxfStyle(dxfID int) int {
+	src, dst := si.src, si.dst
+	if src.Dxfs == nil || dxfID < 0 || dxfID >= len(src.Dxfs.Dxfs) {
+		return dxfID
+	}
+	if dst.Dxfs == nil {
+		dst.Dxfs = &xlsxDxfs{}
+	}
+	if ID := si.dxfs.get(src.Dxfs.Dxfs[dxfID], len(dst.Dxfs.Dxfs), func(i int) interface{} { return dst.Dxfs.Dxfs[i] }); ID != -1 {
+		return ID
+	}
+	dst.Dxfs.Dxfs = append(dst.Dxfs.Dxfs, deepcopy.Copy(src.Dxfs.Dxfs[dxfID]).(*xlsxDxf))
+	dst.Dxfs.Count = len(dst.Dxfs.Dxfs)
+	return dst.Dxfs.Count - 1
+}
+
+// xmlIndex directly maps the index of the first element for each XML
+// serialization of the elements.
+type xmlIndex struct {
+	indexes map[string]int
+	count   int
+}
+
+// get returns the index of the first element which has the same XML
+// serialization with the given value, the element of the given index will be
+// got by the item function. The elements will be serialized once, and only
+// the elements appended since the last call will be added into the index.
+// Returns -1 if no element matched.
+func (x *xmlIndex) get(v interface{}, count int, item func(i int) interface{}) int {
+	if x.indexes == nil {
+		x.indexes = make(map[string]int)
+	}
+	for ; x.count < count; x.count++ {
+		actual, _ := xml.Marshal(item(x.count))
+		if _, ok := x.indexes[string(actual)]; !ok {
+			x.indexes[string(actual)] = x.count
+		}
+	}
+	expected, _ := xml.Marshal(v)
+	if i, ok := x.indexes[string(expected)]; ok {
+		return i
+	}
+	return -1
+}
+
 var getXfIDFuncs = map[string]func(int, xlsxXf, *Style) bool{
 	"numFmt": func(numFmtID int, xf xlsxXf, style *Style) bool {
 		if style.CustomNumFmt == nil && numFmtID == -1 {
@@ -1170,6 +1650,51 @@ func (f *File) SetDefaultFont(fontName string) error {
 	return err
 }
 
+// GetWorkbookBuiltInStyles provides a function to get the built-in named cell
+// styles of the workbook, such as "Normal", "Good", "Bad" and "Neutral". Note
+// that only the named cell styles defined in the workbook will be returned,
+// the new workbook contains the "Normal" style only, the other built-in named
+// cell styles will be added by Excel when they are used.
+//
+// 获取工作簿中定义的内置单元格样式。
+func (f *File) GetWorkbookBuiltInStyles() ([]NamedStyle, error) {
+	return f.getNamedStyles(true)
+}
+
+// GetWorkbookCustomStyles provides a function to get the user-defined named
+// cell styles of the workbook, which has no built-in ID.
+//
+// 获取工作簿中的自定义单元格样式。
+func (f *File) GetWorkbookCustomStyles() ([]NamedStyle, error) {
+	return f.getNamedStyles(false)
+}
+
+// getNamedStyles provides a function to get the built-in or user-defined
+// named cell styles of the workbook.
+func (f *File) getNamedStyles(builtIn bool) ([]NamedStyle, error) {
+	var styles []NamedStyle
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.stylesReader()
+	if err != nil || s.CellStyles == nil {
+		return styles, err
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		if (cellStyle.BuiltInID != nil) != builtIn {
+			continue
+		}
+		style := NamedStyle{Name: cellStyle.Name, XfID: cellStyle.XfID, BuiltInID: cellStyle.BuiltInID}
+		if cellStyle.Hidden != nil {
+			style.Hidden = *cellStyle.Hidden
+		}
+		if cellStyle.CustomBuiltIn != nil {
+			style.CustomBuiltIn = *cellStyle.CustomBuiltIn
+		}
+		styles = append(styles, style)
+	}
+	return styles, err
+}
+
 // readDefaultFont provides an un-marshalled font value.
 func (f *File) readDefaultFont() (*xlsxFont, error) {
 	f.mu.Lock()
@@ -1382,6 +1907,49 @@ func setLangNumFmt(styleSheet *xlsxStyleSheet, style *Style) int {
 	return 0
 }
 
+// styleFillPatterns defined the pattern types of the fill, the index of the
+// pattern type is the value of the 'Fill.Pattern' field.
+var styleFillPatterns = []string{
+	"none",
+	"solid",
+	"mediumGray",
+	"darkGray",
+	"lightGray",
+	"darkHorizontal",
+	"darkVertical",
+	"darkDown",
+	"darkUp",
+	"darkGrid",
+	"darkTrellis",
+	"lightHorizontal",
+	"lightVertical",
+	"lightDown",
+	"lightUp",
+	"lightGrid",
+	"lightTrellis",
+	"gray125",
+	"gray0625",
+}
+
+// styleBorders defined the line styles of the border, the index of the line
+// style is the value of the 'Border.Style' field.
+var styleBorders = []string{
+	"none",
+	"thin",
+	"medium",
+	"dashed",
+	"dotted",
+	"thick",
+	"double",
+	"hair",
+	"mediumDashed",
+	"dashDot",
+	"mediumDashDot",
+	"dashDotDot",
+	"mediumDashDotDot",
+	"slantDashDot",
+}
+
 // getFillID provides a function to get fill ID. If given fill is not
 // exist, will return -1.
 func getFillID(styleSheet *xlsxStyleSheet, style *Style) (fillID int) {
@@ -1405,27 +1973,6 @@ func getFillID(styleSheet *xlsxStyleSheet, style *Style) (fillID int) {
 // newFills provides a function to add fill elements in the styles.xml by
 // given cell format settings.
 func newFills(style *Style, fg bool) *xlsxFill {
-	patterns := []string{
-		"none",
-		"solid",
-		"mediumGray",
-		"darkGray",
-		"lightGray",
-		"darkHorizontal",
-		"darkVertical",
-		"darkDown",
-		"darkUp",
-		"darkGrid",
-		"darkTrellis",
-		"lightHorizontal",
-		"lightVertical",
-		"lightDown",
-		"lightUp",
-		"lightGrid",
-		"lightTrellis",
-		"gray125",
-		"gray0625",
-	}
 	variants := []xlsxGradientFill{
 		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
 		{Degree: 270, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
@@ -1449,6 +1996,10 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
+		if len(style.Fill.GradientStops) > 0 {
+			fill.GradientFill = newGradientFill(&style.Fill)
+			break
+		}
 		if len(style.Fill.Color) != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
 			break
 		}
@@ -1463,21 +2014,19 @@ func newFills(style *Style, fg bool) *xlsxFill {
 		if style.Fill.Pattern > 18 || style.Fill.Pattern < 0 {
 			break
 		}
-		if len(style.Fill.Color) < 1 {
+		if len(style.Fill.Color) < 1 && style.Fill.ColorTheme == nil {
 			break
 		}
 		var pattern xlsxPatternFill
-		pattern.PatternType = patterns[style.Fill.Pattern]
+		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
+		var color string
+		if len(style.Fill.Color) > 0 {
+			color = style.Fill.Color[0]
+		}
 		if fg {
-			if pattern.FgColor == nil {
-				pattern.FgColor = new(xlsxColor)
-			}
-			pattern.FgColor.RGB = getPaletteColor(style.Fill.Color[0])
+			pattern.FgColor = newStyleColor(color, style.Fill.ColorTheme, style.Fill.ColorTint)
 		} else {
-			if pattern.BgColor == nil {
-				pattern.BgColor = new(xlsxColor)
-			}
-			pattern.BgColor.RGB = getPaletteColor(style.Fill.Color[0])
+			pattern.BgColor = newStyleColor(color, style.Fill.ColorTheme, style.Fill.ColorTint)
 		}
 		fill.PatternFill = &pattern
 	default:
@@ -1486,6 +2035,21 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	return &fill
 }
 
+// newGradientFill provides a function to create the gradient fill by given
+// gradient type, degree and stops of the fill settings.
+func newGradientFill(fill *Fill) *xlsxGradientFill {
+	gradient := xlsxGradientFill{Degree: fill.GradientDegree}
+	if fill.GradientType == "path" {
+		gradient.Type = fill.GradientType
+	}
+	for _, stop := range fill.GradientStops {
+		gradient.Stop = append(gradient.Stop, &xlsxGradientFillStop{
+			Position: stop.Position, Color: xlsxColor{RGB: getPaletteColor(stop.Color)},
+		})
+	}
+	return &gradient
+}
+
 // newAlignment provides a function to formatting information pertaining to
 // text alignment in cells. There are a variety of choices for how text is
 // aligned both horizontally and vertically, as well as indentation settings,
@@ -1536,48 +2100,30 @@ func getBorderID(styleSheet *xlsxStyleSheet, style *Style) (borderID int) {
 // newBorders provides a function to add border elements in the styles.xml by
 // given borders format settings.
 func newBorders(style *Style) *xlsxBorder {
-	styles := []string{
-		"none",
-		"thin",
-		"medium",
-		"dashed",
-		"dotted",
-		"thick",
-		"double",
-		"hair",
-		"mediumDashed",
-		"dashDot",
-		"mediumDashDot",
-		"dashDotDot",
-		"mediumDashDotDot",
-		"slantDashDot",
-	}
-
 	var border xlsxBorder
 	for _, v := range style.Border {
 		if 0 <= v.Style && v.Style < 14 {
-			var color xlsxColor
-			color.RGB = getPaletteColor(v.Color)
+			color := newStyleColor(v.Color, v.ColorTheme, v.ColorTint)
 			switch v.Type {
 			case "left":
-				border.Left.Style = styles[v.Style]
-				border.Left.Color = &color
+				border.Left.Style = styleBorders[v.Style]
+				border.Left.Color = color
 			case "right":
-				border.Right.Style = styles[v.Style]
-				border.Right.Color = &color
+				border.Right.Style = styleBorders[v.Style]
+				border.Right.Color = color
 			case "top":
-				border.Top.Style = styles[v.Style]
-				border.Top.Color = &color
+				border.Top.Style = styleBorders[v.Style]
+				border.Top.Color = color
 			case "bottom":
-				border.Bottom.Style = styles[v.Style]
-				border.Bottom.Color = &color
+				border.Bottom.Style = styleBorders[v.Style]
+				border.Bottom.Color = color
 			case "diagonalUp":
-				border.Diagonal.Style = styles[v.Style]
-				border.Diagonal.Color = &color
+				border.Diagonal.Style = styleBorders[v.Style]
+				border.Diagonal.Color = color
 				border.DiagonalUp = true
 			case "diagonalDown":
-				border.Diagonal.Style = styles[v.Style]
-				border.Diagonal.Color = &color
+				border.Diagonal.Style = styleBorders[v.Style]
+				border.Diagonal.Color = color
 				border.DiagonalDown = true
 			}
 		}
@@ -1585,6 +2131,16 @@ func newBorders(style *Style) *xlsxBorder {
 	return &border
 }
 
+// newStyleColor provides a function to create the color of the fill or border
+// by given RGB color, theme color index and tint value. The theme color will
+// be used instead of the RGB color if the theme color index was specified.
+func newStyleColor(color string, theme *int, tint float64) *xlsxColor {
+	if theme != nil {
+		return &xlsxColor{Theme: intPtr(*theme), Tint: tint}
+	}
+	return &xlsxColor{RGB: getPaletteColor(color)}
+}
+
 // setCellXfs provides a function to set describes all of the formatting for a
 // cell.
 func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection) (int, error) {
@@ -1804,6 +2360,136 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 	return err
 }
 
+// SetCellHidden provides a function to set the formula hidden protection
+// property for the cell by given worksheet name, cell reference and hidden
+// state. When the worksheet is protected, the formula of the hidden cell will
+// not be displayed in the formula bar, but the value of the cell is still
+// visible. This function will create a new cell format based on the existing
+// style of the cell if needed. For example, hide the formula of the cell A1
+// on Sheet1:
+//
+//	err := f.SetCellHidden("Sheet1", "A1", true)
+func (f *File) SetCellHidden(sheet, cell string, hidden bool) error {
+	return f.setCellXf(sheet, cell, func(xf *xlsxXf) {
+		if xf.Protection == nil {
+			xf.Protection = &xlsxProtection{}
+		}
+		xf.Protection.Hidden = boolPtr(hidden)
+		xf.ApplyProtection = boolPtr(true)
+	})
+}
+
+// GetCellHidden provides a function to get the formula hidden protection
+// property of the cell by given worksheet name and cell reference.
+func (f *File) GetCellHidden(sheet, cell string) (bool, error) {
+	xf, err := f.getCellXf(sheet, cell)
+	if err != nil || xf == nil || xf.Protection == nil || xf.Protection.Hidden == nil {
+		return false, err
+	}
+	return *xf.Protection.Hidden, err
+}
+
+// SetCellIndent provides a function to set the indent level of the text for
+// the cell by given worksheet name, cell reference and indent level, where an
+// increment of 1 represents 3 spaces. The level should be between 0 and 250.
+// Only the indent of the existing style of the cell will be changed, and the
+// horizontal alignment will be set to left if it was not specified, because
+// the indent only takes effect for the left, right and distributed alignment.
+// For example, indent the text of the cell A1 on Sheet1 by 2 levels:
+//
+//	err := f.SetCellIndent("Sheet1", "A1", 2)
+func (f *File) SetCellIndent(sheet, cell string, level int) error {
+	if level < 0 || level > maxCellIndent {
+		return ErrCellIndent
+	}
+	return f.setCellXf(sheet, cell, func(xf *xlsxXf) {
+		if xf.Alignment == nil {
+			xf.Alignment = &xlsxAlignment{}
+		}
+		if xf.Alignment.Indent = level; level > 0 && xf.Alignment.Horizontal == "" {
+			xf.Alignment.Horizontal = "left"
+		}
+		xf.ApplyAlignment = boolPtr(true)
+	})
+}
+
+// getCellXf provides a function to get a copy of the cell format record which
+// applied for the cell by given worksheet name and cell reference. This
+// function returns nil if the cell format record does not exist.
+func (f *File) getCellXf(sheet, cell string) (*xlsxXf, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || styleID >= len(s.CellXfs.Xf) {
+		return nil, err
+	}
+	xf := deepcopy.Copy(s.CellXfs.Xf[styleID]).(xlsxXf)
+	return &xf, err
+}
+
+// setCellXf provides a function to update the cell format record applied for
+// the cell by given worksheet name, cell reference and update function. The
+// update function will be called with a copy of the existing cell format
+// record, the existing cell format record will be reused if the updated
+// format record already exists, otherwise a new cell format record will be
+// created.
+func (f *File) setCellXf(sheet, cell string, fn func(xf *xlsxXf)) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.prepareSheetXML(col, row)
+	c := &ws.SheetData.Row[row-1].C[col-1]
+	styleID := ws.prepareCellStyle(col, row, c.S)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil {
+		s.CellXfs = &xlsxCellXfs{}
+	}
+	xf := xlsxXf{XfID: intPtr(0)}
+	if styleID < len(s.CellXfs.Xf) {
+		xf = deepcopy.Copy(s.CellXfs.Xf[styleID]).(xlsxXf)
+	}
+	fn(&xf)
+	for idx, v := range s.CellXfs.Xf {
+		if reflect.DeepEqual(v, xf) {
+			c.S = idx
+			return err
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	c.S = s.CellXfs.Count - 1
+	return err
+}
+
 // SetConditionalFormat provides a function to create conditional formatting
 // rule for cell value. Conditional formatting is a feature of Excel which
 // allows you to apply a format to a cell or a range of cells based on certain
@@ -2038,6 +2724,22 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	    },
 //	)
 //
+// type: text - The text type is used to specify Excel's "Specific Text" style
+// conditional format. The criteria could be "containing", "not containing",
+// "begins with" or "ends with", and the text comparison is case-insensitive:
+//
+//	// Highlight cells rules: Text that Contains...
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:     "text",
+//	            Criteria: "containing",
+//	            Format:   format,
+//	            Value:    "error",
+//	        },
+//	    },
+//	)
+//
 // type: top - The top type is used to specify the top n values by number or
 // percentage in a range:
 //
@@ -2192,6 +2894,10 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // BarSolid - Used for turns on a solid (non-gradient) fill for data bars, this
 // is only visible in Excel 2010 and later.
 //
+// BarNegativeColor - Used for sets the fill color of the data bars for the
+// negative values, the default color is red. This is only visible in Excel
+// 2010 and later.
+//
 // IconStyle - The available options are:
 //
 //	3Arrows
@@ -2212,6 +2918,27 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	5Quarters
 //	5Rating
 //
+// IconThresholds - Used for set the thresholds of each icon in the icon set,
+// the number of the thresholds should be the same as the number of the icons,
+// and the first threshold is the lower limit of the first icon. The available
+// types of the threshold are num, percent, percentile and formula. The default
+// thresholds are the equal percent intervals, for example, use the custom
+// thresholds for the 3 traffic lights icon set:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:      "icon_set",
+//	            IconStyle: "3TrafficLights1",
+//	            IconThresholds: []excelize.ConditionalFormatThreshold{
+//	                {Type: "num", Value: "0"},
+//	                {Type: "num", Value: "60"},
+//	                {Type: "num", Value: "90"},
+//	            },
+//	        },
+//	    },
+//	)
+//
 // ReverseIcons - Used for set reversed icons sets.
 //
 // IconsOnly - Used for set displayed without the cell value.
@@ -2232,6 +2959,13 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		"dataBar":         drawCondFmtDataBar,
 		"expression":      drawCondFmtExp,
 		"iconSet":         drawCondFmtIconSet,
+		"text": func(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+			cell, err := getCondFmtTopLeftCell(rangeRef)
+			if err != nil {
+				return nil, nil
+			}
+			return drawCondFmtText(p, ct, cell, format)
+		},
 	}
 
 	ws, err := f.workSheetReader(sheet)
@@ -2447,6 +3181,9 @@ func extractCondFmtDataBar(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 					if rule.DataBar.BorderColor != nil {
 						format.BarBorderColor = "#" + strings.TrimPrefix(strings.ToUpper(rule.DataBar.BorderColor.RGB), "FF")
 					}
+					if color := rule.DataBar.NegativeFillColor; color != nil && !strings.EqualFold(color.RGB, defaultBarNegativeColor) {
+						format.BarNegativeColor = "#" + strings.TrimPrefix(strings.ToUpper(color.RGB), "FF")
+					}
 				}
 			}
 		}
@@ -2496,6 +3233,21 @@ func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 		}
 		format.IconStyle = c.IconSet.IconSet
 		format.ReverseIcons = c.IconSet.Reverse
+		if !reflect.DeepEqual(c.IconSet.Cfvo, getIconSetCfvo(c.IconSet.IconSet)) {
+			for _, cfvo := range c.IconSet.Cfvo {
+				format.IconThresholds = append(format.IconThresholds, ConditionalFormatThreshold{Type: cfvo.Type, Value: cfvo.Val})
+			}
+		}
+	}
+	return format
+}
+
+// extractCondFmtText provides a function to extract conditional format
+// settings for the specific text by given conditional formatting rule.
+func extractCondFmtText(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "text", Criteria: operatorType[c.Operator], Value: c.Text}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
 	}
 	return format
 }
@@ -2513,6 +3265,10 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 		"dataBar":         extractCondFmtDataBar,
 		"expression":      extractCondFmtExp,
 		"iconSet":         extractCondFmtIconSet,
+		"containsText":    extractCondFmtText,
+		"notContainsText": extractCondFmtText,
+		"beginsWith":      extractCondFmtText,
+		"endsWith":        extractCondFmtText,
 	}
 
 	conditionalFormats := make(map[string][]ConditionalFormatOptions)
@@ -2548,6 +3304,146 @@ func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
 	return nil
 }
 
+// SetConditionalFormatTextContains provides a function to set the conditional
+// format rule for the cells which contain the given text by given worksheet
+// name, range reference, text and the conditional format style ID created by
+// the NewConditionalStyle function. The comparison is case-insensitive. For
+// example, highlight the cells in the range A1:A10 on Sheet1 which contain
+// the text "error":
+//
+//	format, err := f.NewConditionalStyle(&excelize.Style{
+//	    Font: &excelize.Font{Color: "9A0511"},
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetConditionalFormatTextContains("Sheet1", "A1:A10", "error", format)
+//
+// 根据给定的工作表名称、单元格区域、文本和条件格式样式 ID，为包含指定文本的单元格设置条件格式。
+func (f *File) SetConditionalFormatTextContains(sheet, rangeRef, text string, styleID int) error {
+	return f.setConditionalFormatText(sheet, rangeRef, "containsText", text, styleID)
+}
+
+// SetConditionalFormatTextNotContains provides a function to set the
+// conditional format rule for the cells which don't contain the given text by
+// given worksheet name, range reference, text and the conditional format
+// style ID created by the NewConditionalStyle function. The comparison is
+// case-insensitive. For example, highlight the cells in the range A1:A10 on
+// Sheet1 which don't contain the text "done":
+//
+//	err := f.SetConditionalFormatTextNotContains("Sheet1", "A1:A10", "done", format)
+//
+// 根据给定的工作表名称、单元格区域、文本和条件格式样式 ID，为不包含指定文本的单元格设置条件格式。
+func (f *File) SetConditionalFormatTextNotContains(sheet, rangeRef, text string, styleID int) error {
+	return f.setConditionalFormatText(sheet, rangeRef, "notContains", text, styleID)
+}
+
+// SetConditionalFormatBeginsWith provides a function to set the conditional
+// format rule for the cells which begin with the given text by given
+// worksheet name, range reference, prefix text and the conditional format
+// style ID created by the NewConditionalStyle function. For example,
+// highlight the cells in the range A1:A10 on Sheet1 which begin with the text
+// "ID-":
+//
+//	err := f.SetConditionalFormatBeginsWith("Sheet1", "A1:A10", "ID-", format)
+//
+// 根据给定的工作表名称、单元格区域、前缀文本和条件格式样式 ID，为以指定文本开头的单元格设置条件格式。
+func (f *File) SetConditionalFormatBeginsWith(sheet, rangeRef, prefix string, styleID int) error {
+	return f.setConditionalFormatText(sheet, rangeRef, "beginsWith", prefix, styleID)
+}
+
+// SetConditionalFormatEndsWith provides a function to set the conditional
+// format rule for the cells which end with the given text by given worksheet
+// name, range reference, suffix text and the conditional format style ID
+// created by the NewConditionalStyle function. For example, highlight the
+// cells in the range A1:A10 on Sheet1 which end with the text ".xlsx":
+//
+//	err := f.SetConditionalFormatEndsWith("Sheet1", "A1:A10", ".xlsx", format)
+//
+// 根据给定的工作表名称、单元格区域、后缀文本和条件格式样式 ID，为以指定文本结尾的单元格设置条件格式。
+func (f *File) SetConditionalFormatEndsWith(sheet, rangeRef, suffix string, styleID int) error {
+	return f.setConditionalFormatText(sheet, rangeRef, "endsWith", suffix, styleID)
+}
+
+// defaultBarNegativeColor defined the default fill color of the negative
+// bars of the data bar conditional format.
+const defaultBarNegativeColor = "FFFF0000"
+
+// condFmtTextRules defined the rule type and the function to build the rule
+// formula by the top-left cell of the range and the quoted text for each
+// operator of the specific text conditional format.
+var condFmtTextRules = map[string]struct {
+	ruleType string
+	formula  func(cell, text string) string
+}{
+	"containsText": {"containsText", func(cell, text string) string {
+		return fmt.Sprintf("NOT(ISERROR(SEARCH(%s,%s)))", text, cell)
+	}},
+	"notContains": {"notContainsText", func(cell, text string) string {
+		return fmt.Sprintf("ISERROR(SEARCH(%s,%s))", text, cell)
+	}},
+	"beginsWith": {"beginsWith", func(cell, text string) string {
+		return fmt.Sprintf("LEFT(%s,LEN(%s))=%s", cell, text, text)
+	}},
+	"endsWith": {"endsWith", func(cell, text string) string {
+		return fmt.Sprintf("RIGHT(%s,LEN(%s))=%s", cell, text, text)
+	}},
+}
+
+// getCondFmtTopLeftCell provides a function to get the top-left cell of the
+// first range by given range reference sequence of the conditional format.
+func getCondFmtTopLeftCell(rangeRef string) (string, error) {
+	refs := strings.Fields(rangeRef)
+	if len(refs) == 0 {
+		return "", ErrParameterInvalid
+	}
+	cell := strings.ReplaceAll(strings.Split(refs[0], ":")[0], "$", "")
+	_, _, err := CellNameToCoordinates(cell)
+	return cell, err
+}
+
+// setConditionalFormatText provides a function to set the text conditional
+// format rule by given worksheet name, range reference, operator, text and
+// conditional format style ID.
+func (f *File) setConditionalFormatText(sheet, rangeRef, operator, text string, styleID int) error {
+	cell, err := getCondFmtTopLeftCell(rangeRef)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if s.Dxfs == nil || styleID < 0 || styleID >= len(s.Dxfs.Dxfs) {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	s.mu.Unlock()
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var rules int
+	for _, cf := range ws.ConditionalFormatting {
+		rules += len(cf.CfRule)
+	}
+	rule, _ := drawCondFmtText(rules, operator, cell, &ConditionalFormatOptions{Format: styleID, Value: text})
+	ws.ConditionalFormatting = append(ws.ConditionalFormatting, &xlsxConditionalFormatting{
+		SQRef:  rangeRef,
+		CfRule: []*xlsxCfRule{rule},
+	})
+	return err
+}
+
 // drawCondFmtCellIs provides a function to create conditional formatting rule
 // for cell value (include between, not between, equal, not equal, greater
 // than and less than) by given priority, criteria type and format settings.
@@ -2657,7 +3553,7 @@ func drawCondFmtColorScale(p int, ct, GUID string, format *ConditionalFormatOpti
 func drawCondFmtDataBar(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	var x14CfRule *xlsxX14CfRule
 	var extLst *xlsxExtLst
-	if format.BarSolid || format.BarDirection == "leftToRight" || format.BarDirection == "rightToLeft" || format.BarBorderColor != "" {
+	if format.BarSolid || format.BarDirection == "leftToRight" || format.BarDirection == "rightToLeft" || format.BarBorderColor != "" || format.BarNegativeColor != "" {
 		extLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><x14:id>%s</x14:id></ext>`, ExtURIConditionalFormattingRuleID, NameSpaceSpreadSheetX14.Value, GUID)}
 		x14CfRule = &xlsxX14CfRule{
 			Type: validType[format.Type],
@@ -2668,13 +3564,16 @@ func drawCondFmtDataBar(p int, ct, GUID string, format *ConditionalFormatOptions
 				Gradient:          !format.BarSolid,
 				Direction:         format.BarDirection,
 				Cfvo:              []*xlsxCfvo{{Type: "autoMin"}, {Type: "autoMax"}},
-				NegativeFillColor: &xlsxColor{RGB: "FFFF0000"},
+				NegativeFillColor: &xlsxColor{RGB: defaultBarNegativeColor},
 				AxisColor:         &xlsxColor{RGB: "FFFF0000"},
 			},
 		}
 		if x14CfRule.DataBar.Border {
 			x14CfRule.DataBar.BorderColor = &xlsxColor{RGB: getPaletteColor(format.BarBorderColor)}
 		}
+		if format.BarNegativeColor != "" {
+			x14CfRule.DataBar.NegativeFillColor.RGB = getPaletteColor(format.BarNegativeColor)
+		}
 	}
 	return &xlsxCfRule{
 		Priority:   p + 1,
@@ -2701,56 +3600,83 @@ func drawCondFmtExp(p int, ct, GUID string, format *ConditionalFormatOptions) (*
 	}, nil
 }
 
+// getIconSetCfvo provides a function to get the default thresholds of the
+// icons by given icon set name, the thresholds are the equal percent
+// intervals of the icons. It returns nil if the icon set is not supported.
+func getIconSetCfvo(iconSet string) []*xlsxCfvo {
+	icons := map[string]int{
+		"3Arrows":         3,
+		"3ArrowsGray":     3,
+		"3Flags":          3,
+		"3Signs":          3,
+		"3Symbols":        3,
+		"3Symbols2":       3,
+		"3TrafficLights1": 3,
+		"3TrafficLights2": 3,
+		"4Arrows":         4,
+		"4ArrowsGray":     4,
+		"4Rating":         4,
+		"4RedToBlack":     4,
+		"4TrafficLights":  4,
+		"5Arrows":         5,
+		"5ArrowsGray":     5,
+		"5Quarters":       5,
+		"5Rating":         5,
+	}[iconSet]
+	var cfvo []*xlsxCfvo
+	for i := 0; i < icons; i++ {
+		cfvo = append(cfvo, &xlsxCfvo{Type: "percent", Val: strconv.Itoa(int(math.Round(float64(i) * 100 / float64(icons))))})
+	}
+	return cfvo
+}
+
 // drawCondFmtIconSet provides a function to create conditional formatting rule
 // for icon set by given priority, criteria type and format settings.
 func drawCondFmtIconSet(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
-	cfvo3 := &xlsxCfRule{IconSet: &xlsxIconSet{Cfvo: []*xlsxCfvo{
-		{Type: "percent", Val: "0"},
-		{Type: "percent", Val: "33"},
-		{Type: "percent", Val: "67"},
-	}}}
-	cfvo4 := &xlsxCfRule{IconSet: &xlsxIconSet{Cfvo: []*xlsxCfvo{
-		{Type: "percent", Val: "0"},
-		{Type: "percent", Val: "25"},
-		{Type: "percent", Val: "50"},
-		{Type: "percent", Val: "75"},
-	}}}
-	cfvo5 := &xlsxCfRule{IconSet: &xlsxIconSet{Cfvo: []*xlsxCfvo{
-		{Type: "percent", Val: "0"},
-		{Type: "percent", Val: "20"},
-		{Type: "percent", Val: "40"},
-		{Type: "percent", Val: "60"},
-		{Type: "percent", Val: "80"},
-	}}}
-	presets := map[string]*xlsxCfRule{
-		"3Arrows":         cfvo3,
-		"3ArrowsGray":     cfvo3,
-		"3Flags":          cfvo3,
-		"3Signs":          cfvo3,
-		"3Symbols":        cfvo3,
-		"3Symbols2":       cfvo3,
-		"3TrafficLights1": cfvo3,
-		"3TrafficLights2": cfvo3,
-		"4Arrows":         cfvo4,
-		"4ArrowsGray":     cfvo4,
-		"4Rating":         cfvo4,
-		"4RedToBlack":     cfvo4,
-		"4TrafficLights":  cfvo4,
-		"5Arrows":         cfvo5,
-		"5ArrowsGray":     cfvo5,
-		"5Quarters":       cfvo5,
-		"5Rating":         cfvo5,
-	}
-	cfRule, ok := presets[format.IconStyle]
+	cfvo := getIconSetCfvo(format.IconStyle)
+	if cfvo == nil {
+		return nil, nil
+	}
+	if len(format.IconThresholds) > 0 {
+		if len(format.IconThresholds) != len(cfvo) {
+			return nil, nil
+		}
+		for i, threshold := range format.IconThresholds {
+			if inStrSlice([]string{"num", "percent", "percentile", "formula"}, threshold.Type, true) == -1 {
+				return nil, nil
+			}
+			cfvo[i] = &xlsxCfvo{Type: threshold.Type, Val: threshold.Value}
+		}
+	}
+	return &xlsxCfRule{
+		Priority: p + 1,
+		Type:     validType[format.Type],
+		IconSet: &xlsxIconSet{
+			Cfvo:      cfvo,
+			IconSet:   format.IconStyle,
+			Reverse:   format.ReverseIcons,
+			ShowValue: boolPtr(!format.IconsOnly),
+		},
+	}, nil
+}
+
+// drawCondFmtText provides a function to create conditional formatting rule
+// for the specific text by given priority, criteria type, the top-left cell
+// of the range and format settings.
+func drawCondFmtText(p int, ct, cell string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	rule, ok := condFmtTextRules[ct]
 	if !ok {
 		return nil, nil
 	}
-	cfRule.Priority = p + 1
-	cfRule.IconSet.IconSet = format.IconStyle
-	cfRule.IconSet.Reverse = format.ReverseIcons
-	cfRule.IconSet.ShowValue = boolPtr(!format.IconsOnly)
-	cfRule.Type = validType[format.Type]
-	return cfRule, nil
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       rule.ruleType,
+		DxfID:      intPtr(format.Format),
+		Operator:   ct,
+		Text:       format.Value,
+		Formula:    []string{rule.formula(cell, "\""+strings.ReplaceAll(format.Value, "\"", "\"\"")+"\"")},
+	}, nil
 }
 
 // getPaletteColor provides a function to convert the RBG color by given
@@ -2773,6 +3699,27 @@ func (f *File) themeReader() (*xlsxTheme, error) {
 	return &theme, nil
 }
 
+// GetThemeColor provides a function to get the color in ARGB hexadecimal
+// notation by given theme color index and tint value. The theme color index
+// is the position of the color in the color scheme of the workbook theme:
+// light 1, dark 1, light 2, dark 2, accent 1 to accent 6, hyperlink and
+// followed hyperlink. The tint value should be between -1 (darkest) and 1
+// (lightest). For example, get the color of the accent 1 with 40% lighter:
+//
+//	color, err := f.GetThemeColor(4, 0.4)
+//
+// 根据给定的主题颜色索引和色调值获取 ARGB 十六进制表示的颜色。
+func (f *File) GetThemeColor(theme int, tint float64) (string, error) {
+	if tint < -1 || tint > 1 {
+		return "", ErrColorTint
+	}
+	baseColor := f.getThemeColorRGB(theme)
+	if len(baseColor) != 6 {
+		return "", ErrThemeColor
+	}
+	return ThemeColor(strings.ToUpper(baseColor), tint), nil
+}
+
 // ThemeColor applied the color with tint value.
 func ThemeColor(baseColor string, tint float64) string {
 	if tint == 0 {
@@ -2793,3 +3740,262 @@ func ThemeColor(baseColor string, tint float64) string {
 	br, bg, bb := HSLToRGB(h, s, l)
 	return fmt.Sprintf("FF%02X%02X%02X", br, bg, bb)
 }
+
+// colors returns the pointers of the colors in the theme color scheme in the
+// order of dark 1, light 1, dark 2, light 2, accent 1 to accent 6, hyperlink
+// and followed hyperlink.
+func (c *ThemeColorScheme) colors() []*string {
+	return []*string{
+		&c.Dark1, &c.Light1, &c.Dark2, &c.Light2, &c.Accent1, &c.Accent2, &c.Accent3,
+		&c.Accent4, &c.Accent5, &c.Accent6, &c.Hyperlink, &c.FollowedHyperlink,
+	}
+}
+
+// colors returns the pointers of the colors in the color scheme in the same
+// order with the theme color scheme.
+func (c *xlsxColorScheme) colors() []*xlsxCTColor {
+	return []*xlsxCTColor{
+		&c.Dk1, &c.Lt1, &c.Dk2, &c.Lt2, &c.Accent1, &c.Accent2, &c.Accent3,
+		&c.Accent4, &c.Accent5, &c.Accent6, &c.Hlink, &c.FolHlink,
+	}
+}
+
+// getThemeColorValue returns the color in RRGGBB hexadecimal notation of the
+// theme color, the system color will be represented by its last computed
+// color value.
+func getThemeColorValue(clr *xlsxCTColor) string {
+	if clr.SrgbClr != nil && clr.SrgbClr.Val != nil {
+		return strings.ToUpper(*clr.SrgbClr.Val)
+	}
+	if clr.SysClr != nil {
+		return strings.ToUpper(clr.SysClr.LastClr)
+	}
+	return ""
+}
+
+// getThemeFont returns the theme font by given font collection.
+func getThemeFont(fc *xlsxFontCollection) ThemeFont {
+	var font ThemeFont
+	if fc.Latin != nil {
+		font.Latin = fc.Latin.Typeface
+	}
+	if fc.Ea != nil {
+		font.EastAsian = fc.Ea.Typeface
+	}
+	if fc.Cs != nil {
+		font.ComplexScript = fc.Cs.Typeface
+	}
+	for _, f := range fc.Font {
+		font.Scripts = append(font.Scripts, ThemeScriptFont{Script: f.Script, Typeface: f.Typeface})
+	}
+	return font
+}
+
+// setThemeFont provides a function to update the font collection by given
+// theme font, the empty fields will be ignored.
+func setThemeFont(fc *xlsxFontCollection, font ThemeFont) {
+	for _, typeface := range []struct {
+		font **xlsxCTTextFont
+		name string
+	}{
+		{&fc.Latin, font.Latin}, {&fc.Ea, font.EastAsian}, {&fc.Cs, font.ComplexScript},
+	} {
+		if typeface.name != "" && (*typeface.font == nil || (*typeface.font).Typeface != typeface.name) {
+			*typeface.font = &xlsxCTTextFont{Typeface: typeface.name}
+		}
+	}
+	if len(font.Scripts) > 0 {
+		fc.Font = nil
+		for _, f := range font.Scripts {
+			fc.Font = append(fc.Font, xlsxCTSupplementalFont{Script: f.Script, Typeface: f.Typeface})
+		}
+	}
+}
+
+// GetWorkbookTheme provides a function to get the color scheme, font scheme
+// and format scheme of the workbook theme. The colors are represented in
+// 'RRGGBB' hexadecimal notation, and the system colors are represented by
+// their last computed color values. This function returns nil theme if the
+// workbook doesn't contain the theme part. For example, get the accent 1
+// color of the workbook theme:
+//
+//	theme, err := f.GetWorkbookTheme()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(theme.ColorScheme.Accent1)
+//
+// 获取工作簿主题的颜色、字体和格式方案。
+func (f *File) GetWorkbookTheme() (*Theme, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Theme == nil {
+		return nil, nil
+	}
+	elements := &f.Theme.ThemeElements
+	theme := Theme{
+		Name: f.Theme.Name,
+		ColorScheme: ThemeColorScheme{
+			Name: elements.ClrScheme.Name,
+		},
+		FontScheme: ThemeFontScheme{
+			Name:  elements.FontScheme.Name,
+			Major: getThemeFont(&elements.FontScheme.MajorFont),
+			Minor: getThemeFont(&elements.FontScheme.MinorFont),
+		},
+		FormatScheme: ThemeFormatScheme{
+			Name:                 elements.FmtScheme.Name,
+			FillStyles:           elements.FmtScheme.FillStyleLst.FillStyleLst,
+			LineStyles:           elements.FmtScheme.LnStyleLst.LnStyleLst,
+			EffectStyles:         elements.FmtScheme.EffectStyleLst.EffectStyleLst,
+			BackgroundFillStyles: elements.FmtScheme.BgFillStyleLst.BgFillStyleLst,
+		},
+	}
+	colors := theme.ColorScheme.colors()
+	for i, clr := range elements.ClrScheme.colors() {
+		*colors[i] = getThemeColorValue(clr)
+	}
+	return &theme, nil
+}
+
+// prepareTheme provides a function to get the workbook theme, and add the
+// default theme part if the workbook doesn't contain it.
+func (f *File) prepareTheme() (*xlsxTheme, error) {
+	if f.Theme != nil {
+		return f.Theme, nil
+	}
+	theme := xlsxTheme{XMLNSa: NameSpaceDrawingML.Value, XMLNSr: SourceRelationship.Value}
+	_ = xml.Unmarshal([]byte(templateTheme), &theme)
+	if err := f.addContentTypePart(0, "theme"); err != nil {
+		return nil, err
+	}
+	relPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return nil, err
+	}
+	var exist bool
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			exist = exist || rel.Type == SourceRelationshipTheme
+		}
+	}
+	if !exist {
+		f.addRels(relPath, SourceRelationshipTheme, strings.TrimPrefix(defaultXMLPathTheme, "xl/"), "")
+	}
+	f.Theme = &theme
+	return f.Theme, nil
+}
+
+// SetWorkbookTheme provides a function to set the color scheme, font scheme
+// and format scheme of the workbook theme, the empty fields will keep the
+// existing settings. The colors should be in 'RRGGBB' hexadecimal notation,
+// and the format scheme should be the DrawingML XML fragments of the style
+// lists. The default theme part will be added if the workbook doesn't contain
+// it. Note that the cells, shapes and charts which using theme colors or
+// theme fonts will be displayed with the new settings. For example, change
+// the accent 1 color and the heading font of the workbook theme:
+//
+//	err := f.SetWorkbookTheme(&excelize.Theme{
+//	    ColorScheme: excelize.ThemeColorScheme{Accent1: "1F4E79"},
+//	    FontScheme: excelize.ThemeFontScheme{
+//	        Major: excelize.ThemeFont{Latin: "Georgia"},
+//	    },
+//	})
+//
+// 设置工作簿主题的颜色、字体和格式方案。
+func (f *File) SetWorkbookTheme(t *Theme) error {
+	if t == nil {
+		return ErrParameterRequired
+	}
+	colorScheme := t.ColorScheme
+	colors := colorScheme.colors()
+	for _, clr := range colors {
+		if *clr == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(*clr, 16, 32); err != nil || len(*clr) != 6 {
+			return newInvalidThemeColorError(*clr)
+		}
+	}
+	for _, lst := range []string{
+		t.FormatScheme.FillStyles, t.FormatScheme.LineStyles,
+		t.FormatScheme.EffectStyles, t.FormatScheme.BackgroundFillStyles,
+	} {
+		if err := xml.Unmarshal([]byte("<lst>"+lst+"</lst>"), &struct{}{}); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	theme, err := f.prepareTheme()
+	if err != nil {
+		return err
+	}
+	elements := &theme.ThemeElements
+	for _, name := range []struct {
+		field *string
+		value string
+	}{
+		{&theme.Name, t.Name},
+		{&elements.ClrScheme.Name, colorScheme.Name},
+		{&elements.FontScheme.Name, t.FontScheme.Name},
+		{&elements.FmtScheme.Name, t.FormatScheme.Name},
+		{&elements.FmtScheme.FillStyleLst.FillStyleLst, t.FormatScheme.FillStyles},
+		{&elements.FmtScheme.LnStyleLst.LnStyleLst, t.FormatScheme.LineStyles},
+		{&elements.FmtScheme.EffectStyleLst.EffectStyleLst, t.FormatScheme.EffectStyles},
+		{&elements.FmtScheme.BgFillStyleLst.BgFillStyleLst, t.FormatScheme.BackgroundFillStyles},
+	} {
+		if name.value != "" {
+			*name.field = name.value
+		}
+	}
+	for i, clr := range elements.ClrScheme.colors() {
+		if val := strings.ToUpper(*colors[i]); val != "" && val != getThemeColorValue(clr) {
+			*clr = xlsxCTColor{SrgbClr: &attrValString{Val: stringPtr(val)}}
+		}
+	}
+	setThemeFont(&elements.FontScheme.MajorFont, t.FontScheme.Major)
+	setThemeFont(&elements.FontScheme.MinorFont, t.FontScheme.Minor)
+	return nil
+}
+
+// ApplyBuiltinTheme provides a function to apply the color scheme and font
+// scheme of the built-in Office theme to the workbook by given theme name,
+// the format scheme of the default theme will be used. The theme name is
+// case-insensitive, and the supported names are:
+//
+//	Office
+//	Office 2007 - 2010
+//	Office 2013 - 2022
+//	Grayscale
+//
+// For example, apply the Office 2007 - 2010 theme to the workbook:
+//
+//	err := f.ApplyBuiltinTheme("Office 2007 - 2010")
+//
+// 根据给定的主题名称为工作簿应用内置主题。
+func (f *File) ApplyBuiltinTheme(name string) error {
+	for _, builtIn := range builtInThemes {
+		if !strings.EqualFold(builtIn.ColorScheme.Name, name) {
+			continue
+		}
+		var defaultTheme xlsxTheme
+		_ = xml.Unmarshal([]byte(templateTheme), &defaultTheme)
+		theme := builtIn
+		fmtScheme := defaultTheme.ThemeElements.FmtScheme
+		theme.FormatScheme = ThemeFormatScheme{
+			Name:                 fmtScheme.Name,
+			FillStyles:           fmtScheme.FillStyleLst.FillStyleLst,
+			LineStyles:           fmtScheme.LnStyleLst.LnStyleLst,
+			EffectStyles:         fmtScheme.EffectStyleLst.EffectStyleLst,
+			BackgroundFillStyles: fmtScheme.BgFillStyleLst.BgFillStyleLst,
+		}
+		fontScheme := defaultTheme.ThemeElements.FontScheme
+		theme.FontScheme.Major.Scripts = getThemeFont(&fontScheme.MajorFont).Scripts
+		theme.FontScheme.Minor.Scripts = getThemeFont(&fontScheme.MinorFont).Scripts
+		return f.SetWorkbookTheme(&theme)
+	}
+	return newUnsupportedThemeError(name)
+}
@@ -192,6 +192,20 @@ func TestSetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", condFmts), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
 	// Test creating a conditional format with invalid icon set style
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "unknown"}}), ErrParameterInvalid.Error())
+	// Test creating a conditional format with the number of the icon thresholds mismatch
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3Arrows", IconThresholds: []ConditionalFormatThreshold{{Type: "num", Value: "0"}}}}))
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3Arrows", IconThresholds: []ConditionalFormatThreshold{
+		{Type: "num", Value: "0"}, {Type: "min", Value: "1"}, {Type: "num", Value: "2"},
+	}}}))
+	// Test creating a text conditional format with invalid criteria and range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "text", Criteria: ">", Value: "error"}}))
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A:A", []ConditionalFormatOptions{{Type: "text", Criteria: "containing", Value: "error"}}))
+	// Test creating a text conditional format
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "$B$2:$B$10", []ConditionalFormatOptions{{Type: "text", Criteria: "begins with", Value: "ID-"}}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []*xlsxCfRule{{Type: "beginsWith", DxfID: intPtr(0), Priority: 1, Operator: "beginsWith", Text: "ID-", Formula: []string{`LEFT(B2,LEN("ID-"))="ID-"`}}}, ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule)
 }
 
 func TestGetConditionalFormats(t *testing.T) {
@@ -209,6 +223,14 @@ func TestGetConditionalFormats(t *testing.T) {
 		{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarDirection: "rightToLeft", BarOnly: true, BarSolid: true, StopIfTrue: true}},
 		{{Type: "formula", Format: 1, Criteria: "="}},
 		{{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true, IconsOnly: true}},
+		{{Type: "icon_set", IconStyle: "5Rating", IconThresholds: []ConditionalFormatThreshold{
+			{Type: "num", Value: "0"}, {Type: "num", Value: "10"}, {Type: "percentile", Value: "50"}, {Type: "percent", Value: "70"}, {Type: "formula", Value: "$B$1"},
+		}}},
+		{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarNegativeColor: "#00B050"}},
+		{{Type: "text", Format: 1, Criteria: "containing", Value: "error"}},
+		{{Type: "text", Format: 1, Criteria: "not containing", Value: "done"}},
+		{{Type: "text", Format: 1, Criteria: "begins with", Value: "ID-"}},
+		{{Type: "text", Format: 1, Criteria: "ends with", Value: "\".xlsx\""}},
 	} {
 		f := NewFile()
 		err := f.SetConditionalFormat("Sheet1", "A1:A2", format)
@@ -242,6 +264,39 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnsetConditionalFormat.xlsx")))
 }
 
+func TestSetConditionalFormatText(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}, Fill: Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormatTextContains("Sheet1", "$B$2:$B$10", "error", format))
+	assert.NoError(t, f.SetConditionalFormatTextNotContains("Sheet1", "C3:C10 E3:E10", `"done"`, format))
+	assert.NoError(t, f.SetConditionalFormatBeginsWith("Sheet1", "D1:D10", "ID-", format))
+	assert.NoError(t, f.SetConditionalFormatEndsWith("Sheet1", "F$5:F$10", ".xlsx", format))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []*xlsxConditionalFormatting{
+		{SQRef: "$B$2:$B$10", CfRule: []*xlsxCfRule{{Type: "containsText", DxfID: intPtr(format), Priority: 1, Operator: "containsText", Text: "error", Formula: []string{`NOT(ISERROR(SEARCH("error",B2)))`}}}},
+		{SQRef: "C3:C10 E3:E10", CfRule: []*xlsxCfRule{{Type: "notContainsText", DxfID: intPtr(format), Priority: 2, Operator: "notContains", Text: `"done"`, Formula: []string{`ISERROR(SEARCH("""done""",C3))`}}}},
+		{SQRef: "D1:D10", CfRule: []*xlsxCfRule{{Type: "beginsWith", DxfID: intPtr(format), Priority: 3, Operator: "beginsWith", Text: "ID-", Formula: []string{`LEFT(D1,LEN("ID-"))="ID-"`}}}},
+		{SQRef: "F$5:F$10", CfRule: []*xlsxCfRule{{Type: "endsWith", DxfID: intPtr(format), Priority: 4, Operator: "endsWith", Text: ".xlsx", Formula: []string{`RIGHT(F5,LEN(".xlsx"))=".xlsx"`}}}},
+	}, ws.(*xlsxWorksheet).ConditionalFormatting)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatText.xlsx")))
+	// Test set text conditional format with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormatTextContains("Sheet1", "", "error", format))
+	assert.EqualError(t, f.SetConditionalFormatTextContains("Sheet1", "A:B", "error", format), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set text conditional format with invalid style ID
+	assert.EqualError(t, f.SetConditionalFormatTextNotContains("Sheet1", "A1", "error", format+1), newInvalidStyleID(format+1).Error())
+	assert.EqualError(t, f.SetConditionalFormatBeginsWith("Sheet1", "A1", "ID-", -1), newInvalidStyleID(-1).Error())
+	assert.EqualError(t, f.SetConditionalFormatEndsWith("Sheet1", "A1", ".xlsx", -1), newInvalidStyleID(-1).Error())
+	assert.EqualError(t, NewFile().SetConditionalFormatTextContains("Sheet1", "A1", "error", 0), newInvalidStyleID(0).Error())
+	// Test set text conditional format on not exists worksheet
+	assert.EqualError(t, f.SetConditionalFormatTextContains("SheetN", "A1", "error", format), "sheet SheetN does not exist")
+	// Test set text conditional format with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetConditionalFormatTextContains("Sheet1", "A1", "error", format), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestNewStyle(t *testing.T) {
 	f := NewFile()
 	for i := 0; i < 18; i++ {
@@ -353,6 +408,125 @@ func TestNewStyle(t *testing.T) {
 	assert.Equal(t, ErrCellStyles, err)
 }
 
+func TestBatchNewStyle(t *testing.T) {
+	f := NewFile()
+	bold := &Style{Font: &Font{Bold: true}}
+	styleIDs, err := f.BatchNewStyle([]*Style{
+		bold,
+		{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}},
+		nil,
+		{Font: &Font{Bold: true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 0, 1}, styleIDs)
+	styleID, err := f.NewStyle(bold)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, styles.CellXfs.Count)
+	// Test create styles with empty styles list
+	styleIDs, err = f.BatchNewStyle(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, styleIDs)
+	// Test create styles with invalid style, no style should be created
+	styleIDs, err = f.BatchNewStyle([]*Style{
+		{Border: []Border{{Type: "left", Style: 1}}},
+		{Font: &Font{Size: MaxFontSize + 1}},
+	})
+	assert.EqualError(t, err, ErrFontSize.Error())
+	assert.Nil(t, styleIDs)
+	assert.Equal(t, 3, styles.CellXfs.Count)
+	// Test create styles with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.BatchNewStyle([]*Style{bold})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGradientFill(t *testing.T) {
+	f := NewFile()
+	fill := Fill{Type: "gradient", GradientType: "path", GradientDegree: 45, GradientStops: []GradientStop{
+		{Position: 0, Color: "FFFFFF"}, {Position: 0.5, Color: "4472C4"}, {Position: 1, Color: "FFFFFF"},
+	}}
+	styleID, err := f.NewStyle(&Style{Fill: fill})
+	assert.NoError(t, err)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	gradient := styles.Fills.Fill[*styles.CellXfs.Xf[styleID].FillID].GradientFill
+	assert.Equal(t, &xlsxGradientFill{Degree: 45, Type: "path", Stop: []*xlsxGradientFillStop{
+		{Color: xlsxColor{RGB: "FFFFFFFF"}}, {Position: 0.5, Color: xlsxColor{RGB: "FF4472C4"}}, {Position: 1, Color: xlsxColor{RGB: "FFFFFFFF"}},
+	}}, gradient)
+	// Test the same gradient fill should reuse the style
+	sameID, err := f.NewStyle(&Style{Fill: fill})
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, sameID)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	// Test the gradient fill round-trip after save and reopen the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGradientFill.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGradientFill.xlsx"))
+	assert.NoError(t, err)
+	idx, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(idx)
+	assert.NoError(t, err)
+	assert.Equal(t, fill, style.Fill)
+	// Test create gradient fill with invalid gradient type and stop position
+	_, err = f.NewStyle(&Style{Fill: Fill{Type: "gradient", GradientType: "radial"}})
+	assert.EqualError(t, err, ErrGradientType.Error())
+	_, err = f.NewStyle(&Style{Fill: Fill{Type: "gradient", GradientStops: []GradientStop{{Position: 1.5}}}})
+	assert.EqualError(t, err, ErrGradientStopPosition.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestGetStyle(t *testing.T) {
+	f := NewFile()
+	exp := "0.00%"
+	expected := &Style{
+		Border: []Border{
+			{Type: "left", Color: "0000FF", Style: 3},
+			{Type: "bottom", Color: "FF0000", Style: 6},
+			{Type: "diagonalUp", Color: "00FF00", Style: 1},
+		},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"E0EBF5"}},
+		Font:         &Font{Bold: true, Italic: true, Underline: "double", Family: "Arial", Size: 12, Strike: true, Color: "777777"},
+		Alignment:    &Alignment{Horizontal: "center", Vertical: "top", WrapText: true, TextRotation: 45},
+		Protection:   &Protection{Hidden: true, Locked: false},
+		CustomNumFmt: &exp,
+	}
+	styleID, err := f.NewStyle(expected)
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	expected.DecimalPlaces = 0
+	assert.Equal(t, expected, style)
+	// Test get style with built-in number format and the gradient shading
+	styleID, err = f.NewStyle(&Style{NumFmt: 14, Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "4E71BE"}, Shading: 1}})
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{NumFmt: 14, Fill: Fill{Type: "gradient", GradientType: "linear", GradientDegree: 270, GradientStops: []GradientStop{
+		{Position: 0, Color: "FFFFFF"}, {Position: 1, Color: "4E71BE"},
+	}}}, style)
+	// Test get the default style
+	style, err = f.GetStyle(0)
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{}, style)
+	// Test get style with invalid style index
+	_, err = f.GetStyle(-1)
+	assert.EqualError(t, err, newInvalidStyleID(-1).Error())
+	_, err = f.GetStyle(styleID + 1)
+	assert.EqualError(t, err, newInvalidStyleID(styleID+1).Error())
+	// Test get style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetStyle(0)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestNewConditionalStyle(t *testing.T) {
 	f := NewFile()
 	// Test create conditional style with unsupported charset style sheet
@@ -389,6 +563,38 @@ func TestSetDefaultFont(t *testing.T) {
 	assert.EqualError(t, f.SetDefaultFont("Arial"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetWorkbookNamedStyles(t *testing.T) {
+	f := NewFile()
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	styles.CellStyles.CellStyle = append(styles.CellStyles.CellStyle,
+		&xlsxCellStyle{Name: "Good", XfID: 1, BuiltInID: intPtr(26), CustomBuiltIn: boolPtr(true)},
+		&xlsxCellStyle{Name: "Custom", XfID: 2, Hidden: boolPtr(true)},
+	)
+	builtInStyles, err := f.GetWorkbookBuiltInStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, []NamedStyle{
+		{Name: "Normal", BuiltInID: intPtr(0)},
+		{Name: "Good", XfID: 1, BuiltInID: intPtr(26), CustomBuiltIn: true},
+	}, builtInStyles)
+	customStyles, err := f.GetWorkbookCustomStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, []NamedStyle{{Name: "Custom", XfID: 2, Hidden: true}}, customStyles)
+	// Test get named styles without cell styles
+	styles.CellStyles = nil
+	builtInStyles, err = f.GetWorkbookBuiltInStyles()
+	assert.NoError(t, err)
+	assert.Empty(t, builtInStyles)
+	// Test get named styles with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookBuiltInStyles()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.GetWorkbookCustomStyles()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestStylesReader(t *testing.T) {
 	f := NewFile()
 	// Test read styles with unsupported charset
@@ -422,6 +628,206 @@ func TestSetCellStyle(t *testing.T) {
 	assert.EqualError(t, f.SetCellStyle("Sheet1", "A1", "A2", 1), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetCellHidden(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B1", style))
+	assert.NoError(t, f.SetCellHidden("Sheet1", "A1", true))
+	assert.NoError(t, f.SetCellHidden("Sheet1", "B1", true))
+	assert.NoError(t, f.SetCellHidden("Sheet1", "C1", true))
+	hidden, err := f.GetCellHidden("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, hidden)
+	// Test the cells with the same format share the cell format record
+	styleA1, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	styleB1, err := f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleA1, styleB1)
+	assert.NotEqual(t, style, styleA1)
+	// Test the font of the existing style was kept
+	xf, err := f.getCellXf("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *xf.FontID)
+	// Test unset formula hidden
+	assert.NoError(t, f.SetCellHidden("Sheet1", "A1", false))
+	hidden, err = f.GetCellHidden("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, hidden)
+	hidden, err = f.GetCellHidden("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.False(t, hidden)
+	// Test set and get cell hidden with invalid cell reference
+	assert.EqualError(t, f.SetCellHidden("Sheet1", "A", true), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	_, err = f.GetCellHidden("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set and get cell hidden on not exists worksheet
+	assert.EqualError(t, f.SetCellHidden("SheetN", "A1", true), "sheet SheetN does not exist")
+	_, err = f.GetCellHidden("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set cell hidden with exceeds cell styles limit
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.CellXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	assert.EqualError(t, f.SetCellHidden("Sheet1", "E1", true), ErrCellStyles.Error())
+	// Test set and get cell hidden with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellHidden("Sheet1", "A1", true), "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.GetCellHidden("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestDiagonalBorder(t *testing.T) {
+	f := NewFile()
+	borders := []Border{
+		{Type: "left", Color: "000000", Style: 2},
+		{Type: "right", Color: "000000", Style: 8},
+		{Type: "top", Color: "000000", Style: 10},
+		{Type: "bottom", Color: "000000", Style: 13},
+		{Type: "diagonalUp", Color: "FF0000", Style: 2},
+		{Type: "diagonalDown", Color: "FF0000", Style: 2},
+	}
+	styleID, err := f.NewStyle(&Style{Border: borders})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDiagonalBorder.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestDiagonalBorder.xlsx"))
+	assert.NoError(t, err)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	border := styles.Borders.Border[*styles.CellXfs.Xf[styleID].BorderID]
+	assert.True(t, border.DiagonalUp)
+	assert.True(t, border.DiagonalDown)
+	assert.Equal(t, "medium", border.Diagonal.Style)
+	for style, line := range map[string]xlsxLine{
+		"medium": border.Left, "mediumDashed": border.Right, "mediumDashDot": border.Top, "slantDashDot": border.Bottom,
+	} {
+		assert.Equal(t, style, line.Style)
+	}
+	idx, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(idx)
+	assert.NoError(t, err)
+	assert.Equal(t, borders, style.Border)
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellIndent(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}, Alignment: &Alignment{Horizontal: "right", WrapText: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B1", style))
+	assert.NoError(t, f.SetCellIndent("Sheet1", "A1", 2))
+	assert.NoError(t, f.SetCellIndent("Sheet1", "B1", 2))
+	// Test the cells with the same format share the cell format record
+	styleA1, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	styleB1, err := f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleA1, styleB1)
+	assert.NotEqual(t, style, styleA1)
+	// Test the other settings of the existing style was kept
+	xf, err := f.getCellXf("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *xf.FontID)
+	assert.Equal(t, &xlsxAlignment{Horizontal: "right", Indent: 2, WrapText: true}, xf.Alignment)
+	// Test set indent for the cell without style
+	assert.NoError(t, f.SetCellIndent("Sheet1", "C1", 1))
+	xf, err = f.getCellXf("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left", Indent: 1}, xf.Alignment)
+	assert.True(t, *xf.ApplyAlignment)
+	// Test remove the indent, the cell should use the original style
+	assert.NoError(t, f.SetCellIndent("Sheet1", "A1", 0))
+	xf, err = f.getCellXf("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxAlignment{Horizontal: "right", WrapText: true}, xf.Alignment)
+	styleA1, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleA1)
+	// Test set cell indent with invalid indent level
+	assert.EqualError(t, f.SetCellIndent("Sheet1", "A1", -1), ErrCellIndent.Error())
+	assert.EqualError(t, f.SetCellIndent("Sheet1", "A1", 251), ErrCellIndent.Error())
+	// Test set cell indent with invalid cell reference
+	assert.EqualError(t, f.SetCellIndent("Sheet1", "A", 1), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set cell indent on not exists worksheet
+	assert.EqualError(t, f.SetCellIndent("SheetN", "A1", 1), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCellStyleByAddress(t *testing.T) {
+	f := NewFile()
+	// Test get the effective style of the cell with default style
+	style, err := f.GetCellStyleByAddress("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{
+		Font:       &Font{Family: "Calibri", Size: 11, ColorTheme: intPtr(1)},
+		Fill:       Fill{Type: "pattern"},
+		Alignment:  &Alignment{},
+		Protection: &Protection{Locked: true},
+	}, style)
+	// Test get the effective style of the cell inherit from the column style
+	colStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "B", colStyle))
+	style, err = f.GetCellStyleByAddress("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, style.Fill)
+	assert.Equal(t, "Calibri", style.Font.Family)
+	// Test get the effective style of the cell inherit from the base cell style format
+	fontStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true, Family: "Arial", Size: 14}})
+	assert.NoError(t, err)
+	cellStyle, err := f.NewStyle(&Style{Border: []Border{{Type: "left", Color: "0000FF", Style: 1}}})
+	assert.NoError(t, err)
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, xlsxXf{
+		NumFmtID: intPtr(10), FontID: s.CellXfs.Xf[fontStyle].FontID,
+		Alignment: &xlsxAlignment{Horizontal: "center"}, Protection: &xlsxProtection{Locked: boolPtr(false)},
+	})
+	s.CellXfs.Xf[cellStyle].XfID = intPtr(len(s.CellStyleXfs.Xf) - 1)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C3", "C3", cellStyle))
+	style, err = f.GetCellStyleByAddress("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, &Style{
+		Border:     []Border{{Type: "left", Color: "0000FF", Style: 1}},
+		Fill:       Fill{Type: "pattern"},
+		Font:       &Font{Bold: true, Family: "Arial", Size: 14},
+		Alignment:  &Alignment{Horizontal: "center"},
+		Protection: &Protection{Locked: false},
+		NumFmt:     10,
+	}, style)
+	// Test get the effective style of the cell with alignment which not specified applied or not
+	s.CellXfs.Xf[cellStyle].Alignment, s.CellXfs.Xf[cellStyle].ApplyAlignment = &xlsxAlignment{Vertical: "top"}, nil
+	style, err = f.GetCellStyleByAddress("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, &Alignment{Vertical: "top"}, style.Alignment)
+	// Test get the effective style of the cell with invalid parameters
+	_, err = f.GetCellStyleByAddress("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellStyleByAddress("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get the effective style of the cell without fonts
+	s.Fonts = nil
+	style, err = f.GetCellStyleByAddress("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{}, style.Font)
+	assert.NoError(t, f.Close())
+	// Test get the effective style of the cell with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetCellStyleByAddress("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetStyleID(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.getStyleID(&xlsxStyleSheet{}, nil)
@@ -458,6 +864,63 @@ func TestThemeColor(t *testing.T) {
 	}
 }
 
+func TestGetThemeColor(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		theme    int
+		tint     float64
+		expected string
+	}{
+		{theme: 4, expected: "FF5B9BD5"},
+		{theme: 4, tint: 0.6, expected: "FFBDD7EE"},
+		{theme: 1, expected: "FF000000"},
+		{theme: 0, tint: -0.5, expected: "FF808080"},
+	} {
+		color, err := f.GetThemeColor(c.theme, c.tint)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, color)
+	}
+	// Test get theme color with invalid theme color index and tint value
+	_, err := f.GetThemeColor(12, 0)
+	assert.EqualError(t, err, ErrThemeColor.Error())
+	_, err = f.GetThemeColor(4, 1.5)
+	assert.EqualError(t, err, ErrColorTint.Error())
+	// Test get theme color without theme
+	f.Theme = nil
+	_, err = f.GetThemeColor(4, 0)
+	assert.EqualError(t, err, ErrThemeColor.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestStyleThemeColor(t *testing.T) {
+	f := NewFile()
+	expected := &Style{
+		Border: []Border{{Type: "left", ColorTheme: intPtr(5), ColorTint: -0.25, Style: 2}},
+		Fill:   Fill{Type: "pattern", Pattern: 1, ColorTheme: intPtr(4), ColorTint: 0.4},
+		Font:   &Font{Family: "Calibri", Size: 11, ColorTheme: intPtr(9), ColorTint: 0.2},
+	}
+	styleID, err := f.NewStyle(expected)
+	assert.NoError(t, err)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	xf := styles.CellXfs.Xf[styleID]
+	assert.Equal(t, &xlsxColor{Theme: intPtr(4), Tint: 0.4}, styles.Fills.Fill[*xf.FillID].PatternFill.FgColor)
+	assert.Equal(t, &xlsxColor{Theme: intPtr(5), Tint: -0.25}, styles.Borders.Border[*xf.BorderID].Left.Color)
+	// Test the same theme color style should reuse the style
+	sameID, err := f.NewStyle(expected)
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, sameID)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	expected.DecimalPlaces = 0
+	assert.Equal(t, expected, style)
+	// Test create the conditional format style with theme color fill
+	dxfID, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, ColorTheme: intPtr(6)}})
+	assert.NoError(t, err)
+	assert.Contains(t, styles.Dxfs.Dxfs[dxfID].Dxf, `<bgColor theme="6"></bgColor>`)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetNumFmtID(t *testing.T) {
 	f := NewFile()
 
@@ -472,3 +935,135 @@ func TestGetNumFmtID(t *testing.T) {
 	assert.NotEqual(t, id1, id2)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleNumFmt.xlsx")))
 }
+
+func TestWorkbookTheme(t *testing.T) {
+	f := NewFile()
+	theme, err := f.GetWorkbookTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Office Theme", theme.Name)
+	assert.Equal(t, ThemeColorScheme{
+		Name: "Office", Dark1: "000000", Light1: "FFFFFF", Dark2: "44546A", Light2: "E7E6E6",
+		Accent1: "5B9BD5", Accent2: "ED7D31", Accent3: "A5A5A5", Accent4: "FFC000", Accent5: "4472C4", Accent6: "70AD47",
+		Hyperlink: "0563C1", FollowedHyperlink: "954F72",
+	}, theme.ColorScheme)
+	assert.Equal(t, "Calibri Light", theme.FontScheme.Major.Latin)
+	assert.Equal(t, "Calibri", theme.FontScheme.Minor.Latin)
+	assert.Contains(t, theme.FontScheme.Minor.Scripts, ThemeScriptFont{Script: "Jpan", Typeface: "游ゴシック"})
+	assert.True(t, strings.HasPrefix(theme.FormatScheme.FillStyles, "<a:solidFill>"))
+
+	// Test set the workbook theme
+	assert.NoError(t, f.SetWorkbookTheme(&Theme{
+		Name:        "Custom Theme",
+		ColorScheme: ThemeColorScheme{Dark1: "000000", Accent1: "1f4e79"},
+		FontScheme: ThemeFontScheme{
+			Major: ThemeFont{Latin: "Georgia"},
+			Minor: ThemeFont{Scripts: []ThemeScriptFont{{Script: "Hans", Typeface: "等线"}}},
+		},
+		FormatScheme: ThemeFormatScheme{BackgroundFillStyles: "<a:solidFill><a:schemeClr val=\"phClr\"/></a:solidFill>"},
+	}))
+	// Test the system color will be kept if the color doesn't change
+	assert.NotNil(t, f.Theme.ThemeElements.ClrScheme.Dk1.SysClr)
+	color, err := f.GetThemeColor(4, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "FF1F4E79", color)
+	path := filepath.Join("test", "TestWorkbookTheme.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	theme, err = f.GetWorkbookTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Custom Theme", theme.Name)
+	assert.Equal(t, "1F4E79", theme.ColorScheme.Accent1)
+	assert.Equal(t, "ED7D31", theme.ColorScheme.Accent2)
+	assert.Equal(t, "Georgia", theme.FontScheme.Major.Latin)
+	assert.Equal(t, "Calibri", theme.FontScheme.Minor.Latin)
+	assert.Equal(t, []ThemeScriptFont{{Script: "Hans", Typeface: "等线"}}, theme.FontScheme.Minor.Scripts)
+	assert.Equal(t, "<a:solidFill><a:schemeClr val=\"phClr\"/></a:solidFill>", theme.FormatScheme.BackgroundFillStyles)
+
+	// Test set the workbook theme with invalid parameters
+	assert.EqualError(t, f.SetWorkbookTheme(nil), ErrParameterRequired.Error())
+	for _, color := range []string{"F00", "GGGGGG", "#FF0000"} {
+		assert.EqualError(t, f.SetWorkbookTheme(&Theme{ColorScheme: ThemeColorScheme{Accent1: color}}), newInvalidThemeColorError(color).Error())
+	}
+	assert.Error(t, f.SetWorkbookTheme(&Theme{FormatScheme: ThemeFormatScheme{FillStyles: "<a:solidFill>"}}))
+	assert.NoError(t, f.Close())
+
+	// Test get and set the workbook theme without theme part
+	f = NewFile()
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	f.Relationships.Store(f.getWorkbookRelsPath(), &xlsxRelationships{})
+	theme, err = f.GetWorkbookTheme()
+	assert.NoError(t, err)
+	assert.Nil(t, theme)
+	assert.NoError(t, f.SetWorkbookTheme(&Theme{ColorScheme: ThemeColorScheme{Accent1: "FF0000"}}))
+	theme, err = f.GetWorkbookTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "FF0000", theme.ColorScheme.Accent1)
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxRelationship{{ID: "rId1", Type: SourceRelationshipTheme, Target: "theme/theme1.xml"}}, rels.Relationships)
+	assert.NoError(t, f.Close())
+
+	// Test set the workbook theme with unsupported charset content types
+	f = NewFile()
+	f.Theme = nil
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookTheme(&Theme{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test set the workbook theme with unsupported charset workbook relationships
+	f = NewFile()
+	f.Theme = nil
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookTheme(&Theme{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestApplyBuiltinTheme(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.ApplyBuiltinTheme("office 2007 - 2010"))
+	theme, err := f.GetWorkbookTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Office 2007 - 2010", theme.Name)
+	assert.Equal(t, "4F81BD", theme.ColorScheme.Accent1)
+	assert.Equal(t, "1F497D", theme.ColorScheme.Dark2)
+	assert.Equal(t, "Cambria", theme.FontScheme.Major.Latin)
+	assert.Equal(t, "Calibri", theme.FontScheme.Minor.Latin)
+	assert.NoError(t, f.ApplyBuiltinTheme("Grayscale"))
+	theme, err = f.GetWorkbookTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "DDDDDD", theme.ColorScheme.Accent1)
+	assert.Equal(t, "Calibri Light", theme.FontScheme.Major.Latin)
+	assert.NotEmpty(t, theme.FontScheme.Major.Scripts)
+	assert.NoError(t, f.ApplyBuiltinTheme("Office"))
+	theme, err = f.GetWorkbookTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "156082", theme.ColorScheme.Accent1)
+	assert.Equal(t, "Aptos Display", theme.FontScheme.Major.Latin)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestApplyBuiltinTheme.xlsx")))
+	// Test apply the built-in theme with unsupported theme name
+	assert.EqualError(t, f.ApplyBuiltinTheme("Unknown"), newUnsupportedThemeError("Unknown").Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestXMLIndex(t *testing.T) {
+	fonts := []*xlsxFont{{B: &attrValBool{Val: boolPtr(true)}}, {I: &attrValBool{Val: boolPtr(true)}}, {B: &attrValBool{Val: boolPtr(true)}}}
+	var idx xmlIndex
+	calls := 0
+	item := func(i int) interface{} {
+		calls++
+		return fonts[i]
+	}
+	assert.Equal(t, 0, idx.get(&xlsxFont{B: &attrValBool{Val: boolPtr(true)}}, len(fonts), item))
+	assert.Equal(t, 1, idx.get(&xlsxFont{I: &attrValBool{Val: boolPtr(true)}}, len(fonts), item))
+	assert.Equal(t, -1, idx.get(&xlsxFont{U: &attrValString{Val: stringPtr("single")}}, len(fonts), item))
+	// Test the appended element will be indexed, and each element will be
+	// serialized once
+	fonts = append(fonts, &xlsxFont{U: &attrValString{Val: stringPtr("single")}})
+	assert.Equal(t, 3, idx.get(&xlsxFont{U: &attrValString{Val: stringPtr("single")}}, len(fonts), item))
+	assert.Equal(t, len(fonts), calls)
+}
@@ -28,6 +28,15 @@ var (
 	conditionFormat  = regexp.MustCompile(`(or|\|\|)`)
 	blankFormat      = regexp.MustCompile("blanks|nonblanks")
 	matchFormat      = regexp.MustCompile("[*?]")
+	// tableTotalsRowFunctions defined the function numbers of the SUBTOTAL
+	// function for the totals row functions of the table column.
+	tableTotalsRowFunctions = map[string]int{
+		"average": 101, "countNums": 102, "count": 103, "max": 104,
+		"min": 105, "none": 0, "stdDev": 107, "sum": 109, "var": 110,
+	}
+	// tableColumnNameEscaper defined the replacer for the special characters
+	// of the column name in the structured reference.
+	tableColumnNameEscaper = strings.NewReplacer("'", "''", "#", "'#", "[", "'[", "]", "']")
 )
 
 // parseTableOptions provides a function to parse the format settings of the
@@ -43,6 +52,21 @@ func parseTableOptions(opts *Table) (*Table, error) {
 	if err = checkDefinedName(opts.Name); err != nil {
 		return opts, err
 	}
+	for idx, column := range opts.Columns {
+		if column.TotalsRowFunction == "" {
+			continue
+		}
+		var ok bool
+		for function := range tableTotalsRowFunctions {
+			if ok = strings.EqualFold(function, column.TotalsRowFunction); ok {
+				opts.Columns[idx].TotalsRowFunction = function
+				break
+			}
+		}
+		if !ok {
+			return opts, ErrParameterInvalid
+		}
+	}
 	return opts, err
 }
 
@@ -80,12 +104,53 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	TableStyleMedium1 - TableStyleMedium28
 //	TableStyleDark1 - TableStyleDark11
 //
+// ShowTotalsRow: Specifies whether to show the totals row of the table, the
+// last row of the range reference will be used as the totals row, and the
+// range reference will be extended if the table doesn't have a data row
+// besides the header and totals row.
+//
+// Columns: Specifies the settings of the table columns, each column was
+// matched with the header of the table by the 'Name' property
+// (case-insensitive). The 'TotalsRowLabel' property specifies the text in the
+// totals row cell of the column. The 'TotalsRowFunction' property specifies
+// the aggregate function in the totals row cell of the column, and the
+// SUBTOTAL formula of the function will be set in the cell. The optional
+// values of the 'TotalsRowFunction' property (case-insensitive) are:
+//
+//	 Function  | SUBTOTAL Function
+//	-----------+-------------------
+//	 average   | 101 (AVERAGE)
+//	 countNums | 102 (COUNT)
+//	 count     | 103 (COUNTA)
+//	 max       | 104 (MAX)
+//	 min       | 105 (MIN)
+//	 stdDev    | 107 (STDEV)
+//	 sum       | 109 (SUM)
+//	 var       | 110 (VAR)
+//	 none      | -
+//
+// For example, create a table of A1:C6 on Sheet1 with the totals row in the
+// row 6, which shows the label in column A and the sum of column C:
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{
+//	    Range:         "A1:C6",
+//	    StyleName:     "TableStyleMedium9",
+//	    ShowTotalsRow: true,
+//	    Columns: []excelize.TableColumn{
+//	        {Name: "Region", TotalsRowLabel: "Total"},
+//	        {Name: "Revenue", TotalsRowFunction: "sum"},
+//	    },
+//	})
+//
 // 根据给定的工作表名、单元格坐标区域和条件格式创建表格。
 func (f *File) AddTable(sheet string, table *Table) error {
 	options, err := parseTableOptions(table)
 	if err != nil {
 		return err
 	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
 	var exist bool
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/tables/table") {
@@ -113,6 +178,9 @@ func (f *File) AddTable(sheet string, table *Table) error {
 	tableID := f.countTables() + 1
 	sheetRelationshipsTableXML := "../tables/table" + strconv.Itoa(tableID) + ".xml"
 	tableXML := strings.ReplaceAll(sheetRelationshipsTableXML, "..", "xl")
+	if err = f.addTable(sheet, tableXML, coordinates[0], coordinates[1], coordinates[2], coordinates[3], tableID, options); err != nil {
+		return err
+	}
 	// Add first table for given sheet.
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
@@ -121,22 +189,26 @@ func (f *File) AddTable(sheet string, table *Table) error {
 		return err
 	}
 	f.addSheetNameSpace(sheet, SourceRelationship)
-	if err = f.addTable(sheet, tableXML, coordinates[0], coordinates[1], coordinates[2], coordinates[3], tableID, options); err != nil {
-		return err
-	}
 	return f.addContentTypePart(tableID, "table")
 }
 
-// countTables provides a function to get table files count storage in the
-// folder xl/tables.
+// countTables provides a function to get the largest number of the table
+// files storage in the folder xl/tables, the number of the deleted tables
+// will not be reused.
 func (f *File) countTables() int {
-	count := 0
+	count, maxNum := 0, 0
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/tables/table") {
 			count++
+			if num, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), "xl/tables/table"), ".xml")); num > maxNum {
+				maxNum = num
+			}
 		}
 		return true
 	})
+	if maxNum > count {
+		return maxNum
+	}
 	return count
 }
 
@@ -225,16 +297,32 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 	if hideHeaderRow {
 		y1++
 	}
+	showTotalsRow := opts != nil && opts.ShowTotalsRow
+	if dataRow := y1 + 1; showTotalsRow && !hideHeaderRow && y2 <= dataRow {
+		y2 = dataRow + 1
+	}
+	if showTotalsRow && hideHeaderRow && y2 <= y1 {
+		y2 = y1 + 1
+	}
 	// Correct table range reference, such correct C1:B3 to B1:C3.
 	ref, err := f.coordinatesToRangeRef([]int{x1, y1, x2, y2})
 	if err != nil {
 		return err
 	}
+	filterRef := ref
+	if showTotalsRow {
+		if filterRef, err = f.coordinatesToRangeRef([]int{x1, y1, x2, y2 - 1}); err != nil {
+			return err
+		}
+	}
 	tableColumns, _ := f.setTableHeader(sheet, !hideHeaderRow, x1, y1, x2)
 	name := opts.Name
 	if name == "" {
 		name = "Table" + strconv.Itoa(i)
 	}
+	if err = f.setTableColumns(sheet, name, showTotalsRow, x1, y2, tableColumns, opts.Columns); err != nil {
+		return err
+	}
 	t := xlsxTable{
 		XMLNS:       NameSpaceSpreadSheet.Value,
 		ID:          i,
@@ -242,7 +330,7 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 		DisplayName: name,
 		Ref:         ref,
 		AutoFilter: &xlsxAutoFilter{
-			Ref: ref,
+			Ref: filterRef,
 		},
 		TableColumns: &xlsxTableColumns{
 			Count:       len(tableColumns),
@@ -260,11 +348,299 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 		t.AutoFilter = nil
 		t.HeaderRowCount = intPtr(0)
 	}
+	if showTotalsRow {
+		t.TotalsRowCount, t.TotalsRowShown = 1, true
+	}
 	table, _ := xml.Marshal(t)
 	f.saveFileList(tableXML, table)
 	return nil
 }
 
+// setTableColumns provides a function to set the totals row functions and
+// labels of the table columns by given column settings, and set the values
+// and SUBTOTAL formulas of the totals row cells if the totals row of the
+// table is shown.
+func (f *File) setTableColumns(sheet, tableName string, showTotalsRow bool, x1, y2 int, tableColumns []*xlsxTableColumn, columns []TableColumn) error {
+	for _, column := range columns {
+		idx := -1
+		for i, tableColumn := range tableColumns {
+			if strings.EqualFold(tableColumn.Name, column.Name) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return ErrColumnNotExist
+		}
+		tableColumns[idx].TotalsRowFunction = column.TotalsRowFunction
+		tableColumns[idx].TotalsRowLabel = column.TotalsRowLabel
+		if !showTotalsRow {
+			continue
+		}
+		cell, err := CoordinatesToCellName(x1+idx, y2)
+		if err != nil {
+			return err
+		}
+		if num := tableTotalsRowFunctions[column.TotalsRowFunction]; num != 0 {
+			formula := fmt.Sprintf("SUBTOTAL(%d,%s[%s])", num, tableName, tableColumnNameEscaper.Replace(tableColumns[idx].Name))
+			if err = f.SetCellFormula(sheet, cell, formula); err != nil {
+				return err
+			}
+			continue
+		}
+		if column.TotalsRowLabel != "" {
+			if err = f.SetCellStr(sheet, cell, column.TotalsRowLabel); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetTables provides a function to get all tables in a worksheet by given
+// worksheet name. For example, get the name and range reference of the
+// tables on Sheet1:
+//
+//	tables, err := f.GetTables("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, table := range tables {
+//	    fmt.Println(table.Name, table.Range)
+//	}
+//
+// 根据给定的工作表名称获取工作表中的全部表格。
+func (f *File) GetTables(sheet string) ([]Table, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var tables []Table
+	sheetTables, err := f.getSheetTables(sheet)
+	if err != nil {
+		return tables, err
+	}
+	for _, sheetTable := range sheetTables {
+		t := sheetTable.table
+		table := Table{
+			Range:          t.Ref,
+			Name:           t.Name,
+			ShowHeaderRow:  boolPtr(t.HeaderRowCount == nil || *t.HeaderRowCount != 0),
+			ShowRowStripes: boolPtr(false),
+			ShowTotalsRow:  t.TotalsRowCount > 0,
+		}
+		if t.TableStyleInfo != nil {
+			table.StyleName = t.TableStyleInfo.Name
+			table.ShowColumnStripes = t.TableStyleInfo.ShowColumnStripes
+			table.ShowFirstColumn = t.TableStyleInfo.ShowFirstColumn
+			table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
+			table.ShowRowStripes = boolPtr(t.TableStyleInfo.ShowRowStripes)
+		}
+		if t.TableColumns != nil {
+			for _, column := range t.TableColumns.TableColumn {
+				table.Columns = append(table.Columns, TableColumn{
+					Name:              column.Name,
+					TotalsRowFunction: column.TotalsRowFunction,
+					TotalsRowLabel:    column.TotalsRowLabel,
+				})
+			}
+		}
+		tables = append(tables, table)
+	}
+	return tables, err
+}
+
+// DeleteTable provides a function to delete the table by given worksheet name
+// and table name. The cells of the table will be kept, and the formulas
+// reference the table will not be updated. For example, delete the table
+// named Table1 on Sheet1:
+//
+//	err := f.DeleteTable("Sheet1", "Table1")
+//
+// 根据给定的工作表名称和表格名称删除表格，表格区域中的单元格将被保留。
+func (f *File) DeleteTable(sheet, tableName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sheetTables, err := f.getSheetTables(sheet)
+	if err != nil {
+		return err
+	}
+	for _, sheetTable := range sheetTables {
+		if sheetTable.table.Name != tableName {
+			continue
+		}
+		ws, _ := f.workSheetReader(sheet)
+		for idx, tablePart := range ws.TableParts.TableParts {
+			if tablePart.RID == sheetTable.rID {
+				ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
+				ws.TableParts.Count = len(ws.TableParts.TableParts)
+				break
+			}
+		}
+		if ws.TableParts.Count == 0 {
+			ws.TableParts = nil
+		}
+		f.deleteSheetRelationships(sheet, sheetTable.rID)
+		f.Pkg.Delete(sheetTable.path)
+		return f.deleteSheetFromContentTypes("/" + sheetTable.path)
+	}
+	return newNoExistTableError(tableName)
+}
+
+// ExpandTable provides the method to extend the range reference of the table
+// by given worksheet name and table name to include the contiguous rows with
+// values below the table. Excel expands a table when typing adjacent to it,
+// but the rows written by functions such as SetSheetRow after the table has
+// been created are not part of the table, call this function after writing
+// the rows to format them with the table style. The table with the totals
+// row will not be expanded. For example, expand the table named Table1 on
+// Sheet1:
+//
+//	err := f.ExpandTable("Sheet1", "Table1")
+//
+// 根据给定的工作表名称和表格名称扩展表格区域，以包含表格下方连续的非空行。
+func (f *File) ExpandTable(sheet, tableName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tableXML, t, err := f.getSheetTable(sheet, tableName)
+	if err != nil || t.TotalsRowCount > 0 {
+		return err
+	}
+	coordinates, err := rangeRefToCoordinates(t.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	for y2 < len(ws.SheetData.Row) && rowHasValue(ws.SheetData.Row[y2].C, x1, x2) {
+		y2++
+	}
+	ws.mu.Unlock()
+	if y2 == coordinates[3] {
+		return err
+	}
+	if t.Ref, err = f.coordinatesToRangeRef([]int{x1, y1, x2, y2}); err != nil {
+		return err
+	}
+	if t.AutoFilter != nil {
+		t.AutoFilter.Ref = t.Ref
+	}
+	table, err := xml.Marshal(t)
+	f.saveFileList(tableXML, table)
+	return err
+}
+
+// GetTableColumnIndex provides a function to get the zero-based offset of the
+// column in the table by given worksheet name, table name and column name.
+// The column name is case-insensitive, and ErrColumnNotExist will be returned
+// if the column doesn't exist in the table. For example, get the offset of
+// the column named Revenue in the table named Table1 on Sheet1:
+//
+//	idx, err := f.GetTableColumnIndex("Sheet1", "Table1", "Revenue")
+//
+// 根据给定的工作表名称、表格名称和列名称获取列在表格中的位置索引（从 0 开始）。
+func (f *File) GetTableColumnIndex(sheet, tableName, colName string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, t, err := f.getSheetTable(sheet, tableName)
+	if err != nil {
+		return -1, err
+	}
+	if t.TableColumns != nil {
+		for idx, column := range t.TableColumns.TableColumn {
+			if strings.EqualFold(column.Name, colName) {
+				return idx, err
+			}
+		}
+	}
+	return -1, ErrColumnNotExist
+}
+
+// sheetTable defined the part path, the relationship ID and the definition
+// of the table in the worksheet.
+type sheetTable struct {
+	path, rID string
+	table     *xlsxTable
+}
+
+// getSheetTables provides a function to get the part path, the relationship
+// ID and the definition of the tables by given worksheet name.
+func (f *File) getSheetTables(sheet string) ([]sheetTable, error) {
+	var tables []sheetTable
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return tables, err
+	}
+	if ws.TableParts != nil {
+		for _, tbl := range ws.TableParts.TableParts {
+			target := f.getSheetRelationshipsTargetByID(sheet, tbl.RID)
+			tableXML := strings.ReplaceAll(target, "..", "xl")
+			content, ok := f.Pkg.Load(tableXML)
+			if !ok {
+				continue
+			}
+			t := new(xlsxTable)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(t); err != nil && err != io.EOF {
+				return tables, err
+			}
+			tables = append(tables, sheetTable{path: tableXML, rID: tbl.RID, table: t})
+		}
+	}
+	return tables, nil
+}
+
+// getSheetTable provides a function to get the part path and definition of
+// the table by given worksheet name and table name.
+func (f *File) getSheetTable(sheet, tableName string) (string, *xlsxTable, error) {
+	tables, err := f.getSheetTables(sheet)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, tbl := range tables {
+		if tbl.table.Name == tableName {
+			return tbl.path, tbl.table, nil
+		}
+	}
+	return "", nil, newNoExistTableError(tableName)
+}
+
+// getTable provides a function to get the worksheet name and definition of
+// the table by given table name in the workbook. The table name is case
+// insensitive, and the definition will be nil if the table doesn't exist.
+func (f *File) getTable(tableName string) (string, *xlsxTable, error) {
+	for _, sheet := range f.GetSheetList() {
+		if sheetXMLPath, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(sheetXMLPath, "xl/worksheets/") {
+			continue
+		}
+		tables, err := f.getSheetTables(sheet)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, tbl := range tables {
+			if strings.EqualFold(tbl.table.Name, tableName) {
+				return sheet, tbl.table, nil
+			}
+		}
+	}
+	return "", nil, nil
+}
+
+// rowHasValue provides a function to check if any cell between the given
+// columns in the row has a value, formula or inline string.
+func rowHasValue(cells []xlsxC, x1, x2 int) bool {
+	for col := x1; col <= x2 && col <= len(cells); col++ {
+		if c := cells[col-1]; c.V != "" || c.F != nil || c.IS != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // AutoFilter provides the method to add auto filter in a worksheet by given
 // worksheet name, range reference and settings. An auto filter in Excel is a
 // way of filtering a 2D range of data based on some simple criteria. For
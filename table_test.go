@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -74,12 +75,263 @@ func TestAddTable(t *testing.T) {
 	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B2"}))
 }
 
+func TestAddTableTotalsRow(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Region", "Revenue", "Cost [USD]"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"East", 10, 4}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"West", 20, 6}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range:         "A1:C4",
+		Name:          "Sales",
+		StyleName:     "TableStyleMedium9",
+		ShowTotalsRow: true,
+		Columns: []TableColumn{
+			{Name: "region", TotalsRowLabel: "Total"},
+			{Name: "Revenue", TotalsRowFunction: "SUM"},
+			{Name: "Cost [USD]", TotalsRowFunction: "average"},
+		},
+	}))
+	_, table, err := f.getSheetTable("Sheet1", "Sales")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C4", table.Ref)
+	assert.Equal(t, "A1:C3", table.AutoFilter.Ref)
+	assert.Equal(t, 1, table.TotalsRowCount)
+	assert.Equal(t, []*xlsxTableColumn{
+		{ID: 1, Name: "Region", TotalsRowLabel: "Total"},
+		{ID: 2, Name: "Revenue", TotalsRowFunction: "sum"},
+		{ID: 3, Name: "Cost [USD]", TotalsRowFunction: "average"},
+	}, table.TableColumns.TableColumn)
+	cellValue, err := f.GetCellValue("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total", cellValue)
+	for cell, expected := range map[string]string{"B4": "SUBTOTAL(109,Sales[Revenue])", "C4": "SUBTOTAL(101,Sales[Cost '[USD']])"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	// Test add table with the totals row and without data row
+	assert.NoError(t, f.SetSheetRow("Sheet1", "E1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "E1:F1", ShowTotalsRow: true,
+		Columns: []TableColumn{{Name: "Value", TotalsRowFunction: "countNums"}, {Name: "Name", TotalsRowFunction: "none"}},
+	}))
+	_, table, err = f.getSheetTable("Sheet1", "Table2")
+	assert.NoError(t, err)
+	assert.Equal(t, "E1:F3", table.Ref)
+	assert.Equal(t, "E1:F2", table.AutoFilter.Ref)
+	formula, err := f.GetCellFormula("Sheet1", "F3")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(102,Table2[Value])", formula)
+	// Test add table with the totals row and without header row
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "H1:H2", ShowHeaderRow: boolPtr(false), ShowTotalsRow: true}))
+	_, table, err = f.getSheetTable("Sheet1", "Table3")
+	assert.NoError(t, err)
+	assert.Equal(t, "H2:H3", table.Ref)
+	// Test add table with the totals row functions without showing totals row
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "J1:J3", Columns: []TableColumn{{Name: "Column1", TotalsRowFunction: "Max"}}}))
+	_, table, err = f.getSheetTable("Sheet1", "Table4")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, table.TotalsRowCount)
+	assert.Equal(t, "max", table.TableColumns.TableColumn[0].TotalsRowFunction)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableTotalsRow.xlsx")))
+	// Test add table with invalid totals row function
+	assert.Equal(t, ErrParameterInvalid, f.AddTable("Sheet1", &Table{Range: "L1:L3", Columns: []TableColumn{{Name: "Column1", TotalsRowFunction: "product"}}}))
+	// Test add table with not exists column
+	assert.Equal(t, ErrColumnNotExist, f.AddTable("Sheet1", &Table{Range: "L1:L3", Columns: []TableColumn{{Name: "Column2"}}}))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 4)
+	// Test add table with the totals row exceeds the maximum rows
+	assert.Equal(t, ErrMaxRows, f.setTableColumns("Sheet1", "Table5", true, 1, TotalRows+1, []*xlsxTableColumn{{Name: "A"}}, []TableColumn{{Name: "A", TotalsRowFunction: "sum"}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetTables(t *testing.T) {
+	f := NewFile()
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	expected := Table{
+		Range:             "A1:B5",
+		Name:              "Table1",
+		StyleName:         "TableStyleLight2",
+		ShowColumnStripes: true,
+		ShowFirstColumn:   true,
+		ShowHeaderRow:     boolPtr(true),
+		ShowLastColumn:    true,
+		ShowRowStripes:    boolPtr(false),
+		ShowTotalsRow:     true,
+		Columns:           []TableColumn{{Name: "Name", TotalsRowLabel: "Total"}, {Name: "Value", TotalsRowFunction: "min"}},
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &expected))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:D3", ShowHeaderRow: boolPtr(false)}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetTables.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetTables.xlsx"))
+	assert.NoError(t, err)
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Table{expected, {
+		Range:          "D2:D3",
+		Name:           "Table2",
+		ShowHeaderRow:  boolPtr(false),
+		ShowRowStripes: boolPtr(true),
+		Columns:        []TableColumn{{Name: "Column1"}},
+	}}, tables)
+	// Test get tables without table style and columns
+	tableXML, table, err := f.getSheetTable("Sheet1", "Table2")
+	assert.NoError(t, err)
+	table.TableStyleInfo, table.TableColumns = nil, nil
+	content, err := xml.Marshal(table)
+	assert.NoError(t, err)
+	f.Pkg.Store(tableXML, content)
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Table{Range: "D2:D3", Name: "Table2", ShowHeaderRow: boolPtr(false), ShowRowStripes: boolPtr(false)}, tables[1])
+	// Test get tables on not exists worksheet
+	_, err = f.GetTables("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get tables with unsupported charset table
+	f.Pkg.Store(tableXML, MacintoshCyrillicCharset)
+	_, err = f.GetTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteTable(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3", Name: "Table1"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:E3", Name: "Table2"}))
+	assert.NoError(t, f.DeleteTable("Sheet1", "Table1"))
+	_, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.False(t, ok)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "Table2", tables[0].Name)
+	cellValue, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", cellValue)
+	// Test add table after delete table, the table part will not be reused
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3", Name: "Table3"}))
+	_, ok = f.Pkg.Load("xl/tables/table3.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.DeleteTable("Sheet1", "Table2"))
+	assert.NoError(t, f.DeleteTable("Sheet1", "Table3"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.TableParts)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range content.Overrides {
+		assert.NotEqual(t, ContentTypeSpreadSheetMLTable, override.ContentType)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteTable.xlsx")))
+	// Test delete not exists table
+	assert.EqualError(t, f.DeleteTable("Sheet1", "Table1"), "table Table1 does not exist")
+	// Test delete table on not exists worksheet
+	assert.EqualError(t, f.DeleteTable("SheetN", "Table1"), "sheet SheetN does not exist")
+	// Test delete table with unsupported charset content types
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3", Name: "Table1"}))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteTable("Sheet1", "Table1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetTableHeader(t *testing.T) {
 	f := NewFile()
 	_, err := f.setTableHeader("Sheet1", true, 1, 0, 1)
 	assert.EqualError(t, err, "invalid cell reference [1, 0]")
 }
 
+func TestGetTableColumnIndex(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B2", &[]interface{}{"Region", "Revenue", "Cost"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "B2:D5", Name: "Table1"}))
+	for colName, expected := range map[string]int{"Region": 0, "revenue": 1, "COST": 2} {
+		idx, err := f.GetTableColumnIndex("Sheet1", "Table1", colName)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, idx)
+	}
+	// Test get table column index with not exists column
+	idx, err := f.GetTableColumnIndex("Sheet1", "Table1", "Profit")
+	assert.Equal(t, -1, idx)
+	assert.EqualError(t, err, ErrColumnNotExist.Error())
+	// Test get table column index with not exists table
+	_, err = f.GetTableColumnIndex("Sheet1", "Table2", "Region")
+	assert.EqualError(t, err, "table Table2 does not exist")
+	// Test get table column index on not exists worksheet
+	_, err = f.GetTableColumnIndex("SheetN", "Table1", "Region")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get table column index with the table without columns
+	_, table, err := f.getSheetTable("Sheet1", "Table1")
+	assert.NoError(t, err)
+	table.TableColumns = nil
+	content, err := xml.Marshal(table)
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/tables/table1.xml", content)
+	_, err = f.GetTableColumnIndex("Sheet1", "Table1", "Region")
+	assert.EqualError(t, err, ErrColumnNotExist.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestExpandTable(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"a", 1}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B2", Name: "Table1"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "D1", &[]interface{}{"Name"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:D2", Name: "Table2"}))
+	assert.NoError(t, f.SetSheetRows("Sheet1", 3, [][]interface{}{{"b", 2}, {nil, 3}, {}, {"d", 4}}))
+	assert.NoError(t, f.ExpandTable("Sheet1", "Table1"))
+	assert.NoError(t, f.ExpandTable("Sheet1", "Table2"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestExpandTable.xlsx")))
+	for tableName, expected := range map[string]string{"Table1": "A1:B4", "Table2": "D1:D2"} {
+		_, table, err := f.getSheetTable("Sheet1", tableName)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, table.Ref)
+		assert.Equal(t, expected, table.AutoFilter.Ref)
+	}
+	// Test the table will not be expanded without the contiguous rows
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", nil))
+	assert.NoError(t, f.ExpandTable("Sheet1", "Table1"))
+	_, table, err := f.getSheetTable("Sheet1", "Table1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B4", table.Ref)
+	// Test the table with the totals row will not be expanded
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"c", 5}))
+	tableXML, table, err := f.getSheetTable("Sheet1", "Table1")
+	assert.NoError(t, err)
+	table.TotalsRowCount = 1
+	content, err := xml.Marshal(table)
+	assert.NoError(t, err)
+	f.saveFileList(tableXML, content)
+	assert.NoError(t, f.ExpandTable("Sheet1", "Table1"))
+	_, table, err = f.getSheetTable("Sheet1", "Table1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B4", table.Ref)
+	// Test expand the table with invalid range reference
+	table.TotalsRowCount, table.Ref = 0, "A:B"
+	content, err = xml.Marshal(table)
+	assert.NoError(t, err)
+	f.saveFileList(tableXML, content)
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ExpandTable("Sheet1", "Table1"))
+	// Test expand not exists table
+	assert.EqualError(t, f.ExpandTable("Sheet1", "TableN"), "table TableN does not exist")
+	// Test expand table on not exists worksheet
+	assert.EqualError(t, f.ExpandTable("SheetN", "Table1"), "sheet SheetN does not exist")
+	// Test expand table with invalid sheet name
+	assert.EqualError(t, f.ExpandTable("Sheet:1", "Table1"), ErrSheetNameInvalid.Error())
+	// Test expand table with unsupported charset table
+	f.Pkg.Store(tableXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExpandTable("Sheet1", "Table1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestAutoFilter(t *testing.T) {
 	outFile := filepath.Join("test", "TestAutoFilter%d.xlsx")
 	f, err := prepareTestBook1()
@@ -0,0 +1,666 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// Record identifiers of the VBA project information and modules information
+// in the dir stream of the VBA project.
+const (
+	vbaDirSysKind          = 0x0001
+	vbaDirLcid             = 0x0002
+	vbaDirCodePage         = 0x0003
+	vbaDirName             = 0x0004
+	vbaDirDocString        = 0x0005
+	vbaDirHelpFilePath     = 0x0006
+	vbaDirHelpContext      = 0x0007
+	vbaDirLibFlags         = 0x0008
+	vbaDirVersion          = 0x0009
+	vbaDirConstants        = 0x000C
+	vbaDirReferenceRegistr = 0x000D
+	vbaDirModules          = 0x000F
+	vbaDirTerminator       = 0x0010
+	vbaDirCookie           = 0x0013
+	vbaDirLcidInvoke       = 0x0014
+	vbaDirReferenceName    = 0x0016
+	vbaDirModuleName       = 0x0019
+	vbaDirModuleStreamName = 0x001A
+	vbaDirModuleDocString  = 0x001C
+	vbaDirModuleHelpCtx    = 0x001E
+	vbaDirModuleProcedural = 0x0021
+	vbaDirModuleDocument   = 0x0022
+	vbaDirModuleTerminator = 0x002B
+	vbaDirModuleCookie     = 0x002C
+	vbaDirModuleOffset     = 0x0031
+	vbaDirModuleStreamUni  = 0x0032
+	vbaDirConstantsUni     = 0x003C
+	vbaDirHelpFilePath2    = 0x003D
+	vbaDirReferenceNameUni = 0x003E
+	vbaDirDocStringUni     = 0x0040
+	vbaDirModuleNameUni    = 0x0047
+	vbaDirModuleDocStrUni  = 0x0048
+)
+
+var (
+	// vbaModuleNameExp defined the regular expression for the name of the
+	// VBA module.
+	vbaModuleNameExp = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z_]{0,30}$`)
+	// vbaCodePages defined the character encodings of the VBA project by the
+	// code page identifiers.
+	vbaCodePages = map[int]encoding.Encoding{
+		874: charmap.Windows874, 932: japanese.ShiftJIS, 936: simplifiedchinese.GBK,
+		949: korean.EUCKR, 950: traditionalchinese.Big5, 1250: charmap.Windows1250,
+		1251: charmap.Windows1251, 1252: charmap.Windows1252, 1253: charmap.Windows1253,
+		1254: charmap.Windows1254, 1255: charmap.Windows1255, 1256: charmap.Windows1256,
+		1257: charmap.Windows1257, 1258: charmap.Windows1258,
+	}
+	// vbaProjectCache defined the content of the _VBA_PROJECT stream without
+	// the performance cache, which makes the application compile the VBA
+	// project from the source code of the modules.
+	vbaProjectCache = []byte{0xCC, 0x61, 0xFF, 0xFF, 0x00, 0x00, 0x00}
+	// vbaDocumentModuleBase defined the class identifiers of the workbook and
+	// worksheet document modules.
+	vbaDocumentModuleBase = map[bool]string{
+		true:  "0{00020819-0000-0000-C000-000000000046}",
+		false: "0{00020820-0000-0000-C000-000000000046}",
+	}
+)
+
+// vbaDirRecord directly maps the record in the dir stream of the VBA project.
+type vbaDirRecord struct {
+	id   uint16
+	data []byte
+}
+
+// vbaProject defined the storages with the class identifiers, the streams and
+// the records of the dir stream of the VBA project.
+type vbaProject struct {
+	storages map[string][]byte
+	streams  map[string][]byte
+	names    []string
+	records  []vbaDirRecord
+	codePage int
+}
+
+// SetWorkbookVBACodeModule provides a function to set the source code of the
+// VBA module by given module name and the code. If the VBA module with the
+// same name (case-insensitive) already exists in the VBA project of the
+// workbook, the source code of the module will be replaced, and the leading
+// 'Attribute' lines of the existing module will be kept if the code doesn't
+// contain them. Otherwise, a new procedural module will be added to the VBA
+// project. If the workbook doesn't contain a VBA project, a new VBA project
+// will be created with the document modules of the workbook and each
+// worksheet, and the code names of the workbook and worksheets will be set if
+// they were not set. The name of the module must start with a letter, and
+// contain only letters, digits and underscores, up to 31 characters. Note
+// that the code will be encoded by the code page of the VBA project, and the
+// compiled cache of the VBA project will be removed, which makes the
+// application compile the VBA project from the source code when opening the
+// workbook. The workbook should be saved with XLSM or XLTM file extension.
+// For example, add a VBA module with a macro:
+//
+//	code := "Sub Hello()\n    MsgBox \"Hello, world!\"\nEnd Sub"
+//	if err := f.SetWorkbookVBACodeModule("Module1", code); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SaveAs("Book1.xlsm"); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// 根据给定的模块名称和源代码设置工作簿 VBA 工程中的代码模块，模块不存在时将添加新的标准模块。
+func (f *File) SetWorkbookVBACodeModule(moduleName, code string) error {
+	if !vbaModuleNameExp.MatchString(moduleName) {
+		return ErrVBAModuleName
+	}
+	var (
+		project *vbaProject
+		err     error
+	)
+	if file, ok := f.Pkg.Load(defaultXMLPathVBAProject); ok {
+		if project, err = readVBAProject(file.([]byte)); err != nil {
+			return err
+		}
+	} else if project, err = f.newVBAProject(); err != nil {
+		return err
+	}
+	if err = project.setModule(moduleName, code); err != nil {
+		return err
+	}
+	return f.AddVBAProject(project.write())
+}
+
+// newVBAProject provides a function to create a VBA project with the
+// document modules of the workbook and each worksheet, and set the code names
+// of the workbook and worksheets which were not set.
+func (f *File) newVBAProject() (*vbaProject, error) {
+	wbProps, err := f.GetWorkbookProps()
+	if err != nil {
+		return nil, err
+	}
+	codeNames, sheets := []string{"ThisWorkbook"}, []string{""}
+	if wbProps.CodeName != nil && vbaModuleNameExp.MatchString(*wbProps.CodeName) {
+		codeNames[0] = *wbProps.CodeName
+	}
+	if err = f.SetWorkbookProps(&WorkbookPropsOptions{CodeName: &codeNames[0]}); err != nil {
+		return nil, err
+	}
+	for _, sheet := range f.GetSheetList() {
+		if name, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(name, "xl/worksheets/") {
+			continue
+		}
+		props, err := f.GetSheetProps(sheet)
+		if err != nil {
+			return nil, err
+		}
+		var codeName string
+		if props.CodeName != nil && vbaModuleNameExp.MatchString(*props.CodeName) &&
+			inStrSlice(codeNames, *props.CodeName, false) == -1 {
+			codeName = *props.CodeName
+		}
+		codeNames, sheets = append(codeNames, codeName), append(sheets, sheet)
+	}
+	for idx, num := 1, 1; idx < len(codeNames); idx++ {
+		for ; codeNames[idx] == ""; num++ {
+			if codeName := fmt.Sprintf("Sheet%d", num); inStrSlice(codeNames, codeName, false) == -1 {
+				codeNames[idx] = codeName
+			}
+		}
+		if err = f.SetSheetProps(sheets[idx], &SheetPropsOptions{CodeName: &codeNames[idx]}); err != nil {
+			return nil, err
+		}
+	}
+	id, err := randomBytes(16)
+	if err != nil {
+		return nil, err
+	}
+	projectID := fmt.Sprintf("{%X-%X-%X-%X-%X}", id[:4], id[4:6], id[6:8], id[8:10], id[10:])
+	project := &vbaProject{storages: map[string][]byte{}, streams: map[string][]byte{}, codePage: 1252}
+	project.records = []vbaDirRecord{
+		{vbaDirSysKind, vbaUint32(1)},
+		{vbaDirLcid, vbaUint32(0x409)},
+		{vbaDirLcidInvoke, vbaUint32(0x409)},
+		{vbaDirCodePage, vbaUint16(1252)},
+		{vbaDirName, []byte("VBAProject")},
+		{vbaDirDocString, nil}, {vbaDirDocStringUni, nil},
+		{vbaDirHelpFilePath, nil}, {vbaDirHelpFilePath2, nil},
+		{vbaDirHelpContext, vbaUint32(0)},
+		{vbaDirLibFlags, vbaUint32(0)},
+		{vbaDirVersion, append(vbaUint32(1), vbaUint16(0)...)},
+		{vbaDirConstants, nil}, {vbaDirConstantsUni, nil},
+		{vbaDirReferenceName, []byte("stdole")},
+		{vbaDirReferenceNameUni, vbaUTF16("stdole")},
+		{vbaDirReferenceRegistr, vbaReferenceLibID(`*\G{00020430-0000-0000-C000-000000000046}#2.0#0#C:\Windows\System32\stdole2.tlb#OLE Automation`)},
+		{vbaDirModules, vbaUint16(0)},
+		{vbaDirCookie, vbaUint16(0xFFFF)},
+		{vbaDirTerminator, nil},
+	}
+	var projectStream strings.Builder
+	projectStream.WriteString("ID=\"" + projectID + "\"\r\n")
+	for _, codeName := range codeNames {
+		projectStream.WriteString("Document=" + codeName + "/&H00000000\r\n")
+	}
+	projectStream.WriteString("Name=\"VBAProject\"\r\nHelpContextID=\"0\"\r\nVersionCompatible32=\"393222000\"\r\n")
+	for _, key := range []string{"CMG", "DPB", "GC"} {
+		value, err := vbaEncryptData(projectID, map[string][]byte{"CMG": vbaUint32(0), "DPB": {0}, "GC": {0xFF}}[key])
+		if err != nil {
+			return nil, err
+		}
+		projectStream.WriteString(key + "=\"" + value + "\"\r\n")
+	}
+	projectStream.WriteString("\r\n[Host Extender Info]\r\n&H00000001={3832D640-CF90-11CF-8E43-00A0C911005A};VBE;&H00000000\r\n")
+	project.streams["PROJECT"] = []byte(projectStream.String())
+	project.streams["PROJECTwm"] = []byte{0, 0}
+	for idx, codeName := range codeNames {
+		var source strings.Builder
+		source.WriteString("Attribute VB_Name = \"" + codeName + "\"\r\n")
+		source.WriteString("Attribute VB_Base = \"" + vbaDocumentModuleBase[idx == 0] + "\"\r\n")
+		source.WriteString("Attribute VB_GlobalNameSpace = False\r\nAttribute VB_Creatable = False\r\n")
+		source.WriteString("Attribute VB_PredeclaredId = True\r\nAttribute VB_Exposed = True\r\n")
+		source.WriteString("Attribute VB_TemplateDerived = False\r\nAttribute VB_Customizable = True\r\n")
+		project.addModule(codeName, []byte(source.String()), true)
+	}
+	return project, err
+}
+
+// readVBAProject provides a function to read the storages, the streams and the
+// records of the dir stream of the VBA project by given vbaProject.bin file
+// content.
+func readVBAProject(file []byte) (*vbaProject, error) {
+	doc, err := mscfb.New(bytes.NewReader(file))
+	if err != nil {
+		return nil, ErrAddVBAProject
+	}
+	project := &vbaProject{storages: map[string][]byte{}, streams: map[string][]byte{}, codePage: 1252}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		name := strings.Join(append(append([]string{}, entry.Path...), entry.Name), "/")
+		if entry.FileInfo().IsDir() {
+			project.storages[name+"/"] = vbaCLSID(entry.ID())
+			continue
+		}
+		buf := make([]byte, entry.Size)
+		if _, err = doc.Read(buf); err != nil && entry.Size > 0 {
+			return nil, ErrAddVBAProject
+		}
+		project.streams[name] = buf
+		project.names = append(project.names, name)
+	}
+	dir, ok := project.streams["VBA/dir"]
+	if !ok {
+		return nil, ErrAddVBAProject
+	}
+	if dir, err = decompressVBA(dir); err != nil {
+		return nil, err
+	}
+	for pos := 0; pos < len(dir); {
+		if pos+6 > len(dir) {
+			return nil, ErrAddVBAProject
+		}
+		id, size := binary.LittleEndian.Uint16(dir[pos:]), int(binary.LittleEndian.Uint32(dir[pos+2:]))
+		if id == vbaDirVersion {
+			size = 6
+		}
+		if pos += 6; size < 0 || pos+size > len(dir) {
+			return nil, ErrAddVBAProject
+		}
+		project.records = append(project.records, vbaDirRecord{id: id, data: dir[pos : pos+size]})
+		if pos += size; id == vbaDirCodePage && size == 2 {
+			project.codePage = int(binary.LittleEndian.Uint16(dir[pos-2:]))
+		}
+	}
+	return project, err
+}
+
+// setModule provides a function to set the source code of the VBA module by
+// given module name and the code.
+func (p *vbaProject) setModule(name, code string) error {
+	code = strings.ReplaceAll(strings.ReplaceAll(code, "\r\n", "\n"), "\n", "\r\n")
+	if !strings.HasSuffix(code, "\r\n") {
+		code += "\r\n"
+	}
+	source, err := p.encode(code)
+	if err != nil {
+		return err
+	}
+	p.streams["VBA/_VBA_PROJECT"] = vbaProjectCache
+	for _, stream := range p.names {
+		if strings.HasPrefix(stream, "VBA/__SRP_") {
+			delete(p.streams, stream)
+		}
+	}
+	var moduleName, streamName string
+	for idx, record := range p.records {
+		switch record.id {
+		case vbaDirModuleName:
+			moduleName, streamName = string(record.data), ""
+		case vbaDirModuleStreamName:
+			streamName = string(record.data)
+		case vbaDirModuleStreamUni:
+			streamName = vbaUTF16Decode(record.data)
+		case vbaDirModuleOffset:
+			if !strings.EqualFold(moduleName, name) || len(record.data) != 4 {
+				continue
+			}
+			stream, ok := p.streams["VBA/"+streamName]
+			if offset := int(binary.LittleEndian.Uint32(record.data)); !ok || offset > len(stream) {
+				return ErrAddVBAProject
+			}
+			if !vbaHasAttributes(source) {
+				existing, err := decompressVBA(stream[binary.LittleEndian.Uint32(record.data):])
+				if err != nil {
+					return err
+				}
+				source = append(vbaAttributes(existing), source...)
+			}
+			p.streams["VBA/"+streamName] = compressVBA(source)
+			p.records[idx].data = vbaUint32(0)
+			return err
+		}
+	}
+	for streamName := range p.streams {
+		if strings.EqualFold(streamName, "VBA/"+name) {
+			return ErrVBAModuleName
+		}
+	}
+	if !vbaHasAttributes(source) {
+		source = append([]byte("Attribute VB_Name = \""+name+"\"\r\n"), source...)
+	}
+	project := string(p.streams["PROJECT"])
+	if idx := strings.Index(project, "Name=\""); idx != -1 {
+		project = project[:idx] + "Module=" + name + "\r\n" + project[idx:]
+	}
+	p.streams["PROJECT"] = []byte(project)
+	p.addModule(name, source, false)
+	return err
+}
+
+// addModule provides a function to add the records, the stream and the name
+// map entry of the VBA module by given module name, the source code and
+// whether it is a document module.
+func (p *vbaProject) addModule(name string, source []byte, document bool) {
+	moduleType := uint16(vbaDirModuleProcedural)
+	if document {
+		moduleType = vbaDirModuleDocument
+	}
+	records := []vbaDirRecord{
+		{vbaDirModuleName, []byte(name)},
+		{vbaDirModuleNameUni, vbaUTF16(name)},
+		{vbaDirModuleStreamName, []byte(name)},
+		{vbaDirModuleStreamUni, vbaUTF16(name)},
+		{vbaDirModuleDocString, nil}, {vbaDirModuleDocStrUni, nil},
+		{vbaDirModuleOffset, vbaUint32(0)},
+		{vbaDirModuleHelpCtx, vbaUint32(0)},
+		{vbaDirModuleCookie, vbaUint16(0xFFFF)},
+		{moduleType, nil},
+		{vbaDirModuleTerminator, nil},
+	}
+	for idx := len(p.records) - 1; idx >= 0; idx-- {
+		switch p.records[idx].id {
+		case vbaDirTerminator:
+			p.records = append(p.records[:idx], append(records, p.records[idx:]...)...)
+		case vbaDirModules:
+			p.records[idx].data = vbaUint16(int(binary.LittleEndian.Uint16(p.records[idx].data)) + 1)
+		}
+	}
+	p.streams["VBA/"+name] = compressVBA(source)
+	if nameMap, ok := p.streams["PROJECTwm"]; ok && len(nameMap) >= 2 {
+		entry := append(append([]byte(name), 0), append(vbaUTF16(name), 0, 0)...)
+		p.streams["PROJECTwm"] = append(append(nameMap[:len(nameMap)-2:len(nameMap)-2], entry...), 0, 0)
+	}
+}
+
+// encode provides a function to encode the text by the code page of the VBA
+// project.
+func (p *vbaProject) encode(text string) ([]byte, error) {
+	if enc, ok := vbaCodePages[p.codePage]; ok {
+		return enc.NewEncoder().Bytes([]byte(text))
+	}
+	return []byte(text), nil
+}
+
+// write provides a function to create the vbaProject.bin file content by the
+// storages, the streams and the records of the dir stream of the VBA project.
+func (p *vbaProject) write() []byte {
+	var dir []byte
+	for _, record := range p.records {
+		size := len(record.data)
+		if record.id == vbaDirVersion {
+			size = 4
+		}
+		dir = append(append(append(dir, vbaUint16(int(record.id))...), vbaUint32(size)...), record.data...)
+	}
+	p.streams["VBA/dir"] = compressVBA(dir)
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5}},
+	}
+	if _, ok := p.storages["VBA/"]; !ok {
+		p.storages["VBA/"] = nil
+	}
+	for name, clsID := range p.storages {
+		compoundFile.put(name, nil)
+		compoundFile.sectors[len(compoundFile.sectors)-1].clsID = clsID
+	}
+	for name, stream := range p.streams {
+		compoundFile.put(name, stream)
+	}
+	return compoundFile.write()
+}
+
+// vbaHasAttributes returns if the source code of the VBA module starts with
+// the 'Attribute' lines.
+func vbaHasAttributes(source []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(source, " \t"), []byte("Attribute "))
+}
+
+// vbaAttributes provides a function to get the leading 'Attribute' lines of
+// the source code of the VBA module.
+func vbaAttributes(source []byte) []byte {
+	var attributes []byte
+	for _, line := range bytes.SplitAfter(source, []byte("\n")) {
+		if !vbaHasAttributes(line) {
+			break
+		}
+		if !bytes.HasSuffix(line, []byte("\r\n")) {
+			line = append(bytes.TrimSuffix(line, []byte("\n")), '\r', '\n')
+		}
+		attributes = append(attributes, line...)
+	}
+	return attributes
+}
+
+// vbaReferenceLibID provides a function to get the data of the
+// REFERENCEREGISTERED record by given library identifier.
+func vbaReferenceLibID(libID string) []byte {
+	data := append(vbaUint32(len(libID)), libID...)
+	return append(append(data, vbaUint32(0)...), vbaUint16(0)...)
+}
+
+// vbaEncryptData provides a function to encrypt the data by given project
+// identifier with the data encryption algorithm of the VBA project, and
+// returns the hexadecimal encoded result.
+func vbaEncryptData(projectID string, data []byte) (string, error) {
+	random, err := randomBytes(4)
+	if err != nil {
+		return "", err
+	}
+	var projKey byte
+	for _, b := range []byte(projectID) {
+		projKey += b
+	}
+	seed := random[0]
+	encrypted := []byte{seed, seed ^ 2, seed ^ projKey}
+	unencrypted1, encrypted1, encrypted2 := projKey, seed^projKey, seed^2
+	plain := append(append(random[1:1+(seed&6)/2:1+(seed&6)/2], vbaUint32(len(data))...), data...)
+	for _, b := range plain {
+		enc := b ^ (encrypted2 + unencrypted1)
+		encrypted = append(encrypted, enc)
+		encrypted2, encrypted1, unencrypted1 = encrypted1, enc, b
+	}
+	return fmt.Sprintf("%X", encrypted), err
+}
+
+// vbaCopyTokenBitCount provides a function to get the bit count of the offset
+// in the copy token by given decompressed size of the current chunk.
+func vbaCopyTokenBitCount(size int) int {
+	bitCount := 4
+	for 1<<bitCount < size {
+		bitCount++
+	}
+	return bitCount
+}
+
+// decompressVBA provides a function to decompress the compressed container
+// with the compression algorithm of the VBA project.
+func decompressVBA(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != 1 {
+		return nil, ErrAddVBAProject
+	}
+	var decompressed []byte
+	for pos := 1; pos < len(data); {
+		if pos+2 > len(data) {
+			return nil, ErrAddVBAProject
+		}
+		header := binary.LittleEndian.Uint16(data[pos:])
+		end, chunkStart := pos+int(header&0x0FFF)+3, len(decompressed)
+		if end > len(data) {
+			end = len(data)
+		}
+		if pos += 2; header&0x8000 == 0 {
+			if pos+4096 > len(data) {
+				return nil, ErrAddVBAProject
+			}
+			decompressed = append(decompressed, data[pos:pos+4096]...)
+			pos += 4096
+			continue
+		}
+		for pos < end {
+			flags := data[pos]
+			pos++
+			for bit := 0; bit < 8 && pos < end; bit++ {
+				if flags&(1<<bit) == 0 {
+					decompressed = append(decompressed, data[pos])
+					pos++
+					continue
+				}
+				if pos+2 > end {
+					return nil, ErrAddVBAProject
+				}
+				token := binary.LittleEndian.Uint16(data[pos:])
+				pos += 2
+				bitCount := vbaCopyTokenBitCount(len(decompressed) - chunkStart)
+				length, offset := int(token&(0xFFFF>>bitCount))+3, int(token>>(16-bitCount))+1
+				if offset > len(decompressed)-chunkStart {
+					return nil, ErrAddVBAProject
+				}
+				for src := len(decompressed) - offset; length > 0; length-- {
+					decompressed = append(decompressed, decompressed[src])
+					src++
+				}
+			}
+		}
+	}
+	return decompressed, nil
+}
+
+// compressVBA provides a function to compress the data into the compressed
+// container with the compression algorithm of the VBA project. Only the
+// chunks of 4096 bytes which can't be compressed will be stored as raw
+// chunks. The last chunk will always be compressed to avoid the padding
+// bytes after the decompressed data, and it will be split into smaller
+// compressed chunks if the compressed chunk exceeds the size limit.
+func compressVBA(data []byte) []byte {
+	compressed := []byte{1}
+	for start := 0; start < len(data); {
+		end := start + 4096
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := compressVBAChunk(data[start:end])
+		if len(chunk) > 4096 {
+			if end-start == 4096 {
+				compressed = append(append(compressed, vbaUint16(0x3FFF)...), data[start:end]...)
+				start = end
+				continue
+			}
+			// Up to 3640 bytes with one flag byte per 8 literal bytes could
+			// always be stored in a compressed chunk of 4096 bytes
+			end = start + 3640
+			chunk = compressVBAChunk(data[start:end])
+		}
+		compressed = append(append(compressed, vbaUint16(0xB000|(len(chunk)-1))...), chunk...)
+		start = end
+	}
+	return compressed
+}
+
+// compressVBAChunk provides a function to compress the decompressed chunk
+// which contains up to 4096 bytes into the token sequences.
+func compressVBAChunk(chunk []byte) []byte {
+	var compressed []byte
+	for pos := 0; pos < len(chunk); {
+		flagPos := len(compressed)
+		compressed = append(compressed, 0)
+		for bit := 0; bit < 8 && pos < len(chunk); bit++ {
+			bitCount := vbaCopyTokenBitCount(pos)
+			maxLength := 0xFFFF>>bitCount + 3
+			if maxLength > len(chunk)-pos {
+				maxLength = len(chunk) - pos
+			}
+			var offset, length int
+			for candidate := pos - 1; candidate >= 0; candidate-- {
+				var size int
+				for size < maxLength && chunk[candidate+size] == chunk[pos+size] {
+					size++
+				}
+				if size > length {
+					offset, length = pos-candidate, size
+				}
+				if length == maxLength {
+					break
+				}
+			}
+			if length < 3 {
+				compressed = append(compressed, chunk[pos])
+				pos++
+				continue
+			}
+			compressed = append(compressed, vbaUint16((offset-1)<<(16-bitCount)|(length-3))...)
+			compressed[flagPos] |= 1 << bit
+			pos += length
+		}
+	}
+	return compressed
+}
+
+// vbaUint16 returns the little-endian bytes of the given 16-bit value.
+func vbaUint16(value int) []byte {
+	buf := make([]byte, 2)
+	binary.LittleEndian.PutUint16(buf, uint16(value))
+	return buf
+}
+
+// vbaUint32 returns the little-endian bytes of the given 32-bit value.
+func vbaUint32(value int) []byte {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, uint32(value))
+	return buf
+}
+
+// vbaUTF16 returns the UTF-16 little-endian bytes of the given text.
+func vbaUTF16(text string) []byte {
+	var buf []byte
+	for _, unit := range utf16.Encode([]rune(text)) {
+		buf = append(buf, byte(unit), byte(unit>>8))
+	}
+	return buf
+}
+
+// vbaCLSID returns the bytes of the class identifier by given text in the
+// registry format, such as {C62A69F0-16DC-11CE-9E98-00AA00574A4F}.
+func vbaCLSID(text string) []byte {
+	id, err := hex.DecodeString(strings.NewReplacer("{", "", "}", "", "-", "").Replace(text))
+	if err != nil || len(id) != 16 {
+		return nil
+	}
+	for _, part := range [][]byte{id[:4], id[4:6], id[6:8]} {
+		for i, j := 0, len(part)-1; i < j; i, j = i+1, j-1 {
+			part[i], part[j] = part[j], part[i]
+		}
+	}
+	return id
+}
+
+// vbaUTF16Decode returns the text of the given UTF-16 little-endian bytes.
+func vbaUTF16Decode(buf []byte) string {
+	units := make([]uint16, len(buf)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(buf[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,214 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetWorkbookVBACodeModule(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetProps("Sheet2", &SheetPropsOptions{CodeName: stringPtr("Sheet1")}))
+	assert.NoError(t, f.SetWorkbookVBACodeModule("Module1", "Sub Hello()\n    MsgBox \"Hello\"\nEnd Sub"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetWorkbookVBACodeModule.xlsm")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetWorkbookVBACodeModule.xlsm"))
+	assert.NoError(t, err)
+	// Test the code names of the workbook and worksheets has been set
+	wbProps, err := f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "ThisWorkbook", *wbProps.CodeName)
+	for sheet, codeName := range map[string]string{"Sheet1": "Sheet2", "Sheet2": "Sheet1"} {
+		props, err := f.GetSheetProps(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, codeName, *props.CodeName)
+	}
+	file, ok := f.Pkg.Load(defaultXMLPathVBAProject)
+	assert.True(t, ok)
+	project, err := readVBAProject(file.([]byte))
+	assert.NoError(t, err)
+	assert.Equal(t, 1252, project.codePage)
+	assert.Equal(t, vbaProjectCache, project.streams["VBA/_VBA_PROJECT"])
+	assert.Contains(t, string(project.streams["PROJECT"]), "Document=ThisWorkbook/&H00000000\r\nDocument=Sheet2/&H00000000\r\nDocument=Sheet1/&H00000000\r\nModule=Module1\r\nName=\"VBAProject\"")
+	source, err := decompressVBA(project.streams["VBA/Module1"])
+	assert.NoError(t, err)
+	assert.Equal(t, "Attribute VB_Name = \"Module1\"\r\nSub Hello()\r\n    MsgBox \"Hello\"\r\nEnd Sub\r\n", string(source))
+	// Test replace the source code of the document module
+	assert.NoError(t, f.SetWorkbookVBACodeModule("thisworkbook", "Private Sub Workbook_Open()\r\nEnd Sub\r\n"))
+	file, _ = f.Pkg.Load(defaultXMLPathVBAProject)
+	project, err = readVBAProject(file.([]byte))
+	assert.NoError(t, err)
+	source, err = decompressVBA(project.streams["VBA/ThisWorkbook"])
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(source, []byte("Attribute VB_Name = \"ThisWorkbook\"\r\nAttribute VB_Base = \"0{00020819-0000-0000-C000-000000000046}\"\r\n")))
+	assert.True(t, bytes.HasSuffix(source, []byte("Attribute VB_Customizable = True\r\nPrivate Sub Workbook_Open()\r\nEnd Sub\r\n")))
+	assert.NoError(t, f.Close())
+
+	// Test add module to the existing VBA project
+	f = NewFile()
+	raw, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddVBAProject(raw))
+	assert.NoError(t, f.SetWorkbookVBACodeModule("Module1", "Attribute VB_Name = \"Module1\"\nSub A()\nEnd Sub"))
+	assert.NoError(t, f.SetWorkbookVBACodeModule("Sheet1", "Sub B()\nEnd Sub"))
+	file, _ = f.Pkg.Load(defaultXMLPathVBAProject)
+	project, err = readVBAProject(file.([]byte))
+	assert.NoError(t, err)
+	assert.Equal(t, 936, project.codePage)
+	var modules []string
+	for _, record := range project.records {
+		if record.id == vbaDirModuleName {
+			modules = append(modules, string(record.data))
+		}
+		if record.id == vbaDirModules {
+			assert.Equal(t, []byte{3, 0}, record.data)
+		}
+	}
+	assert.Equal(t, []string{"ThisWorkbook", "Sheet1", "Module1"}, modules)
+	for name := range project.streams {
+		assert.NotContains(t, name, "__SRP_")
+	}
+	source, err = decompressVBA(project.streams["VBA/Module1"])
+	assert.NoError(t, err)
+	assert.Equal(t, "Attribute VB_Name = \"Module1\"\r\nSub A()\r\nEnd Sub\r\n", string(source))
+	source, err = decompressVBA(project.streams["VBA/Sheet1"])
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(source, []byte("Attribute VB_Name = \"Sheet1\"\r\n")))
+	assert.True(t, bytes.HasSuffix(source, []byte("Attribute VB_Customizable = True\r\nSub B()\r\nEnd Sub\r\n")))
+	assert.Contains(t, string(project.streams["PROJECTwm"]), "Module1\x00M\x00o\x00d\x00u\x00l\x00e\x001\x00\x00\x00\x00\x00")
+	// Test set the VBA module with invalid module name
+	for _, name := range []string{"", "1Module", "Module 1", "Module1Module1Module1Module1Modu"} {
+		assert.Equal(t, ErrVBAModuleName, f.SetWorkbookVBACodeModule(name, ""))
+	}
+	assert.Equal(t, ErrVBAModuleName, f.SetWorkbookVBACodeModule("dir", ""))
+	// Test set the VBA module with the characters which not supported by
+	// the code page of the VBA project
+	assert.Error(t, f.SetWorkbookVBACodeModule("Module2", "' \U0001F600"))
+	// Test set the VBA module with invalid VBA project
+	f.Pkg.Store(defaultXMLPathVBAProject, []byte("VBA"))
+	assert.Equal(t, ErrAddVBAProject, f.SetWorkbookVBACodeModule("Module2", ""))
+	assert.NoError(t, f.Close())
+
+	// Test set the VBA module with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookVBACodeModule("Module1", ""), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestReadVBAProject(t *testing.T) {
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	project, err := readVBAProject(file)
+	assert.NoError(t, err)
+	writeStreams := func(streams map[string][]byte) []byte {
+		compoundFile := &cfb{
+			paths:   []string{"Root Entry/"},
+			sectors: []sector{{name: "Root Entry", typeID: 5}},
+		}
+		compoundFile.put("VBA/", nil)
+		for name, stream := range streams {
+			compoundFile.put(name, stream)
+		}
+		return compoundFile.write()
+	}
+	// Test read VBA project without dir stream
+	delete(project.streams, "VBA/dir")
+	_, err = readVBAProject(writeStreams(project.streams))
+	assert.Equal(t, ErrAddVBAProject, err)
+	// Test read VBA project with invalid dir stream
+	for _, dir := range [][]byte{
+		{0},
+		compressVBA([]byte{0x01, 0x00}),
+		compressVBA([]byte{0x04, 0x00, 0x0A, 0x00, 0x00, 0x00}),
+	} {
+		project.streams["VBA/dir"] = dir
+		_, err = readVBAProject(writeStreams(project.streams))
+		assert.Equal(t, ErrAddVBAProject, err)
+	}
+	// Test write VBA project with the storages besides the VBA storage
+	project, err = readVBAProject(file)
+	assert.NoError(t, err)
+	project.storages["UserForm1/"] = vbaCLSID("{C62A69F0-16DC-11CE-9E98-00AA00574A4F}")
+	project.streams["UserForm1/f"], project.streams["UserForm1/o"] = []byte{0x00, 0x04}, []byte{0x01}
+	written, err := readVBAProject(project.write())
+	assert.NoError(t, err)
+	assert.Equal(t, project.storages, written.storages)
+	assert.Equal(t, []byte{0xF0, 0x69, 0x2A, 0xC6, 0xDC, 0x16, 0xCE, 0x11, 0x9E, 0x98, 0x00, 0xAA, 0x00, 0x57, 0x4A, 0x4F}, written.storages["UserForm1/"])
+	assert.Equal(t, project.streams, written.streams)
+	assert.Nil(t, vbaCLSID("{C62A69F0}"))
+	// Test set the VBA module with invalid module stream
+	project, err = readVBAProject(file)
+	assert.NoError(t, err)
+	project.streams["VBA/Sheet1"] = []byte{0}
+	assert.Equal(t, ErrAddVBAProject, project.setModule("Sheet1", ""))
+	delete(project.streams, "VBA/Sheet1")
+	assert.Equal(t, ErrAddVBAProject, project.setModule("Sheet1", ""))
+}
+
+func TestCompressVBA(t *testing.T) {
+	random := make([]byte, 8096)
+	_, err := rand.New(rand.NewSource(1)).Read(random)
+	assert.NoError(t, err)
+	printable := make([]byte, 4000)
+	for i := range printable {
+		printable[i] = ' ' + random[i]%95
+	}
+	for _, data := range [][]byte{
+		nil,
+		[]byte("#aaabcdefaaaaghijaaaaaklaaamnopqaaaaaaaaaaaarstuvwxyzaaa"),
+		bytes.Repeat([]byte("Attribute VB_Name = \"Module1\"\r\n"), 500),
+		random[:5000],
+		random,
+		printable,
+	} {
+		decompressed, err := decompressVBA(compressVBA(data))
+		assert.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+	}
+	// Test only the chunk of 4096 bytes which can't be compressed will be
+	// stored as raw chunk
+	compressed := compressVBA(random[:5000])
+	assert.Equal(t, []byte{0xFF, 0x3F}, compressed[1:3])
+	assert.Equal(t, byte(0xB0), compressed[4100]&0xF0)
+	// Test the last chunk which can't be compressed into 4096 bytes will be
+	// split into the compressed chunks
+	for _, data := range [][]byte{random, printable} {
+		compressed = compressVBA(data)
+		for pos := 1; pos < len(compressed); {
+			header := binary.LittleEndian.Uint16(compressed[pos:])
+			assert.Equal(t, uint16(0x3000), header&0x7000)
+			pos += int(header&0x0FFF) + 3
+		}
+	}
+	// Test decompress the compressed container of the specification example
+	decompressed, err := decompressVBA([]byte{
+		0x01, 0x2F, 0xB0, 0x00, 0x23, 0x61, 0x61, 0x61, 0x62, 0x63, 0x64, 0x65, 0x82, 0x66, 0x00, 0x70,
+		0x61, 0x67, 0x68, 0x69, 0x6A, 0x01, 0x38, 0x08, 0x61, 0x6B, 0x6C, 0x00, 0x30, 0x6D, 0x6E, 0x6F,
+		0x70, 0x06, 0x71, 0x02, 0x70, 0x04, 0x10, 0x72, 0x73, 0x74, 0x75, 0x76, 0x10, 0x77, 0x78, 0x79,
+		0x7A, 0x00, 0x3C,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "#aaabcdefaaaaghijaaaaaklaaamnopqaaaaaaaaaaaarstuvwxyzaaa", string(decompressed))
+	// Test decompress the invalid compressed container
+	for _, data := range [][]byte{
+		nil,
+		{0},
+		{1, 0},
+		{1, 0xFF, 0x3F, 0},
+		{1, 0x01, 0xB0, 0x01, 0x00},
+		{1, 0x02, 0xB0, 0x01, 0x00, 0x00},
+	} {
+		_, err = decompressVBA(data)
+		assert.Equal(t, ErrAddVBAProject, err)
+	}
+}
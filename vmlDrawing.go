@@ -133,7 +133,24 @@ type decodeVmlDrawing struct {
 
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
-	Val string `xml:",innerxml"`
+	Style     string `xml:"style,attr"`
+	Fillcolor string `xml:"fillcolor,attr"`
+	Val       string `xml:",innerxml"`
+}
+
+// decodeShapeVal defines the structure used to parse the sub-element of the
+// shape in the file xl/drawings/vmlDrawing%d.vml.
+type decodeShapeVal struct {
+	ClientData decodeVMLClientData `xml:"ClientData"`
+}
+
+// decodeVMLClientData defines the structure used to parse the x:ClientData
+// element in the file xl/drawings/vmlDrawing%d.vml.
+type decodeVMLClientData struct {
+	ObjectType string  `xml:"ObjectType,attr"`
+	Visible    *string `xml:"Visible"`
+	Row        int     `xml:"Row"`
+	Column     int     `xml:"Column"`
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
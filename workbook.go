@@ -13,7 +13,6 @@ package excelize
 
 import (
 	"bytes"
-	"encoding/xml"
 	"io"
 	"path/filepath"
 	"strconv"
@@ -61,6 +60,28 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	return opts, err
 }
 
+// SetWorkbookCodeName provides a function to sets the code name of the
+// workbook, which is used by the VBA code to reference the workbook object.
+// For example, set the code name of the workbook as "ThisWorkbook":
+//
+//	err := f.SetWorkbookCodeName("ThisWorkbook")
+//
+// SetWorkbookCodeName 用于设置工作簿的代码名称
+func (f *File) SetWorkbookCodeName(codeName string) error {
+	return f.SetWorkbookProps(&WorkbookPropsOptions{CodeName: &codeName})
+}
+
+// GetWorkbookCodeName provides a function to gets the code name of the
+// workbook, it returns empty if the code name was not set.
+// GetWorkbookCodeName 用于获取工作簿的代码名称
+func (f *File) GetWorkbookCodeName() (string, error) {
+	wb, err := f.workbookReader()
+	if err != nil || wb.WorkbookPr == nil {
+		return "", err
+	}
+	return wb.WorkbookPr.CodeName, err
+}
+
 // ProtectWorkbook provides a function to prevent other users from viewing
 // hidden worksheets, adding, moving, deleting, or hiding worksheets, and
 // renaming worksheets in a workbook. The optional field AlgorithmName
@@ -136,6 +157,35 @@ func (f *File) UnprotectWorkbook(password ...string) error {
 	return err
 }
 
+// GetWorkbookProtectionStatus provides a function to get the protection
+// settings of the workbook. It returns nil if the workbook is not protected.
+// The password of the workbook can't be recovered from the stored hash value,
+// so the Password field of the returned settings is always empty. For
+// example, check whether the structure of the workbook is locked:
+//
+//	opts, err := f.GetWorkbookProtectionStatus()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if opts != nil && opts.LockStructure {
+//	    fmt.Println("the workbook structure is locked")
+//	}
+//
+// 获取工作簿的保护设置，工作簿未受保护时返回 nil。
+func (f *File) GetWorkbookProtectionStatus() (*WorkbookProtectionOptions, error) {
+	wb, err := f.workbookReader()
+	if err != nil || wb.WorkbookProtection == nil {
+		return nil, err
+	}
+	return &WorkbookProtectionOptions{
+		AlgorithmName: wb.WorkbookProtection.WorkbookAlgorithmName,
+		LockStructure: wb.WorkbookProtection.LockStructure,
+		LockWindows:   wb.WorkbookProtection.LockWindows,
+		IsProtected:   true,
+	}, err
+}
+
 // setWorkbook update workbook property of the spreadsheet. Maximum 31
 // characters are allowed in sheet title.
 func (f *File) setWorkbook(name string, sheetID, rid int) {
@@ -197,19 +247,3 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 	}
 	return f.WorkBook, err
 }
-
-// workBookWriter provides a function to save workbook.xml after serialize
-// structure.
-func (f *File) workBookWriter() {
-	if f.WorkBook != nil {
-		if f.WorkBook.DecodeAlternateContent != nil {
-			f.WorkBook.AlternateContent = &xlsxAlternateContent{
-				Content: f.WorkBook.DecodeAlternateContent.Content,
-				XMLNSMC: SourceRelationshipCompatibility.Value,
-			}
-		}
-		f.WorkBook.DecodeAlternateContent = nil
-		output, _ := xml.Marshal(f.WorkBook)
-		f.saveFileList(f.getWorkbookPath(), replaceRelationshipsBytes(f.replaceNameSpaceBytes(f.getWorkbookPath(), output)))
-	}
-}
@@ -31,3 +31,26 @@ func TestWorkbookProps(t *testing.T) {
 	_, err = f.GetWorkbookProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestWorkbookCodeName(t *testing.T) {
+	f := NewFile()
+	codeName, err := f.GetWorkbookCodeName()
+	assert.NoError(t, err)
+	assert.Empty(t, codeName)
+	assert.NoError(t, f.SetWorkbookCodeName("ThisWorkbook"))
+	codeName, err = f.GetWorkbookCodeName()
+	assert.NoError(t, err)
+	assert.Equal(t, "ThisWorkbook", codeName)
+	opts, err := f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "ThisWorkbook", *opts.CodeName)
+	// Test set workbook code name with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookCodeName("ThisWorkbook"), "XML syntax error on line 1: invalid UTF-8")
+	// Test get workbook code name with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookCodeName()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
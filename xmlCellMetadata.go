@@ -0,0 +1,43 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxCellMetadata directly maps the root element of the custom XML part
+// which stores the application-specific metadata of the cells. The metadata
+// are grouped by the worksheet name and cell reference.
+type xlsxCellMetadata struct {
+	XMLName xml.Name                `xml:"cellMetadata"`
+	Sheet   []xlsxCellMetadataSheet `xml:"sheet"`
+}
+
+// xlsxCellMetadataSheet directly maps the sheet element in the cell metadata
+// part, which contains the metadata of the cells in the worksheet.
+type xlsxCellMetadataSheet struct {
+	Name string                 `xml:"name,attr"`
+	Cell []xlsxCellMetadataCell `xml:"cell"`
+}
+
+// xlsxCellMetadataCell directly maps the cell element in the cell metadata
+// part, which contains the metadata key-value pairs of the cell.
+type xlsxCellMetadataCell struct {
+	R    string                 `xml:"r,attr"`
+	Item []xlsxCellMetadataItem `xml:"item"`
+}
+
+// xlsxCellMetadataItem directly maps the item element in the cell metadata
+// part, which specifies a metadata key-value pair.
+type xlsxCellMetadataItem struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
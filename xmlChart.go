@@ -17,15 +17,56 @@ import "encoding/xml"
 // DrawingML is for representing visualizations of numeric data with column
 // charts, pie charts, scatter charts, or other types of charts.
 type xlsxChartSpace struct {
-	XMLName        xml.Name        `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
-	XMLNSa         string          `xml:"xmlns:a,attr"`
-	Date1904       *attrValBool    `xml:"date1904"`
-	Lang           *attrValString  `xml:"lang"`
-	RoundedCorners *attrValBool    `xml:"roundedCorners"`
-	Chart          cChart          `xml:"chart"`
-	SpPr           *cSpPr          `xml:"spPr"`
-	TxPr           *cTxPr          `xml:"txPr"`
-	PrintSettings  *cPrintSettings `xml:"printSettings"`
+	XMLName          xml.Name              `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
+	XMLNSa           string                `xml:"xmlns:a,attr"`
+	XMLNSr           string                `xml:"xmlns:r,attr,omitempty"`
+	Namespaces       []xml.Attr            `xml:",any,attr"`
+	Date1904         *attrValBool          `xml:"date1904"`
+	Lang             *attrValString        `xml:"lang"`
+	RoundedCorners   *attrValBool          `xml:"roundedCorners"`
+	AlternateContent *xlsxAlternateContent `xml:"mc:AlternateContent"`
+	Style            *attrValInt           `xml:"style"`
+	ClrMapOvr        *cClrMapOvr           `xml:"clrMapOvr"`
+	PivotSource      *xlsxInnerXML         `xml:"pivotSource"`
+	Protection       *xlsxInnerXML         `xml:"protection"`
+	Chart            cChart                `xml:"chart"`
+	SpPr             *cSpPr                `xml:"spPr"`
+	TxPr             *cTxPr                `xml:"txPr"`
+	ExternalData     *cExternalData        `xml:"externalData"`
+	PrintSettings    *cPrintSettings       `xml:"printSettings"`
+	UserShapes       *cUserShapes          `xml:"userShapes"`
+	ExtLst           *xlsxExtLst           `xml:"extLst"`
+}
+
+// cClrMapOvr (Color Map Override) directly maps the clrMapOvr element. This
+// element specifies the color mapping overrides of the chart.
+type cClrMapOvr struct {
+	Bg1      string `xml:"bg1,attr"`
+	Tx1      string `xml:"tx1,attr"`
+	Bg2      string `xml:"bg2,attr"`
+	Tx2      string `xml:"tx2,attr"`
+	Accent1  string `xml:"accent1,attr"`
+	Accent2  string `xml:"accent2,attr"`
+	Accent3  string `xml:"accent3,attr"`
+	Accent4  string `xml:"accent4,attr"`
+	Accent5  string `xml:"accent5,attr"`
+	Accent6  string `xml:"accent6,attr"`
+	Hlink    string `xml:"hlink,attr"`
+	FolHlink string `xml:"folHlink,attr"`
+}
+
+// cExternalData directly maps the externalData element. This element
+// specifies the relationship to the embedded data of the chart.
+type cExternalData struct {
+	RID        string       `xml:"r:id,attr"`
+	AutoUpdate *attrValBool `xml:"autoUpdate"`
+}
+
+// cUserShapes directly maps the userShapes element. This element specifies
+// the relationship to the chart drawing part which contains the shapes drawn
+// on the chart.
+type cUserShapes struct {
+	RID string `xml:"r:id,attr"`
 }
 
 // cThicknessSpPr directly maps the element that specifies the thickness of
@@ -41,6 +82,7 @@ type cThicknessSpPr struct {
 type cChart struct {
 	Title            *cTitle            `xml:"title"`
 	AutoTitleDeleted *cAutoTitleDeleted `xml:"autoTitleDeleted"`
+	PivotFmts        *xlsxInnerXML      `xml:"pivotFmts"`
 	View3D           *cView3D           `xml:"view3D"`
 	Floor            *cThicknessSpPr    `xml:"floor"`
 	SideWall         *cThicknessSpPr    `xml:"sideWall"`
@@ -50,16 +92,17 @@ type cChart struct {
 	PlotVisOnly      *attrValBool       `xml:"plotVisOnly"`
 	DispBlanksAs     *attrValString     `xml:"dispBlanksAs"`
 	ShowDLblsOverMax *attrValBool       `xml:"showDLblsOverMax"`
+	ExtLst           *xlsxExtLst        `xml:"extLst"`
 }
 
 // cTitle (Title) directly maps the title element. This element specifies a
 // title.
 type cTitle struct {
-	Tx      cTx          `xml:"tx,omitempty"`
-	Layout  string       `xml:"layout,omitempty"`
+	Tx      *cTx         `xml:"tx"`
+	Layout  *cLayout     `xml:"layout"`
 	Overlay *attrValBool `xml:"overlay"`
-	SpPr    cSpPr        `xml:"spPr,omitempty"`
-	TxPr    cTxPr        `xml:"txPr,omitempty"`
+	SpPr    *cSpPr       `xml:"spPr"`
+	TxPr    *cTxPr       `xml:"txPr"`
 }
 
 // cTx (Chart Text) directly maps the tx element. This element specifies text
@@ -73,7 +116,7 @@ type cTx struct {
 // string with rich text formatting.
 type cRich struct {
 	BodyPr   aBodyPr `xml:"a:bodyPr,omitempty"`
-	LstStyle string  `xml:"a:lstStyle,omitempty"`
+	LstStyle *string `xml:"a:lstStyle"`
 	P        aP      `xml:"a:p"`
 }
 
@@ -223,8 +266,8 @@ type cSpPr struct {
 	NoFill    *string     `xml:"a:noFill"`
 	SolidFill *aSolidFill `xml:"a:solidFill"`
 	Ln        *aLn        `xml:"a:ln"`
-	Sp3D      *aSp3D      `xml:"a:sp3d"`
 	EffectLst *string     `xml:"a:effectLst"`
+	Sp3D      *aSp3D      `xml:"a:sp3d"`
 }
 
 // aSp3D (3-D Shape Properties) directly maps the a:sp3d element. This element
@@ -252,16 +295,16 @@ type aLn struct {
 	Cap       string      `xml:"cap,attr,omitempty"`
 	Cmpd      string      `xml:"cmpd,attr,omitempty"`
 	W         int         `xml:"w,attr,omitempty"`
-	NoFill    string      `xml:"a:noFill,omitempty"`
-	Round     string      `xml:"a:round,omitempty"`
+	NoFill    *string     `xml:"a:noFill"`
 	SolidFill *aSolidFill `xml:"a:solidFill"`
+	Round     *string     `xml:"a:round"`
 }
 
 // cTxPr (Text Properties) directly maps the txPr element. This element
 // specifies text formatting. The lstStyle element is not supported.
 type cTxPr struct {
 	BodyPr   aBodyPr `xml:"a:bodyPr,omitempty"`
-	LstStyle string  `xml:"a:lstStyle,omitempty"`
+	LstStyle *string `xml:"a:lstStyle"`
 	P        aP      `xml:"a:p,omitempty"`
 }
 
@@ -291,8 +334,8 @@ type cAutoTitleDeleted struct {
 type cView3D struct {
 	RotX         *attrValInt `xml:"rotX"`
 	RotY         *attrValInt `xml:"rotY"`
-	RAngAx       *attrValInt `xml:"rAngAx"`
 	DepthPercent *attrValInt `xml:"depthPercent"`
+	RAngAx       *attrValInt `xml:"rAngAx"`
 	Perspective  *attrValInt `xml:"perspective"`
 	ExtLst       *xlsxExtLst `xml:"extLst"`
 }
@@ -300,47 +343,69 @@ type cView3D struct {
 // cPlotArea directly maps the plotArea element. This element specifies the
 // plot area of the chart.
 type cPlotArea struct {
-	Layout         *string  `xml:"layout"`
-	AreaChart      *cCharts `xml:"areaChart"`
-	Area3DChart    *cCharts `xml:"area3DChart"`
-	BarChart       *cCharts `xml:"barChart"`
-	Bar3DChart     *cCharts `xml:"bar3DChart"`
-	BubbleChart    *cCharts `xml:"bubbleChart"`
-	DoughnutChart  *cCharts `xml:"doughnutChart"`
-	LineChart      *cCharts `xml:"lineChart"`
-	Line3DChart    *cCharts `xml:"line3DChart"`
-	PieChart       *cCharts `xml:"pieChart"`
-	Pie3DChart     *cCharts `xml:"pie3DChart"`
-	OfPieChart     *cCharts `xml:"ofPieChart"`
-	RadarChart     *cCharts `xml:"radarChart"`
-	ScatterChart   *cCharts `xml:"scatterChart"`
-	Surface3DChart *cCharts `xml:"surface3DChart"`
-	SurfaceChart   *cCharts `xml:"surfaceChart"`
-	CatAx          []*cAxs  `xml:"catAx"`
-	ValAx          []*cAxs  `xml:"valAx"`
-	SerAx          []*cAxs  `xml:"serAx"`
-	SpPr           *cSpPr   `xml:"spPr"`
+	Layout         *cLayout      `xml:"layout"`
+	AreaChart      *cCharts      `xml:"areaChart"`
+	Area3DChart    *cCharts      `xml:"area3DChart"`
+	BarChart       *cCharts      `xml:"barChart"`
+	Bar3DChart     *cCharts      `xml:"bar3DChart"`
+	BubbleChart    *cCharts      `xml:"bubbleChart"`
+	DoughnutChart  *cCharts      `xml:"doughnutChart"`
+	LineChart      *cCharts      `xml:"lineChart"`
+	Line3DChart    *cCharts      `xml:"line3DChart"`
+	PieChart       *cCharts      `xml:"pieChart"`
+	Pie3DChart     *cCharts      `xml:"pie3DChart"`
+	OfPieChart     *cCharts      `xml:"ofPieChart"`
+	RadarChart     *cCharts      `xml:"radarChart"`
+	ScatterChart   *cCharts      `xml:"scatterChart"`
+	Surface3DChart *cCharts      `xml:"surface3DChart"`
+	SurfaceChart   *cCharts      `xml:"surfaceChart"`
+	CatAx          []*cAxs       `xml:"catAx"`
+	ValAx          []*cAxs       `xml:"valAx"`
+	SerAx          []*cAxs       `xml:"serAx"`
+	DTable         *xlsxInnerXML `xml:"dTable"`
+	SpPr           *cSpPr        `xml:"spPr"`
+	ExtLst         *xlsxExtLst   `xml:"extLst"`
+}
+
+// cLayout directly maps the layout element. This element specifies how the
+// chart element is placed on the chart.
+type cLayout struct {
+	ManualLayout *cManualLayout `xml:"manualLayout"`
+}
+
+// cManualLayout directly maps the manualLayout element. This element
+// specifies the exact position of a chart element, the position and size are
+// specified as a fraction of the width or height of the chart.
+type cManualLayout struct {
+	LayoutTarget *attrValString `xml:"layoutTarget"`
+	XMode        *attrValString `xml:"xMode"`
+	YMode        *attrValString `xml:"yMode"`
+	X            *attrValFloat  `xml:"x"`
+	Y            *attrValFloat  `xml:"y"`
+	W            *attrValFloat  `xml:"w"`
+	H            *attrValFloat  `xml:"h"`
 }
 
 // cCharts specifies the common element of the chart.
 type cCharts struct {
-	BarDir       *attrValString `xml:"barDir"`
-	BubbleScale  *attrValFloat  `xml:"bubbleScale"`
-	Grouping     *attrValString `xml:"grouping"`
-	RadarStyle   *attrValString `xml:"radarStyle"`
-	ScatterStyle *attrValString `xml:"scatterStyle"`
-	OfPieType    *attrValString `xml:"ofPieType"`
-	VaryColors   *attrValBool   `xml:"varyColors"`
-	Wireframe    *attrValBool   `xml:"wireframe"`
-	Ser          *[]cSer        `xml:"ser"`
-	SplitPos     *attrValInt    `xml:"splitPos"`
-	SerLines     *attrValString `xml:"serLines"`
-	DLbls        *cDLbls        `xml:"dLbls"`
-	Shape        *attrValString `xml:"shape"`
-	HoleSize     *attrValInt    `xml:"holeSize"`
-	Smooth       *attrValBool   `xml:"smooth"`
-	Overlap      *attrValInt    `xml:"overlap"`
-	AxID         []*attrValInt  `xml:"axId"`
+	BarDir        *attrValString `xml:"barDir"`
+	Grouping      *attrValString `xml:"grouping"`
+	RadarStyle    *attrValString `xml:"radarStyle"`
+	ScatterStyle  *attrValString `xml:"scatterStyle"`
+	OfPieType     *attrValString `xml:"ofPieType"`
+	VaryColors    *attrValBool   `xml:"varyColors"`
+	Wireframe     *attrValBool   `xml:"wireframe"`
+	Ser           *[]cSer        `xml:"ser"`
+	DLbls         *cDLbls        `xml:"dLbls"`
+	BubbleScale   *attrValFloat  `xml:"bubbleScale"`
+	FirstSliceAng *attrValInt    `xml:"firstSliceAng"`
+	HoleSize      *attrValInt    `xml:"holeSize"`
+	Smooth        *attrValBool   `xml:"smooth"`
+	Overlap       *attrValInt    `xml:"overlap"`
+	SplitPos      *attrValInt    `xml:"splitPos"`
+	SerLines      *attrValString `xml:"serLines"`
+	Shape         *attrValString `xml:"shape"`
+	AxID          []*attrValInt  `xml:"axId"`
 }
 
 // cAxs directly maps the catAx and valAx element.
@@ -394,21 +459,35 @@ type cNumFmt struct {
 // cSer directly maps the ser element. This element specifies a series on a
 // chart.
 type cSer struct {
-	IDx              *attrValInt  `xml:"idx"`
-	Order            *attrValInt  `xml:"order"`
-	Tx               *cTx         `xml:"tx"`
-	SpPr             *cSpPr       `xml:"spPr"`
-	DPt              []*cDPt      `xml:"dPt"`
-	DLbls            *cDLbls      `xml:"dLbls"`
-	Marker           *cMarker     `xml:"marker"`
-	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
-	Cat              *cCat        `xml:"cat"`
-	Val              *cVal        `xml:"val"`
-	XVal             *cCat        `xml:"xVal"`
-	YVal             *cVal        `xml:"yVal"`
-	Smooth           *attrValBool `xml:"smooth"`
-	BubbleSize       *cVal        `xml:"bubbleSize"`
-	Bubble3D         *attrValBool `xml:"bubble3D"`
+	IDx              *attrValInt   `xml:"idx"`
+	Order            *attrValInt   `xml:"order"`
+	Tx               *cTx          `xml:"tx"`
+	SpPr             *cSpPr        `xml:"spPr"`
+	InvertIfNegative *attrValBool  `xml:"invertIfNegative"`
+	Marker           *cMarker      `xml:"marker"`
+	DPt              []*cDPt       `xml:"dPt"`
+	DLbls            *cDLbls       `xml:"dLbls"`
+	Trendline        []*cTrendline `xml:"trendline"`
+	Cat              *cCat         `xml:"cat"`
+	Val              *cVal         `xml:"val"`
+	XVal             *cCat         `xml:"xVal"`
+	YVal             *cVal         `xml:"yVal"`
+	Smooth           *attrValBool  `xml:"smooth"`
+	BubbleSize       *cVal         `xml:"bubbleSize"`
+	Bubble3D         *attrValBool  `xml:"bubble3D"`
+}
+
+// cTrendline (Trendline) directly maps the trendline element. This element
+// specifies a trendline.
+type cTrendline struct {
+	TrendlineType *attrValString `xml:"trendlineType"`
+	Order         *attrValInt    `xml:"order"`
+	Period        *attrValInt    `xml:"period"`
+	Forward       *attrValFloat  `xml:"forward"`
+	Backward      *attrValFloat  `xml:"backward"`
+	Intercept     *attrValFloat  `xml:"intercept"`
+	DispRSqr      *attrValBool   `xml:"dispRSqr"`
+	DispEq        *attrValBool   `xml:"dispEq"`
 }
 
 // cMarker (Marker) directly maps the marker element. This element specifies a
@@ -444,8 +523,8 @@ type cStrRef struct {
 // cStrCache (String Cache) directly maps the strCache element. This element
 // specifies the last string data used for a chart.
 type cStrCache struct {
-	Pt      []*cPt      `xml:"pt"`
 	PtCount *attrValInt `xml:"ptCount"`
+	Pt      []*cPt      `xml:"pt"`
 }
 
 // cPt directly maps the pt element. This element specifies data for a
@@ -472,8 +551,8 @@ type cNumRef struct {
 // last data shown on the chart for a series.
 type cNumCache struct {
 	FormatCode string      `xml:"formatCode"`
-	Pt         []*cPt      `xml:"pt"`
 	PtCount    *attrValInt `xml:"ptCount"`
+	Pt         []*cPt      `xml:"pt"`
 }
 
 // cDLbls (Data Labels) directly maps the dLbls element. This element serves
@@ -482,6 +561,8 @@ type cNumCache struct {
 // the specific formatting and positioning settings.
 type cDLbls struct {
 	NumFmt          *cNumFmt     `xml:"numFmt"`
+	SpPr            *cSpPr       `xml:"spPr"`
+	TxPr            *cTxPr       `xml:"txPr"`
 	ShowLegendKey   *attrValBool `xml:"showLegendKey"`
 	ShowVal         *attrValBool `xml:"showVal"`
 	ShowCatName     *attrValBool `xml:"showCatName"`
@@ -494,8 +575,8 @@ type cDLbls struct {
 // cLegend (Legend) directly maps the legend element. This element specifies
 // the legend.
 type cLegend struct {
-	Layout    *string        `xml:"layout"`
 	LegendPos *attrValString `xml:"legendPos"`
+	Layout    *cLayout       `xml:"layout"`
 	Overlay   *attrValBool   `xml:"overlay"`
 	SpPr      *cSpPr         `xml:"spPr"`
 	TxPr      *cTxPr         `xml:"txPr"`
@@ -547,6 +628,16 @@ type ChartDimension struct {
 	Height uint
 }
 
+// ChartLayout directly maps the manual layout settings of the chart element,
+// the position and size are specified as a fraction of the width or height of
+// the chart.
+type ChartLayout struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
 // ChartPlotArea directly maps the format settings of the plot area.
 type ChartPlotArea struct {
 	SecondPlotValues int
@@ -557,35 +648,65 @@ type ChartPlotArea struct {
 	ShowSerName      bool
 	ShowVal          bool
 	NumFmt           ChartNumFmt
+	Layout           *ChartLayout
+	PlotVisOnly      bool
 }
 
 // Chart directly maps the format settings of the chart.
 type Chart struct {
-	Type         ChartType
-	Series       []ChartSeries
-	Format       GraphicOptions
-	Dimension    ChartDimension
-	Legend       ChartLegend
-	Title        ChartTitle
-	VaryColors   *bool
-	XAxis        ChartAxis
-	YAxis        ChartAxis
-	PlotArea     ChartPlotArea
-	ShowBlanksAs string
-	HoleSize     int
-	order        int
+	Type            ChartType
+	Series          []ChartSeries
+	Format          GraphicOptions
+	Dimension       ChartDimension
+	Legend          ChartLegend
+	Title           ChartTitle
+	VaryColors      *bool
+	XAxis           ChartAxis
+	YAxis           ChartAxis
+	PlotArea        ChartPlotArea
+	ShowBlanksAs    string
+	HoleSize        int
+	FirstSliceAngle int
+	Smooth          bool
+	Waterfall       WaterfallOptions
+	order           int
+}
+
+// WaterfallOptions directly maps the format settings of the waterfall chart.
+type WaterfallOptions struct {
+	ConnectorLines *bool
+	IncreaseColor  string
+	DecreaseColor  string
+	TotalColor     string
+	IsTotal        []bool
 }
 
 // ChartLegend directly maps the format settings of the chart legend.
 type ChartLegend struct {
 	Position      string
 	ShowLegendKey bool
+	Overlay       bool
+	Font          *Font
 }
 
 // ChartMarker directly maps the format settings of the chart marker.
 type ChartMarker struct {
-	Symbol string
-	Size   int
+	Symbol      string
+	Size        int
+	FillColor   string
+	BorderColor string
+}
+
+// AnnotationOptions directly maps the format settings of the chart
+// annotation.
+type AnnotationOptions struct {
+	Width   uint
+	Height  uint
+	OffsetX int
+	OffsetY int
+	Font    Font
+	Fill    Fill
+	Line    ShapeLine
 }
 
 // ChartLine directly maps the format settings of the chart line.
@@ -603,9 +724,77 @@ type ChartSeries struct {
 	Fill       Fill
 	Line       ChartLine
 	Marker     ChartMarker
+	TrendLine  ChartTrendLine
+}
+
+// ChartTrendLine directly maps the format settings of the chart series trend
+// line.
+type ChartTrendLine struct {
+	Type            string
+	Order           int
+	Period          int
+	Forward         float64
+	Backward        float64
+	Intercept       *float64
+	DisplayEquation bool
+	DisplayRSquared bool
 }
 
 // ChartTitle directly maps the format settings of the chart title.
 type ChartTitle struct {
 	Name string
 }
+
+// cdrUserShapes directly maps the c:userShapes element of the chart drawing
+// part. This element contains the shapes drawn on the chart.
+type cdrUserShapes struct {
+	XMLName  xml.Name `xml:"c:userShapes"`
+	XMLNSc   string   `xml:"xmlns:c,attr"`
+	XMLNSa   string   `xml:"xmlns:a,attr"`
+	XMLNScdr string   `xml:"xmlns:cdr,attr"`
+	Content  string   `xml:",innerxml"`
+}
+
+// cdrRelSizeAnchor directly maps the cdr:relSizeAnchor element. This element
+// specifies a shape anchored by the relative position of the top left and
+// bottom right corners in the chart area.
+type cdrRelSizeAnchor struct {
+	XMLName xml.Name  `xml:"cdr:relSizeAnchor"`
+	From    cdrMarker `xml:"cdr:from"`
+	To      cdrMarker `xml:"cdr:to"`
+	Sp      cdrSp     `xml:"cdr:sp"`
+}
+
+// cdrMarker directly maps the cdr:from and cdr:to element. The x and y
+// coordinates are the fractions of the width and height of the chart area.
+type cdrMarker struct {
+	X float64 `xml:"cdr:x"`
+	Y float64 `xml:"cdr:y"`
+}
+
+// cdrSp directly maps the cdr:sp element. This element specifies a shape
+// drawn on the chart.
+type cdrSp struct {
+	Macro    string    `xml:"macro,attr"`
+	Textlink string    `xml:"textlink,attr"`
+	NvSpPr   cdrNvSpPr `xml:"cdr:nvSpPr"`
+	SpPr     cdrSpPr   `xml:"cdr:spPr"`
+	TxBody   xdrTxBody `xml:"cdr:txBody"`
+}
+
+// cdrNvSpPr directly maps the cdr:nvSpPr element. This element specifies the
+// non-visual properties of the shape drawn on the chart.
+type cdrNvSpPr struct {
+	CNvPr   xlsxCNvPr  `xml:"cdr:cNvPr"`
+	CNvSpPr xdrCNvSpPr `xml:"cdr:cNvSpPr"`
+}
+
+// cdrSpPr directly maps the cdr:spPr element. This element specifies the
+// visual properties of the shape drawn on the chart.
+type cdrSpPr struct {
+	Xfrm      xlsxXfrm     `xml:"a:xfrm"`
+	PrstGeom  xlsxPrstGeom `xml:"a:prstGeom"`
+	NoFill    *string      `xml:"a:noFill"`
+	SolidFill *aSolidFill  `xml:"a:solidFill"`
+	Ln        *aLn         `xml:"a:ln"`
+}
@@ -0,0 +1,175 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxChartExSpace directly maps the chartSpace element of the Office 2016
+// chartEx part, which used to store the chart types those can't be described
+// by the DrawingML chart part, such as the waterfall, treemap and sunburst
+// chart.
+type xlsxChartExSpace struct {
+	XMLName   xml.Name    `xml:"cx:chartSpace"`
+	XMLNSa    string      `xml:"xmlns:a,attr"`
+	XMLNSr    string      `xml:"xmlns:r,attr"`
+	XMLNScx   string      `xml:"xmlns:cx,attr"`
+	ChartData cxChartData `xml:"cx:chartData"`
+	Chart     cxChart     `xml:"cx:chart"`
+}
+
+// cxChartData directly maps the chartData element. This element specifies
+// the data used by the chart.
+type cxChartData struct {
+	Data []*cxData `xml:"cx:data"`
+}
+
+// cxData directly maps the data element. This element specifies a single
+// data set which can be referenced by a series.
+type cxData struct {
+	ID     int    `xml:"id,attr"`
+	StrDim *cxDim `xml:"cx:strDim"`
+	NumDim *cxDim `xml:"cx:numDim"`
+}
+
+// cxDim directly maps the strDim and numDim element. This element specifies
+// the formula which reference a string or numeric dimension of the data.
+type cxDim struct {
+	Type string `xml:"type,attr"`
+	F    string `xml:"cx:f"`
+}
+
+// cxChart directly maps the chart element of the chartEx part.
+type cxChart struct {
+	Title    *cxTitle   `xml:"cx:title"`
+	PlotArea cxPlotArea `xml:"cx:plotArea"`
+	Legend   *cxLegend  `xml:"cx:legend"`
+}
+
+// cxTitle directly maps the title element of the chartEx part.
+type cxTitle struct {
+	Pos     string `xml:"pos,attr"`
+	Align   string `xml:"align,attr"`
+	Overlay bool   `xml:"overlay,attr"`
+	Tx      *cxTx  `xml:"cx:tx"`
+}
+
+// cxTx directly maps the tx element of the chartEx part.
+type cxTx struct {
+	TxData *cxTxData `xml:"cx:txData"`
+}
+
+// cxTxData directly maps the txData element. This element specifies the text
+// by a formula or a literal value.
+type cxTxData struct {
+	F string `xml:"cx:f,omitempty"`
+	V string `xml:"cx:v,omitempty"`
+}
+
+// cxPlotArea directly maps the plotArea element of the chartEx part.
+type cxPlotArea struct {
+	PlotAreaRegion cxPlotAreaRegion `xml:"cx:plotAreaRegion"`
+	Axis           []*cxAxis        `xml:"cx:axis"`
+}
+
+// cxPlotAreaRegion directly maps the plotAreaRegion element.
+type cxPlotAreaRegion struct {
+	Series []*cxSeries `xml:"cx:series"`
+}
+
+// cxSeries directly maps the series element of the chartEx part.
+type cxSeries struct {
+	LayoutID   string        `xml:"layoutId,attr"`
+	Tx         *cxTx         `xml:"cx:tx"`
+	SpPr       *cSpPr        `xml:"cx:spPr"`
+	DataPt     []*cxDataPt   `xml:"cx:dataPt"`
+	DataLabels *cxDataLabels `xml:"cx:dataLabels"`
+	DataID     *attrValInt   `xml:"cx:dataId"`
+	LayoutPr   *cxLayoutPr   `xml:"cx:layoutPr"`
+}
+
+// cxDataPt directly maps the dataPt element. This element specifies the
+// formatting of a single data point.
+type cxDataPt struct {
+	Idx  int    `xml:"idx,attr"`
+	SpPr *cSpPr `xml:"cx:spPr"`
+}
+
+// cxDataLabels directly maps the dataLabels element of the chartEx part.
+type cxDataLabels struct {
+	Pos        string                  `xml:"pos,attr,omitempty"`
+	Visibility *cxDataLabelsVisibility `xml:"cx:visibility"`
+}
+
+// cxDataLabelsVisibility directly maps the visibility element of the data
+// labels.
+type cxDataLabelsVisibility struct {
+	SeriesName   bool `xml:"seriesName,attr"`
+	CategoryName bool `xml:"categoryName,attr"`
+	Value        bool `xml:"value,attr"`
+}
+
+// cxLayoutPr directly maps the layoutPr element. This element specifies the
+// layout properties of the series.
+type cxLayoutPr struct {
+	ParentLabelLayout *attrValString      `xml:"cx:parentLabelLayout"`
+	Visibility        *cxSeriesVisibility `xml:"cx:visibility"`
+	Subtotals         *cxSubtotals        `xml:"cx:subtotals"`
+}
+
+// cxSeriesVisibility directly maps the visibility element of the series
+// layout properties.
+type cxSeriesVisibility struct {
+	ConnectorLines bool `xml:"connectorLines,attr"`
+}
+
+// cxSubtotals directly maps the subtotals element. This element specifies
+// the indexes of the data points which shall be shown as subtotals.
+type cxSubtotals struct {
+	Idx []*attrValInt `xml:"cx:idx"`
+}
+
+// cxAxis directly maps the axis element of the chartEx part.
+type cxAxis struct {
+	ID             int           `xml:"id,attr"`
+	Hidden         bool          `xml:"hidden,attr,omitempty"`
+	CatScaling     *cxCatScaling `xml:"cx:catScaling"`
+	ValScaling     *cxValScaling `xml:"cx:valScaling"`
+	MajorGridlines *cxGridlines  `xml:"cx:majorGridlines"`
+	MinorGridlines *cxGridlines  `xml:"cx:minorGridlines"`
+	TickLabels     *cxTickLabels `xml:"cx:tickLabels"`
+}
+
+// cxCatScaling directly maps the catScaling element of the category axis.
+type cxCatScaling struct {
+	GapWidth string `xml:"gapWidth,attr,omitempty"`
+}
+
+// cxValScaling directly maps the valScaling element of the value axis.
+type cxValScaling struct {
+	Max string `xml:"max,attr,omitempty"`
+	Min string `xml:"min,attr,omitempty"`
+}
+
+// cxGridlines directly maps the majorGridlines and minorGridlines element.
+type cxGridlines struct {
+	SpPr *cSpPr `xml:"cx:spPr"`
+}
+
+// cxTickLabels directly maps the tickLabels element.
+type cxTickLabels struct{}
+
+// cxLegend directly maps the legend element of the chartEx part.
+type cxLegend struct {
+	Pos     string `xml:"pos,attr"`
+	Align   string `xml:"align,attr"`
+	Overlay bool   `xml:"overlay,attr"`
+}
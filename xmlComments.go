@@ -72,11 +72,52 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
-// Comment directly maps the comment information.
+// xlsxThreadedComments directly maps the ThreadedComments element. This
+// element is the root of the threaded comments part of a worksheet, each
+// threaded comment is anchored to a cell and the replies of the comment refer
+// to the parent comment by ID.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element.
+type xlsxThreadedComment struct {
+	Ref      string `xml:"ref,attr"`
+	DT       string `xml:"dT,attr,omitempty"`
+	PersonID string `xml:"personId,attr"`
+	ID       string `xml:"id,attr"`
+	ParentID string `xml:"parentId,attr,omitempty"`
+	Done     *bool  `xml:"done,attr"`
+	Text     string `xml:"text"`
+}
+
+// xlsxPersonList directly maps the personList element. This element
+// specifies the authors of the threaded comments in the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"personList"`
+	Person  []xlsxPerson `xml:"person"`
+}
+
+// xlsxPerson directly maps the person element.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr,omitempty"`
+}
+
+// Comment directly maps the comment information. The Visible, Width, Height
+// and BackgroundColor fields are only filled by the GetCellComments function,
+// the width and height of the comment box are in pixels.
 type Comment struct {
-	Author   string
-	AuthorID int
-	Cell     string
-	Text     string
-	Runs     []RichTextRun
+	Author          string
+	AuthorID        int
+	Cell            string
+	Text            string
+	Runs            []RichTextRun
+	Visible         bool
+	Width           uint
+	Height          uint
+	BackgroundColor string
 }
@@ -77,10 +77,54 @@ type decodeWsDr struct {
 // specifies a two cell anchor placeholder for a group, a shape, or a drawing
 // element. It moves with cells and its extents are in EMU units.
 type decodeTwoCellAnchor struct {
-	From       *decodeFrom       `xml:"from"`
-	To         *decodeTo         `xml:"to"`
-	Pic        *decodePic        `xml:"pic"`
-	ClientData *decodeClientData `xml:"clientData"`
+	From             *decodeFrom             `xml:"from"`
+	To               *decodeTo               `xml:"to"`
+	Ext              *decodeExt              `xml:"ext"`
+	Pic              *decodePic              `xml:"pic"`
+	GraphicFrame     *decodeGraphicFrame     `xml:"graphicFrame"`
+	AlternateContent *decodeAlternateContent `xml:"AlternateContent"`
+	ClientData       *decodeClientData       `xml:"clientData"`
+}
+
+// decodeAlternateContent directly maps the AlternateContent element in the
+// cell anchor, which contains the graphic frame of the chartEx or slicer.
+type decodeAlternateContent struct {
+	Choice *struct {
+		GraphicFrame *decodeGraphicFrame `xml:"graphicFrame"`
+	} `xml:"Choice"`
+}
+
+// decodeGraphicFrame (Graphic Frame) directly maps the graphicFrame element.
+// This element specifies the existence of a graphics frame.
+type decodeGraphicFrame struct {
+	Graphic *decodeGraphic `xml:"graphic"`
+}
+
+// decodeGraphic (Graphic Object) directly maps the graphic element. This
+// element specifies the existence of a single graphic object.
+type decodeGraphic struct {
+	GraphicData *decodeGraphicData `xml:"graphicData"`
+}
+
+// decodeGraphicData (Graphic Object Data) directly maps the graphicData
+// element. This element specifies the reference to a graphic object within
+// the document.
+type decodeGraphicData struct {
+	URI    string        `xml:"uri,attr"`
+	Chart  *decodeChart  `xml:"chart"`
+	Slicer *decodeSlicer `xml:"slicer"`
+}
+
+// decodeChart directly maps the chart element. This element specifies the
+// relationship ID of the chart part.
+type decodeChart struct {
+	RID string `xml:"id,attr"`
+}
+
+// decodeSlicer directly maps the slicer element in the graphic data. This
+// element specifies the name of the slicer.
+type decodeSlicer struct {
+	Name string `xml:"name,attr"`
 }
 
 // decodeCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
@@ -88,10 +132,17 @@ type decodeTwoCellAnchor struct {
 // information that does not affect the appearance of the picture to be
 // stored.
 type decodeCNvPr struct {
-	ID    int    `xml:"id,attr"`
-	Name  string `xml:"name,attr"`
-	Descr string `xml:"descr,attr"`
-	Title string `xml:"title,attr,omitempty"`
+	ID         int               `xml:"id,attr"`
+	Name       string            `xml:"name,attr"`
+	Descr      string            `xml:"descr,attr"`
+	Title      string            `xml:"title,attr,omitempty"`
+	HlinkClick *decodeHlinkClick `xml:"hlinkClick"`
+}
+
+// decodeHlinkClick directly maps the hlinkClick element. This element
+// specifies the relationship ID of the on-click hyperlink.
+type decodeHlinkClick struct {
+	RID string `xml:"id,attr"`
 }
 
 // decodePicLocks directly maps the picLocks (Picture Locks). This element
@@ -232,3 +283,30 @@ type decodeClientData struct {
 	FLocksWithSheet  bool `xml:"fLocksWithSheet,attr"`
 	FPrintsWithSheet bool `xml:"fPrintsWithSheet,attr"`
 }
+
+// decodeUserShapes directly maps the userShapes element of the chart drawing
+// part, which used to append shapes to the existing chart drawing part.
+type decodeUserShapes struct {
+	RelSizeAnchor []xlsxInnerXML `xml:"relSizeAnchor"`
+	AbsSizeAnchor []xlsxInnerXML `xml:"absSizeAnchor"`
+	Content       string         `xml:",innerxml"`
+}
+
+// decodeChartSpace directly maps the chartSpace element. The chart part will
+// be decoded with prefixed struct field tags, which can't get the inner XML of
+// elements, so decodeChartSpace just used for getting the content of the
+// elements which not supported by the chart structures.
+type decodeChartSpace struct {
+	AlternateContent *xlsxInnerXML `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	PivotSource      *xlsxInnerXML `xml:"pivotSource"`
+	Protection       *xlsxInnerXML `xml:"protection"`
+	Chart            struct {
+		PivotFmts *xlsxInnerXML `xml:"pivotFmts"`
+		PlotArea  struct {
+			DTable *xlsxInnerXML `xml:"dTable"`
+			ExtLst *xlsxExtLst   `xml:"extLst"`
+		} `xml:"plotArea"`
+		ExtLst *xlsxExtLst `xml:"extLst"`
+	} `xml:"chart"`
+	ExtLst *xlsxExtLst `xml:"extLst"`
+}
@@ -14,6 +14,7 @@ package excelize
 import (
 	"encoding/xml"
 	"sync"
+	"time"
 )
 
 // Source relationship and namespace list, associated prefixes and schema in which it was
@@ -23,6 +24,12 @@ var (
 	NameSpaceDrawing2016SVG                 = xml.Attr{Name: xml.Name{Local: "asvg", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2016/SVG/main"}
 	NameSpaceDrawingML                      = xml.Attr{Name: xml.Name{Local: "a", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/main"}
 	NameSpaceDrawingMLChart                 = xml.Attr{Name: xml.Name{Local: "c", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/chart"}
+	NameSpaceDrawingMLChartEx               = xml.Attr{Name: xml.Name{Local: "cx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2014/chartex"}
+	NameSpaceDrawingMLChartEx2015           = xml.Attr{Name: xml.Name{Local: "cx1", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2015/9/8/chartex"}
+	NameSpaceDrawingMLDiagram               = xml.Attr{Name: xml.Name{Local: "dgm", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/diagram"}
+	NameSpaceDrawingMLA14                   = xml.Attr{Name: xml.Name{Local: "a14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/main"}
+	NameSpaceDrawingMLSlicer                = xml.Attr{Name: xml.Name{Local: "sle", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/slicer"}
+	NameSpaceDrawingMLSlicerX15             = xml.Attr{Name: xml.Name{Local: "sle15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/slicer"}
 	NameSpaceDrawingMLSpreadSheet           = xml.Attr{Name: xml.Name{Local: "xdr", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"}
 	NameSpaceMacExcel2008Main               = xml.Attr{Name: xml.Name{Local: "mx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/mac/excel/2008/main"}
 	NameSpaceSpreadSheet                    = xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: "http://schemas.openxmlformats.org/spreadsheetml/2006/main"}
@@ -41,6 +48,12 @@ const (
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
+	ContentTypeDrawingMLChartEx                   = "application/vnd.ms-office.chartex+xml"
+	ContentTypeDrawingMLChartShapes               = "application/vnd.openxmlformats-officedocument.drawingml.chartshapes+xml"
+	ContentTypeDrawingMLDiagramColors             = "application/vnd.openxmlformats-officedocument.drawingml.diagramColors+xml"
+	ContentTypeDrawingMLDiagramData               = "application/vnd.openxmlformats-officedocument.drawingml.diagramData+xml"
+	ContentTypeDrawingMLDiagramLayout             = "application/vnd.openxmlformats-officedocument.drawingml.diagramLayout+xml"
+	ContentTypeDrawingMLDiagramStyle              = "application/vnd.openxmlformats-officedocument.drawingml.diagramStyle+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
@@ -50,10 +63,14 @@ const (
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
 	ContentTypeSpreadSheetMLTable                 = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	ContentTypeSlicer                             = "application/vnd.ms-excel.slicer+xml"
+	ContentTypeSlicerCache                        = "application/vnd.ms-excel.slicerCache+xml"
+	ContentTypeTheme                              = "application/vnd.openxmlformats-officedocument.theme+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	NameSpaceDrawingMLChartDrawing                = "http://schemas.openxmlformats.org/drawingml/2006/chartDrawing"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
 	NameSpaceDublinCore                           = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
@@ -62,8 +79,15 @@ const (
 	NameSpaceXML                                  = "http://www.w3.org/XML/1998/namespace"
 	NameSpaceXMLSchemaInstance                    = "http://www.w3.org/2001/XMLSchema-instance"
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+	SourceRelationshipChartEx                     = "http://schemas.microsoft.com/office/2014/relationships/chartEx"
+	SourceRelationshipChartUserShapes             = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartUserShapes"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
+	SourceRelationshipCustomXML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/customXml"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipDiagramColors               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/diagramColors"
+	SourceRelationshipDiagramData                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/diagramData"
+	SourceRelationshipDiagramLayout               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/diagramLayout"
+	SourceRelationshipDiagramQuickStyle           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/diagramQuickStyle"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipDrawingML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
 	SourceRelationshipDrawingVML                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
@@ -71,10 +95,16 @@ const (
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipPerson                      = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
+	SourceRelationshipPivotCacheRecords           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheRecords"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+	SourceRelationshipSlicer                      = "http://schemas.microsoft.com/office/2007/relationships/slicer"
+	SourceRelationshipSlicerCache                 = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipTheme                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"
+	SourceRelationshipThreadedComment             = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
@@ -97,8 +127,11 @@ const (
 	ExtURIDrawingBlip                 = "{28A0092B-C50C-407E-A947-70E740481C1C}"
 	ExtURIIgnoredErrors               = "{01252117-D84E-4E92-8308-4BE1C098FCBB}"
 	ExtURIMacExcelMX                  = "{64002731-A6B0-56B0-2670-7721B7C09600}"
+	ExtURIPivotCacheDefinition        = "{725AE2AE-9491-48be-B2B4-4EB974FC3084}"
 	ExtURIProtectedRanges             = "{FC87AEE6-9EDD-4A0A-B7FB-166176984837}"
+	ExtURISlicerCacheDefinition       = "{2F2917AC-EB37-4324-AD4E-5DD8C200BD13}"
 	ExtURISlicerCachesListX14         = "{BBE1A952-AA13-448e-AADC-164F8A28A991}"
+	ExtURISlicerCachesListX15         = "{46BE6895-7355-4a93-B00E-2C351335B9C9}"
 	ExtURISlicerListX14               = "{A8765BA9-456A-4DAB-B4F3-ACF838C121DE}"
 	ExtURISlicerListX15               = "{3A4CF648-6AED-40f4-86FF-DC5316D8AED3}"
 	ExtURISparklineGroups             = "{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"
@@ -116,9 +149,17 @@ var extensionURIPriority = []string{
 	ExtURIProtectedRanges,
 	ExtURIIgnoredErrors,
 	ExtURIWebExtensions,
+	ExtURISlicerListX15,
 	ExtURITimelineRefs,
 }
 
+// workbookExtURIPriority is the priority of URI in the workbook extension
+// lists.
+var workbookExtURIPriority = []string{
+	ExtURISlicerCachesListX14,
+	ExtURISlicerCachesListX15,
+}
+
 // Excel specifications and limits
 const (
 	MaxCellStyles        = 65430
@@ -147,8 +188,21 @@ const (
 	defaultChartDimensionHeight = 260
 	defaultChartLegendPosition  = "bottom"
 	defaultChartShowBlanksAs    = "gap"
+	defaultChartHoleSize        = 75
+	defaultAnnotationWidth      = 160
+	defaultAnnotationHeight     = 40
+	defaultAnnotationFontSize   = 10
+	defaultSmartArtWidth        = 480
+	defaultSmartArtHeight       = 288
 	defaultShapeSize            = 160
 	defaultShapeLineWidth       = 1
+	defaultPictureHTTPTimeout   = 30 * time.Second
+	maxPictureRedirects         = 5
+	maxPictureSize              = 100 << 20
+	defaultSlicerWidth          = 200
+	defaultSlicerHeight         = 200
+	defaultSlicerRowHeight      = 241300
+	defaultSlicerStyle          = "SlicerStyleLight1"
 )
 
 // ColorMappingType is the type of color transformation.
@@ -199,6 +253,15 @@ var supportedImageTypes = map[string]string{
 	".tif": ".tiff", ".tiff": ".tiff", ".wmf": ".wmf", ".wmz": ".wmz",
 }
 
+// supportedImageContentTypes defined supported image MIME types and the
+// corresponding image extensions.
+var supportedImageContentTypes = map[string]string{
+	"image/bmp": ".bmp", "image/emf": ".emf", "image/gif": ".gif",
+	"image/jpeg": ".jpeg", "image/jpg": ".jpeg", "image/png": ".png",
+	"image/svg+xml": ".svg", "image/tiff": ".tiff", "image/wmf": ".wmf",
+	"image/x-emf": ".emf", "image/x-ms-bmp": ".bmp", "image/x-wmf": ".wmf",
+}
+
 // supportedContentTypes defined supported file format types.
 var supportedContentTypes = map[string]string{
 	".xlam": ContentTypeAddinMacro,
@@ -489,8 +552,29 @@ type xlsxGraphic struct {
 // document. This graphic object is provided entirely by the document authors
 // who choose to persist this data within the document.
 type xlsxGraphicData struct {
-	URI   string     `xml:"uri,attr"`
-	Chart *xlsxChart `xml:"c:chart,omitempty"`
+	URI     string       `xml:"uri,attr"`
+	Chart   *xlsxChart   `xml:"c:chart,omitempty"`
+	ChartEx *xlsxChartEx `xml:"cx:chart,omitempty"`
+	RelIds  *xlsxRelIds  `xml:"dgm:relIds,omitempty"`
+	Slicer  *sleSlicer   `xml:"sle:slicer,omitempty"`
+}
+
+// sleSlicer directly maps the sle:slicer element, which references the
+// slicer in the slicers part of the worksheet by name.
+type sleSlicer struct {
+	XMLNSSle string `xml:"xmlns:sle,attr"`
+	Name     string `xml:"name,attr"`
+}
+
+// xlsxRelIds directly maps the dgm:relIds element, which references the data,
+// layout, quick style and colors parts of the SmartArt graphic.
+type xlsxRelIds struct {
+	DGM string `xml:"xmlns:dgm,attr"`
+	R   string `xml:"xmlns:r,attr"`
+	DM  string `xml:"r:dm,attr"`
+	LO  string `xml:"r:lo,attr"`
+	QS  string `xml:"r:qs,attr"`
+	CS  string `xml:"r:cs,attr"`
 }
 
 // xlsxChart (Chart) directly maps the c:chart element.
@@ -500,6 +584,38 @@ type xlsxChart struct {
 	R   string `xml:"xmlns:r,attr"`
 }
 
+// xlsxChartEx directly maps the cx:chart element, which references an Office
+// 2016 chartEx part.
+type xlsxChartEx struct {
+	CX  string `xml:"xmlns:cx,attr"`
+	RID string `xml:"r:id,attr"`
+	R   string `xml:"xmlns:r,attr"`
+}
+
+// xdrAlternateContent directly maps the mc:AlternateContent element in the
+// drawing cell anchor, which used to store the graphic frame of the chartEx
+// or the slicer with a shape fallback for the applications doesn't support it.
+type xdrAlternateContent struct {
+	XMLName  xml.Name     `xml:"mc:AlternateContent"`
+	XMLNSMC  string       `xml:"xmlns:mc,attr"`
+	Choice   *xdrChoice   `xml:"mc:Choice"`
+	Fallback *xdrFallback `xml:"mc:Fallback"`
+}
+
+// xdrChoice directly maps the mc:Choice element.
+type xdrChoice struct {
+	XMLNSA14     string            `xml:"xmlns:a14,attr,omitempty"`
+	XMLNSCX1     string            `xml:"xmlns:cx1,attr,omitempty"`
+	XMLNSSle15   string            `xml:"xmlns:sle15,attr,omitempty"`
+	Requires     string            `xml:"Requires,attr"`
+	GraphicFrame *xlsxGraphicFrame `xml:"xdr:graphicFrame"`
+}
+
+// xdrFallback directly maps the mc:Fallback element.
+type xdrFallback struct {
+	Sp *xdrSp `xml:"xdr:sp"`
+}
+
 // xdrSp (Shape) directly maps the xdr:sp element. This element specifies the
 // existence of a single shape. A shape can either be a preset or a custom
 // geometry, defined using the SpreadsheetDrawingML framework. In addition to a
@@ -588,6 +704,19 @@ type Picture struct {
 	Format    *GraphicOptions
 }
 
+// SheetImage directly maps the picture embed in the worksheet, includes the
+// anchor cell reference, name, extension, raw content, display size in
+// pixels and hyperlink of the picture.
+type SheetImage struct {
+	Cell      string
+	Name      string
+	Extension string
+	Data      []byte
+	Width     uint
+	Height    uint
+	Hyperlink string
+}
+
 // GraphicOptions directly maps the format settings of the picture.
 type GraphicOptions struct {
 	AltText         string
@@ -602,6 +731,7 @@ type GraphicOptions struct {
 	Hyperlink       string
 	HyperlinkType   string
 	Positioning     string
+	HTTPTimeout     time.Duration
 }
 
 // Shape directly maps the format settings of the shape.
@@ -226,3 +226,42 @@ type xlsxMeasureGroups struct{}
 
 // xlsxMaps represents the PivotTable OLAP measure group - Dimension maps.
 type xlsxMaps struct{}
+
+// decodePivotCacheDefinition directly maps the cache fields of the
+// pivotCacheDefinition part, and keeps the shared items of each field in
+// document order.
+type decodePivotCacheDefinition struct {
+	XMLName     xml.Name         `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotCacheDefinition"`
+	RID         string           `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	CacheSource *xlsxCacheSource `xml:"cacheSource"`
+	CacheFields struct {
+		CacheField []*decodeCacheField `xml:"cacheField"`
+	} `xml:"cacheFields"`
+}
+
+// decodeCacheField directly maps the cacheField element of the pivot cache
+// definition.
+type decodeCacheField struct {
+	Name          string `xml:"name,attr"`
+	DatabaseField *bool  `xml:"databaseField,attr"`
+	SharedItems   struct {
+		Items []decodePivotCacheItem `xml:",any"`
+	} `xml:"sharedItems"`
+}
+
+// decodePivotCacheRecords directly maps the pivotCacheRecords part, which
+// contains the underlying source data of the PivotCache.
+type decodePivotCacheRecords struct {
+	XMLName xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotCacheRecords"`
+	R       []struct {
+		Items []decodePivotCacheItem `xml:",any"`
+	} `xml:"r"`
+}
+
+// decodePivotCacheItem directly maps a value in the shared items or records
+// of the PivotCache, the element name m, n, b, e, s, d or x specifies the
+// value type, and x specifies the index of the shared item.
+type decodePivotCacheItem struct {
+	XMLName xml.Name
+	V       string `xml:"v,attr"`
+}
@@ -0,0 +1,182 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxSlicers directly maps the slicers element. This element is the root
+// element of the slicers part, which specifies the slicer views of the
+// worksheet.
+type xlsxSlicers struct {
+	XMLName xml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicers"`
+	Slicer  []*xlsxSlicer `xml:"slicer"`
+}
+
+// xlsxSlicer directly maps the slicer element. This element specifies a
+// slicer view on the worksheet, which references to a slicer cache by name.
+type xlsxSlicer struct {
+	Name           string `xml:"name,attr"`
+	Cache          string `xml:"cache,attr"`
+	Caption        string `xml:"caption,attr,omitempty"`
+	StartItem      *int   `xml:"startItem,attr"`
+	ColumnCount    *int   `xml:"columnCount,attr"`
+	ShowCaption    *bool  `xml:"showCaption,attr"`
+	Level          int    `xml:"level,attr,omitempty"`
+	Style          string `xml:"style,attr,omitempty"`
+	LockedPosition bool   `xml:"lockedPosition,attr,omitempty"`
+	RowHeight      int    `xml:"rowHeight,attr"`
+}
+
+// xlsxSlicerCacheDefinition directly maps the slicerCacheDefinition element.
+// This element is the root element of the slicer cache part, which specifies
+// the source field of the slicer, the table extension or the pivot tables and
+// the pivot cache data of the slicer cache.
+type xlsxSlicerCacheDefinition struct {
+	XMLName     xml.Name                    `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main slicerCacheDefinition"`
+	XMLNSMC     string                      `xml:"xmlns:mc,attr,omitempty"`
+	MCIgnorable string                      `xml:"mc:Ignorable,attr,omitempty"`
+	XMLNSX      string                      `xml:"xmlns:x,attr,omitempty"`
+	Name        string                      `xml:"name,attr"`
+	SourceName  string                      `xml:"sourceName,attr"`
+	PivotTables *xlsxSlicerCachePivotTables `xml:"pivotTables"`
+	Data        *xlsxSlicerCacheData        `xml:"data"`
+	ExtLst      *xlsxExtLst                 `xml:"extLst"`
+}
+
+// xlsxSlicerCachePivotTables directly maps the pivotTables element. This
+// element specifies the pivot tables filtered by the slicer cache.
+type xlsxSlicerCachePivotTables struct {
+	PivotTable []xlsxSlicerCachePivotTable `xml:"pivotTable"`
+}
+
+// xlsxSlicerCachePivotTable directly maps the pivotTable element. This element
+// specifies the sheet ID and name of a pivot table filtered by the slicer
+// cache.
+type xlsxSlicerCachePivotTable struct {
+	TabID int    `xml:"tabId,attr"`
+	Name  string `xml:"name,attr"`
+}
+
+// xlsxSlicerCacheData directly maps the data element. This element specifies
+// the data source of the slicer cache for the pivot tables.
+type xlsxSlicerCacheData struct {
+	Tabular *xlsxTabularSlicerCache `xml:"tabular"`
+}
+
+// xlsxTabularSlicerCache directly maps the tabular element. This element
+// specifies the pivot cache and the display settings of the items in the
+// slicer cache which based on a non-OLAP pivot cache.
+type xlsxTabularSlicerCache struct {
+	PivotCacheID   int    `xml:"pivotCacheId,attr"`
+	SortOrder      string `xml:"sortOrder,attr,omitempty"`
+	CustomListSort *bool  `xml:"customListSort,attr"`
+	ShowMissing    *bool  `xml:"showMissing,attr"`
+	CrossFilter    string `xml:"crossFilter,attr,omitempty"`
+}
+
+// xlsxTableSlicerCache directly maps the x15:tableSlicerCache element. This
+// element specifies the table and the column of the table slicer cache.
+type xlsxTableSlicerCache struct {
+	XMLName xml.Name `xml:"x15:tableSlicerCache"`
+	TableID int      `xml:"tableId,attr"`
+	Column  int      `xml:"column,attr"`
+}
+
+// xlsxX14SlicerList directly maps the x14:slicerList element in the extension
+// list of the worksheet. This element specifies the slicers parts of the
+// worksheet.
+type xlsxX14SlicerList struct {
+	XMLName xml.Name             `xml:"x14:slicerList"`
+	XMLNS   string               `xml:"xmlns:x14,attr"`
+	Slicer  []*xlsxX14SlicerPart `xml:"x14:slicer"`
+}
+
+// xlsxX14SlicerPart directly maps the x14:slicer element. This element
+// specifies the relationship ID of the slicers part.
+type xlsxX14SlicerPart struct {
+	RID string `xml:"r:id,attr"`
+}
+
+// xlsxX14SlicerCaches directly maps the x14:slicerCaches and x15:slicerCaches
+// element in the extension list of the workbook. This element specifies the
+// slicer cache parts of the workbook.
+type xlsxX14SlicerCaches struct {
+	XMLName     xml.Name
+	XMLNSX14    string               `xml:"xmlns:x14,attr"`
+	XMLNSX15    string               `xml:"xmlns:x15,attr,omitempty"`
+	SlicerCache []*xlsxX14SlicerPart `xml:"x14:slicerCache"`
+}
+
+// decodeX14SlicerList directly maps the slicerList, slicerCaches and
+// pivotCacheDefinition element in the extension lists, and keeps the
+// relationship IDs of the slicers parts or slicer cache parts, and the
+// identifier of the pivot cache.
+type decodeX14SlicerList struct {
+	PivotCacheID *int `xml:"pivotCacheId,attr"`
+	Slicer       []struct {
+		RID string `xml:"id,attr"`
+	} `xml:",any"`
+}
+
+// decodeSlicerCacheDefinition directly maps the slicerCacheDefinition element
+// for deserialization.
+type decodeSlicerCacheDefinition struct {
+	XMLName     xml.Name                    `xml:"slicerCacheDefinition"`
+	Name        string                      `xml:"name,attr"`
+	SourceName  string                      `xml:"sourceName,attr"`
+	PivotTables *xlsxSlicerCachePivotTables `xml:"pivotTables"`
+	ExtLst      *decodeWorksheetExt         `xml:"extLst"`
+}
+
+// decodeTableSlicerCache directly maps the tableSlicerCache element for
+// deserialization.
+type decodeTableSlicerCache struct {
+	XMLName xml.Name `xml:"tableSlicerCache"`
+	TableID int      `xml:"tableId,attr"`
+	Column  int      `xml:"column,attr"`
+}
+
+// SlicerOptions represents the settings of the slicer.
+//
+// Name specifies the slicer name, which should be unique in the workbook. The
+// default value is the column name, with a sequence number suffix if the name
+// already exists.
+//
+// TableName specifies the name of the table or pivot table, which the slicer
+// will be associated with.
+//
+// ColumnName specifies the name of the table column or pivot table field to
+// slice on.
+//
+// Cell specifies the top-left cell of the slicer.
+//
+// Caption specifies the caption of the slicer, the default value is the
+// column name.
+//
+// Style specifies the slicer style name, the default value is
+// SlicerStyleLight1.
+//
+// Width and Height specify the size of the slicer in pixels, the default
+// value is 200.
+//
+// LockedPosition specifies if the slicer can not be moved or resized.
+type SlicerOptions struct {
+	Name           string
+	TableName      string
+	ColumnName     string
+	Cell           string
+	Caption        string
+	Style          string
+	Width          uint
+	Height         uint
+	LockedPosition bool
+}
@@ -0,0 +1,91 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxDataModel directly maps the dgm:dataModel element, which is the root
+// element of the SmartArt data part. This element specifies the points in the
+// SmartArt graphic, and the connections between them.
+type xlsxDataModel struct {
+	XMLName xml.Name       `xml:"dgm:dataModel"`
+	DGM     string         `xml:"xmlns:dgm,attr"`
+	A       string         `xml:"xmlns:a,attr"`
+	PtLst   xlsxDgmPtLst   `xml:"dgm:ptLst"`
+	CxnLst  *xlsxDgmCxnLst `xml:"dgm:cxnLst"`
+	Bg      struct{}       `xml:"dgm:bg"`
+	Whole   struct{}       `xml:"dgm:whole"`
+}
+
+// xlsxDgmPtLst directly maps the dgm:ptLst element, which specifies the list
+// of points in the SmartArt data model.
+type xlsxDgmPtLst struct {
+	Pt []*xlsxDgmPt `xml:"dgm:pt"`
+}
+
+// xlsxDgmPt directly maps the dgm:pt element. A point is the document, a
+// node with text, or a parent or sibling transition of the connection.
+type xlsxDgmPt struct {
+	ModelID string        `xml:"modelId,attr"`
+	Type    string        `xml:"type,attr,omitempty"`
+	CxnID   string        `xml:"cxnId,attr,omitempty"`
+	PrSet   *xlsxDgmPrSet `xml:"dgm:prSet"`
+	SpPr    struct{}      `xml:"dgm:spPr"`
+	T       *xlsxDgmT     `xml:"dgm:t"`
+}
+
+// xlsxDgmPrSet directly maps the dgm:prSet element, which specifies the
+// property set of the point. The layout, quick style and colors definitions
+// are specified on the document point.
+type xlsxDgmPrSet struct {
+	PhldrT   string `xml:"phldrT,attr,omitempty"`
+	LoTypeID string `xml:"loTypeId,attr,omitempty"`
+	LoCatID  string `xml:"loCatId,attr,omitempty"`
+	QsTypeID string `xml:"qsTypeId,attr,omitempty"`
+	QsCatID  string `xml:"qsCatId,attr,omitempty"`
+	CsTypeID string `xml:"csTypeId,attr,omitempty"`
+	CsCatID  string `xml:"csCatId,attr,omitempty"`
+}
+
+// xlsxDgmT directly maps the dgm:t element, which specifies the text body of
+// the point.
+type xlsxDgmT struct {
+	BodyPr   struct{} `xml:"a:bodyPr"`
+	LstStyle struct{} `xml:"a:lstStyle"`
+	P        []*aP    `xml:"a:p"`
+}
+
+// xlsxDgmCxnLst directly maps the dgm:cxnLst element, which specifies the list
+// of connections between the points in the SmartArt data model.
+type xlsxDgmCxnLst struct {
+	Cxn []*xlsxDgmCxn `xml:"dgm:cxn"`
+}
+
+// xlsxDgmCxn directly maps the dgm:cxn element, which specifies a parent and
+// child connection between two points.
+type xlsxDgmCxn struct {
+	ModelID    string `xml:"modelId,attr"`
+	SrcID      string `xml:"srcId,attr"`
+	DestID     string `xml:"destId,attr"`
+	SrcOrd     int    `xml:"srcOrd,attr"`
+	DestOrd    int    `xml:"destOrd,attr"`
+	ParTransID string `xml:"parTransId,attr"`
+	SibTransID string `xml:"sibTransId,attr"`
+}
+
+// SmartArtOptions directly maps the format settings of the SmartArt graphic.
+type SmartArtOptions struct {
+	Items  []string
+	Width  uint
+	Height uint
+	Format GraphicOptions
+}
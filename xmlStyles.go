@@ -327,9 +327,11 @@ type Alignment struct {
 
 // Border directly maps the border settings of the cells.
 type Border struct {
-	Type  string
-	Color string
-	Style int
+	Type       string
+	Color      string
+	ColorTheme *int
+	ColorTint  float64
+	Style      int
 }
 
 // Font directly maps the font settings of the fonts.
@@ -347,12 +349,23 @@ type Font struct {
 	VertAlign    string
 }
 
+// GradientStop directly maps the stop settings of the gradient fill.
+type GradientStop struct {
+	Position float64
+	Color    string
+}
+
 // Fill directly maps the fill settings of the cells.
 type Fill struct {
-	Type    string
-	Pattern int
-	Color   []string
-	Shading int
+	Type           string
+	Pattern        int
+	Color          []string
+	ColorTheme     *int
+	ColorTint      float64
+	Shading        int
+	GradientType   string
+	GradientDegree float64
+	GradientStops  []GradientStop
 }
 
 // Protection directly maps the protection settings of the cells.
@@ -361,6 +374,16 @@ type Protection struct {
 	Locked bool
 }
 
+// NamedStyle directly maps the settings of the named cell style. The
+// BuiltInID is nil for the user-defined named cell style.
+type NamedStyle struct {
+	Name          string
+	XfID          int
+	BuiltInID     *int
+	Hidden        bool
+	CustomBuiltIn bool
+}
+
 // Style directly maps the style settings of the cells.
 type Style struct {
 	Border        []Border
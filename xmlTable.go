@@ -206,6 +206,15 @@ type Table struct {
 	ShowHeaderRow     *bool
 	ShowLastColumn    bool
 	ShowRowStripes    *bool
+	ShowTotalsRow     bool
+	Columns           []TableColumn
+}
+
+// TableColumn directly maps the settings of the column of the table.
+type TableColumn struct {
+	Name              string
+	TotalsRowFunction string
+	TotalsRowLabel    string
 }
 
 // AutoFilterOptions directly maps the auto filter settings.
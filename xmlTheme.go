@@ -161,3 +161,65 @@ type xlsxSysClr struct {
 	Val     string `xml:"val,attr"`
 	LastClr string `xml:"lastClr,attr"`
 }
+
+// ThemeColorScheme directly maps the color scheme settings of the workbook
+// theme, the colors are represented in 'RRGGBB' hexadecimal notation.
+type ThemeColorScheme struct {
+	Name              string
+	Dark1             string
+	Light1            string
+	Dark2             string
+	Light2            string
+	Accent1           string
+	Accent2           string
+	Accent3           string
+	Accent4           string
+	Accent5           string
+	Accent6           string
+	Hyperlink         string
+	FollowedHyperlink string
+}
+
+// ThemeScriptFont directly maps the font settings of the theme font for the
+// specified script, such as "Jpan", "Hans" or "Arab".
+type ThemeScriptFont struct {
+	Script   string
+	Typeface string
+}
+
+// ThemeFont directly maps the font collection settings of the major or minor
+// font in the theme font scheme.
+type ThemeFont struct {
+	Latin         string
+	EastAsian     string
+	ComplexScript string
+	Scripts       []ThemeScriptFont
+}
+
+// ThemeFontScheme directly maps the font scheme settings of the workbook
+// theme, the major font is used for the headings and the minor font is used
+// for the body text.
+type ThemeFontScheme struct {
+	Name  string
+	Major ThemeFont
+	Minor ThemeFont
+}
+
+// ThemeFormatScheme directly maps the format scheme settings of the workbook
+// theme, the fill, line, effect and background fill styles are represented as
+// the DrawingML XML fragments.
+type ThemeFormatScheme struct {
+	Name                 string
+	FillStyles           string
+	LineStyles           string
+	EffectStyles         string
+	BackgroundFillStyles string
+}
+
+// Theme directly maps the settings of the workbook theme.
+type Theme struct {
+	Name         string
+	ColorScheme  ThemeColorScheme
+	FontScheme   ThemeFontScheme
+	FormatScheme ThemeFormatScheme
+}
@@ -31,6 +31,16 @@ type xlsxRelationship struct {
 	TargetMode string `xml:",attr,omitempty"`
 }
 
+// Relationship directly maps the relationship of the part, includes the
+// relationship ID, the full URI of the relationship type, the target and the
+// target mode.
+type Relationship struct {
+	ID         string
+	Type       string
+	Target     string
+	TargetMode string
+}
+
 // xlsxWorkbook contains elements and attributes that encompass the data
 // content of the workbook. The workbook's child elements each have their own
 // subclause references.
@@ -333,10 +343,13 @@ type WorkbookPropsOptions struct {
 }
 
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
+// The IsProtected field is only used by GetWorkbookProtectionStatus to report
+// the protection status, and will be ignored by ProtectWorkbook.
 // WorkbookProtectionOptions 定义了保护工作簿的设置选项。
 type WorkbookProtectionOptions struct {
 	AlgorithmName string //AlgorithmName 支持指定哈希算法 XOR、MD4、MD5、SHA-1、SHA-256、SHA-384 或 SHA-512，如果未指定哈希算法，默认使用 XOR 算法。
 	Password      string
 	LockStructure bool
 	LockWindows   bool
+	IsProtected   bool
 }
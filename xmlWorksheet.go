@@ -728,9 +728,52 @@ type decodeWorksheetExt struct {
 
 // decodeX14SparklineGroups directly maps the sparklineGroups element.
 type decodeX14SparklineGroups struct {
-	XMLName xml.Name `xml:"sparklineGroups"`
-	XMLNSXM string   `xml:"xmlns:xm,attr"`
-	Content string   `xml:",innerxml"`
+	XMLName         xml.Name                   `xml:"sparklineGroups"`
+	XMLNSXM         string                     `xml:"xmlns:xm,attr"`
+	SparklineGroups []*decodeX14SparklineGroup `xml:"sparklineGroup"`
+	Content         string                     `xml:",innerxml"`
+}
+
+// decodeX14SparklineGroup directly maps the sparklineGroup element.
+type decodeX14SparklineGroup struct {
+	XMLName             xml.Name            `xml:"sparklineGroup"`
+	ManualMax           int                 `xml:"manualMax,attr"`
+	ManualMin           int                 `xml:"manualMin,attr"`
+	LineWeight          float64             `xml:"lineWeight,attr"`
+	Type                string              `xml:"type,attr"`
+	DateAxis            bool                `xml:"dateAxis,attr"`
+	DisplayEmptyCellsAs string              `xml:"displayEmptyCellsAs,attr"`
+	Markers             bool                `xml:"markers,attr"`
+	High                bool                `xml:"high,attr"`
+	Low                 bool                `xml:"low,attr"`
+	First               bool                `xml:"first,attr"`
+	Last                bool                `xml:"last,attr"`
+	Negative            bool                `xml:"negative,attr"`
+	DisplayXAxis        bool                `xml:"displayXAxis,attr"`
+	DisplayHidden       bool                `xml:"displayHidden,attr"`
+	MinAxisType         string              `xml:"minAxisType,attr"`
+	MaxAxisType         string              `xml:"maxAxisType,attr"`
+	RightToLeft         bool                `xml:"rightToLeft,attr"`
+	ColorSeries         *xlsxColor          `xml:"colorSeries"`
+	ColorNegative       *xlsxColor          `xml:"colorNegative"`
+	ColorAxis           *xlsxColor          `xml:"colorAxis"`
+	ColorMarkers        *xlsxColor          `xml:"colorMarkers"`
+	ColorFirst          *xlsxColor          `xml:"colorFirst"`
+	ColorLast           *xlsxColor          `xml:"colorLast"`
+	ColorHigh           *xlsxColor          `xml:"colorHigh"`
+	ColorLow            *xlsxColor          `xml:"colorLow"`
+	Sparklines          decodeX14Sparklines `xml:"sparklines"`
+}
+
+// decodeX14Sparklines directly maps the sparklines element.
+type decodeX14Sparklines struct {
+	Sparkline []*decodeX14Sparkline `xml:"sparkline"`
+}
+
+// decodeX14Sparkline directly maps the sparkline element.
+type decodeX14Sparkline struct {
+	F     string `xml:"f"`
+	Sqref string `xml:"sqref"`
 }
 
 // decodeX14ConditionalFormattingExt directly maps the ext
@@ -914,30 +957,39 @@ type Panes struct {
 
 // ConditionalFormatOptions directly maps the conditional format settings of the cells.
 type ConditionalFormatOptions struct {
-	Type           string
-	AboveAverage   bool
-	Percent        bool
-	Format         int
-	Criteria       string
-	Value          string
-	MinType        string
-	MidType        string
-	MaxType        string
-	MinValue       string
-	MidValue       string
-	MaxValue       string
-	MinColor       string
-	MidColor       string
-	MaxColor       string
-	BarColor       string
-	BarBorderColor string
-	BarDirection   string
-	BarOnly        bool
-	BarSolid       bool
-	IconStyle      string
-	ReverseIcons   bool
-	IconsOnly      bool
-	StopIfTrue     bool
+	Type             string
+	AboveAverage     bool
+	Percent          bool
+	Format           int
+	Criteria         string
+	Value            string
+	MinType          string
+	MidType          string
+	MaxType          string
+	MinValue         string
+	MidValue         string
+	MaxValue         string
+	MinColor         string
+	MidColor         string
+	MaxColor         string
+	BarColor         string
+	BarBorderColor   string
+	BarDirection     string
+	BarOnly          bool
+	BarSolid         bool
+	BarNegativeColor string
+	IconStyle        string
+	IconThresholds   []ConditionalFormatThreshold
+	ReverseIcons     bool
+	IconsOnly        bool
+	StopIfTrue       bool
+}
+
+// ConditionalFormatThreshold directly maps the threshold settings of each
+// icon in the icon set conditional format.
+type ConditionalFormatThreshold struct {
+	Type  string
+	Value string
 }
 
 // SheetProtectionOptions directly maps the settings of worksheet protection.
@@ -1008,6 +1060,14 @@ type PageLayoutOptions struct {
 	BlackAndWhite *bool
 }
 
+// CustomSheetView directly maps the settings of the custom sheet view. The
+// ViewID is the GUID of the custom view, and Hidden specifies whether the
+// worksheet is hidden in this custom view.
+type CustomSheetView struct {
+	ViewID string
+	Hidden bool
+}
+
 // ViewOptions directly maps the settings of sheet view.
 type ViewOptions struct {
 	// DefaultGridColor indicating that the consuming application should use
@@ -1103,3 +1163,23 @@ type SheetPropsOptions struct {
 	// ThickBottom specifies if rows have a thick bottom border by default.
 	ThickBottom *bool
 }
+
+// ExtEntry directly maps the extension in the extLst element of the
+// worksheet. URI is the GUID which identifies the feature of the extension,
+// and Content is the raw inner XML of the ext element.
+type ExtEntry struct {
+	URI     string
+	Content []byte
+}
+
+// OutlineProps directly maps the outline summary settings of the worksheet.
+type OutlineProps struct {
+	// SummaryBelow specifies if the summary rows appear below the detail rows
+	// in the outline. The summary rows appear above the detail rows if this
+	// is false.
+	SummaryBelow bool
+	// SummaryRight specifies if the summary columns appear to the right of the
+	// detail columns in the outline. The summary columns appear to the left of
+	// the detail columns if this is false.
+	SummaryRight bool
+}